@@ -13,6 +13,8 @@ import (
 	"github.com/ethereum/go-ethereum/core/types"
 	"github.com/iotexproject/go-pkgs/crypto"
 	"github.com/pkg/errors"
+
+	"github.com/iotexproject/iotex-core/signer"
 )
 
 type (
@@ -39,7 +41,7 @@ type (
 )
 
 // Sign signs the action using sender's private key
-func Sign(act Envelope, sk crypto.PrivateKey) (SealedEnvelope, error) {
+func Sign(act Envelope, sk signer.HashSigner) (SealedEnvelope, error) {
 	sealed := SealedEnvelope{
 		Envelope:  act,
 		srcPubkey: sk.PublicKey(),
@@ -92,7 +94,7 @@ func CalculateIntrinsicGas(baseIntrinsicGas uint64, payloadGas uint64, payloadSi
 // IsSystemAction determine whether input action belongs to system action
 func IsSystemAction(act SealedEnvelope) bool {
 	switch act.Action().(type) {
-	case *GrantReward, *PutPollResult:
+	case *GrantReward, *PutPollResult, *DoubleSignEvidence:
 		return true
 	default:
 		return false
@@ -0,0 +1,72 @@
+// Copyright (c) 2022 IoTeX Foundation
+// This is an alpha (internal) release and is not suitable for production. This source code is provided 'as is' and no
+// warranties are given as to title or non-infringement, merchantability or fitness for purpose and, to the extent
+// permitted by law, all liability for your use of the code is disclaimed. This source code is governed by Apache
+// License 2.0 that can be found in the LICENSE file.
+
+package action
+
+import (
+	"math/big"
+
+	"github.com/iotexproject/iotex-core/action/evidence"
+	"github.com/iotexproject/iotex-core/pkg/version"
+)
+
+// DoubleSignEvidence is the system action to include a double sign evidence into a block, which punishes the
+// offender in staking protocol
+type DoubleSignEvidence struct {
+	AbstractAction
+
+	evidence *evidence.DoubleSign
+}
+
+// NewDoubleSignEvidence instantiates a double sign evidence action struct
+func NewDoubleSignEvidence(nonce uint64, ds *evidence.DoubleSign) *DoubleSignEvidence {
+	return &DoubleSignEvidence{
+		AbstractAction: AbstractAction{
+			version:  version.ProtocolVersion,
+			nonce:    nonce,
+			gasLimit: 0,
+			gasPrice: big.NewInt(0),
+		},
+		evidence: ds,
+	}
+}
+
+// Evidence returns the double sign evidence
+func (d *DoubleSignEvidence) Evidence() *evidence.DoubleSign { return d.evidence }
+
+// Serialize returns the byte representation of the double sign evidence action
+func (d *DoubleSignEvidence) Serialize() []byte {
+	return d.evidence.Serialize()
+}
+
+// LoadProto loads the double sign evidence action from serialized evidence, and verifies the evidence
+func (d *DoubleSignEvidence) LoadProto(buf []byte) error {
+	if d == nil {
+		return ErrNilAction
+	}
+	*d = DoubleSignEvidence{
+		evidence: &evidence.DoubleSign{},
+	}
+	return d.evidence.Deserialize(buf)
+}
+
+// IntrinsicGas returns the intrinsic gas of a double sign evidence action, which is 0
+func (*DoubleSignEvidence) IntrinsicGas() (uint64, error) {
+	return 0, nil
+}
+
+// Cost returns the total cost of a double sign evidence action
+func (*DoubleSignEvidence) Cost() (*big.Int, error) {
+	return big.NewInt(0), nil
+}
+
+// SanityCheck validates the variables in the action
+func (d *DoubleSignEvidence) SanityCheck() error {
+	if d.evidence == nil {
+		return ErrNilAction
+	}
+	return d.AbstractAction.SanityCheck()
+}
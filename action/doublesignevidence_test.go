@@ -0,0 +1,92 @@
+// Copyright (c) 2022 IoTeX Foundation
+// This is an alpha (internal) release and is not suitable for production. This source code is provided 'as is' and no
+// warranties are given as to title or non-infringement, merchantability or fitness for purpose and, to the extent
+// permitted by law, all liability for your use of the code is disclaimed. This source code is governed by Apache
+// License 2.0 that can be found in the LICENSE file.
+
+package action
+
+import (
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/iotexproject/go-pkgs/hash"
+	"github.com/iotexproject/iotex-proto/golang/iotextypes"
+	blake2b "github.com/minio/blake2b-simd"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/proto"
+
+	"github.com/iotexproject/iotex-core/action/evidence"
+	"github.com/iotexproject/iotex-core/endorsement"
+	"github.com/iotexproject/iotex-core/test/identityset"
+)
+
+type testVote iotextypes.ConsensusVote
+
+func (v *testVote) Hash() ([]byte, error) {
+	ser, err := proto.Marshal((*iotextypes.ConsensusVote)(v))
+	if err != nil {
+		return nil, err
+	}
+	h := blake2b.Sum256(ser)
+	return h[:], nil
+}
+
+func newTestDoubleSign(t *testing.T) *evidence.DoubleSign {
+	ts := time.Unix(1600000000, 0)
+	sk := identityset.PrivateKey(1)
+	var msgs []*iotextypes.ConsensusMessage
+	for _, b := range []string{"block1", "block2"} {
+		blkHash := hash.Hash256b([]byte(b))
+		vote := &iotextypes.ConsensusVote{BlockHash: blkHash[:], Topic: iotextypes.ConsensusVote_COMMIT}
+		en, err := endorsement.Endorse(sk, (*testVote)(vote), ts)
+		require.NoError(t, err)
+		enPb, err := en.Proto()
+		require.NoError(t, err)
+		msgs = append(msgs, &iotextypes.ConsensusMessage{
+			Height:      10,
+			Msg:         &iotextypes.ConsensusMessage_Vote{Vote: vote},
+			Endorsement: enPb,
+		})
+	}
+	ds, err := evidence.NewDoubleSign(msgs[0], msgs[1])
+	require.NoError(t, err)
+	return ds
+}
+
+func TestDoubleSignEvidence(t *testing.T) {
+	require := require.New(t)
+	ds := newTestDoubleSign(t)
+	act := NewDoubleSignEvidence(0, ds)
+	require.Equal(uint64(0), act.Nonce())
+	require.Equal(ds.Hash(), act.Evidence().Hash())
+	igas, err := act.IntrinsicGas()
+	require.NoError(err)
+	require.Zero(igas)
+	cost, err := act.Cost()
+	require.NoError(err)
+	require.Zero(cost.Cmp(big.NewInt(0)))
+	require.NoError(act.SanityCheck())
+
+	clone := &DoubleSignEvidence{}
+	require.NoError(clone.LoadProto(act.Serialize()))
+	require.Equal(ds.Hash(), clone.Evidence().Hash())
+	require.Error(clone.LoadProto([]byte("invalid evidence")))
+
+	// the evidence is carried in the envelope, and survives signing and loading
+	elp := (&EnvelopeBuilder{}).SetNonce(0).SetGasLimit(0).SetAction(act).Build()
+	selp, err := Sign(elp, identityset.PrivateKey(2))
+	require.NoError(err)
+	require.True(IsSystemAction(selp))
+	loaded, err := (&Deserializer{}).ActionToSealedEnvelope(selp.Proto())
+	require.NoError(err)
+	loadedAct, ok := loaded.Action().(*DoubleSignEvidence)
+	require.True(ok)
+	require.Equal(ds.Hash(), loadedAct.Evidence().Hash())
+	h1, err := selp.Hash()
+	require.NoError(err)
+	h2, err := loaded.Hash()
+	require.NoError(err)
+	require.Equal(h1, h2)
+}
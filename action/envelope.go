@@ -7,8 +7,13 @@ import (
 	"github.com/pkg/errors"
 
 	"github.com/iotexproject/iotex-core/pkg/log"
+	"github.com/iotexproject/iotex-core/pkg/util/protoutil"
 )
 
+// _doubleSignEvidenceFieldNum is the extension field of ActionCore which carries a double sign evidence, since
+// there is no such action in the oneof of ActionCore
+const _doubleSignEvidenceFieldNum = 100
+
 type (
 	// Envelope defines an envelope wrapped on action with some envelope metadata.
 	Envelope interface {
@@ -125,12 +130,24 @@ func (elp *envelope) Proto() *iotextypes.ActionCore {
 		actCore.Action = &iotextypes.ActionCore_CandidateRegister{CandidateRegister: act.Proto()}
 	case *CandidateUpdate:
 		actCore.Action = &iotextypes.ActionCore_CandidateUpdate{CandidateUpdate: act.Proto()}
+	case *DoubleSignEvidence:
+		protoutil.SetExtensionField(actCore, _doubleSignEvidenceFieldNum, act.Serialize())
 	default:
 		log.S().Panicf("Cannot convert type of action %T.\r\n", act)
 	}
 	return actCore
 }
 
+// EnvelopeFromProto creates an envelope from protobuf format, e.g., an unsigned action serialized to be signed
+// elsewhere
+func EnvelopeFromProto(pbAct *iotextypes.ActionCore) (Envelope, error) {
+	elp := &envelope{}
+	if err := elp.LoadProto(pbAct); err != nil {
+		return nil, err
+	}
+	return elp, nil
+}
+
 // LoadProto loads fields from protobuf format.
 func (elp *envelope) LoadProto(pbAct *iotextypes.ActionCore) error {
 	if pbAct == nil {
@@ -247,7 +264,18 @@ func (elp *envelope) LoadProto(pbAct *iotextypes.ActionCore) error {
 		}
 		elp.payload = act
 	default:
-		return errors.Errorf("no applicable action to handle proto type %T", pbAct.Action)
+		ev, err := protoutil.ExtensionField(pbAct, _doubleSignEvidenceFieldNum)
+		if err != nil {
+			return err
+		}
+		if ev == nil {
+			return errors.Errorf("no applicable action to handle proto type %T", pbAct.Action)
+		}
+		act := &DoubleSignEvidence{}
+		if err := act.LoadProto(ev); err != nil {
+			return err
+		}
+		elp.payload = act
 	}
 	elp.payload.SetEnvelopeContext(elp)
 	return nil
@@ -67,6 +67,12 @@ func TestEnvelope_Proto(t *testing.T) {
 	req.Equal(tsf.amount, tsf2.amount)
 	req.Equal(tsf.recipient, tsf2.recipient)
 	req.Equal(tsf.payload, tsf2.payload)
+
+	evlp3, err := EnvelopeFromProto(proto)
+	req.NoError(err)
+	req.Equal(proto, evlp3.Proto())
+	_, err = EnvelopeFromProto(nil)
+	req.Equal(ErrNilProto, err)
 }
 
 func TestEnvelope_Actions(t *testing.T) {
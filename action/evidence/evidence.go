@@ -0,0 +1,246 @@
+// Copyright (c) 2022 IoTeX Foundation
+// This is an alpha (internal) release and is not suitable for production. This source code is provided 'as is' and no
+// warranties are given as to title or non-infringement, merchantability or fitness for purpose and, to the extent
+// permitted by law, all liability for your use of the code is disclaimed. This source code is governed by Apache
+// License 2.0 that can be found in the LICENSE file.
+
+package evidence
+
+import (
+	"bytes"
+	"time"
+
+	"github.com/iotexproject/go-pkgs/crypto"
+	"github.com/iotexproject/go-pkgs/hash"
+	"github.com/iotexproject/iotex-proto/golang/iotextypes"
+	blake2b "github.com/minio/blake2b-simd"
+	"github.com/pkg/errors"
+	"google.golang.org/protobuf/proto"
+
+	"github.com/iotexproject/iotex-core/action/evidence/evidencepb"
+	"github.com/iotexproject/iotex-core/endorsement"
+	"github.com/iotexproject/iotex-core/pkg/util/byteutil"
+	"github.com/iotexproject/iotex-core/pkg/util/protoutil"
+)
+
+// ErrInvalidEvidence indicates the evidence does not prove a double sign
+var ErrInvalidEvidence = errors.New("invalid double sign evidence")
+
+const (
+	// _proposalKind is the kind of a block proposal, which is distinguished from the topics of consensus votes
+	_proposalKind = -1
+	// _gossipFieldNum is the number of the unknown field which carries an evidence in a consensus message
+	_gossipFieldNum = 100
+)
+
+type (
+	// DoubleSign is the evidence that a delegate has endorsed two conflicting consensus messages in the same round,
+	// i.e., two different block proposals, or two votes on the same topic for different blocks. A DoubleSign is
+	// always verified upon creation, so that it could be used to punish the offender without further check.
+	DoubleSign struct {
+		first  *iotextypes.ConsensusMessage
+		second *iotextypes.ConsensusMessage
+
+		offender  crypto.PublicKey
+		kind      int
+		timestamp time.Time
+	}
+
+	// signedMessage is the document hash and endorsement of a consensus message
+	signedMessage struct {
+		kind     int
+		docHash  []byte
+		blkHash  []byte
+		endorser crypto.PublicKey
+		en       *endorsement.Endorsement
+	}
+
+	// docHash implements endorsement.Document for a known document hash
+	docHash []byte
+)
+
+// NewDoubleSign creates a double sign evidence from two conflicting consensus messages
+func NewDoubleSign(a, b *iotextypes.ConsensusMessage) (*DoubleSign, error) {
+	if a == nil || b == nil {
+		return nil, errors.Wrap(ErrInvalidEvidence, "nil consensus message")
+	}
+	sa, err := newSignedMessage(a)
+	if err != nil {
+		return nil, err
+	}
+	sb, err := newSignedMessage(b)
+	if err != nil {
+		return nil, err
+	}
+	if err := checkConflict(sa, sb); err != nil {
+		return nil, err
+	}
+	// order the messages by document hash, such that the same pair of messages always yields the same evidence
+	if bytes.Compare(sa.docHash, sb.docHash) > 0 {
+		a, b = b, a
+	}
+	return &DoubleSign{
+		first:     proto.Clone(a).(*iotextypes.ConsensusMessage),
+		second:    proto.Clone(b).(*iotextypes.ConsensusMessage),
+		offender:  sa.endorser,
+		kind:      sa.kind,
+		timestamp: sa.en.Timestamp(),
+	}, nil
+}
+
+// Offender returns the public key of the delegate who double signed
+func (ds *DoubleSign) Offender() crypto.PublicKey {
+	return ds.offender
+}
+
+// Timestamp returns the timestamp of the conflicting endorsements
+func (ds *DoubleSign) Timestamp() time.Time {
+	return ds.timestamp
+}
+
+// Height returns the height claimed by the conflicting consensus messages, which is not signed by the offender
+func (ds *DoubleSign) Height() uint64 {
+	return ds.first.GetHeight()
+}
+
+// Messages returns the two conflicting consensus messages
+func (ds *DoubleSign) Messages() (*iotextypes.ConsensusMessage, *iotextypes.ConsensusMessage) {
+	return ds.first, ds.second
+}
+
+// Hash returns the hash identifying the double sign. It is computed from the data signed by the offender only, i.e.
+// the offender, the kind of the messages and the timestamp of the endorsements, which identifies the height and the
+// round, so that an evidence can't be made into another one by changing the unsigned fields of the messages
+func (ds *DoubleSign) Hash() hash.Hash256 {
+	b := append([]byte{}, ds.offender.Bytes()...)
+	b = append(b, byteutil.Uint64ToBytesBigEndian(uint64(ds.kind))...)
+	b = append(b, byteutil.Uint64ToBytesBigEndian(uint64(ds.timestamp.Unix()))...)
+	return hash.Hash256b(append(b, byteutil.Uint32ToBytesBigEndian(uint32(ds.timestamp.Nanosecond()))...))
+}
+
+// Proto converts the evidence to protobuf message
+func (ds *DoubleSign) Proto() *evidencepb.DoubleSign {
+	return &evidencepb.DoubleSign{
+		First:  ds.first,
+		Second: ds.second,
+	}
+}
+
+// LoadProto loads the evidence from protobuf message, and verifies it
+func (ds *DoubleSign) LoadProto(dsPb *evidencepb.DoubleSign) error {
+	if dsPb == nil {
+		return errors.Wrap(ErrInvalidEvidence, "nil proto")
+	}
+	loaded, err := NewDoubleSign(dsPb.GetFirst(), dsPb.GetSecond())
+	if err != nil {
+		return err
+	}
+	*ds = *loaded
+	return nil
+}
+
+// Serialize returns the serialized bytes of the evidence
+func (ds *DoubleSign) Serialize() []byte {
+	return byteutil.Must(proto.Marshal(ds.Proto()))
+}
+
+// Deserialize converts a byte slice to the evidence
+func (ds *DoubleSign) Deserialize(buf []byte) error {
+	dsPb := &evidencepb.DoubleSign{}
+	if err := proto.Unmarshal(buf, dsPb); err != nil {
+		return errors.Wrap(err, "failed to unmarshal double sign evidence")
+	}
+	return ds.LoadProto(dsPb)
+}
+
+// ConsensusMessage wraps the evidence into a consensus message without vote or proposal, such that it could be
+// gossiped over the p2p network
+func (ds *DoubleSign) ConsensusMessage() *iotextypes.ConsensusMessage {
+	msg := &iotextypes.ConsensusMessage{Height: ds.Height()}
+	protoutil.SetExtensionField(msg, _gossipFieldNum, ds.Serialize())
+	return msg
+}
+
+// FromConsensusMessage extracts and verifies the evidence gossiped in a consensus message. It returns nil if the
+// message does not carry an evidence.
+func FromConsensusMessage(msg *iotextypes.ConsensusMessage) (*DoubleSign, error) {
+	if msg.GetMsg() != nil {
+		return nil, nil
+	}
+	data, err := protoutil.ExtensionField(msg, _gossipFieldNum)
+	if err != nil || data == nil {
+		return nil, err
+	}
+	ds := &DoubleSign{}
+	if err := ds.Deserialize(data); err != nil {
+		return nil, err
+	}
+	return ds, nil
+}
+
+func newSignedMessage(msg *iotextypes.ConsensusMessage) (*signedMessage, error) {
+	if msg.GetEndorsement() == nil {
+		return nil, errors.Wrap(ErrInvalidEvidence, "missing endorsement")
+	}
+	sm := &signedMessage{en: &endorsement.Endorsement{}}
+	if err := sm.en.LoadProto(msg.GetEndorsement()); err != nil {
+		return nil, errors.Wrap(ErrInvalidEvidence, err.Error())
+	}
+	var err error
+	if sm.kind, sm.docHash, err = documentHash(msg); err != nil {
+		return nil, err
+	}
+	if vote := msg.GetVote(); vote != nil {
+		sm.blkHash = vote.GetBlockHash()
+	}
+	if !endorsement.VerifyEndorsement(docHash(sm.docHash), sm.en) {
+		return nil, errors.Wrap(ErrInvalidEvidence, "invalid endorsement")
+	}
+	sm.endorser = sm.en.Endorser()
+	return sm, nil
+}
+
+// documentHash returns the kind of the consensus message and the hash of the document it endorses, which is
+// computed in the same way as the consensus votes and block proposals of roll-DPoS
+func documentHash(msg *iotextypes.ConsensusMessage) (int, []byte, error) {
+	switch {
+	case msg.GetVote() != nil:
+		ser, err := proto.Marshal(msg.GetVote())
+		if err != nil {
+			return 0, nil, err
+		}
+		h := blake2b.Sum256(ser)
+		return int(msg.GetVote().GetTopic()), h[:], nil
+	case msg.GetBlockProposal() != nil:
+		ser, err := proto.Marshal(msg.GetBlockProposal())
+		if err != nil {
+			return 0, nil, err
+		}
+		h := hash.Hash256b(ser)
+		return _proposalKind, h[:], nil
+	default:
+		return 0, nil, errors.Wrap(ErrInvalidEvidence, "unknown consensus message type")
+	}
+}
+
+func checkConflict(a, b *signedMessage) error {
+	switch {
+	case !bytes.Equal(a.endorser.Bytes(), b.endorser.Bytes()):
+		return errors.Wrap(ErrInvalidEvidence, "different endorsers")
+	case !a.en.Timestamp().Equal(b.en.Timestamp()):
+		return errors.Wrap(ErrInvalidEvidence, "different rounds")
+	case a.kind != b.kind:
+		return errors.Wrap(ErrInvalidEvidence, "different message types")
+	case bytes.Equal(a.docHash, b.docHash):
+		return errors.Wrap(ErrInvalidEvidence, "same message")
+	case a.kind != _proposalKind && (len(a.blkHash) == 0 || len(b.blkHash) == 0):
+		// a vote for nothing does not conflict with a vote for a block
+		return errors.Wrap(ErrInvalidEvidence, "empty vote")
+	}
+	return nil
+}
+
+// Hash returns the document hash
+func (h docHash) Hash() ([]byte, error) {
+	return h, nil
+}
@@ -0,0 +1,158 @@
+// Copyright (c) 2022 IoTeX Foundation
+// This is an alpha (internal) release and is not suitable for production. This source code is provided 'as is' and no
+// warranties are given as to title or non-infringement, merchantability or fitness for purpose and, to the extent
+// permitted by law, all liability for your use of the code is disclaimed. This source code is governed by Apache
+// License 2.0 that can be found in the LICENSE file.
+
+package evidence
+
+import (
+	"testing"
+	"time"
+
+	"github.com/iotexproject/go-pkgs/crypto"
+	"github.com/iotexproject/go-pkgs/hash"
+	"github.com/iotexproject/iotex-proto/golang/iotextypes"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/proto"
+
+	"github.com/iotexproject/iotex-core/endorsement"
+	"github.com/iotexproject/iotex-core/test/identityset"
+)
+
+func signMessage(t *testing.T, sk crypto.PrivateKey, msg *iotextypes.ConsensusMessage, ts time.Time) *iotextypes.ConsensusMessage {
+	_, h, err := documentHash(msg)
+	require.NoError(t, err)
+	en, err := endorsement.Endorse(sk, docHash(h), ts)
+	require.NoError(t, err)
+	msg.Endorsement, err = en.Proto()
+	require.NoError(t, err)
+	return msg
+}
+
+func voteMessage(t *testing.T, sk crypto.PrivateKey, blkHash []byte, topic iotextypes.ConsensusVote_Topic, ts time.Time) *iotextypes.ConsensusMessage {
+	return signMessage(t, sk, &iotextypes.ConsensusMessage{
+		Height: 10,
+		Msg: &iotextypes.ConsensusMessage_Vote{
+			Vote: &iotextypes.ConsensusVote{BlockHash: blkHash, Topic: topic},
+		},
+	}, ts)
+}
+
+func proposalMessage(t *testing.T, sk crypto.PrivateKey, height uint64, ts time.Time) *iotextypes.ConsensusMessage {
+	return signMessage(t, sk, &iotextypes.ConsensusMessage{
+		Height: height,
+		Msg: &iotextypes.ConsensusMessage_BlockProposal{
+			BlockProposal: &iotextypes.BlockProposal{
+				Block: &iotextypes.Block{
+					Header: &iotextypes.BlockHeader{
+						Core: &iotextypes.BlockHeaderCore{Height: height},
+					},
+				},
+			},
+		},
+	}, ts)
+}
+
+func TestDoubleSign(t *testing.T) {
+	require := require.New(t)
+	ts := time.Unix(1600000000, 0)
+	sk := identityset.PrivateKey(1)
+	hash1, hash2 := hash.Hash256b([]byte("block1")), hash.Hash256b([]byte("block2"))
+
+	t.Run("conflicting votes", func(t *testing.T) {
+		a := voteMessage(t, sk, hash1[:], iotextypes.ConsensusVote_COMMIT, ts)
+		b := voteMessage(t, sk, hash2[:], iotextypes.ConsensusVote_COMMIT, ts)
+		ds, err := NewDoubleSign(a, b)
+		require.NoError(err)
+		require.Equal(sk.PublicKey().Bytes(), ds.Offender().Bytes())
+		require.True(ts.Equal(ds.Timestamp()))
+		require.Equal(uint64(10), ds.Height())
+
+		// the evidence is independent of the order of the messages
+		ds2, err := NewDoubleSign(b, a)
+		require.NoError(err)
+		require.Equal(ds.Hash(), ds2.Hash())
+
+		// the unsigned height doesn't make another evidence of the same double sign
+		a2, b2 := proto.Clone(a).(*iotextypes.ConsensusMessage), proto.Clone(b).(*iotextypes.ConsensusMessage)
+		a2.Height, b2.Height = 11, 11
+		ds2, err = NewDoubleSign(a2, b2)
+		require.NoError(err)
+		require.Equal(ds.Hash(), ds2.Hash())
+		// neither does another conflicting vote in the same round
+		hash3 := hash.Hash256b([]byte("block3"))
+		ds2, err = NewDoubleSign(a, voteMessage(t, sk, hash3[:], iotextypes.ConsensusVote_COMMIT, ts))
+		require.NoError(err)
+		require.Equal(ds.Hash(), ds2.Hash())
+		// a double sign on another topic or in another round is another evidence
+		ds2, err = NewDoubleSign(
+			voteMessage(t, sk, hash1[:], iotextypes.ConsensusVote_LOCK, ts),
+			voteMessage(t, sk, hash2[:], iotextypes.ConsensusVote_LOCK, ts),
+		)
+		require.NoError(err)
+		require.NotEqual(ds.Hash(), ds2.Hash())
+		ds2, err = NewDoubleSign(
+			voteMessage(t, sk, hash1[:], iotextypes.ConsensusVote_COMMIT, ts.Add(time.Second)),
+			voteMessage(t, sk, hash2[:], iotextypes.ConsensusVote_COMMIT, ts.Add(time.Second)),
+		)
+		require.NoError(err)
+		require.NotEqual(ds.Hash(), ds2.Hash())
+
+		ds3 := &DoubleSign{}
+		require.NoError(ds3.Deserialize(ds.Serialize()))
+		require.Equal(ds.Hash(), ds3.Hash())
+		require.Equal(ds.Offender().Bytes(), ds3.Offender().Bytes())
+
+		// gossip the evidence in consensus message
+		ds4, err := FromConsensusMessage(ds.ConsensusMessage())
+		require.NoError(err)
+		require.Equal(ds.Hash(), ds4.Hash())
+		ds4, err = FromConsensusMessage(a)
+		require.NoError(err)
+		require.Nil(ds4)
+		ds4, err = FromConsensusMessage(&iotextypes.ConsensusMessage{Height: 10})
+		require.NoError(err)
+		require.Nil(ds4)
+	})
+	t.Run("conflicting proposals", func(t *testing.T) {
+		ds, err := NewDoubleSign(proposalMessage(t, sk, 10, ts), proposalMessage(t, sk, 11, ts))
+		require.NoError(err)
+		require.Equal(sk.PublicKey().Bytes(), ds.Offender().Bytes())
+	})
+	t.Run("not conflicting", func(t *testing.T) {
+		a := voteMessage(t, sk, hash1[:], iotextypes.ConsensusVote_COMMIT, ts)
+		for _, b := range []*iotextypes.ConsensusMessage{
+			// same vote
+			voteMessage(t, sk, hash1[:], iotextypes.ConsensusVote_COMMIT, ts),
+			// different topic
+			voteMessage(t, sk, hash2[:], iotextypes.ConsensusVote_LOCK, ts),
+			// different round
+			voteMessage(t, sk, hash2[:], iotextypes.ConsensusVote_COMMIT, ts.Add(time.Second)),
+			// different endorser
+			voteMessage(t, identityset.PrivateKey(2), hash2[:], iotextypes.ConsensusVote_COMMIT, ts),
+			// vote for nothing
+			voteMessage(t, sk, nil, iotextypes.ConsensusVote_COMMIT, ts),
+			// proposal
+			proposalMessage(t, sk, 10, ts),
+		} {
+			_, err := NewDoubleSign(a, b)
+			require.Equal(ErrInvalidEvidence, errors.Cause(err))
+		}
+	})
+	t.Run("invalid signature", func(t *testing.T) {
+		a := voteMessage(t, sk, hash1[:], iotextypes.ConsensusVote_COMMIT, ts)
+		b := voteMessage(t, sk, hash2[:], iotextypes.ConsensusVote_COMMIT, ts)
+		hash3 := hash.Hash256b([]byte("block3"))
+		b.GetVote().BlockHash = hash3[:]
+		_, err := NewDoubleSign(a, b)
+		require.Equal(ErrInvalidEvidence, errors.Cause(err))
+
+		b.Endorsement = nil
+		_, err = NewDoubleSign(a, b)
+		require.Equal(ErrInvalidEvidence, errors.Cause(err))
+		_, err = NewDoubleSign(a, nil)
+		require.Equal(ErrInvalidEvidence, errors.Cause(err))
+	})
+}
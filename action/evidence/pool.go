@@ -0,0 +1,72 @@
+// Copyright (c) 2022 IoTeX Foundation
+// This is an alpha (internal) release and is not suitable for production. This source code is provided 'as is' and no
+// warranties are given as to title or non-infringement, merchantability or fitness for purpose and, to the extent
+// permitted by law, all liability for your use of the code is disclaimed. This source code is governed by Apache
+// License 2.0 that can be found in the LICENSE file.
+
+package evidence
+
+import (
+	"bytes"
+	"sort"
+	"sync"
+
+	"github.com/iotexproject/go-pkgs/hash"
+)
+
+// Pool keeps the double sign evidences which are waiting to be included into a block
+type Pool struct {
+	mutex     sync.RWMutex
+	evidences map[hash.Hash256]*DoubleSign
+}
+
+// NewPool creates an evidence pool
+func NewPool() *Pool {
+	return &Pool{
+		evidences: map[hash.Hash256]*DoubleSign{},
+	}
+}
+
+// Add adds an evidence into the pool, and returns false if it already exists
+func (p *Pool) Add(ds *DoubleSign) bool {
+	h := ds.Hash()
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+	if _, ok := p.evidences[h]; ok {
+		return false
+	}
+	p.evidences[h] = ds
+	return true
+}
+
+// Remove removes an evidence from the pool
+func (p *Pool) Remove(h hash.Hash256) {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+	delete(p.evidences, h)
+}
+
+// Size returns the number of evidences in the pool
+func (p *Pool) Size() int {
+	p.mutex.RLock()
+	defer p.mutex.RUnlock()
+	return len(p.evidences)
+}
+
+// Pending returns the evidences in the pool, sorted by timestamp and hash
+func (p *Pool) Pending() []*DoubleSign {
+	p.mutex.RLock()
+	evidences := make([]*DoubleSign, 0, len(p.evidences))
+	for _, ds := range p.evidences {
+		evidences = append(evidences, ds)
+	}
+	p.mutex.RUnlock()
+	sort.Slice(evidences, func(i, j int) bool {
+		if !evidences[i].Timestamp().Equal(evidences[j].Timestamp()) {
+			return evidences[i].Timestamp().Before(evidences[j].Timestamp())
+		}
+		hi, hj := evidences[i].Hash(), evidences[j].Hash()
+		return bytes.Compare(hi[:], hj[:]) < 0
+	})
+	return evidences
+}
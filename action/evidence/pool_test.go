@@ -0,0 +1,52 @@
+// Copyright (c) 2022 IoTeX Foundation
+// This is an alpha (internal) release and is not suitable for production. This source code is provided 'as is' and no
+// warranties are given as to title or non-infringement, merchantability or fitness for purpose and, to the extent
+// permitted by law, all liability for your use of the code is disclaimed. This source code is governed by Apache
+// License 2.0 that can be found in the LICENSE file.
+
+package evidence
+
+import (
+	"testing"
+	"time"
+
+	"github.com/iotexproject/go-pkgs/hash"
+	"github.com/iotexproject/iotex-proto/golang/iotextypes"
+	"github.com/stretchr/testify/require"
+
+	"github.com/iotexproject/iotex-core/test/identityset"
+)
+
+func TestPool(t *testing.T) {
+	require := require.New(t)
+	ts := time.Unix(1600000000, 0)
+	hash1, hash2 := hash.Hash256b([]byte("block1")), hash.Hash256b([]byte("block2"))
+
+	var evidences []*DoubleSign
+	for i := 2; i >= 0; i-- {
+		sk := identityset.PrivateKey(i)
+		ds, err := NewDoubleSign(
+			voteMessage(t, sk, hash1[:], iotextypes.ConsensusVote_PROPOSAL, ts.Add(time.Duration(i)*time.Second)),
+			voteMessage(t, sk, hash2[:], iotextypes.ConsensusVote_PROPOSAL, ts.Add(time.Duration(i)*time.Second)),
+		)
+		require.NoError(err)
+		evidences = append(evidences, ds)
+	}
+
+	p := NewPool()
+	for _, ds := range evidences {
+		require.True(p.Add(ds))
+		require.False(p.Add(ds))
+	}
+	require.Equal(3, p.Size())
+	pending := p.Pending()
+	require.Len(pending, 3)
+	for i := range pending {
+		require.Equal(evidences[2-i].Hash(), pending[i].Hash())
+	}
+
+	p.Remove(evidences[0].Hash())
+	require.Equal(2, p.Size())
+	p.Remove(evidences[0].Hash())
+	require.Equal(2, p.Size())
+}
@@ -14,6 +14,8 @@ import (
 	"github.com/ethereum/go-ethereum/core/vm"
 	"github.com/iotexproject/go-pkgs/hash"
 	"github.com/iotexproject/iotex-address/address"
+
+	"github.com/iotexproject/iotex-core/action"
 	"github.com/iotexproject/iotex-core/blockchain/genesis"
 	"github.com/iotexproject/iotex-core/pkg/log"
 )
@@ -33,6 +35,10 @@ type (
 
 	vmConfigContextKey struct{}
 
+	handleTracerContextKey struct{}
+
+	storageTracerContextKey struct{}
+
 	// TipInfo contains the tip block information
 	TipInfo struct {
 		Height    uint64
@@ -76,6 +82,13 @@ type (
 		Nonce uint64
 	}
 
+	// HandleTracer is called with the name of the protocol which handled an action, the receipt and the time it took
+	HandleTracer func(name string, act action.Action, receipt *action.Receipt, elapsed time.Duration)
+
+	// StorageTracer is called with the contract, the storage key and its values before and after, for every slot
+	// of contract storage changed by an action. A nil value means the slot did not exist
+	StorageTracer func(contract hash.Hash160, key hash.Hash256, before, after []byte)
+
 	// CheckFunc is function type to check by height.
 	CheckFunc func(height uint64) bool
 
@@ -108,6 +121,7 @@ type (
 		CreateLegacyNonceAccount                bool
 		FixGasAndNonceUpdate                    bool
 		FixUnproductiveDelegates                bool
+		EnableDoubleSignSlashing                bool
 	}
 
 	// FeatureWithHeightCtx provides feature check functions.
@@ -240,6 +254,7 @@ func WithFeatureCtx(ctx context.Context) context.Context {
 			CreateLegacyNonceAccount:                !g.IsOkhotsk(height),
 			FixGasAndNonceUpdate:                    g.IsOkhotsk(height),
 			FixUnproductiveDelegates:                g.IsOkhotsk(height),
+			EnableDoubleSignSlashing:                g.IsSlashing(height),
 		},
 	)
 }
@@ -318,3 +333,25 @@ func GetVMConfigCtx(ctx context.Context) (vm.Config, bool) {
 	cfg, ok := ctx.Value(vmConfigContextKey{}).(vm.Config)
 	return cfg, ok
 }
+
+// WithHandleTracerCtx adds a tracer of handling actions to context
+func WithHandleTracerCtx(ctx context.Context, tracer HandleTracer) context.Context {
+	return context.WithValue(ctx, handleTracerContextKey{}, tracer)
+}
+
+// GetHandleTracerCtx returns the tracer of handling actions from context
+func GetHandleTracerCtx(ctx context.Context) (HandleTracer, bool) {
+	tracer, ok := ctx.Value(handleTracerContextKey{}).(HandleTracer)
+	return tracer, ok
+}
+
+// WithStorageTracerCtx adds a tracer of contract storage changes to context
+func WithStorageTracerCtx(ctx context.Context, tracer StorageTracer) context.Context {
+	return context.WithValue(ctx, storageTracerContextKey{}, tracer)
+}
+
+// GetStorageTracerCtx returns the tracer of contract storage changes from context
+func GetStorageTracerCtx(ctx context.Context) (StorageTracer, bool) {
+	tracer, ok := ctx.Value(storageTracerContextKey{}).(StorageTracer)
+	return tracer, ok
+}
@@ -40,6 +40,7 @@ type (
 		Commit() error
 		LoadRoot() error
 		Iterator() (trie.Iterator, error)
+		IteratorFrom([]byte) (trie.Iterator, error)
 		Snapshot() Contract
 	}
 
@@ -60,6 +61,11 @@ func (c *contract) Iterator() (trie.Iterator, error) {
 	return mptrie.NewLeafIterator(c.trie)
 }
 
+// IteratorFrom returns the iterator of the storage from the first key not less than start
+func (c *contract) IteratorFrom(start []byte) (trie.Iterator, error) {
+	return mptrie.NewLeafIteratorFrom(c.trie, start)
+}
+
 // GetState get the committed value of a key
 func (c *contract) GetCommittedState(key hash.Hash256) ([]byte, error) {
 	if v, ok := c.committed[key]; ok {
@@ -7,7 +7,9 @@
 package evm
 
 import (
+	"bytes"
 	"math/big"
+	"sort"
 	"testing"
 
 	"github.com/ethereum/go-ethereum/common"
@@ -239,3 +241,50 @@ func TestSnapshot(t *testing.T) {
 		testfunc(true)
 	})
 }
+
+func TestReadContractStorageRange(t *testing.T) {
+	require := require.New(t)
+	ctrl := gomock.NewController(t)
+	sm, err := initMockStateManager(ctrl)
+	require.NoError(err)
+
+	addr := identityset.Address(28)
+	_, err = accountutil.LoadOrCreateAccount(sm, addr)
+	require.NoError(err)
+	stateDB, err := NewStateDBAdapter(sm, 0, hash.ZeroHash256)
+	require.NoError(err)
+	evmAddr := common.BytesToAddress(addr.Bytes())
+	stateDB.SetCode(evmAddr, _bytecode)
+	keys := []common.Hash{_k1, _k2, _k3, _k4}
+	for _, k := range keys {
+		stateDB.SetState(evmAddr, k, _v1)
+	}
+	sort.Slice(keys, func(i, j int) bool {
+		return bytes.Compare(keys[i][:], keys[j][:]) < 0
+	})
+	stateDB.AddPreimage(keys[1], []byte("preimage"))
+	require.NoError(stateDB.CommitContracts())
+
+	slots, next, err := ReadContractStorageRange(sm, addr, nil, 3)
+	require.NoError(err)
+	require.Len(slots, 3)
+	for i, s := range slots {
+		require.Equal(keys[i][:], s.Key[:])
+		require.Equal(_v1[:], s.Value)
+	}
+	require.Nil(slots[0].Preimage)
+	require.Equal([]byte("preimage"), slots[1].Preimage)
+	require.Equal(keys[3][:], next)
+
+	slots, next, err = ReadContractStorageRange(sm, addr, next, 3)
+	require.NoError(err)
+	require.Len(slots, 1)
+	require.Equal(keys[3][:], slots[0].Key[:])
+	require.Nil(next)
+
+	// not a contract
+	slots, next, err = ReadContractStorageRange(sm, identityset.Address(29), nil, 3)
+	require.NoError(err)
+	require.Empty(slots)
+	require.Nil(next)
+}
@@ -27,10 +27,13 @@ import (
 
 	"github.com/iotexproject/iotex-core/action"
 	"github.com/iotexproject/iotex-core/action/protocol"
+	accountutil "github.com/iotexproject/iotex-core/action/protocol/account/util"
 	"github.com/iotexproject/iotex-core/blockchain/genesis"
+	"github.com/iotexproject/iotex-core/db/trie"
 	"github.com/iotexproject/iotex-core/pkg/log"
 	"github.com/iotexproject/iotex-core/pkg/tracer"
 	"github.com/iotexproject/iotex-core/pkg/util/byteutil"
+	"github.com/iotexproject/iotex-core/state"
 )
 
 var (
@@ -291,6 +294,63 @@ func ReadContractStorage(
 	return res[:], nil
 }
 
+// ContractStorageSlot is a slot of contract storage, Preimage is the preimage of the key if it has been recorded
+type ContractStorageSlot struct {
+	Key      hash.Hash256
+	Value    []byte
+	Preimage []byte
+}
+
+// ReadContractStorageRange reads at most limit slots of contract's storage in the ascending order of keys, from the
+// first key not less than start. The key of the next slot is returned if there are more
+func ReadContractStorageRange(
+	sm protocol.StateManager,
+	contract address.Address,
+	start []byte,
+	limit uint32,
+) ([]*ContractStorageSlot, []byte, error) {
+	addrHash := hash.BytesToHash160(contract.Bytes())
+	account, err := accountutil.LoadAccountByHash160(sm, addrHash)
+	if err != nil {
+		return nil, nil, err
+	}
+	if !account.IsContract() {
+		return nil, nil, nil
+	}
+	ctt, err := newContract(addrHash, account, sm, false)
+	if err != nil {
+		return nil, nil, err
+	}
+	iter, err := ctt.IteratorFrom(start)
+	if err != nil {
+		return nil, nil, err
+	}
+	slots := []*ContractStorageSlot{}
+	for {
+		key, value, err := iter.Next()
+		if err == trie.ErrEndOfIterator {
+			return slots, nil, nil
+		}
+		if err != nil {
+			return nil, nil, err
+		}
+		if uint32(len(slots)) == limit {
+			return slots, key, nil
+		}
+		var preimage protocol.SerializableBytes
+		switch _, err := sm.State(&preimage, protocol.NamespaceOption(PreimageKVNameSpace), protocol.KeyOption(key)); errors.Cause(err) {
+		case nil, state.ErrStateNotExist:
+		default:
+			return nil, nil, err
+		}
+		slots = append(slots, &ContractStorageSlot{
+			Key:      hash.BytesToHash256(key),
+			Value:    value,
+			Preimage: preimage,
+		})
+	}
+}
+
 func prepareStateDB(ctx context.Context, sm protocol.StateManager) (*StateDBAdapter, error) {
 	actionCtx := protocol.MustGetActionCtx(ctx)
 	blkCtx := protocol.MustGetBlockCtx(ctx)
@@ -317,6 +377,9 @@ func prepareStateDB(ctx context.Context, sm protocol.StateManager) (*StateDBAdap
 	if !featureCtx.FixUnproductiveDelegates {
 		opts = append(opts, NotCheckPutStateErrorOption())
 	}
+	if tracer, ok := protocol.GetStorageTracerCtx(ctx); ok {
+		opts = append(opts, StorageTracerOption(tracer))
+	}
 
 	return NewStateDBAdapter(
 		sm,
@@ -74,6 +74,8 @@ type (
 		fixSnapshotOrder           bool
 		revertLog                  bool
 		notCheckPutStateError      bool
+		storageTracer              protocol.StorageTracer
+		storageOrigins             map[hash.Hash160]map[hash.Hash256][]byte // values of the storage slots before set
 	}
 )
 
@@ -144,6 +146,15 @@ func NotCheckPutStateErrorOption() StateDBAdapterOption {
 	}
 }
 
+// StorageTracerOption reports the storage slots changed by the execution to tracer on commit
+func StorageTracerOption(tracer protocol.StorageTracer) StateDBAdapterOption {
+	return func(adapter *StateDBAdapter) error {
+		adapter.storageTracer = tracer
+		adapter.storageOrigins = make(map[hash.Hash160]map[hash.Hash256][]byte)
+		return nil
+	}
+}
+
 // NewStateDBAdapter creates a new state db with iotex blockchain
 func NewStateDBAdapter(
 	sm protocol.StateManager,
@@ -903,6 +914,13 @@ func (stateDB *StateDBAdapter) SetState(evmAddr common.Address, k, v common.Hash
 		return
 	}
 	log.L().Debug("Called SetState", log.Hex("addrHash", evmAddr[:]), log.Hex("k", k[:]))
+	if stateDB.storageTracer != nil {
+		if err := stateDB.recordStorageOrigin(addr, contract, hash.BytesToHash256(k[:])); err != nil {
+			log.L().Error("Failed to record storage origin.", zap.Error(err), log.Hex("addrHash", addr[:]))
+			stateDB.logError(err)
+			return
+		}
+	}
 	if err := contract.SetState(hash.BytesToHash256(k[:]), v[:]); err != nil {
 		log.L().Error("Failed to set state.", zap.Error(err), log.Hex("addrHash", addr[:]))
 		stateDB.logError(err)
@@ -910,6 +928,52 @@ func (stateDB *StateDBAdapter) SetState(evmAddr common.Address, k, v common.Hash
 	}
 }
 
+func (stateDB *StateDBAdapter) recordStorageOrigin(addr hash.Hash160, contract Contract, key hash.Hash256) error {
+	origins, ok := stateDB.storageOrigins[addr]
+	if !ok {
+		origins = make(map[hash.Hash256][]byte)
+		stateDB.storageOrigins[addr] = origins
+	}
+	if _, ok := origins[key]; ok {
+		return nil
+	}
+	v, err := contractStorage(contract, key)
+	if err != nil {
+		return err
+	}
+	origins[key] = v
+	return nil
+}
+
+func (stateDB *StateDBAdapter) traceStorage(addr hash.Hash160, contract Contract) error {
+	origins := stateDB.storageOrigins[addr]
+	keys := make([]hash.Hash256, 0, len(origins))
+	for k := range origins {
+		keys = append(keys, k)
+	}
+	sort.Slice(keys, func(i, j int) bool { return bytes.Compare(keys[i][:], keys[j][:]) < 0 })
+	for _, k := range keys {
+		v, err := contractStorage(contract, k)
+		if err != nil {
+			return err
+		}
+		if !bytes.Equal(origins[k], v) {
+			stateDB.storageTracer(addr, k, origins[k], v)
+		}
+	}
+	delete(stateDB.storageOrigins, addr)
+	return nil
+}
+
+// contractStorage returns the value of a storage slot, or nil if it does not exist
+func contractStorage(contract Contract, key hash.Hash256) ([]byte, error) {
+	v, err := contract.GetState(key)
+	if errors.Cause(err) == trie.ErrNotExist {
+		return nil, nil
+	}
+	return v, err
+}
+
 // CommitContracts commits contract code to db and update pending contract account changes to trie
 func (stateDB *StateDBAdapter) CommitContracts() error {
 	addrStrs := make([]string, 0)
@@ -930,6 +994,12 @@ func (stateDB *StateDBAdapter) CommitContracts() error {
 			continue
 		}
 		contract := stateDB.cachedContract[addr]
+		if stateDB.storageTracer != nil {
+			if err := stateDB.traceStorage(addr, contract); err != nil {
+				stateDB.logError(err)
+				return errors.Wrap(err, "failed to trace contract storage")
+			}
+		}
 		if err := contract.Commit(); err != nil {
 			stateDB.logError(err)
 			return errors.Wrap(err, "failed to commit contract")
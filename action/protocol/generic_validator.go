@@ -24,15 +24,30 @@ type (
 	GenericValidator struct {
 		accountState AccountState
 		sr           StateReader
+		sv           *action.SignatureVerifier
 	}
+
+	// GenericValidatorOption sets an optional parameter of generic validator
+	GenericValidatorOption func(*GenericValidator)
 )
 
+// SignatureVerifierOption sets the signature verifier to skip the actions whose signatures have been verified
+func SignatureVerifierOption(sv *action.SignatureVerifier) GenericValidatorOption {
+	return func(v *GenericValidator) {
+		v.sv = sv
+	}
+}
+
 // NewGenericValidator constructs a new genericValidator
-func NewGenericValidator(sr StateReader, accountState AccountState) *GenericValidator {
-	return &GenericValidator{
+func NewGenericValidator(sr StateReader, accountState AccountState, opts ...GenericValidatorOption) *GenericValidator {
+	v := &GenericValidator{
 		sr:           sr,
 		accountState: accountState,
 	}
+	for _, opt := range opts {
+		opt(v)
+	}
+	return v
 }
 
 // Validate validates a generic action
@@ -46,7 +61,7 @@ func (v *GenericValidator) Validate(ctx context.Context, selp action.SealedEnvel
 	}
 
 	// Verify action using action sender's public key
-	if err := selp.VerifySignature(); err != nil {
+	if err := v.sv.Verify(&selp); err != nil {
 		return err
 	}
 	caller := selp.SenderAddress()
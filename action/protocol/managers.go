@@ -84,6 +84,14 @@ type (
 		Dock
 	}
 
+	// DeferredStateManager is a state manager which is able to defer a read-modify-write of states until the changes
+	// are committed. The states updated in this way, e.g., the rewarding fund every action deposits gas fee into,
+	// won't make the actions conflict with each other when they are executed in parallel
+	DeferredStateManager interface {
+		StateManager
+		Defer(func(StateManager) error) error
+	}
+
 	// Dock defines an interface for protocol to read/write their private data in StateReader/Manager
 	// data are stored as interface{}, user needs to type-assert on their own upon Unload()
 	Dock interface {
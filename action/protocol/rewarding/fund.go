@@ -82,13 +82,21 @@ func (p *Protocol) Deposit(
 		return nil, err
 	}
 	// Add balance to fund
-	f := fund{}
-	if _, err := p.state(ctx, sm, _fundKey, &f); err != nil {
-		return nil, err
+	addToFund := func(sm protocol.StateManager) error {
+		f := fund{}
+		if _, err := p.state(ctx, sm, _fundKey, &f); err != nil {
+			return err
+		}
+		f.totalBalance = big.NewInt(0).Add(f.totalBalance, amount)
+		f.unclaimedBalance = big.NewInt(0).Add(f.unclaimedBalance, amount)
+		return p.putState(ctx, sm, _fundKey, &f)
+	}
+	if dsm, ok := sm.(protocol.DeferredStateManager); ok {
+		err = dsm.Defer(addToFund)
+	} else {
+		err = addToFund(sm)
 	}
-	f.totalBalance = big.NewInt(0).Add(f.totalBalance, amount)
-	f.unclaimedBalance = big.NewInt(0).Add(f.unclaimedBalance, amount)
-	if err := p.putState(ctx, sm, _fundKey, &f); err != nil {
+	if err != nil {
 		return nil, err
 	}
 	return &action.TransactionLog{
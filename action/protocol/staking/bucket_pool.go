@@ -82,12 +82,14 @@ func (t *totalAmount) AddBalance(amount *big.Int, newBucket bool) {
 	}
 }
 
-func (t *totalAmount) SubBalance(amount *big.Int) error {
-	if amount.Cmp(t.amount) == 1 || t.count == 0 {
+func (t *totalAmount) SubBalance(amount *big.Int, deleteBucket bool) error {
+	if amount.Cmp(t.amount) == 1 || deleteBucket && t.count == 0 {
 		return state.ErrNotEnoughBalance
 	}
 	t.amount.Sub(t.amount, amount)
-	t.count--
+	if deleteBucket {
+		t.count--
+	}
 	return nil
 }
 
@@ -133,7 +135,20 @@ func (bp *BucketPool) Commit(sr protocol.StateReader) error {
 
 // CreditPool subtracts staked amount out of the pool
 func (bp *BucketPool) CreditPool(sm protocol.StateManager, amount *big.Int) error {
-	if err := bp.total.SubBalance(amount); err != nil {
+	if err := bp.total.SubBalance(amount, true); err != nil {
+		return err
+	}
+
+	if bp.enableSMStorage {
+		_, err := sm.PutState(bp.total, protocol.NamespaceOption(_stakingNameSpace), protocol.KeyOption(_bucketPoolAddrKey))
+		return err
+	}
+	return sm.Load(_protocolID, _stakingBucketPool, bp.total)
+}
+
+// SlashPool subtracts slashed amount out of the pool, the slashed bucket remains in the pool
+func (bp *BucketPool) SlashPool(sm protocol.StateManager, amount *big.Int) error {
+	if err := bp.total.SubBalance(amount, false); err != nil {
 		return err
 	}
 
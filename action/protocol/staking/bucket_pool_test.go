@@ -40,11 +40,11 @@ func TestTotalAmount(t *testing.T) {
 	r.Equal(a, b)
 
 	// test sub balance
-	r.Equal(state.ErrNotEnoughBalance, a.SubBalance(big.NewInt(11)))
-	r.NoError(a.SubBalance(big.NewInt(4)))
+	r.Equal(state.ErrNotEnoughBalance, a.SubBalance(big.NewInt(11), true))
+	r.NoError(a.SubBalance(big.NewInt(4), true))
 	r.Equal(big.NewInt(6), a.amount)
 	r.EqualValues(0, a.count)
-	r.Equal(state.ErrNotEnoughBalance, a.SubBalance(big.NewInt(1)))
+	r.Equal(state.ErrNotEnoughBalance, a.SubBalance(big.NewInt(1), true))
 
 	// test add balance
 	a.AddBalance(big.NewInt(1), true)
@@ -53,6 +53,11 @@ func TestTotalAmount(t *testing.T) {
 	a.AddBalance(big.NewInt(0), false)
 	r.Equal(big.NewInt(7), a.amount)
 	r.EqualValues(1, a.count)
+
+	// sub balance without deleting bucket keeps the count
+	r.NoError(a.SubBalance(big.NewInt(2), false))
+	r.Equal(big.NewInt(5), a.amount)
+	r.EqualValues(1, a.count)
 }
 
 func TestBucketPool(t *testing.T) {
@@ -245,6 +245,22 @@ func (m *CandidateCenter) GetByOwner(owner address.Address) *Candidate {
 	return nil
 }
 
+// GetByOperator returns the candidate by operator
+func (m *CandidateCenter) GetByOperator(operator address.Address) *Candidate {
+	if operator == nil {
+		return nil
+	}
+
+	if d := m.change.getByOperator(operator); d != nil {
+		return d
+	}
+
+	if d, hit := m.base.getByOperator(operator.String()); hit && !m.change.containsOwner(d.Owner) {
+		return d.Clone()
+	}
+	return nil
+}
+
 // GetBySelfStakingIndex returns the candidate by self-staking index
 func (m *CandidateCenter) GetBySelfStakingIndex(index uint64) *Candidate {
 	if d := m.change.getBySelfStakingIndex(index); d != nil {
@@ -378,6 +394,15 @@ func (cc *candChange) getByOwner(owner address.Address) *Candidate {
 	return nil
 }
 
+func (cc *candChange) getByOperator(operator address.Address) *Candidate {
+	for _, d := range cc.dirty {
+		if address.Equal(operator, d.Operator) {
+			return d.Clone()
+		}
+	}
+	return nil
+}
+
 func (cc *candChange) getBySelfStakingIndex(index uint64) *Candidate {
 	for _, d := range cc.dirty {
 		if index == d.SelfStakeBucketIdx {
@@ -55,9 +55,11 @@ type (
 		ContainsSelfStakingBucket(uint64) bool
 		GetByName(string) *Candidate
 		GetByOwner(address.Address) *Candidate
+		GetByOperator(address.Address) *Candidate
 		GetBySelfStakingIndex(uint64) *Candidate
 		Upsert(*Candidate) error
 		CreditBucketPool(*big.Int) error
+		SlashBucketPool(*big.Int) error
 		DebitBucketPool(*big.Int, bool) error
 		Commit() error
 		SM() protocol.StateManager
@@ -141,6 +143,10 @@ func (csm *candSM) GetByOwner(addr address.Address) *Candidate {
 	return csm.candCenter.GetByOwner(addr)
 }
 
+func (csm *candSM) GetByOperator(addr address.Address) *Candidate {
+	return csm.candCenter.GetByOperator(addr)
+}
+
 func (csm *candSM) GetBySelfStakingIndex(index uint64) *Candidate {
 	return csm.candCenter.GetBySelfStakingIndex(index)
 }
@@ -168,6 +174,10 @@ func (csm *candSM) CreditBucketPool(amount *big.Int) error {
 	return csm.bucketPool.CreditPool(csm.StateManager, amount)
 }
 
+func (csm *candSM) SlashBucketPool(amount *big.Int) error {
+	return csm.bucketPool.SlashPool(csm.StateManager, amount)
+}
+
 func (csm *candSM) DebitBucketPool(amount *big.Int, newBucket bool) error {
 	return csm.bucketPool.DebitPool(csm, amount, newBucket)
 }
@@ -34,6 +34,7 @@ const (
 	HandleRestake           = "restake"
 	HandleCandidateRegister = "candidateRegister"
 	HandleCandidateUpdate   = "candidateUpdate"
+	HandleDoubleSign        = "doubleSign"
 )
 
 const _withdrawWaitingTime = 14 * 24 * time.Hour // to maintain backward compatibility with r0.11 code
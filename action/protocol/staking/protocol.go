@@ -22,6 +22,7 @@ import (
 	"github.com/iotexproject/iotex-proto/golang/iotextypes"
 
 	"github.com/iotexproject/iotex-core/action"
+	"github.com/iotexproject/iotex-core/action/evidence"
 	"github.com/iotexproject/iotex-core/action/protocol"
 	accountutil "github.com/iotexproject/iotex-core/action/protocol/account/util"
 	"github.com/iotexproject/iotex-core/action/protocol/rolldpos"
@@ -49,6 +50,7 @@ const (
 	_bucket
 	_voterIndex
 	_candIndex
+	_evidence
 )
 
 // Errors
@@ -64,6 +66,9 @@ type (
 		ReceiptStatus() uint64
 	}
 
+	// handler handles a staking action, and returns the receipt log and transaction logs of it
+	handler func(context.Context, CandidateStateManager) (*receiptLog, []*action.TransactionLog, error)
+
 	// Protocol defines the protocol of handling staking
 	Protocol struct {
 		addr               address.Address
@@ -71,6 +76,7 @@ type (
 		config             Configuration
 		candBucketsIndexer *CandidatesBucketsIndexer
 		voteReviser        *VoteReviser
+		evidencePool       *evidence.Pool
 	}
 
 	// Configuration is the staking protocol configuration.
@@ -80,6 +86,7 @@ type (
 		WithdrawWaitingPeriod time.Duration
 		MinStakeAmount        *big.Int
 		BootstrapCandidates   []genesis.BootstrapCandidate
+		DoubleSignSlashRate   uint64
 	}
 
 	// DepositGas deposits gas to some pool
@@ -125,6 +132,10 @@ func NewProtocol(depositGas DepositGas, cfg genesis.Staking, candBucketsIndexer
 		return nil, ErrInvalidAmount
 	}
 
+	if cfg.DoubleSignSlashRate > 100 {
+		return nil, errors.Wrapf(ErrInvalidAmount, "invalid double sign slash rate %d", cfg.DoubleSignSlashRate)
+	}
+
 	// new vote reviser, revise ate greenland
 	voteReviser := NewVoteReviser(cfg.VoteWeightCalConsts, reviseHeights...)
 
@@ -139,6 +150,7 @@ func NewProtocol(depositGas DepositGas, cfg genesis.Staking, candBucketsIndexer
 			WithdrawWaitingPeriod: cfg.WithdrawWaitingPeriod,
 			MinStakeAmount:        minStakeAmount,
 			BootstrapCandidates:   cfg.BootstrapCandidates,
+			DoubleSignSlashRate:   cfg.DoubleSignSlashRate,
 		},
 		depositGas:         depositGas,
 		candBucketsIndexer: candBucketsIndexer,
@@ -309,6 +321,10 @@ func (p *Protocol) Commit(ctx context.Context, sm protocol.StateManager) error {
 
 // Handle handles a staking message
 func (p *Protocol) Handle(ctx context.Context, act action.Action, sm protocol.StateManager) (*action.Receipt, error) {
+	if h, _ := p.handlerOf(act); h == nil {
+		// no need to load the candidate view for the other actions
+		return nil, nil
+	}
 	featureWithHeightCtx := protocol.MustGetFeatureWithHeightCtx(ctx)
 	height, err := sm.Height()
 	if err != nil {
@@ -322,40 +338,74 @@ func (p *Protocol) Handle(ctx context.Context, act action.Action, sm protocol.St
 	return p.handle(ctx, act, csm)
 }
 
-func (p *Protocol) handle(ctx context.Context, act action.Action, csm CandidateStateManager) (*action.Receipt, error) {
-	var (
-		rLog  *receiptLog
-		tLogs []*action.TransactionLog
-		err   error
-		logs  []*action.Log
-	)
-
+// handlerOf returns the handler of a staking action and whether it is a system action, or nil for the actions not
+// handled by staking protocol
+func (p *Protocol) handlerOf(act action.Action) (handler, bool) {
 	switch act := act.(type) {
 	case *action.CreateStake:
-		rLog, tLogs, err = p.handleCreateStake(ctx, act, csm)
+		return func(ctx context.Context, csm CandidateStateManager) (*receiptLog, []*action.TransactionLog, error) {
+			return p.handleCreateStake(ctx, act, csm)
+		}, false
 	case *action.Unstake:
-		rLog, err = p.handleUnstake(ctx, act, csm)
+		return func(ctx context.Context, csm CandidateStateManager) (*receiptLog, []*action.TransactionLog, error) {
+			rLog, err := p.handleUnstake(ctx, act, csm)
+			return rLog, nil, err
+		}, false
 	case *action.WithdrawStake:
-		rLog, tLogs, err = p.handleWithdrawStake(ctx, act, csm)
+		return func(ctx context.Context, csm CandidateStateManager) (*receiptLog, []*action.TransactionLog, error) {
+			return p.handleWithdrawStake(ctx, act, csm)
+		}, false
 	case *action.ChangeCandidate:
-		rLog, err = p.handleChangeCandidate(ctx, act, csm)
+		return func(ctx context.Context, csm CandidateStateManager) (*receiptLog, []*action.TransactionLog, error) {
+			rLog, err := p.handleChangeCandidate(ctx, act, csm)
+			return rLog, nil, err
+		}, false
 	case *action.TransferStake:
-		rLog, err = p.handleTransferStake(ctx, act, csm)
+		return func(ctx context.Context, csm CandidateStateManager) (*receiptLog, []*action.TransactionLog, error) {
+			rLog, err := p.handleTransferStake(ctx, act, csm)
+			return rLog, nil, err
+		}, false
 	case *action.DepositToStake:
-		rLog, tLogs, err = p.handleDepositToStake(ctx, act, csm)
+		return func(ctx context.Context, csm CandidateStateManager) (*receiptLog, []*action.TransactionLog, error) {
+			return p.handleDepositToStake(ctx, act, csm)
+		}, false
 	case *action.Restake:
-		rLog, err = p.handleRestake(ctx, act, csm)
+		return func(ctx context.Context, csm CandidateStateManager) (*receiptLog, []*action.TransactionLog, error) {
+			rLog, err := p.handleRestake(ctx, act, csm)
+			return rLog, nil, err
+		}, false
 	case *action.CandidateRegister:
-		rLog, tLogs, err = p.handleCandidateRegister(ctx, act, csm)
+		return func(ctx context.Context, csm CandidateStateManager) (*receiptLog, []*action.TransactionLog, error) {
+			return p.handleCandidateRegister(ctx, act, csm)
+		}, false
 	case *action.CandidateUpdate:
-		rLog, err = p.handleCandidateUpdate(ctx, act, csm)
+		return func(ctx context.Context, csm CandidateStateManager) (*receiptLog, []*action.TransactionLog, error) {
+			rLog, err := p.handleCandidateUpdate(ctx, act, csm)
+			return rLog, nil, err
+		}, false
+	case *action.DoubleSignEvidence:
+		return func(ctx context.Context, csm CandidateStateManager) (*receiptLog, []*action.TransactionLog, error) {
+			rLog, err := p.handleDoubleSignEvidence(ctx, act, csm)
+			return rLog, nil, err
+		}, true
 	default:
-		return nil, nil
+		return nil, false
 	}
+}
 
+func (p *Protocol) handle(ctx context.Context, act action.Action, csm CandidateStateManager) (*action.Receipt, error) {
+	h, isSystemAction := p.handlerOf(act)
+	if h == nil {
+		return nil, nil
+	}
+	rLog, tLogs, err := h(ctx, csm)
+	var logs []*action.Log
 	if l := rLog.Build(ctx, err); l != nil {
 		logs = append(logs, l)
 	}
+	if isSystemAction {
+		return p.settleSystemAction(ctx, err, logs)
+	}
 	if err == nil {
 		return p.settleAction(ctx, csm.SM(), uint64(iotextypes.ReceiptStatus_Success), logs, tLogs)
 	}
@@ -393,6 +443,8 @@ func (p *Protocol) Validate(ctx context.Context, act action.Action, sr protocol.
 		return p.validateCandidateRegister(ctx, act)
 	case *action.CandidateUpdate:
 		return p.validateCandidateUpdate(ctx, act)
+	case *action.DoubleSignEvidence:
+		return p.validateDoubleSignEvidence(ctx, act, sr)
 	}
 	return nil
 }
@@ -0,0 +1,270 @@
+// Copyright (c) 2022 IoTeX Foundation
+// This is an alpha (internal) release and is not suitable for production. This source code is provided 'as is' and no
+// warranties are given as to title or non-infringement, merchantability or fitness for purpose and, to the extent
+// permitted by law, all liability for your use of the code is disclaimed. This source code is governed by Apache
+// License 2.0 that can be found in the LICENSE file.
+
+package staking
+
+import (
+	"context"
+	"encoding/hex"
+	"math/big"
+	"time"
+
+	"github.com/iotexproject/go-pkgs/hash"
+	"github.com/iotexproject/iotex-address/address"
+	"github.com/iotexproject/iotex-proto/golang/iotextypes"
+	"github.com/pkg/errors"
+	"go.uber.org/zap"
+
+	"github.com/iotexproject/iotex-core/action"
+	"github.com/iotexproject/iotex-core/action/evidence"
+	"github.com/iotexproject/iotex-core/action/protocol"
+	"github.com/iotexproject/iotex-core/blockchain/genesis"
+	"github.com/iotexproject/iotex-core/pkg/log"
+	"github.com/iotexproject/iotex-core/pkg/util/byteutil"
+	"github.com/iotexproject/iotex-core/state"
+)
+
+const (
+	// _maxEvidencesPerBlock is the max number of double sign evidences included in a block
+	_maxEvidencesPerBlock = 16
+	// _evidenceExpiryEpochs is the number of epochs after a double sign, during which its evidence could be included
+	_evidenceExpiryEpochs = 24
+)
+
+// evidenceRecord records the height at which a double sign evidence is included, so that the offender is punished
+// only once for the same evidence
+type evidenceRecord struct {
+	height uint64
+}
+
+// Deserialize deserializes bytes into evidence record
+func (r *evidenceRecord) Deserialize(data []byte) error {
+	r.height = byteutil.BytesToUint64BigEndian(data)
+	return nil
+}
+
+// Serialize serializes evidence record into bytes
+func (r *evidenceRecord) Serialize() ([]byte, error) {
+	return byteutil.Uint64ToBytesBigEndian(r.height), nil
+}
+
+func evidenceKey(h hash.Hash256) []byte {
+	key := []byte{_evidence}
+	return append(key, h[:]...)
+}
+
+// SetEvidencePool sets the pool of double sign evidences to be included into blocks
+func (p *Protocol) SetEvidencePool(pool *evidence.Pool) {
+	p.evidencePool = pool
+}
+
+// CreatePostSystemActions creates the actions to include the pending double sign evidences into block
+func (p *Protocol) CreatePostSystemActions(ctx context.Context, sr protocol.StateReader) ([]action.Envelope, error) {
+	if p.evidencePool == nil || !protocol.MustGetFeatureCtx(ctx).EnableDoubleSignSlashing {
+		return nil, nil
+	}
+	csr, err := ConstructBaseView(sr)
+	if err != nil {
+		return nil, err
+	}
+	elps := []action.Envelope{}
+	for _, ds := range p.evidencePool.Pending() {
+		h := ds.Hash()
+		recorded, err := isEvidenceRecorded(sr, h)
+		if err != nil {
+			return nil, err
+		}
+		if recorded || checkEvidenceAge(ctx, ds) != nil || csr.BaseView().candCenter.GetByOperator(ds.Offender().Address()) == nil {
+			// the evidence has been included or expired, or there is no one to punish
+			p.evidencePool.Remove(h)
+			continue
+		}
+		eb := action.EnvelopeBuilder{}
+		elps = append(elps, eb.SetNonce(0).
+			SetGasPrice(big.NewInt(0)).
+			SetAction(action.NewDoubleSignEvidence(0, ds)).
+			Build())
+		if len(elps) == _maxEvidencesPerBlock {
+			break
+		}
+	}
+	return elps, nil
+}
+
+func (p *Protocol) validateDoubleSignEvidence(ctx context.Context, act *action.DoubleSignEvidence, sr protocol.StateReader) error {
+	if !protocol.MustGetFeatureCtx(ctx).EnableDoubleSignSlashing {
+		return errors.New("double sign evidence is not enabled")
+	}
+	actionCtx := protocol.MustGetActionCtx(ctx)
+	if !address.Equal(protocol.MustGetBlockCtx(ctx).Producer, actionCtx.Caller) {
+		return errors.New("only producer could include double sign evidence")
+	}
+	if actionCtx.GasPrice != nil && actionCtx.GasPrice.Sign() != 0 || actionCtx.IntrinsicGas != 0 {
+		return errors.New("invalid gas price or intrinsic gas for double sign evidence")
+	}
+	if err := checkEvidenceAge(ctx, act.Evidence()); err != nil {
+		return err
+	}
+	recorded, err := isEvidenceRecorded(sr, act.Evidence().Hash())
+	if err != nil {
+		return err
+	}
+	if recorded {
+		return errors.New("double sign evidence has already been included")
+	}
+	return nil
+}
+
+// handleDoubleSignEvidence slashes the self-stake bucket of the delegate who double signed. The evidence is
+// recorded once the offender's self-stake bucket is found, so that it won't be included again, while a rejected
+// evidence leaves no state behind. The state of the block could change after the evidence is picked, so a rejected
+// evidence yields a failed receipt rather than an invalid block.
+func (p *Protocol) handleDoubleSignEvidence(ctx context.Context, act *action.DoubleSignEvidence, csm CandidateStateManager,
+) (*receiptLog, error) {
+	blkCtx := protocol.MustGetBlockCtx(ctx)
+	featureCtx := protocol.MustGetFeatureCtx(ctx)
+	log := newReceiptLog(p.addr.String(), HandleDoubleSign, featureCtx.NewStakingReceiptFormat)
+
+	if !featureCtx.EnableDoubleSignSlashing {
+		return log, &handleError{
+			err:           errors.New("double sign evidence is not enabled"),
+			failureStatus: iotextypes.ReceiptStatus_Failure,
+		}
+	}
+	if err := checkEvidenceAge(ctx, act.Evidence()); err != nil {
+		return log, &handleError{
+			err:           err,
+			failureStatus: iotextypes.ReceiptStatus_Failure,
+		}
+	}
+	h := act.Evidence().Hash()
+	recorded, err := isEvidenceRecorded(csm.SM(), h)
+	if err != nil {
+		return log, errors.Wrapf(err, "failed to check double sign evidence %x", h)
+	}
+	if recorded {
+		return log, &handleError{
+			err:           errors.Errorf("double sign evidence %x has already been included", h),
+			failureStatus: iotextypes.ReceiptStatus_Failure,
+		}
+	}
+	offender := act.Evidence().Offender().Address()
+	candidate := csm.GetByOperator(offender)
+	if candidate == nil {
+		return log, &handleError{
+			err:           errors.Errorf("no candidate is operated by %s", offender.String()),
+			failureStatus: iotextypes.ReceiptStatus_ErrCandidateNotExist,
+		}
+	}
+	bucket, fetchErr := p.fetchBucket(csm, nil, candidate.SelfStakeBucketIdx, false, true)
+	if fetchErr != nil {
+		return log, fetchErr
+	}
+	if _, err := csm.SM().PutState(
+		&evidenceRecord{height: blkCtx.BlockHeight},
+		protocol.NamespaceOption(_stakingNameSpace),
+		protocol.KeyOption(evidenceKey(h))); err != nil {
+		return log, errors.Wrap(err, "failed to record double sign evidence")
+	}
+	log.AddTopics(byteutil.Uint64ToBytesBigEndian(bucket.Index), candidate.Owner.Bytes())
+
+	slashed := new(big.Int).Mul(bucket.StakedAmount, new(big.Int).SetUint64(p.config.DoubleSignSlashRate))
+	slashed.Div(slashed, big.NewInt(100))
+	prevWeightedVotes := p.calculateVoteWeight(bucket, true)
+	bucket.StakedAmount.Sub(bucket.StakedAmount, slashed)
+	if err := csm.updateBucket(bucket.Index, bucket); err != nil {
+		return log, errors.Wrapf(err, "failed to update bucket for candidate %s", candidate.Owner.String())
+	}
+
+	// the votes of an unstaked bucket have already been removed from the candidate
+	if !bucket.isUnstaked() {
+		if err := candidate.SubVote(prevWeightedVotes); err != nil {
+			return log, &handleError{
+				err:           errors.Wrapf(err, "failed to subtract vote for candidate %s", candidate.Owner.String()),
+				failureStatus: iotextypes.ReceiptStatus_ErrNotEnoughBalance,
+			}
+		}
+		if err := candidate.AddVote(p.calculateVoteWeight(bucket, true)); err != nil {
+			return log, &handleError{
+				err:           errors.Wrapf(err, "failed to add vote for candidate %s", candidate.Owner.String()),
+				failureStatus: iotextypes.ReceiptStatus_ErrInvalidBucketAmount,
+			}
+		}
+		// the candidate is no longer active if the remaining self-stake falls below the minimum
+		candidate.SelfStake = new(big.Int).Set(bucket.StakedAmount)
+	}
+	if err := csm.Upsert(candidate); err != nil {
+		return log, csmErrorToHandleError(candidate.Owner.String(), err)
+	}
+
+	// the slashed amount is burnt, while the slashed bucket remains in the pool
+	if err := csm.SlashBucketPool(slashed); err != nil {
+		return log, &handleError{
+			err:           errors.Wrapf(err, "failed to update staking bucket pool %s", err.Error()),
+			failureStatus: iotextypes.ReceiptStatus_ErrWriteAccount,
+		}
+	}
+	log.SetData(slashed.Bytes())
+	return log, nil
+}
+
+// settleSystemAction creates the receipt of a system action, which consumes no gas and doesn't update nonce
+func (p *Protocol) settleSystemAction(ctx context.Context, err error, logs []*action.Log) (*action.Receipt, error) {
+	actionCtx := protocol.MustGetActionCtx(ctx)
+	status := uint64(iotextypes.ReceiptStatus_Success)
+	if err != nil {
+		receiptErr, ok := err.(ReceiptError)
+		if !ok {
+			return nil, err
+		}
+		log.L().With(
+			zap.String("actionHash", hex.EncodeToString(actionCtx.ActionHash[:]))).Debug("Failed to commit staking system action", zap.Error(err))
+		status = receiptErr.ReceiptStatus()
+	}
+	blkCtx := protocol.MustGetBlockCtx(ctx)
+	r := action.Receipt{
+		Status:          status,
+		BlockHeight:     blkCtx.BlockHeight,
+		ActionHash:      actionCtx.ActionHash,
+		GasConsumed:     0,
+		ContractAddress: p.addr.String(),
+	}
+	r.AddLogs(logs...)
+	return &r, nil
+}
+
+// checkEvidenceAge returns error if the double sign happens after the block, or more than _evidenceExpiryEpochs
+// epochs before the block. The timestamp of the double sign is signed by the offender, and the duration of an epoch
+// is estimated from the block interval in genesis
+func checkEvidenceAge(ctx context.Context, ds *evidence.DoubleSign) error {
+	g := genesis.MustExtractGenesisContext(ctx)
+	blkCtx := protocol.MustGetBlockCtx(ctx)
+	numSubEpochs := g.NumSubEpochs
+	if g.IsDardanelles(blkCtx.BlockHeight) {
+		numSubEpochs = g.DardanellesNumSubEpochs
+	}
+	expiry := time.Duration(_evidenceExpiryEpochs*g.NumDelegates*numSubEpochs) * g.BlockInterval
+	switch {
+	case ds.Timestamp().After(blkCtx.BlockTimeStamp):
+		return errors.Errorf("double sign at %s is after the block", ds.Timestamp())
+	case ds.Timestamp().Before(blkCtx.BlockTimeStamp.Add(-expiry)):
+		return errors.Errorf("double sign at %s is more than %d epochs before the block", ds.Timestamp(), _evidenceExpiryEpochs)
+	}
+	return nil
+}
+
+func isEvidenceRecorded(sr protocol.StateReader, h hash.Hash256) (bool, error) {
+	var record evidenceRecord
+	_, err := sr.State(&record, protocol.NamespaceOption(_stakingNameSpace), protocol.KeyOption(evidenceKey(h)))
+	switch errors.Cause(err) {
+	case nil:
+		return true, nil
+	case state.ErrStateNotExist:
+		return false, nil
+	default:
+		return false, err
+	}
+}
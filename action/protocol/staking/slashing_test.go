@@ -0,0 +1,214 @@
+// Copyright (c) 2022 IoTeX Foundation
+// This is an alpha (internal) release and is not suitable for production. This source code is provided 'as is' and no
+// warranties are given as to title or non-infringement, merchantability or fitness for purpose and, to the extent
+// permitted by law, all liability for your use of the code is disclaimed. This source code is governed by Apache
+// License 2.0 that can be found in the LICENSE file.
+
+package staking
+
+import (
+	"context"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/iotexproject/go-pkgs/crypto"
+	"github.com/iotexproject/go-pkgs/hash"
+	"github.com/iotexproject/iotex-proto/golang/iotextypes"
+	blake2b "github.com/minio/blake2b-simd"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/proto"
+
+	"github.com/iotexproject/iotex-core/action"
+	"github.com/iotexproject/iotex-core/action/evidence"
+	"github.com/iotexproject/iotex-core/action/protocol"
+	"github.com/iotexproject/iotex-core/blockchain/genesis"
+	"github.com/iotexproject/iotex-core/endorsement"
+	"github.com/iotexproject/iotex-core/test/identityset"
+)
+
+type testVoteDoc []byte
+
+func (d testVoteDoc) Hash() ([]byte, error) {
+	h := blake2b.Sum256(d)
+	return h[:], nil
+}
+
+func newTestDoubleSign(t *testing.T, sk crypto.PrivateKey, ts time.Time) *evidence.DoubleSign {
+	msgs := make([]*iotextypes.ConsensusMessage, 2)
+	for i := range msgs {
+		blkHash := hash.Hash256b([]byte{byte(i)})
+		vote := &iotextypes.ConsensusVote{BlockHash: blkHash[:], Topic: iotextypes.ConsensusVote_COMMIT}
+		ser, err := proto.Marshal(vote)
+		require.NoError(t, err)
+		en, err := endorsement.Endorse(sk, testVoteDoc(ser), ts)
+		require.NoError(t, err)
+		enPb, err := en.Proto()
+		require.NoError(t, err)
+		msgs[i] = &iotextypes.ConsensusMessage{
+			Height:      10,
+			Endorsement: enPb,
+			Msg:         &iotextypes.ConsensusMessage_Vote{Vote: vote},
+		}
+	}
+	ds, err := evidence.NewDoubleSign(msgs[0], msgs[1])
+	require.NoError(t, err)
+	return ds
+}
+
+func TestProtocol_HandleDoubleSignEvidence(t *testing.T) {
+	require := require.New(t)
+	ctrl := gomock.NewController(t)
+	sm, p, _, _ := initAll(t, ctrl)
+
+	g := genesis.Default
+	g.SlashingBlockHeight = 1
+	producer := identityset.Address(30)
+	dsTime := time.Unix(1600000000, 0)
+	blkTime := dsTime.Add(time.Hour)
+	newCtx := func(g genesis.Genesis, height uint64) context.Context {
+		ctx := protocol.WithActionCtx(context.Background(), protocol.ActionCtx{
+			Caller:   producer,
+			GasPrice: big.NewInt(0),
+		})
+		ctx = protocol.WithBlockCtx(ctx, protocol.BlockCtx{
+			BlockHeight:    height,
+			BlockTimeStamp: blkTime,
+			Producer:       producer,
+			GasLimit:       1000000,
+		})
+		ctx = genesis.WithGenesisContext(ctx, g)
+		return protocol.WithFeatureCtx(protocol.WithFeatureWithHeightCtx(ctx))
+	}
+
+	// register a candidate operated by identityset 28
+	owner := identityset.Address(27)
+	require.NoError(setupAccount(sm, owner, 1500000))
+	register, err := action.NewCandidateRegister(1, "test", identityset.Address(28).String(), identityset.Address(29).String(),
+		"", "1200000000000000000000000", 1, false, nil, uint64(1000000), big.NewInt(1000))
+	require.NoError(err)
+	ctx := protocol.WithActionCtx(newCtx(g, 1), protocol.ActionCtx{
+		Caller:   owner,
+		GasPrice: big.NewInt(1000),
+		Nonce:    1,
+	})
+	r, err := p.Handle(ctx, register, sm)
+	require.NoError(err)
+	require.Equal(uint64(iotextypes.ReceiptStatus_Success), r.Status)
+	require.NoError(p.Commit(ctx, sm))
+	csm, err := NewCandidateStateManager(sm, false)
+	require.NoError(err)
+	prevVotes := new(big.Int).Set(csm.GetByOwner(owner).Votes)
+	prevTotal := new(big.Int).Set(csm.(*candSM).bucketPool.Total())
+	prevCount := csm.(*candSM).bucketPool.Count()
+
+	// create evidence for the pool
+	pool := evidence.NewPool()
+	p.SetEvidencePool(pool)
+	ds := newTestDoubleSign(t, identityset.PrivateKey(28), dsTime)
+	require.True(pool.Add(ds))
+	// evidence of a non-candidate is dropped
+	require.True(pool.Add(newTestDoubleSign(t, identityset.PrivateKey(26), dsTime)))
+	// evidence out of the window is dropped
+	expiry := time.Duration(_evidenceExpiryEpochs*g.NumDelegates*g.NumSubEpochs) * g.BlockInterval
+	require.True(pool.Add(newTestDoubleSign(t, identityset.PrivateKey(28), blkTime.Add(-expiry-time.Second))))
+	require.True(pool.Add(newTestDoubleSign(t, identityset.PrivateKey(28), blkTime.Add(time.Second))))
+	elps, err := p.CreatePostSystemActions(newCtx(genesis.Default, 2), sm)
+	require.NoError(err)
+	require.Empty(elps)
+	elps, err = p.CreatePostSystemActions(newCtx(g, 2), sm)
+	require.NoError(err)
+	require.Len(elps, 1)
+	require.Equal(1, pool.Size())
+	act, ok := elps[0].Action().(*action.DoubleSignEvidence)
+	require.True(ok)
+	require.Equal(ds.Hash(), act.Evidence().Hash())
+
+	// evidence is not accepted before the slashing height
+	require.Error(p.Validate(newCtx(genesis.Default, 2), act, sm))
+	r, err = p.Handle(newCtx(genesis.Default, 2), act, sm)
+	require.NoError(err)
+	require.Equal(uint64(iotextypes.ReceiptStatus_Failure), r.Status)
+	// only the producer could include evidence
+	ctx = protocol.WithActionCtx(newCtx(g, 2), protocol.ActionCtx{Caller: owner, GasPrice: big.NewInt(0)})
+	require.Error(p.Validate(ctx, act, sm))
+
+	// evidence of a non-candidate is rejected without being recorded
+	nonCand := newTestDoubleSign(t, identityset.PrivateKey(26), dsTime)
+	r, err = p.Handle(newCtx(g, 2), action.NewDoubleSignEvidence(0, nonCand), sm)
+	require.NoError(err)
+	require.Equal(uint64(iotextypes.ReceiptStatus_ErrCandidateNotExist), r.Status)
+	recorded, err := isEvidenceRecorded(sm, nonCand.Hash())
+	require.NoError(err)
+	require.False(recorded)
+
+	ctx = newCtx(g, 2)
+	require.NoError(p.Validate(ctx, act, sm))
+	r, err = p.Handle(ctx, act, sm)
+	require.NoError(err)
+	require.Equal(uint64(iotextypes.ReceiptStatus_Success), r.Status)
+	require.Zero(r.GasConsumed)
+	require.Len(r.Logs(), 1)
+	require.NoError(p.Commit(ctx, sm))
+
+	slashed, _ := new(big.Int).SetString("120000000000000000000000", 10)
+	require.Equal(slashed.Bytes(), r.Logs()[0].Data)
+	csm, err = NewCandidateStateManager(sm, false)
+	require.NoError(err)
+	cand := csm.GetByOwner(owner)
+	require.Equal("1080000000000000000000000", cand.SelfStake.String())
+	require.True(cand.Votes.Cmp(prevVotes) < 0)
+	bucket, err := csm.getBucket(cand.SelfStakeBucketIdx)
+	require.NoError(err)
+	require.Equal(cand.SelfStake, bucket.StakedAmount)
+	require.Equal(new(big.Int).Sub(prevTotal, slashed), csm.(*candSM).bucketPool.Total())
+	// the slashed bucket remains in the pool
+	require.Equal(prevCount, csm.(*candSM).bucketPool.Count())
+	// the candidate falls below the minimum self-stake
+	require.True(cand.SelfStake.Cmp(p.config.RegistrationConsts.MinSelfStake) < 0)
+
+	// the same evidence could not be included again
+	require.Error(p.Validate(ctx, act, sm))
+	r, err = p.Handle(ctx, act, sm)
+	require.NoError(err)
+	require.Equal(uint64(iotextypes.ReceiptStatus_Failure), r.Status)
+	// nor could it be included again by changing the unsigned fields
+	first, second := ds.Messages()
+	first, second = proto.Clone(first).(*iotextypes.ConsensusMessage), proto.Clone(second).(*iotextypes.ConsensusMessage)
+	first.Height, second.Height = 11, 11
+	rewrapped, err := evidence.NewDoubleSign(first, second)
+	require.NoError(err)
+	require.Equal(ds.Hash(), rewrapped.Hash())
+	require.False(pool.Add(rewrapped))
+	require.Error(p.Validate(ctx, action.NewDoubleSignEvidence(0, rewrapped), sm))
+	elps, err = p.CreatePostSystemActions(newCtx(g, 3), sm)
+	require.NoError(err)
+	require.Empty(elps)
+	require.Zero(pool.Size())
+
+	// the operator changes in the same block after the evidence is picked
+	ds = newTestDoubleSign(t, identityset.PrivateKey(28), dsTime.Add(time.Minute))
+	require.True(pool.Add(ds))
+	elps, err = p.CreatePostSystemActions(newCtx(g, 3), sm)
+	require.NoError(err)
+	require.Len(elps, 1)
+	update, err := action.NewCandidateUpdate(2, "test", identityset.Address(25).String(), identityset.Address(29).String(),
+		uint64(1000000), big.NewInt(1000))
+	require.NoError(err)
+	ctx = protocol.WithActionCtx(newCtx(g, 3), protocol.ActionCtx{
+		Caller:   owner,
+		GasPrice: big.NewInt(1000),
+		Nonce:    2,
+	})
+	r, err = p.Handle(ctx, update, sm)
+	require.NoError(err)
+	require.Equal(uint64(iotextypes.ReceiptStatus_Success), r.Status)
+	// the evidence yields a failed receipt instead of failing the block
+	r, err = p.Handle(newCtx(g, 3), elps[0].Action(), sm)
+	require.NoError(err)
+	require.Equal(uint64(iotextypes.ReceiptStatus_ErrCandidateNotExist), r.Status)
+	recorded, err = isEvidenceRecorded(sm, ds.Hash())
+	require.NoError(err)
+	require.False(recorded)
+}
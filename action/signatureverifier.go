@@ -0,0 +1,114 @@
+// Copyright (c) 2022 IoTeX Foundation
+// This is an alpha (internal) release and is not suitable for production. This source code is provided 'as is' and no
+// warranties are given as to title or non-infringement, merchantability or fitness for purpose and, to the extent
+// permitted by law, all liability for your use of the code is disclaimed. This source code is governed by Apache
+// License 2.0 that can be found in the LICENSE file.
+
+package action
+
+import (
+	"bytes"
+	"runtime"
+	"sync"
+
+	"github.com/iotexproject/go-pkgs/cache"
+	"github.com/pkg/errors"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+var _signatureCacheMtc = prometheus.NewCounterVec(
+	prometheus.CounterOpts{
+		Name: "iotex_signature_cache",
+		Help: "IoTeX verified signature cache counter.",
+	},
+	[]string{"result"},
+)
+
+func init() {
+	prometheus.MustRegister(_signatureCacheMtc)
+}
+
+// SignatureVerifier verifies the signatures of actions and remembers the ones verified, such that an action is
+// verified only once no matter it is received by actpool, or validated as a part of a block, or both
+type SignatureVerifier struct {
+	verified cache.LRUCache
+	workers  int
+}
+
+// NewSignatureVerifier creates a signature verifier, which remembers at most cacheSize actions verified, and
+// verifies the actions of a batch with the given number of workers, 0 means the number of CPUs
+func NewSignatureVerifier(cacheSize, workers int) *SignatureVerifier {
+	if workers <= 0 {
+		workers = runtime.NumCPU()
+	}
+	sv := &SignatureVerifier{workers: workers}
+	if cacheSize > 0 {
+		sv.verified = cache.NewThreadSafeLruCache(cacheSize)
+	}
+	return sv
+}
+
+// Verify verifies the signature of an action if it hasn't been verified. A nil verifier always verifies the
+// signature
+func (sv *SignatureVerifier) Verify(selp *SealedEnvelope) error {
+	if sv == nil || sv.verified == nil {
+		return selp.VerifySignature()
+	}
+	if selp.SrcPubkey() == nil {
+		return errors.New("empty public key")
+	}
+	h, err := selp.Hash()
+	if err != nil {
+		return err
+	}
+	// the hash of an ethereum encoded action doesn't cover the public key, which has to match as well
+	pk := selp.SrcPubkey().Bytes()
+	if v, ok := sv.verified.Get(h); ok && bytes.Equal(v.([]byte), pk) {
+		_signatureCacheMtc.WithLabelValues("hit").Inc()
+		return nil
+	}
+	_signatureCacheMtc.WithLabelValues("miss").Inc()
+	if err := selp.VerifySignature(); err != nil {
+		return err
+	}
+	sv.verified.Add(h, pk)
+	return nil
+}
+
+// VerifyBatch verifies the signatures of actions in parallel, and returns the first error encountered
+func (sv *SignatureVerifier) VerifyBatch(selps []SealedEnvelope) error {
+	workers := 1
+	if sv != nil {
+		workers = sv.workers
+	}
+	if workers > len(selps) {
+		workers = len(selps)
+	}
+	var (
+		wg       sync.WaitGroup
+		errOnce  sync.Once
+		firstErr error
+		tasks    = make(chan int, len(selps))
+	)
+	for i := range selps {
+		tasks <- i
+	}
+	close(tasks)
+	for i := 0; i < workers; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range tasks {
+				selp := selps[i]
+				if err := sv.Verify(&selp); err != nil {
+					errOnce.Do(func() {
+						firstErr = errors.Wrapf(err, "failed to verify signature of action %d", i)
+					})
+					return
+				}
+			}
+		}()
+	}
+	wg.Wait()
+	return firstErr
+}
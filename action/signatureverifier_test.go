@@ -0,0 +1,47 @@
+// Copyright (c) 2022 IoTeX Foundation
+// This is an alpha (internal) release and is not suitable for production. This source code is provided 'as is' and no
+// warranties are given as to title or non-infringement, merchantability or fitness for purpose and, to the extent
+// permitted by law, all liability for your use of the code is disclaimed. This source code is governed by Apache
+// License 2.0 that can be found in the LICENSE file.
+
+package action
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/iotexproject/iotex-core/test/identityset"
+)
+
+func TestSignatureVerifier(t *testing.T) {
+	require := require.New(t)
+
+	var selps []SealedEnvelope
+	for i := 0; i < 10; i++ {
+		selp, err := SignedTransfer(identityset.Address(28).String(), identityset.PrivateKey(i), 1, big.NewInt(10), nil, 10000, big.NewInt(1))
+		require.NoError(err)
+		selps = append(selps, selp)
+	}
+	forged := AssembleSealedEnvelope(selps[0].Envelope, identityset.PrivateKey(1).PublicKey(), selps[0].Signature())
+
+	for _, sv := range []*SignatureVerifier{nil, NewSignatureVerifier(0, 0), NewSignatureVerifier(100, 4)} {
+		require.NoError(sv.VerifyBatch(selps))
+		require.NoError(sv.VerifyBatch(nil))
+		for i := range selps {
+			require.NoError(sv.Verify(&selps[i]))
+		}
+		// the action with the same hash isn't verified by the cache if the public key differs
+		require.ErrorIs(sv.Verify(&forged), ErrInvalidSender)
+		require.ErrorIs(sv.VerifyBatch(append([]SealedEnvelope{forged}, selps...)), ErrInvalidSender)
+	}
+
+	sv := NewSignatureVerifier(100, 0)
+	require.NoError(sv.Verify(&selps[0]))
+	h, err := selps[0].Hash()
+	require.NoError(err)
+	v, ok := sv.verified.Get(h)
+	require.True(ok)
+	require.Equal(selps[0].SrcPubkey().Bytes(), v)
+}
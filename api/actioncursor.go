@@ -0,0 +1,94 @@
+// Copyright (c) 2022 IoTeX Foundation
+// This is an alpha (internal) release and is not suitable for production. This source code is provided 'as is' and no
+// warranties are given as to title or non-infringement, merchantability or fitness for purpose and, to the extent
+// permitted by law, all liability for your use of the code is disclaimed. This source code is governed by Apache
+// License 2.0 that can be found in the LICENSE file.
+
+package api
+
+import (
+	"bytes"
+	"encoding/base64"
+	"math"
+	"sort"
+
+	"github.com/pkg/errors"
+
+	"github.com/iotexproject/iotex-core/action"
+	"github.com/iotexproject/iotex-core/blockindex"
+	"github.com/iotexproject/iotex-core/pkg/util/byteutil"
+)
+
+// the cursor of listing actions is the order, the position and the anchor of blockindex.ActionCursor encoded in
+// base64, and is opaque to the clients
+const (
+	_cursorAsc  byte = 0
+	_cursorDesc byte = 1
+)
+
+// encodeActionCursor encodes the cursor, nil is encoded to empty string
+func encodeActionCursor(c *blockindex.ActionCursor) string {
+	if c == nil {
+		return ""
+	}
+	order := _cursorAsc
+	if c.Desc {
+		order = _cursorDesc
+	}
+	b := append([]byte{order}, byteutil.Uint64ToBytesBigEndian(c.Position)...)
+	return base64.RawURLEncoding.EncodeToString(append(b, c.Anchor...))
+}
+
+// decodeActionCursor decodes the cursor, the first page in the given order is listed if the cursor is empty
+func decodeActionCursor(s string, desc bool) (*blockindex.ActionCursor, error) {
+	if s == "" {
+		c := &blockindex.ActionCursor{Desc: desc}
+		if desc {
+			c.Position = math.MaxUint64
+		}
+		return c, nil
+	}
+	b, err := base64.RawURLEncoding.DecodeString(s)
+	if err != nil {
+		return nil, errors.Wrap(err, "invalid cursor")
+	}
+	if len(b) < 9 || b[0] > _cursorDesc {
+		return nil, errors.New("invalid cursor")
+	}
+	c := &blockindex.ActionCursor{
+		Desc:     b[0] == _cursorDesc,
+		Position: byteutil.BytesToUint64BigEndian(b[1:9]),
+		Anchor:   b[9:],
+	}
+	if c.Desc != desc {
+		return nil, errors.New("the order of the cursor does not match")
+	}
+	return c, nil
+}
+
+// pendingActionKey returns the key to order the actions in actpool, which is the sender and the nonce
+func pendingActionKey(selp action.SealedEnvelope) []byte {
+	return append(selp.SrcPubkey().Hash(), byteutil.Uint64ToBytesBigEndian(selp.Nonce())...)
+}
+
+// pagePendingActions sorts the actions in actpool by their keys, and returns the index of the first action to scan
+// from the cursor, the scan goes downward from the index minus one in the descending order
+func pagePendingActions(selps []action.SealedEnvelope, c *blockindex.ActionCursor) int {
+	sort.Slice(selps, func(i, j int) bool {
+		return bytes.Compare(pendingActionKey(selps[i]), pendingActionKey(selps[j])) < 0
+	})
+	if len(c.Anchor) == 0 {
+		if c.Position > uint64(len(selps)) {
+			return len(selps)
+		}
+		return int(c.Position)
+	}
+	// the actions may have been added or removed since the cursor was made, so the scan continues from the key
+	return sort.Search(len(selps), func(i int) bool {
+		cmp := bytes.Compare(pendingActionKey(selps[i]), c.Anchor)
+		if c.Desc {
+			return cmp >= 0
+		}
+		return cmp > 0
+	})
+}
@@ -0,0 +1,48 @@
+// Copyright (c) 2022 IoTeX Foundation
+// This is an alpha (internal) release and is not suitable for production. This source code is provided 'as is' and no
+// warranties are given as to title or non-infringement, merchantability or fitness for purpose and, to the extent
+// permitted by law, all liability for your use of the code is disclaimed. This source code is governed by Apache
+// License 2.0 that can be found in the LICENSE file.
+
+package api
+
+import (
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/iotexproject/iotex-core/blockindex"
+)
+
+func TestActionCursor(t *testing.T) {
+	require := require.New(t)
+
+	for _, c := range []*blockindex.ActionCursor{
+		{Position: 3, Anchor: []byte{1, 2, 3}},
+		{Position: 7, Desc: true, Anchor: []byte{4, 5}},
+		{Position: 10, Anchor: []byte{}},
+	} {
+		s := encodeActionCursor(c)
+		decoded, err := decodeActionCursor(s, c.Desc)
+		require.NoError(err)
+		require.Equal(c, decoded)
+		// the order must match
+		_, err = decodeActionCursor(s, !c.Desc)
+		require.Error(err)
+	}
+	require.Empty(encodeActionCursor(nil))
+
+	// the first page
+	c, err := decodeActionCursor("", false)
+	require.NoError(err)
+	require.Equal(&blockindex.ActionCursor{}, c)
+	c, err = decodeActionCursor("", true)
+	require.NoError(err)
+	require.Equal(&blockindex.ActionCursor{Position: math.MaxUint64, Desc: true}, c)
+
+	for _, s := range []string{"!", "AAA", "AgAAAAAAAAAB"} {
+		_, err = decodeActionCursor(s, false)
+		require.Error(err)
+	}
+}
@@ -0,0 +1,301 @@
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.2.0
+// - protoc             (unknown)
+// source: api/apipb/api_ext.proto
+
+package apipb
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.32.0 or later.
+const _ = grpc.SupportPackageIsVersion7
+
+// APIServiceExtClient is the client API for APIServiceExt service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+type APIServiceExtClient interface {
+	// GetReceiptsByBlock returns the receipts of all actions in a block
+	GetReceiptsByBlock(ctx context.Context, in *GetReceiptsByBlockRequest, opts ...grpc.CallOption) (*GetReceiptsByBlockResponse, error)
+	// GetStateDiff returns the accounts, contract storage and other states changed by each action of a block
+	GetStateDiff(ctx context.Context, in *GetStateDiffRequest, opts ...grpc.CallOption) (*GetStateDiffResponse, error)
+	// GetContractStorageRange pages through the storage slots of a contract in the ascending order of keys
+	GetContractStorageRange(ctx context.Context, in *GetContractStorageRangeRequest, opts ...grpc.CallOption) (*GetContractStorageRangeResponse, error)
+	// GetBalanceHistory lists the balance changes of an account recorded by the balance indexer
+	GetBalanceHistory(ctx context.Context, in *GetBalanceHistoryRequest, opts ...grpc.CallOption) (*GetBalanceHistoryResponse, error)
+	// GetBalanceAtHeight reads the balance of an account after a block from the balance indexer, which does not
+	// require archive data
+	GetBalanceAtHeight(ctx context.Context, in *GetBalanceAtHeightRequest, opts ...grpc.CallOption) (*GetBalanceAtHeightResponse, error)
+	// ListActionsByAddress pages through the actions of an address with cursors, which are stable as new blocks
+	// arrive, and filters the actions on the server
+	ListActionsByAddress(ctx context.Context, in *ListActionsByAddressRequest, opts ...grpc.CallOption) (*ListActionsByAddressResponse, error)
+}
+
+type aPIServiceExtClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewAPIServiceExtClient(cc grpc.ClientConnInterface) APIServiceExtClient {
+	return &aPIServiceExtClient{cc}
+}
+
+func (c *aPIServiceExtClient) GetReceiptsByBlock(ctx context.Context, in *GetReceiptsByBlockRequest, opts ...grpc.CallOption) (*GetReceiptsByBlockResponse, error) {
+	out := new(GetReceiptsByBlockResponse)
+	err := c.cc.Invoke(ctx, "/apipb.APIServiceExt/GetReceiptsByBlock", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *aPIServiceExtClient) GetStateDiff(ctx context.Context, in *GetStateDiffRequest, opts ...grpc.CallOption) (*GetStateDiffResponse, error) {
+	out := new(GetStateDiffResponse)
+	err := c.cc.Invoke(ctx, "/apipb.APIServiceExt/GetStateDiff", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *aPIServiceExtClient) GetContractStorageRange(ctx context.Context, in *GetContractStorageRangeRequest, opts ...grpc.CallOption) (*GetContractStorageRangeResponse, error) {
+	out := new(GetContractStorageRangeResponse)
+	err := c.cc.Invoke(ctx, "/apipb.APIServiceExt/GetContractStorageRange", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *aPIServiceExtClient) GetBalanceHistory(ctx context.Context, in *GetBalanceHistoryRequest, opts ...grpc.CallOption) (*GetBalanceHistoryResponse, error) {
+	out := new(GetBalanceHistoryResponse)
+	err := c.cc.Invoke(ctx, "/apipb.APIServiceExt/GetBalanceHistory", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *aPIServiceExtClient) GetBalanceAtHeight(ctx context.Context, in *GetBalanceAtHeightRequest, opts ...grpc.CallOption) (*GetBalanceAtHeightResponse, error) {
+	out := new(GetBalanceAtHeightResponse)
+	err := c.cc.Invoke(ctx, "/apipb.APIServiceExt/GetBalanceAtHeight", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *aPIServiceExtClient) ListActionsByAddress(ctx context.Context, in *ListActionsByAddressRequest, opts ...grpc.CallOption) (*ListActionsByAddressResponse, error) {
+	out := new(ListActionsByAddressResponse)
+	err := c.cc.Invoke(ctx, "/apipb.APIServiceExt/ListActionsByAddress", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// APIServiceExtServer is the server API for APIServiceExt service.
+// All implementations must embed UnimplementedAPIServiceExtServer
+// for forward compatibility
+type APIServiceExtServer interface {
+	// GetReceiptsByBlock returns the receipts of all actions in a block
+	GetReceiptsByBlock(context.Context, *GetReceiptsByBlockRequest) (*GetReceiptsByBlockResponse, error)
+	// GetStateDiff returns the accounts, contract storage and other states changed by each action of a block
+	GetStateDiff(context.Context, *GetStateDiffRequest) (*GetStateDiffResponse, error)
+	// GetContractStorageRange pages through the storage slots of a contract in the ascending order of keys
+	GetContractStorageRange(context.Context, *GetContractStorageRangeRequest) (*GetContractStorageRangeResponse, error)
+	// GetBalanceHistory lists the balance changes of an account recorded by the balance indexer
+	GetBalanceHistory(context.Context, *GetBalanceHistoryRequest) (*GetBalanceHistoryResponse, error)
+	// GetBalanceAtHeight reads the balance of an account after a block from the balance indexer, which does not
+	// require archive data
+	GetBalanceAtHeight(context.Context, *GetBalanceAtHeightRequest) (*GetBalanceAtHeightResponse, error)
+	// ListActionsByAddress pages through the actions of an address with cursors, which are stable as new blocks
+	// arrive, and filters the actions on the server
+	ListActionsByAddress(context.Context, *ListActionsByAddressRequest) (*ListActionsByAddressResponse, error)
+	mustEmbedUnimplementedAPIServiceExtServer()
+}
+
+// UnimplementedAPIServiceExtServer must be embedded to have forward compatible implementations.
+type UnimplementedAPIServiceExtServer struct {
+}
+
+func (UnimplementedAPIServiceExtServer) GetReceiptsByBlock(context.Context, *GetReceiptsByBlockRequest) (*GetReceiptsByBlockResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetReceiptsByBlock not implemented")
+}
+func (UnimplementedAPIServiceExtServer) GetStateDiff(context.Context, *GetStateDiffRequest) (*GetStateDiffResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetStateDiff not implemented")
+}
+func (UnimplementedAPIServiceExtServer) GetContractStorageRange(context.Context, *GetContractStorageRangeRequest) (*GetContractStorageRangeResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetContractStorageRange not implemented")
+}
+func (UnimplementedAPIServiceExtServer) GetBalanceHistory(context.Context, *GetBalanceHistoryRequest) (*GetBalanceHistoryResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetBalanceHistory not implemented")
+}
+func (UnimplementedAPIServiceExtServer) GetBalanceAtHeight(context.Context, *GetBalanceAtHeightRequest) (*GetBalanceAtHeightResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetBalanceAtHeight not implemented")
+}
+func (UnimplementedAPIServiceExtServer) ListActionsByAddress(context.Context, *ListActionsByAddressRequest) (*ListActionsByAddressResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListActionsByAddress not implemented")
+}
+func (UnimplementedAPIServiceExtServer) mustEmbedUnimplementedAPIServiceExtServer() {}
+
+// UnsafeAPIServiceExtServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to APIServiceExtServer will
+// result in compilation errors.
+type UnsafeAPIServiceExtServer interface {
+	mustEmbedUnimplementedAPIServiceExtServer()
+}
+
+func RegisterAPIServiceExtServer(s grpc.ServiceRegistrar, srv APIServiceExtServer) {
+	s.RegisterService(&APIServiceExt_ServiceDesc, srv)
+}
+
+func _APIServiceExt_GetReceiptsByBlock_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetReceiptsByBlockRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(APIServiceExtServer).GetReceiptsByBlock(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/apipb.APIServiceExt/GetReceiptsByBlock",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(APIServiceExtServer).GetReceiptsByBlock(ctx, req.(*GetReceiptsByBlockRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _APIServiceExt_GetStateDiff_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetStateDiffRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(APIServiceExtServer).GetStateDiff(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/apipb.APIServiceExt/GetStateDiff",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(APIServiceExtServer).GetStateDiff(ctx, req.(*GetStateDiffRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _APIServiceExt_GetContractStorageRange_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetContractStorageRangeRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(APIServiceExtServer).GetContractStorageRange(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/apipb.APIServiceExt/GetContractStorageRange",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(APIServiceExtServer).GetContractStorageRange(ctx, req.(*GetContractStorageRangeRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _APIServiceExt_GetBalanceHistory_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetBalanceHistoryRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(APIServiceExtServer).GetBalanceHistory(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/apipb.APIServiceExt/GetBalanceHistory",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(APIServiceExtServer).GetBalanceHistory(ctx, req.(*GetBalanceHistoryRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _APIServiceExt_GetBalanceAtHeight_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetBalanceAtHeightRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(APIServiceExtServer).GetBalanceAtHeight(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/apipb.APIServiceExt/GetBalanceAtHeight",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(APIServiceExtServer).GetBalanceAtHeight(ctx, req.(*GetBalanceAtHeightRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _APIServiceExt_ListActionsByAddress_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListActionsByAddressRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(APIServiceExtServer).ListActionsByAddress(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/apipb.APIServiceExt/ListActionsByAddress",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(APIServiceExtServer).ListActionsByAddress(ctx, req.(*ListActionsByAddressRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// APIServiceExt_ServiceDesc is the grpc.ServiceDesc for APIServiceExt service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var APIServiceExt_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "apipb.APIServiceExt",
+	HandlerType: (*APIServiceExtServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "GetReceiptsByBlock",
+			Handler:    _APIServiceExt_GetReceiptsByBlock_Handler,
+		},
+		{
+			MethodName: "GetStateDiff",
+			Handler:    _APIServiceExt_GetStateDiff_Handler,
+		},
+		{
+			MethodName: "GetContractStorageRange",
+			Handler:    _APIServiceExt_GetContractStorageRange_Handler,
+		},
+		{
+			MethodName: "GetBalanceHistory",
+			Handler:    _APIServiceExt_GetBalanceHistory_Handler,
+		},
+		{
+			MethodName: "GetBalanceAtHeight",
+			Handler:    _APIServiceExt_GetBalanceAtHeight_Handler,
+		},
+		{
+			MethodName: "ListActionsByAddress",
+			Handler:    _APIServiceExt_ListActionsByAddress_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "api/apipb/api_ext.proto",
+}
@@ -13,9 +13,13 @@ import (
 	"fmt"
 	"math"
 	"math/big"
+	"reflect"
 	"strconv"
 	"time"
 
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/core/vm"
 	"github.com/pkg/errors"
 	"go.uber.org/zap"
 	"golang.org/x/sync/errgroup"
@@ -100,8 +104,12 @@ type (
 		Actions(start uint64, count uint64) ([]*iotexapi.ActionInfo, error)
 		// Action returns action by action hash
 		Action(actionHash string, checkPending bool) (*iotexapi.ActionInfo, error)
-		// ActionsByAddress returns all actions associated with an address
-		ActionsByAddress(addr address.Address, start uint64, count uint64) ([]*iotexapi.ActionInfo, error)
+		// ActionsByAddress returns at most count actions associated with an address matching the filter from the cursor,
+		// and the cursor of the next page, which is empty once all actions are listed
+		ActionsByAddress(addr address.Address, filter *blockindex.ActionFilter, cursor string, desc bool, count uint64) ([]*iotexapi.ActionInfo, string, error)
+		// LegacyActionsByAddress returns the actions associated with an address in [start, start+count), which serves
+		// the offset based GetActions
+		LegacyActionsByAddress(addr address.Address, start uint64, count uint64) ([]*iotexapi.ActionInfo, error)
 		// ActionByActionHash returns action by action hash
 		ActionByActionHash(h hash.Hash256) (action.SealedEnvelope, hash.Hash256, uint64, uint32, error)
 		// ActPoolActions returns the all Transaction Identifiers in the actpool
@@ -112,12 +120,19 @@ type (
 		BlockByHeight(uint64) (*apitypes.BlockWithReceipts, error)
 		// BlockByHash returns the block and its receipt
 		BlockByHash(string) (*apitypes.BlockWithReceipts, error)
-		// UnconfirmedActionsByAddress returns all unconfirmed actions in actpool associated with an address
-		UnconfirmedActionsByAddress(address string, start uint64, count uint64) ([]*iotexapi.ActionInfo, error)
+		// UnconfirmedActionsByAddress returns at most count unconfirmed actions in actpool associated with an address
+		// matching the filter from the cursor, and the cursor of the next page
+		UnconfirmedActionsByAddress(address string, filter *blockindex.ActionFilter, cursor string, desc bool, count uint64) ([]*iotexapi.ActionInfo, string, error)
+		// LegacyUnconfirmedActionsByAddress returns the unconfirmed actions in actpool associated with an address in
+		// [start, start+count) in the order of actpool, which serves the offset based GetActions
+		LegacyUnconfirmedActionsByAddress(address string, start uint64, count uint64) ([]*iotexapi.ActionInfo, error)
 		// EstimateGasForNonExecution  estimates action gas except execution
 		EstimateGasForNonExecution(action.Action) (uint64, error)
 		// EstimateExecutionGasConsumption estimate gas consumption for execution action
-		EstimateExecutionGasConsumption(ctx context.Context, sc *action.Execution, callerAddr address.Address) (uint64, error)
+		EstimateExecutionGasConsumption(ctx context.Context, sc *action.Execution, callerAddr address.Address, opts ...apitypes.EstimateOption) (uint64, error)
+		// CreateAccessList creates the access list of an execution, and returns it with the receipt of running the
+		// execution with the list applied
+		CreateAccessList(ctx context.Context, callerAddr address.Address, sc *action.Execution) (types.AccessList, *action.Receipt, error)
 		// LogsInBlockByHash filter logs in the block by hash
 		LogsInBlockByHash(filter *logfilter.LogFilter, blockHash hash.Hash256) ([]*action.Log, error)
 		// LogsInRange filter logs among [start, end] blocks
@@ -138,10 +153,25 @@ type (
 		TipHeight() uint64
 		// PendingNonce returns the pending nonce of an account
 		PendingNonce(address.Address) (uint64, error)
+		// PendingBlock returns the simulated pending block on top of the tip and its receipts
+		PendingBlock() (*apitypes.BlockWithReceipts, error)
+		// PendingAccount returns the state of an account after running the pending block
+		PendingAccount(address.Address) (*state.Account, error)
+		// ReadPendingContract reads the state in a contract address on top of the pending block
+		ReadPendingContract(ctx context.Context, callerAddr address.Address, sc *action.Execution) (string, *iotextypes.Receipt, error)
 		// ReceiveBlock broadcasts the block to api subscribers
 		ReceiveBlock(blk *block.Block) error
 		// BlockHashByBlockHeight returns block hash by block height
 		BlockHashByBlockHeight(blkHeight uint64) (hash.Hash256, error)
+		// StateDiff returns the states changed by each action of the block at height
+		StateDiff(ctx context.Context, height uint64) ([]*factory.ActionStateDiff, error)
+		// ContractStorageRange reads at most count slots of contract's storage at height from the first key not less than start
+		ContractStorageRange(ctx context.Context, addr address.Address, height uint64, start []byte, count uint32) ([]*evm.ContractStorageSlot, []byte, error)
+		// BalanceAt returns the balance of an account after the block at height from the balance indexer
+		BalanceAt(addr address.Address, height uint64) (*big.Int, error)
+		// BalanceHistory returns at most count balance changes of an account from startHeight, and the height from
+		// which the balances are complete
+		BalanceHistory(addr address.Address, startHeight uint64, count uint32) ([]*blockindex.BalanceChange, uint64, error)
 	}
 
 	// coreService implements the CoreService interface
@@ -160,6 +190,8 @@ type (
 		chainListener     apitypes.Listener
 		electionCommittee committee.Committee
 		readCache         *ReadCache
+		pendingBlock      *pendingBlock
+		balanceIndexer    blockindex.BalanceIndexer
 	}
 
 	// jobDesc provides a struct to get and store logs in core.LogsInRange
@@ -189,6 +221,13 @@ func WithNativeElection(committee committee.Committee) Option {
 	}
 }
 
+// WithBalanceIndexer is the option to serve the balance history of accounts
+func WithBalanceIndexer(indexer blockindex.BalanceIndexer) Option {
+	return func(svr *coreService) {
+		svr.balanceIndexer = indexer
+	}
+}
+
 type intrinsicGasCalculator interface {
 	IntrinsicGas() (uint64, error)
 }
@@ -211,7 +250,7 @@ func newCoreService(
 	registry *protocol.Registry,
 	opts ...Option,
 ) (CoreService, error) {
-	if cfg == (config.API{}) {
+	if reflect.DeepEqual(cfg, config.API{}) {
 		log.L().Warn("API server is not configured.")
 		cfg = config.Default.API
 	}
@@ -235,6 +274,12 @@ func newCoreService(
 		readCache:     NewReadCache(),
 	}
 
+	pendingBlock, err := newPendingBlock(cfg.PendingBlockInterval, chain, sf, actPool)
+	if err != nil {
+		return nil, err
+	}
+	core.pendingBlock = pendingBlock
+
 	for _, opt := range opts {
 		opt(&core)
 	}
@@ -436,6 +481,62 @@ func (core *coreService) PendingNonce(addr address.Address) (uint64, error) {
 	return core.ap.GetPendingNonce(addr.String())
 }
 
+// PendingBlock returns the simulated pending block on top of the tip and its receipts
+func (core *coreService) PendingBlock() (*apitypes.BlockWithReceipts, error) {
+	blk, err := core.pendingBlock.Block()
+	if err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+	return &apitypes.BlockWithReceipts{
+		Block:    blk,
+		Receipts: blk.Receipts,
+	}, nil
+}
+
+// PendingAccount returns the state of an account after running the pending block
+func (core *coreService) PendingAccount(addr address.Address) (*state.Account, error) {
+	var acct *state.Account
+	if err := core.pendingBlock.Run(func(ctx context.Context, _ *block.Block, sm protocol.StateManager) error {
+		var err error
+		acct, err = accountutil.AccountState(ctx, sm, addr)
+		return err
+	}); err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+	return acct, nil
+}
+
+// ReadPendingContract reads the state in a contract address on top of the pending block
+func (core *coreService) ReadPendingContract(ctx context.Context, callerAddr address.Address, sc *action.Execution) (string, *iotextypes.Receipt, error) {
+	_, span := tracer.NewSpan(ctx, "coreService.ReadPendingContract")
+	defer span.End()
+	var (
+		retval  []byte
+		receipt *action.Receipt
+	)
+	if err := core.pendingBlock.Run(func(pendingCtx context.Context, _ *block.Block, sm protocol.StateManager) error {
+		acct, err := accountutil.AccountState(pendingCtx, sm, callerAddr)
+		if err != nil {
+			return status.Error(codes.InvalidArgument, err.Error())
+		}
+		sc.SetNonce(acct.PendingNonce())
+		blockGasLimit := core.bc.Genesis().BlockGasLimit
+		if sc.GasLimit() == 0 || blockGasLimit < sc.GasLimit() {
+			sc.SetGasLimit(blockGasLimit)
+		}
+		sc.SetGasPrice(big.NewInt(0))
+		retval, receipt, err = evm.SimulateExecution(pendingCtx, sm, callerAddr, sc, core.dao.GetBlockHash)
+		return err
+	}); err != nil {
+		if _, ok := status.FromError(err); ok {
+			return "", nil, err
+		}
+		return "", nil, status.Error(codes.Internal, err.Error())
+	}
+	receipt.Status = uint64(iotextypes.ReceiptStatus_Success)
+	return hex.EncodeToString(retval), receipt.ConvertToReceiptPb(), nil
+}
+
 func (core *coreService) validateChainID(chainID uint32) error {
 	if ge := core.bc.Genesis(); ge.IsMidway(core.bc.TipHeight()) && chainID != core.bc.ChainID() && chainID != 0 {
 		return status.Errorf(codes.InvalidArgument, "ChainID does not match, expecting %d, got %d", core.bc.ChainID(), chainID)
@@ -940,36 +1041,72 @@ func (core *coreService) Action(actionHash string, checkPending bool) (*iotexapi
 	return act, nil
 }
 
-// ActionsByAddress returns all actions associated with an address
-func (core *coreService) ActionsByAddress(addr address.Address, start uint64, count uint64) ([]*iotexapi.ActionInfo, error) {
+// ActionsByAddress returns at most count actions associated with an address matching the filter from the cursor, an
+// empty cursor lists the first page in the given order
+func (core *coreService) ActionsByAddress(
+	addr address.Address,
+	filter *blockindex.ActionFilter,
+	cursor string,
+	desc bool,
+	count uint64,
+) ([]*iotexapi.ActionInfo, string, error) {
 	if err := core.checkActionIndex(); err != nil {
-		return nil, err
+		return nil, "", err
 	}
 	if count == 0 {
-		return nil, status.Error(codes.InvalidArgument, "count must be greater than zero")
+		return nil, "", status.Error(codes.InvalidArgument, "count must be greater than zero")
 	}
 	if count > core.cfg.RangeQueryLimit {
-		return nil, status.Error(codes.InvalidArgument, "range exceeds the limit")
+		return nil, "", status.Error(codes.InvalidArgument, "range exceeds the limit")
 	}
-
-	actions, err := core.indexer.GetActionsByAddress(hash.BytesToHash160(addr.Bytes()), start, count)
+	c, err := decodeActionCursor(cursor, desc)
 	if err != nil {
-		if errors.Cause(err) == db.ErrBucketNotExist || errors.Cause(err) == db.ErrNotExist {
-			// no actions associated with address, return nil
-			return nil, nil
-		}
-		return nil, status.Error(codes.NotFound, err.Error())
+		return nil, "", status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	addrHash := hash.BytesToHash160(addr.Bytes())
+	actions, next, err := core.indexer.FilterActionsByAddress(addrHash, filter, c, count)
+	switch errors.Cause(err) {
+	case nil:
+	case blockindex.ErrStaleActionCursor:
+		return nil, "", status.Error(codes.FailedPrecondition, err.Error())
+	default:
+		return nil, "", status.Error(codes.NotFound, err.Error())
 	}
 
 	var res []*iotexapi.ActionInfo
-	for i := range actions {
-		act, err := core.getAction(hash.BytesToHash256(actions[i]), false)
+	for _, a := range actions {
+		if a.Unverified {
+			// the action is indexed without the details to match the filter
+			matched, err := core.matchAction(addrHash, a, filter)
+			if err != nil {
+				return nil, "", status.Error(codes.Internal, err.Error())
+			}
+			if !matched {
+				continue
+			}
+		}
+		act, err := core.getAction(a.Hash, false)
 		if err != nil {
 			continue
 		}
 		res = append(res, act)
 	}
-	return res, nil
+	return res, encodeActionCursor(next), nil
+}
+
+func (core *coreService) matchAction(addr hash.Hash160, a *blockindex.AddressAction, filter *blockindex.ActionFilter) (bool, error) {
+	selp, _, _, _, err := core.ActionByActionHash(a.Hash)
+	if err != nil {
+		return false, err
+	}
+	var receipt *action.Receipt
+	if filter.Status != blockindex.AnyStatus {
+		if receipt, err = core.dao.GetReceiptByActionHash(a.Hash, a.BlockHeight); err != nil {
+			return false, err
+		}
+	}
+	return filter.MatchAction(addr, a.BlockHeight, selp, receipt)
 }
 
 // BlockHashByBlockHeight returns block hash by block height
@@ -998,8 +1135,111 @@ func (core *coreService) ActionByActionHash(h hash.Hash256) (action.SealedEnvelo
 	return selp, blk.HashBlock(), actIndex.BlockHeight(), index, nil
 }
 
-// UnconfirmedActionsByAddress returns all unconfirmed actions in actpool associated with an address
-func (core *coreService) UnconfirmedActionsByAddress(address string, start uint64, count uint64) ([]*iotexapi.ActionInfo, error) {
+// UnconfirmedActionsByAddress returns at most count unconfirmed actions in actpool associated with an address matching
+// the filter from the cursor. The actions are ordered by their senders and nonces, and the filter cannot select the
+// status or the heights which pending actions do not have
+func (core *coreService) UnconfirmedActionsByAddress(
+	addrStr string,
+	filter *blockindex.ActionFilter,
+	cursor string,
+	desc bool,
+	count uint64,
+) ([]*iotexapi.ActionInfo, string, error) {
+	if count == 0 {
+		return nil, "", status.Error(codes.InvalidArgument, "count must be greater than zero")
+	}
+	if count > core.cfg.RangeQueryLimit {
+		return nil, "", status.Error(codes.InvalidArgument, "range exceeds the limit")
+	}
+	if filter == nil {
+		filter = &blockindex.ActionFilter{}
+	}
+	if filter.Status != blockindex.AnyStatus || filter.StartHeight > 0 || filter.EndHeight > 0 {
+		return nil, "", status.Error(codes.InvalidArgument, "unconfirmed actions cannot be filtered by status or height")
+	}
+	c, err := decodeActionCursor(cursor, desc)
+	if err != nil {
+		return nil, "", status.Error(codes.InvalidArgument, err.Error())
+	}
+	addr, err := address.FromString(addrStr)
+	if err != nil {
+		return nil, "", status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	var (
+		addrHash = hash.BytesToHash160(addr.Bytes())
+		selps    = core.ap.GetUnconfirmedActs(addrStr)
+		i        = pagePendingActions(selps, c)
+		res      = []*iotexapi.ActionInfo{}
+		last     action.SealedEnvelope
+	)
+	for uint64(len(res)) < count {
+		if desc {
+			if i == 0 {
+				return res, "", nil
+			}
+			i--
+			last = selps[i]
+		} else {
+			if i >= len(selps) {
+				return res, "", nil
+			}
+			last = selps[i]
+			i++
+		}
+		matched, err := filter.MatchAction(addrHash, 0, last, nil)
+		if err != nil || !matched {
+			continue
+		}
+		if act, err := core.pendingAction(last); err == nil {
+			res = append(res, act)
+		}
+	}
+	if (desc && i == 0) || (!desc && i >= len(selps)) {
+		return res, "", nil
+	}
+	return res, encodeActionCursor(&blockindex.ActionCursor{
+		Position: uint64(i),
+		Desc:     desc,
+		Anchor:   pendingActionKey(last),
+	}), nil
+}
+
+// LegacyActionsByAddress returns the actions associated with an address in [start, start+count)
+func (core *coreService) LegacyActionsByAddress(addr address.Address, start uint64, count uint64) ([]*iotexapi.ActionInfo, error) {
+	if err := core.checkActionIndex(); err != nil {
+		return nil, err
+	}
+	if count == 0 {
+		return nil, status.Error(codes.InvalidArgument, "count must be greater than zero")
+	}
+	if count > core.cfg.RangeQueryLimit {
+		return nil, status.Error(codes.InvalidArgument, "range exceeds the limit")
+	}
+
+	actions, err := core.indexer.GetActionsByAddress(hash.BytesToHash160(addr.Bytes()), start, count)
+	if err != nil {
+		if errors.Cause(err) == db.ErrBucketNotExist || errors.Cause(err) == db.ErrNotExist {
+			// no actions associated with address, return nil
+			return nil, nil
+		}
+		return nil, status.Error(codes.NotFound, err.Error())
+	}
+
+	var res []*iotexapi.ActionInfo
+	for i := range actions {
+		act, err := core.getAction(hash.BytesToHash256(actions[i]), false)
+		if err != nil {
+			continue
+		}
+		res = append(res, act)
+	}
+	return res, nil
+}
+
+// LegacyUnconfirmedActionsByAddress returns the unconfirmed actions in actpool associated with an address in
+// [start, start+count), in the order of actpool
+func (core *coreService) LegacyUnconfirmedActionsByAddress(address string, start uint64, count uint64) ([]*iotexapi.ActionInfo, error) {
 	if count == 0 {
 		return nil, status.Error(codes.InvalidArgument, "count must be greater than zero")
 	}
@@ -1350,7 +1590,21 @@ func (core *coreService) EstimateGasForNonExecution(actType action.Action) (uint
 }
 
 // EstimateExecutionGasConsumption estimate gas consumption for execution action
-func (core *coreService) EstimateExecutionGasConsumption(ctx context.Context, sc *action.Execution, callerAddr address.Address) (uint64, error) {
+func (core *coreService) EstimateExecutionGasConsumption(ctx context.Context, sc *action.Execution, callerAddr address.Address, opts ...apitypes.EstimateOption) (uint64, error) {
+	cfg := apitypes.EstimateConfig{}
+	for _, opt := range opts {
+		opt(&cfg)
+	}
+	if cfg.WithAccessList {
+		list, _, err := core.CreateAccessList(ctx, callerAddr, sc)
+		if err != nil {
+			return 0, err
+		}
+		sc, err = action.NewExecutionWithAccessList(sc.Contract(), sc.Nonce(), sc.Amount(), sc.GasLimit(), sc.GasPrice(), sc.Data(), list)
+		if err != nil {
+			return 0, status.Error(codes.InvalidArgument, err.Error())
+		}
+	}
 	ctx = genesis.WithGenesisContext(ctx, core.bc.Genesis())
 	state, err := accountutil.AccountState(ctx, core.sf, callerAddr)
 	if err != nil {
@@ -1398,6 +1652,43 @@ func (core *coreService) EstimateExecutionGasConsumption(ctx context.Context, sc
 	return estimatedGas, nil
 }
 
+// CreateAccessList creates the access list of an execution, by tracing the execution with the list of last run
+// applied until the list does not change any more
+func (core *coreService) CreateAccessList(ctx context.Context, callerAddr address.Address, sc *action.Execution) (types.AccessList, *action.Receipt, error) {
+	if g := core.bc.Genesis(); !g.IsOkhotsk(core.bc.TipHeight() + 1) {
+		return nil, nil, status.Error(codes.FailedPrecondition, "access list is not activated yet")
+	}
+	var (
+		from = common.BytesToAddress(callerAddr.Bytes())
+		to   common.Address
+	)
+	if sc.Contract() != action.EmptyAddress {
+		contract, err := address.FromString(sc.Contract())
+		if err != nil {
+			return nil, nil, status.Error(codes.InvalidArgument, err.Error())
+		}
+		to = common.BytesToAddress(contract.Bytes())
+	}
+	prevTracer := vm.NewAccessListTracer(sc.AccessList(), from, to, vm.PrecompiledAddressesBerlin)
+	for {
+		list := prevTracer.AccessList()
+		exec, _ := action.NewExecutionWithAccessList(sc.Contract(), sc.Nonce(), sc.Amount(), sc.GasLimit(), big.NewInt(0), sc.Data(), list)
+		tracer := vm.NewAccessListTracer(list, from, to, vm.PrecompiledAddressesBerlin)
+		_, receipt, err := core.SimulateExecution(protocol.WithVMConfigCtx(ctx, vm.Config{
+			Debug:     true,
+			Tracer:    tracer,
+			NoBaseFee: true,
+		}), callerAddr, exec)
+		if err != nil {
+			return nil, nil, status.Error(codes.Internal, err.Error())
+		}
+		if tracer.Equal(prevTracer) {
+			return list, receipt, nil
+		}
+		prevTracer = tracer
+	}
+}
+
 func (core *coreService) isGasLimitEnough(
 	ctx context.Context,
 	caller address.Address,
@@ -1561,6 +1852,117 @@ func (core *coreService) SimulateExecution(ctx context.Context, addr address.Add
 	return core.sf.SimulateExecution(ctx, addr, exec, core.dao.GetBlockHash)
 }
 
+// StateDiff returns the states changed by each action of the block at height, which is read from the state diff
+// index, or replayed atop the states of its previous block on archive nodes
+func (core *coreService) StateDiff(ctx context.Context, height uint64) ([]*factory.ActionStateDiff, error) {
+	if height == 0 || height > core.bc.TipHeight() {
+		return nil, status.Errorf(codes.InvalidArgument, "invalid block height %d", height)
+	}
+	blk, err := core.dao.GetBlockByHeight(height)
+	if err != nil {
+		return nil, status.Error(codes.NotFound, err.Error())
+	}
+	prev, err := core.dao.HeaderByHeight(height - 1)
+	if err != nil {
+		return nil, status.Error(codes.NotFound, err.Error())
+	}
+	ctx, err = core.bc.Context(ctx)
+	if err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+	bcCtx := protocol.MustGetBlockchainCtx(ctx)
+	bcCtx.Tip = protocol.TipInfo{
+		Height:    height - 1,
+		Hash:      blk.PrevHash(),
+		Timestamp: prev.Timestamp(),
+	}
+	ctx = protocol.WithFeatureCtx(protocol.WithBlockCtx(
+		protocol.WithBlockchainCtx(ctx, bcCtx),
+		protocol.BlockCtx{
+			BlockHeight:    height,
+			BlockTimeStamp: blk.Timestamp(),
+			GasLimit:       core.bc.Genesis().BlockGasLimit,
+			Producer:       blk.PublicKey().Address(),
+		},
+	))
+	diffs, err := core.sf.StateDiff(ctx, blk)
+	switch errors.Cause(err) {
+	case nil:
+		return diffs, nil
+	case factory.ErrNoArchiveData, factory.ErrNotSupported:
+		return nil, status.Error(codes.FailedPrecondition, err.Error())
+	default:
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+}
+
+// ContractStorageRange reads at most count slots of contract's storage at height in the ascending order of keys, from
+// the first key not less than start, and returns the key of the next slot if there are more
+func (core *coreService) ContractStorageRange(
+	ctx context.Context,
+	addr address.Address,
+	height uint64,
+	start []byte,
+	count uint32,
+) ([]*evm.ContractStorageSlot, []byte, error) {
+	if count == 0 || uint64(count) > core.cfg.RangeQueryLimit {
+		return nil, nil, status.Error(codes.InvalidArgument, "range exceeds the limit")
+	}
+	if len(start) > len(hash.ZeroHash256) {
+		return nil, nil, status.Errorf(codes.InvalidArgument, "invalid storage key %x", start)
+	}
+	if height > core.bc.TipHeight() {
+		return nil, nil, status.Errorf(codes.InvalidArgument, "invalid block height %d", height)
+	}
+	ctx, err := core.bc.Context(ctx)
+	if err != nil {
+		return nil, nil, status.Error(codes.Internal, err.Error())
+	}
+	slots, next, err := core.sf.ContractStorageRange(ctx, height, addr, start, count)
+	switch errors.Cause(err) {
+	case nil:
+		return slots, next, nil
+	case factory.ErrNoArchiveData, factory.ErrNotSupported:
+		return nil, nil, status.Error(codes.FailedPrecondition, err.Error())
+	default:
+		return nil, nil, status.Error(codes.Internal, err.Error())
+	}
+}
+
+// BalanceAt returns the balance of an account after the block at height from the balance indexer
+func (core *coreService) BalanceAt(addr address.Address, height uint64) (*big.Int, error) {
+	if core.balanceIndexer == nil {
+		return nil, status.Error(codes.FailedPrecondition, "balance indexer is not enabled")
+	}
+	if height > core.bc.TipHeight() {
+		return nil, status.Errorf(codes.InvalidArgument, "invalid block height %d", height)
+	}
+	balance, err := core.balanceIndexer.BalanceAt(hash.BytesToHash160(addr.Bytes()), height)
+	switch errors.Cause(err) {
+	case nil:
+		return balance, nil
+	case blockindex.ErrBalanceHistoryNA:
+		return nil, status.Error(codes.FailedPrecondition, err.Error())
+	default:
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+}
+
+// BalanceHistory returns at most count balance changes of an account from startHeight
+func (core *coreService) BalanceHistory(addr address.Address, startHeight uint64, count uint32) ([]*blockindex.BalanceChange, uint64, error) {
+	if core.balanceIndexer == nil {
+		return nil, 0, status.Error(codes.FailedPrecondition, "balance indexer is not enabled")
+	}
+	if count == 0 || uint64(count) > core.cfg.RangeQueryLimit {
+		return nil, 0, status.Error(codes.InvalidArgument, "range exceeds the limit")
+	}
+	changes, err := core.balanceIndexer.BalanceHistory(hash.BytesToHash160(addr.Bytes()), startHeight, uint64(count))
+	if err != nil {
+		return nil, 0, status.Error(codes.Internal, err.Error())
+	}
+	return changes, core.balanceIndexer.StartHeight(), nil
+}
+
 // SyncingProgress returns the syncing status of node
 func (core *coreService) SyncingProgress() (uint64, uint64, uint64) {
 	startingHeight, currentHeight, targetHeight, _ := core.bs.SyncStatus()
@@ -0,0 +1,468 @@
+// Copyright (c) 2022 IoTeX Foundation
+// This is an alpha (internal) release and is not suitable for production. This source code is provided 'as is' and no
+// warranties are given as to title or non-infringement, merchantability or fitness for purpose and, to the extent
+// permitted by law, all liability for your use of the code is disclaimed. This source code is governed by Apache
+// License 2.0 that can be found in the LICENSE file.
+
+package api
+
+import (
+	"context"
+	"encoding/hex"
+	"encoding/json"
+	"math/big"
+	"net/http"
+	"sync/atomic"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/graph-gophers/graphql-go"
+	gqlerrors "github.com/graph-gophers/graphql-go/errors"
+	"github.com/iotexproject/go-pkgs/hash"
+	"github.com/iotexproject/iotex-address/address"
+	"github.com/iotexproject/iotex-proto/golang/iotexapi"
+	"github.com/iotexproject/iotex-proto/golang/iotextypes"
+	"github.com/pkg/errors"
+	"go.uber.org/zap"
+	"google.golang.org/protobuf/proto"
+
+	"github.com/iotexproject/iotex-core/config"
+	"github.com/iotexproject/iotex-core/pkg/log"
+)
+
+// _graphQLMaxBodySize is the max size of the body of a GraphQL request
+const _graphQLMaxBodySize = 1 << 20
+
+var errGraphQLCostExceeded = errors.New("query cost exceeds the limit")
+
+type (
+	// GraphQLHandler handles the GraphQL queries over http
+	GraphQLHandler struct {
+		schema  *graphql.Schema
+		maxCost int64
+	}
+
+	graphQLRequest struct {
+		Query         string                 `json:"query"`
+		OperationName string                 `json:"operationName"`
+		Variables     map[string]interface{} `json:"variables"`
+	}
+
+	// graphQLCost is the cost charged by the resolvers of a query
+	graphQLCost struct {
+		used  int64
+		limit int64
+	}
+
+	graphQLCostCtxKey struct{}
+
+	// graphQLResolver is the root resolver of the queries and mutations
+	graphQLResolver struct {
+		core       CoreService
+		web3       *web3Handler
+		rangeLimit uint64
+	}
+
+	pageArgs struct {
+		Offset *int32
+		Limit  *int32
+	}
+)
+
+// NewGraphQLHandler creates a GraphQL handler backed by core service
+func NewGraphQLHandler(core CoreService, cfg config.API) *GraphQLHandler {
+	resolver := &graphQLResolver{
+		core:       core,
+		web3:       &web3Handler{coreService: core},
+		rangeLimit: cfg.RangeQueryLimit,
+	}
+	return &GraphQLHandler{
+		schema:  graphql.MustParseSchema(_graphQLSchema, resolver, graphql.MaxDepth(cfg.GraphQL.MaxDepth)),
+		maxCost: int64(cfg.GraphQL.MaxCost),
+	}
+}
+
+func (h *GraphQLHandler) ServeHTTP(w http.ResponseWriter, req *http.Request) {
+	var gqlReq graphQLRequest
+	switch req.Method {
+	case http.MethodGet:
+		query := req.URL.Query()
+		gqlReq.Query, gqlReq.OperationName = query.Get("query"), query.Get("operationName")
+		if vars := query.Get("variables"); vars != "" {
+			if err := json.Unmarshal([]byte(vars), &gqlReq.Variables); err != nil {
+				writeGraphQLError(w, http.StatusBadRequest, err)
+				return
+			}
+		}
+	case http.MethodPost:
+		if err := json.NewDecoder(http.MaxBytesReader(w, req.Body, _graphQLMaxBodySize)).Decode(&gqlReq); err != nil {
+			writeGraphQLError(w, http.StatusBadRequest, err)
+			return
+		}
+	default:
+		w.WriteHeader(http.StatusMethodNotAllowed)
+		return
+	}
+	ctx := req.Context()
+	if err := consumeAPIQuota(ctx, "graphql"); err != nil {
+		writeGraphQLError(w, http.StatusTooManyRequests, err)
+		return
+	}
+	ctx = context.WithValue(ctx, graphQLCostCtxKey{}, &graphQLCost{limit: h.maxCost})
+	resp := h.schema.Exec(ctx, gqlReq.Query, gqlReq.OperationName, gqlReq.Variables)
+	w.Header().Set("Content-Type", "application/json; charset=UTF-8")
+	if resp.Data == nil && len(resp.Errors) > 0 {
+		w.WriteHeader(http.StatusBadRequest)
+	}
+	if err := json.NewEncoder(w).Encode(resp); err != nil {
+		log.Logger("api").Warn("fail to respond graphql request.", zap.Error(err))
+	}
+}
+
+func writeGraphQLError(w http.ResponseWriter, code int, err error) {
+	w.Header().Set("Content-Type", "application/json; charset=UTF-8")
+	w.WriteHeader(code)
+	if err := json.NewEncoder(w).Encode(&graphql.Response{
+		Errors: []*gqlerrors.QueryError{gqlerrors.Errorf("%s", err.Error())},
+	}); err != nil {
+		log.Logger("api").Warn("fail to respond graphql request.", zap.Error(err))
+	}
+}
+
+// chargeGraphQLCost charges the cost of loading data, and returns errGraphQLCostExceeded if the query costs more
+// than the limit
+func chargeGraphQLCost(ctx context.Context, cost uint64) error {
+	c, ok := ctx.Value(graphQLCostCtxKey{}).(*graphQLCost)
+	if !ok || c.limit <= 0 {
+		return nil
+	}
+	if cost > uint64(c.limit) || atomic.AddInt64(&c.used, int64(cost)) > c.limit {
+		return errGraphQLCostExceeded
+	}
+	return nil
+}
+
+// page returns the offset and the limit of the page, the limit is capped by the range query limit
+func (r *graphQLResolver) page(args pageArgs) (uint32, uint32, error) {
+	var offset, limit uint64 = 0, r.rangeLimit
+	if args.Offset != nil {
+		if *args.Offset < 0 {
+			return 0, 0, errors.New("offset should not be negative")
+		}
+		offset = uint64(*args.Offset)
+	}
+	if args.Limit != nil {
+		if *args.Limit <= 0 || uint64(*args.Limit) > r.rangeLimit {
+			return 0, 0, errors.Errorf("limit should be in [1, %d]", r.rangeLimit)
+		}
+		limit = uint64(*args.Limit)
+	}
+	return uint32(offset), uint32(limit), nil
+}
+
+// blockRange returns the block range [from, to], in which to is the tip height if omitted or beyond the tip, and
+// from is to if omitted
+func (r *graphQLResolver) blockRange(fromArg, toArg *hexutil.Uint64) (uint64, uint64) {
+	to := r.core.TipHeight()
+	if toArg != nil && uint64(*toArg) < to {
+		to = uint64(*toArg)
+	}
+	from := to
+	if fromArg != nil {
+		from = uint64(*fromArg)
+	}
+	return from, to
+}
+
+// Block returns the block of the hash or the number, or the tip block if both are omitted
+func (r *graphQLResolver) Block(ctx context.Context, args struct {
+	Number *hexutil.Uint64
+	Hash   *common.Hash
+}) (*gqlBlock, error) {
+	if err := chargeGraphQLCost(ctx, 1); err != nil {
+		return nil, err
+	}
+	var (
+		blk *gqlBlock
+		err error
+	)
+	switch {
+	case args.Hash != nil:
+		blk, err = r.blockByHash(hash.BytesToHash256(args.Hash.Bytes()))
+	case args.Number != nil:
+		blk, err = r.blockByHeight(uint64(*args.Number))
+	default:
+		blk, err = r.blockByHeight(r.core.TipHeight())
+	}
+	if errors.Cause(err) == ErrNotFound {
+		return nil, nil
+	}
+	return blk, err
+}
+
+// Blocks returns the blocks in [from, to]
+func (r *graphQLResolver) Blocks(ctx context.Context, args struct {
+	From *hexutil.Uint64
+	To   *hexutil.Uint64
+}) ([]*gqlBlock, error) {
+	from, to := r.blockRange(args.From, args.To)
+	if from > to {
+		return []*gqlBlock{}, nil
+	}
+	if to-from+1 > r.rangeLimit {
+		return nil, errors.Errorf("range exceeds the limit %d", r.rangeLimit)
+	}
+	if err := chargeGraphQLCost(ctx, to-from+1); err != nil {
+		return nil, err
+	}
+	blks, err := r.core.BlockByHeightRange(from, to-from+1)
+	if err != nil {
+		return nil, err
+	}
+	ret := make([]*gqlBlock, 0, len(blks))
+	for _, blk := range blks {
+		ret = append(ret, &gqlBlock{r: r, blk: blk})
+	}
+	return ret, nil
+}
+
+// Pending returns the actions in the actpool
+func (r *graphQLResolver) Pending() *gqlPending {
+	return &gqlPending{r: r}
+}
+
+// Transaction returns the action of the hash, in the chain or in the actpool
+func (r *graphQLResolver) Transaction(ctx context.Context, args struct{ Hash common.Hash }) (*gqlTransaction, error) {
+	tx, err := r.transactionByHash(ctx, hash.BytesToHash256(args.Hash.Bytes()))
+	if errors.Cause(err) == ErrNotFound {
+		return nil, nil
+	}
+	return tx, err
+}
+
+// Logs returns the logs matching the filter in the block range
+func (r *graphQLResolver) Logs(ctx context.Context, args struct{ Filter filterCriteria }) ([]*gqlLog, error) {
+	from, to := r.blockRange(args.Filter.FromBlock, args.Filter.ToBlock)
+	if from > to {
+		return []*gqlLog{}, nil
+	}
+	if err := chargeGraphQLCost(ctx, to-from+1); err != nil {
+		return nil, err
+	}
+	filter, err := newLogFilterFrom(args.Filter.addresses(), args.Filter.topics())
+	if err != nil {
+		return nil, err
+	}
+	logs, _, err := r.core.LogsInRange(filter, from, to, 0)
+	if err != nil {
+		return nil, err
+	}
+	ret := make([]*gqlLog, 0, len(logs))
+	for _, l := range logs {
+		ret = append(ret, &gqlLog{r: r, log: l})
+	}
+	return ret, nil
+}
+
+// GasPrice returns the suggested gas price
+func (r *graphQLResolver) GasPrice() (hexutil.Big, error) {
+	price, err := r.core.SuggestGasPrice()
+	if err != nil {
+		return hexutil.Big{}, err
+	}
+	return hexutil.Big(*new(big.Int).SetUint64(price)), nil
+}
+
+// Syncing returns the syncing progress, or null if the node is synced
+func (r *graphQLResolver) Syncing() *gqlSyncState {
+	start, curr, highest := r.core.SyncingProgress()
+	if curr >= highest {
+		return nil
+	}
+	return &gqlSyncState{start: start, curr: curr, highest: highest}
+}
+
+// ChainID returns the chain id of evm
+func (r *graphQLResolver) ChainID() hexutil.Big {
+	return hexutil.Big(*big.NewInt(int64(r.core.EVMNetworkID())))
+}
+
+// Epoch returns the epoch of the number, or the current epoch if the number is omitted
+func (r *graphQLResolver) Epoch(ctx context.Context, args struct{ Number *hexutil.Uint64 }) (*gqlEpoch, error) {
+	if err := chargeGraphQLCost(ctx, 1); err != nil {
+		return nil, err
+	}
+	var num uint64
+	if args.Number != nil {
+		num = uint64(*args.Number)
+	} else {
+		chainMeta, _, err := r.core.ChainMeta()
+		if err != nil {
+			return nil, err
+		}
+		num = chainMeta.GetEpoch().GetNum()
+	}
+	epoch, numBlks, producers, err := r.core.EpochMeta(num)
+	if err != nil {
+		return nil, err
+	}
+	return &gqlEpoch{epoch: epoch, numBlks: numBlks, producers: producers}, nil
+}
+
+// Candidates returns a page of the candidates
+func (r *graphQLResolver) Candidates(ctx context.Context, args pageArgs) ([]*gqlCandidate, error) {
+	offset, limit, err := r.page(args)
+	if err != nil {
+		return nil, err
+	}
+	var candidates iotextypes.CandidateListV2
+	if err := r.readStaking(ctx, iotexapi.ReadStakingDataMethod_CANDIDATES, &iotexapi.ReadStakingDataRequest{
+		Request: &iotexapi.ReadStakingDataRequest_Candidates_{
+			Candidates: &iotexapi.ReadStakingDataRequest_Candidates{
+				Pagination: &iotexapi.PaginationParam{Offset: offset, Limit: limit},
+			},
+		},
+	}, &candidates); err != nil {
+		return nil, err
+	}
+	ret := make([]*gqlCandidate, 0, len(candidates.Candidates))
+	for _, c := range candidates.Candidates {
+		ret = append(ret, &gqlCandidate{r: r, cand: c})
+	}
+	return ret, nil
+}
+
+// Candidate returns the candidate of the name, or null if it isn't registered
+func (r *graphQLResolver) Candidate(ctx context.Context, args struct{ Name string }) (*gqlCandidate, error) {
+	var candidate iotextypes.CandidateV2
+	if err := r.readStaking(ctx, iotexapi.ReadStakingDataMethod_CANDIDATE_BY_NAME, &iotexapi.ReadStakingDataRequest{
+		Request: &iotexapi.ReadStakingDataRequest_CandidateByName_{
+			CandidateByName: &iotexapi.ReadStakingDataRequest_CandidateByName{CandName: args.Name},
+		},
+	}, &candidate); err != nil {
+		return nil, err
+	}
+	if candidate.Name == "" {
+		return nil, nil
+	}
+	return &gqlCandidate{r: r, cand: &candidate}, nil
+}
+
+// Buckets returns a page of the buckets, or the buckets of the voter if it is given
+func (r *graphQLResolver) Buckets(ctx context.Context, args struct {
+	Offset *int32
+	Limit  *int32
+	Voter  *common.Address
+}) ([]*gqlBucket, error) {
+	offset, limit, err := r.page(pageArgs{args.Offset, args.Limit})
+	if err != nil {
+		return nil, err
+	}
+	pagination := &iotexapi.PaginationParam{Offset: offset, Limit: limit}
+	if args.Voter == nil {
+		return r.buckets(ctx, iotexapi.ReadStakingDataMethod_BUCKETS, &iotexapi.ReadStakingDataRequest{
+			Request: &iotexapi.ReadStakingDataRequest_Buckets{
+				Buckets: &iotexapi.ReadStakingDataRequest_VoteBuckets{Pagination: pagination},
+			},
+		})
+	}
+	voter, err := address.FromBytes(args.Voter.Bytes())
+	if err != nil {
+		return nil, err
+	}
+	return r.buckets(ctx, iotexapi.ReadStakingDataMethod_BUCKETS_BY_VOTER, &iotexapi.ReadStakingDataRequest{
+		Request: &iotexapi.ReadStakingDataRequest_BucketsByVoter{
+			BucketsByVoter: &iotexapi.ReadStakingDataRequest_VoteBucketsByVoter{
+				VoterAddress: voter.String(),
+				Pagination:   pagination,
+			},
+		},
+	})
+}
+
+// SendRawTransaction sends the RLP-encoded signed ethereum transaction
+func (r *graphQLResolver) SendRawTransaction(args struct{ Data hexutil.Bytes }) (common.Hash, error) {
+	actHash, err := r.web3.sendEthRawTx(hex.EncodeToString(args.Data))
+	if err != nil {
+		return common.Hash{}, err
+	}
+	return common.HexToHash(actHash), nil
+}
+
+func (r *graphQLResolver) blockByHeight(height uint64) (*gqlBlock, error) {
+	blk, err := r.core.BlockByHeight(height)
+	if err != nil {
+		return nil, err
+	}
+	return &gqlBlock{r: r, blk: blk}, nil
+}
+
+func (r *graphQLResolver) blockByHash(h hash.Hash256) (*gqlBlock, error) {
+	blk, err := r.core.BlockByHash(hex.EncodeToString(h[:]))
+	if err != nil {
+		return nil, err
+	}
+	return &gqlBlock{r: r, blk: blk}, nil
+}
+
+// transactionByHash loads the action of the hash from the chain, or from the actpool if it isn't in the chain
+func (r *graphQLResolver) transactionByHash(ctx context.Context, h hash.Hash256) (*gqlTransaction, error) {
+	if err := chargeGraphQLCost(ctx, 1); err != nil {
+		return nil, err
+	}
+	selp, blkHash, blkHeight, _, err := r.core.ActionByActionHash(h)
+	if err != nil {
+		if errors.Cause(err) != ErrNotFound {
+			return nil, err
+		}
+		selps, err := r.core.ActionsInActPool([]string{hex.EncodeToString(h[:])})
+		if err != nil || len(selps) == 0 {
+			return nil, ErrNotFound
+		}
+		return &gqlTransaction{r: r, hash: h, selp: selps[0]}, nil
+	}
+	receipt, err := r.core.ReceiptByActionHash(h)
+	if err != nil {
+		return nil, err
+	}
+	return &gqlTransaction{
+		r:         r,
+		hash:      h,
+		selp:      selp,
+		receipt:   receipt,
+		blkHash:   blkHash,
+		blkHeight: blkHeight,
+	}, nil
+}
+
+// readStaking reads the staking data of the method into resp
+func (r *graphQLResolver) readStaking(ctx context.Context, method iotexapi.ReadStakingDataMethod_Name, req *iotexapi.ReadStakingDataRequest, resp proto.Message) error {
+	if err := chargeGraphQLCost(ctx, 1); err != nil {
+		return err
+	}
+	methodName, err := proto.Marshal(&iotexapi.ReadStakingDataMethod{Method: method})
+	if err != nil {
+		return err
+	}
+	arg, err := proto.Marshal(req)
+	if err != nil {
+		return err
+	}
+	res, err := r.core.ReadState("staking", "", methodName, [][]byte{arg})
+	if err != nil {
+		return err
+	}
+	return proto.Unmarshal(res.GetData(), resp)
+}
+
+func (r *graphQLResolver) buckets(ctx context.Context, method iotexapi.ReadStakingDataMethod_Name, req *iotexapi.ReadStakingDataRequest) ([]*gqlBucket, error) {
+	var buckets iotextypes.VoteBucketList
+	if err := r.readStaking(ctx, method, req, &buckets); err != nil {
+		return nil, err
+	}
+	ret := make([]*gqlBucket, 0, len(buckets.Buckets))
+	for _, b := range buckets.Buckets {
+		ret = append(ret, &gqlBucket{b})
+	}
+	return ret, nil
+}
@@ -0,0 +1,202 @@
+// Copyright (c) 2022 IoTeX Foundation
+// This is an alpha (internal) release and is not suitable for production. This source code is provided 'as is' and no
+// warranties are given as to title or non-infringement, merchantability or fitness for purpose and, to the extent
+// permitted by law, all liability for your use of the code is disclaimed. This source code is governed by Apache
+// License 2.0 that can be found in the LICENSE file.
+
+package api
+
+// _graphQLSchema follows the schema of EIP-1767 for blocks, transactions, logs and accounts, which is extended with
+// the staking buckets, candidates and epochs of IoTeX
+const _graphQLSchema = `
+    # Bytes32 is a 32 byte binary string, represented as 0x-prefixed hexadecimal.
+    scalar Bytes32
+    # Address is a 20 byte Ethereum address, represented as 0x-prefixed hexadecimal.
+    scalar Address
+    # Bytes is an arbitrary length binary string, represented as 0x-prefixed hexadecimal.
+    scalar Bytes
+    # BigInt is a large integer, represented as 0x-prefixed hexadecimal.
+    scalar BigInt
+    # Long is a 64 bit unsigned integer.
+    scalar Long
+
+    schema {
+        query: Query
+        mutation: Mutation
+    }
+
+    # Account is an account at a particular block.
+    type Account {
+        address: Address!
+        # ioAddress is the address in IoTeX format, e.g. io1...
+        ioAddress: String!
+        balance: BigInt!
+        transactionCount: Long!
+        code: Bytes!
+        storage(slot: Bytes32!): Bytes32!
+    }
+
+    # Log is an event emitted by a smart contract.
+    type Log {
+        index: Int!
+        account(block: Long): Account!
+        topics: [Bytes32!]!
+        data: Bytes!
+        transaction: Transaction!
+    }
+
+    # Transaction is an action of IoTeX.
+    type Transaction {
+        hash: Bytes32!
+        nonce: Long!
+        index: Int
+        from(block: Long): Account!
+        to(block: Long): Account
+        value: BigInt!
+        gasPrice: BigInt!
+        gas: Long!
+        inputData: Bytes!
+        block: Block
+        status: Long
+        gasUsed: Long
+        cumulativeGasUsed: Long
+        createdContract(block: Long): Account
+        logs: [Log!]
+        # actionType is the type of action in IoTeX, e.g. transfer, execution, createStake
+        actionType: String!
+    }
+
+    input BlockFilterCriteria {
+        addresses: [Address!]
+        topics: [[Bytes32!]!]
+    }
+
+    # Block is a block of IoTeX, in which the fields of proof of work are always empty.
+    type Block {
+        number: Long!
+        hash: Bytes32!
+        parent: Block
+        nonce: Bytes!
+        transactionsRoot: Bytes32!
+        transactionCount: Int
+        stateRoot: Bytes32!
+        receiptsRoot: Bytes32!
+        miner(block: Long): Account!
+        extraData: Bytes!
+        gasLimit: Long!
+        gasUsed: Long!
+        timestamp: Long!
+        logsBloom: Bytes!
+        mixHash: Bytes32!
+        difficulty: BigInt!
+        totalDifficulty: BigInt!
+        ommerCount: Int
+        ommers: [Block]
+        ommerAt(index: Int!): Block
+        ommerHash: Bytes32!
+        transactions: [Transaction!]
+        transactionAt(index: Int!): Transaction
+        logs(filter: BlockFilterCriteria!): [Log!]!
+        account(address: Address!): Account!
+        call(data: CallData!): CallResult
+        estimateGas(data: CallData!): Long!
+    }
+
+    input CallData {
+        from: Address
+        to: Address
+        gas: Long
+        gasPrice: BigInt
+        value: BigInt
+        data: Bytes
+    }
+
+    type CallResult {
+        data: Bytes!
+        gasUsed: Long!
+        status: Long!
+    }
+
+    input FilterCriteria {
+        fromBlock: Long
+        toBlock: Long
+        addresses: [Address!]
+        topics: [[Bytes32!]!]
+    }
+
+    type SyncState {
+        startingBlock: Long!
+        currentBlock: Long!
+        highestBlock: Long!
+    }
+
+    # Pending is the actions in the actpool, the state is the one of the latest block.
+    type Pending {
+        transactionCount: Int!
+        transactions: [Transaction!]
+        account(address: Address!): Account!
+        call(data: CallData!): CallResult
+        estimateGas(data: CallData!): Long!
+    }
+
+    # Epoch is an epoch of the delegates producing blocks.
+    type Epoch {
+        number: Long!
+        height: Long!
+        gravityChainStartHeight: Long!
+        numBlocks: Long!
+        producers: [BlockProducer!]!
+    }
+
+    type BlockProducer {
+        address: String!
+        votes: BigInt!
+        active: Boolean!
+        production: Long!
+    }
+
+    # Candidate is a candidate of delegates registered in staking protocol.
+    type Candidate {
+        name: String!
+        ownerAddress: String!
+        operatorAddress: String!
+        rewardAddress: String!
+        totalWeightedVotes: BigInt!
+        selfStakeBucketIdx: Long!
+        selfStakingTokens: BigInt!
+        buckets(offset: Int, limit: Int): [Bucket!]!
+    }
+
+    # Bucket is a bucket of native staking.
+    type Bucket {
+        index: Long!
+        candidateAddress: String!
+        owner: String!
+        stakedAmount: BigInt!
+        stakedDuration: Int!
+        createTime: Long!
+        stakeStartTime: Long!
+        unstakeStartTime: Long!
+        autoStake: Boolean!
+    }
+
+    type Query {
+        block(number: Long, hash: Bytes32): Block
+        blocks(from: Long, to: Long): [Block!]!
+        pending: Pending!
+        transaction(hash: Bytes32!): Transaction
+        logs(filter: FilterCriteria!): [Log!]!
+        gasPrice: BigInt!
+        syncing: SyncState
+        chainID: BigInt!
+        # epoch returns the epoch, or the current epoch if the number is omitted
+        epoch(number: Long): Epoch!
+        candidates(offset: Int, limit: Int): [Candidate!]!
+        candidate(name: String!): Candidate
+        buckets(offset: Int, limit: Int, voter: Address): [Bucket!]!
+    }
+
+    type Mutation {
+        sendRawTransaction(data: Bytes!): Bytes32!
+    }
+`
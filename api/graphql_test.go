@@ -0,0 +1,124 @@
+// Copyright (c) 2022 IoTeX Foundation
+// This is an alpha (internal) release and is not suitable for production. This source code is provided 'as is' and no
+// warranties are given as to title or non-infringement, merchantability or fitness for purpose and, to the extent
+// permitted by law, all liability for your use of the code is disclaimed. This source code is governed by Apache
+// License 2.0 that can be found in the LICENSE file.
+
+package api
+
+import (
+	"bytes"
+	"encoding/hex"
+	"encoding/json"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/iotexproject/iotex-proto/golang/iotexapi"
+	"github.com/iotexproject/iotex-proto/golang/iotextypes"
+	"github.com/stretchr/testify/require"
+	"github.com/tidwall/gjson"
+	"google.golang.org/protobuf/proto"
+
+	"github.com/iotexproject/iotex-core/action"
+	apitypes "github.com/iotexproject/iotex-core/api/types"
+	"github.com/iotexproject/iotex-core/blockchain/block"
+	"github.com/iotexproject/iotex-core/config"
+	"github.com/iotexproject/iotex-core/test/identityset"
+	"github.com/iotexproject/iotex-core/test/mock/mock_apicoreservice"
+)
+
+func TestGraphQLHandler(t *testing.T) {
+	require := require.New(t)
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	core := mock_apicoreservice.NewMockCoreService(ctrl)
+	cfg := config.Default.API
+	cfg.GraphQL.MaxCost = 5
+	handler := NewGraphQLHandler(core, cfg)
+	query := func(q string) (int, gjson.Result) {
+		body, err := json.Marshal(&graphQLRequest{Query: q})
+		require.NoError(err)
+		resp := httptest.NewRecorder()
+		handler.ServeHTTP(resp, httptest.NewRequest(http.MethodPost, "http://url.com", bytes.NewReader(body)))
+		return resp.Code, gjson.Parse(resp.Body.String())
+	}
+
+	t.Run("MethodNotAllowed", func(t *testing.T) {
+		resp := httptest.NewRecorder()
+		handler.ServeHTTP(resp, httptest.NewRequest(http.MethodPut, "http://url.com", nil))
+		require.Equal(http.StatusMethodNotAllowed, resp.Code)
+	})
+
+	t.Run("Block", func(t *testing.T) {
+		selp, err := action.SignedTransfer(identityset.Address(1).String(), identityset.PrivateKey(0), 1, big.NewInt(10), nil, 21000, big.NewInt(1))
+		require.NoError(err)
+		actHash, err := selp.Hash()
+		require.NoError(err)
+		blk, err := block.NewTestingBuilder().
+			SetHeight(2).
+			SetTimeStamp(time.Unix(1000, 0)).
+			AddActions(selp).
+			SignAndBuild(identityset.PrivateKey(1))
+		require.NoError(err)
+		receipt := &action.Receipt{Status: 1, BlockHeight: 2, ActionHash: actHash, GasConsumed: 10000}
+		core.EXPECT().BlockByHeight(uint64(2)).Return(&apitypes.BlockWithReceipts{
+			Block:    &blk,
+			Receipts: []*action.Receipt{receipt},
+		}, nil)
+
+		code, res := query(`{ block(number: 2) { number timestamp gasLimit gasUsed ommerCount
+			miner { ioAddress } transactions { hash nonce value actionType status cumulativeGasUsed from { ioAddress } } } }`)
+		require.Equal(http.StatusOK, code)
+		require.False(res.Get("errors").Exists(), res.Raw)
+		b := res.Get("data.block")
+		require.Equal("0x2", b.Get("number").String())
+		require.Equal("0x3e8", b.Get("timestamp").String())
+		require.Equal("0x5208", b.Get("gasLimit").String())
+		require.Equal("0x2710", b.Get("gasUsed").String())
+		require.Equal(int64(0), b.Get("ommerCount").Int())
+		require.Equal(identityset.Address(1).String(), b.Get("miner.ioAddress").String())
+		tx := b.Get("transactions.0")
+		require.Equal("0x"+hex.EncodeToString(actHash[:]), tx.Get("hash").String())
+		require.Equal("0x1", tx.Get("nonce").String())
+		require.Equal("0xa", tx.Get("value").String())
+		require.Equal("transfer", tx.Get("actionType").String())
+		require.Equal("0x1", tx.Get("status").String())
+		require.Equal(identityset.Address(0).String(), tx.Get("from.ioAddress").String())
+
+		core.EXPECT().BlockByHeight(uint64(3)).Return(nil, ErrNotFound)
+		_, res = query(`{ block(number: 3) { number } }`)
+		require.False(res.Get("errors").Exists(), res.Raw)
+		require.Equal("null", res.Get("data.block").Raw)
+	})
+
+	t.Run("Staking", func(t *testing.T) {
+		candidates, err := proto.Marshal(&iotextypes.CandidateListV2{
+			Candidates: []*iotextypes.CandidateV2{{Name: "alice", TotalWeightedVotes: "1024"}},
+		})
+		require.NoError(err)
+		core.EXPECT().ReadState("staking", "", gomock.Any(), gomock.Any()).Return(&iotexapi.ReadStateResponse{Data: candidates}, nil)
+		_, res := query(`{ candidates(limit: 10) { name totalWeightedVotes } }`)
+		require.False(res.Get("errors").Exists(), res.Raw)
+		require.Equal("alice", res.Get("data.candidates.0.name").String())
+		require.Equal("0x400", res.Get("data.candidates.0.totalWeightedVotes").String())
+
+		_, res = query(`{ candidates(limit: 0) { name } }`)
+		require.True(res.Get("errors").Exists())
+	})
+
+	t.Run("QueryCost", func(t *testing.T) {
+		core.EXPECT().TipHeight().Return(uint64(100))
+		_, res := query(`{ blocks(from: 1, to: 10) { number } }`)
+		require.Contains(res.Get("errors.0.message").String(), errGraphQLCostExceeded.Error())
+	})
+
+	t.Run("MaxDepth", func(t *testing.T) {
+		code, res := query(`{ block { parent { parent { parent { parent { parent { parent { parent { parent { parent { parent { number } } } } } } } } } } } }`)
+		require.Equal(http.StatusBadRequest, code)
+		require.True(res.Get("errors").Exists())
+	})
+}
@@ -0,0 +1,867 @@
+// Copyright (c) 2022 IoTeX Foundation
+// This is an alpha (internal) release and is not suitable for production. This source code is provided 'as is' and no
+// warranties are given as to title or non-infringement, merchantability or fitness for purpose and, to the extent
+// permitted by law, all liability for your use of the code is disclaimed. This source code is governed by Apache
+// License 2.0 that can be found in the LICENSE file.
+
+package api
+
+import (
+	"context"
+	"encoding/hex"
+	"math/big"
+	"reflect"
+	"unicode"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/iotexproject/go-pkgs/hash"
+	"github.com/iotexproject/iotex-address/address"
+	"github.com/iotexproject/iotex-proto/golang/iotexapi"
+	"github.com/iotexproject/iotex-proto/golang/iotextypes"
+	"github.com/pkg/errors"
+
+	"github.com/iotexproject/iotex-core/action"
+	apitypes "github.com/iotexproject/iotex-core/api/types"
+	"github.com/iotexproject/iotex-core/blockchain/block"
+)
+
+// _emptyOmmerHash is the hash of the RLP encoding of an empty list of ommers
+var _emptyOmmerHash = common.HexToHash("0x1dcc4de8dec75d7aab85b567b6ccd41ad312451b948a7413f0a142fd40d49347")
+
+type (
+	// blockArgs is the argument of the fields at a block, which is ignored since the state is always the latest
+	blockArgs struct {
+		Block *hexutil.Uint64
+	}
+
+	callData struct {
+		From     *common.Address
+		To       *common.Address
+		Gas      *hexutil.Uint64
+		GasPrice *hexutil.Big
+		Value    *hexutil.Big
+		Data     *hexutil.Bytes
+	}
+
+	blockFilterCriteria struct {
+		Addresses *[]common.Address
+		Topics    *[][]common.Hash
+	}
+
+	filterCriteria struct {
+		FromBlock *hexutil.Uint64
+		ToBlock   *hexutil.Uint64
+		Addresses *[]common.Address
+		Topics    *[][]common.Hash
+	}
+
+	gqlAccount struct {
+		r    *graphQLResolver
+		addr address.Address
+	}
+
+	gqlLog struct {
+		r   *graphQLResolver
+		log *action.Log
+	}
+
+	// gqlTransaction is an action, the receipt of which is nil if it is pending
+	gqlTransaction struct {
+		r         *graphQLResolver
+		hash      hash.Hash256
+		selp      action.SealedEnvelope
+		receipt   *action.Receipt
+		blkHash   hash.Hash256
+		blkHeight uint64
+		// blk is the block of the action, which is loaded on demand if nil
+		blk *gqlBlock
+	}
+
+	gqlBlock struct {
+		r   *graphQLResolver
+		blk *apitypes.BlockWithReceipts
+	}
+
+	gqlCallResult struct {
+		data    hexutil.Bytes
+		gasUsed uint64
+		status  uint64
+	}
+
+	gqlSyncState struct {
+		start, curr, highest uint64
+	}
+
+	gqlPending struct {
+		r *graphQLResolver
+	}
+
+	gqlEpoch struct {
+		epoch     *iotextypes.EpochData
+		numBlks   uint64
+		producers []*iotexapi.BlockProducerInfo
+	}
+
+	gqlBlockProducer struct {
+		producer *iotexapi.BlockProducerInfo
+	}
+
+	gqlCandidate struct {
+		r    *graphQLResolver
+		cand *iotextypes.CandidateV2
+	}
+
+	gqlBucket struct {
+		bucket *iotextypes.VoteBucket
+	}
+)
+
+func (f *blockFilterCriteria) addresses() []string {
+	return ethAddrsToHex(f.Addresses)
+}
+
+func (f *blockFilterCriteria) topics() [][]string {
+	return ethTopicsToHex(f.Topics)
+}
+
+func (f *filterCriteria) addresses() []string {
+	return ethAddrsToHex(f.Addresses)
+}
+
+func (f *filterCriteria) topics() [][]string {
+	return ethTopicsToHex(f.Topics)
+}
+
+func ethAddrsToHex(addrs *[]common.Address) []string {
+	if addrs == nil {
+		return nil
+	}
+	ret := make([]string, 0, len(*addrs))
+	for _, addr := range *addrs {
+		ret = append(ret, addr.Hex())
+	}
+	return ret
+}
+
+func ethTopicsToHex(topics *[][]common.Hash) [][]string {
+	if topics == nil {
+		return nil
+	}
+	ret := make([][]string, 0, len(*topics))
+	for _, topic := range *topics {
+		hexes := make([]string, 0, len(topic))
+		for _, h := range topic {
+			hexes = append(hexes, h.Hex())
+		}
+		ret = append(ret, hexes)
+	}
+	return ret
+}
+
+func decimalToBig(str string) (hexutil.Big, error) {
+	if str == "" {
+		return hexutil.Big{}, nil
+	}
+	v, ok := new(big.Int).SetString(str, 10)
+	if !ok {
+		return hexutil.Big{}, errors.Wrapf(errUnkownType, "int: %s", str)
+	}
+	return hexutil.Big(*v), nil
+}
+
+func ioAddrToAccount(r *graphQLResolver, ioAddr string) (*gqlAccount, error) {
+	addr, err := address.FromString(ioAddr)
+	if err != nil {
+		return nil, err
+	}
+	return &gqlAccount{r: r, addr: addr}, nil
+}
+
+func ethAddrToAccount(r *graphQLResolver, ethAddr common.Address) (*gqlAccount, error) {
+	addr, err := address.FromBytes(ethAddr.Bytes())
+	if err != nil {
+		return nil, err
+	}
+	return &gqlAccount{r: r, addr: addr}, nil
+}
+
+// call reads the contract with the call data at the latest state
+func (r *graphQLResolver) call(ctx context.Context, data callData) (*gqlCallResult, error) {
+	if err := chargeGraphQLCost(ctx, 1); err != nil {
+		return nil, err
+	}
+	from, to, gasLimit, value, input, err := data.parse()
+	if err != nil {
+		return nil, err
+	}
+	gasPrice := big.NewInt(0)
+	if data.GasPrice != nil {
+		gasPrice = data.GasPrice.ToInt()
+	}
+	exec, err := action.NewExecution(to, 0, value, gasLimit, gasPrice, input)
+	if err != nil {
+		return nil, err
+	}
+	ret, receipt, err := r.core.ReadContract(ctx, from, exec)
+	if err != nil {
+		return nil, err
+	}
+	retData, err := hex.DecodeString(ret)
+	if err != nil {
+		return nil, err
+	}
+	return &gqlCallResult{
+		data:    retData,
+		gasUsed: receipt.GetGasConsumed(),
+		status:  receipt.GetStatus(),
+	}, nil
+}
+
+// estimateGas estimates the gas of the action built from the call data at the latest state
+func (r *graphQLResolver) estimateGas(ctx context.Context, data callData) (hexutil.Uint64, error) {
+	if err := chargeGraphQLCost(ctx, 1); err != nil {
+		return 0, err
+	}
+	from, to, gasLimit, value, input, err := data.parse()
+	if err != nil {
+		return 0, err
+	}
+	gas, err := r.web3.estimateCallGas(from, to, gasLimit, value, input, nil)
+	return hexutil.Uint64(gas), err
+}
+
+func (data *callData) parse() (address.Address, string, uint64, *big.Int, []byte, error) {
+	var (
+		from     = common.Address{}
+		to       string
+		gasLimit uint64
+		value    = big.NewInt(0)
+		input    []byte
+	)
+	if data.From != nil {
+		from = *data.From
+	}
+	fromAddr, err := address.FromBytes(from.Bytes())
+	if err != nil {
+		return nil, "", 0, nil, nil, err
+	}
+	if data.To != nil {
+		toAddr, err := address.FromBytes(data.To.Bytes())
+		if err != nil {
+			return nil, "", 0, nil, nil, err
+		}
+		to = toAddr.String()
+	}
+	if data.Gas != nil {
+		gasLimit = uint64(*data.Gas)
+	}
+	if data.Value != nil {
+		value = data.Value.ToInt()
+	}
+	if data.Data != nil {
+		input = *data.Data
+	}
+	return fromAddr, to, gasLimit, value, input, nil
+}
+
+func (a *gqlAccount) meta(ctx context.Context) (*iotextypes.AccountMeta, error) {
+	if err := chargeGraphQLCost(ctx, 1); err != nil {
+		return nil, err
+	}
+	meta, _, err := a.r.core.Account(a.addr)
+	return meta, err
+}
+
+func (a *gqlAccount) Address() common.Address {
+	return common.BytesToAddress(a.addr.Bytes())
+}
+
+func (a *gqlAccount) IoAddress() string {
+	return a.addr.String()
+}
+
+func (a *gqlAccount) Balance(ctx context.Context) (hexutil.Big, error) {
+	meta, err := a.meta(ctx)
+	if err != nil {
+		return hexutil.Big{}, err
+	}
+	return decimalToBig(meta.GetBalance())
+}
+
+func (a *gqlAccount) TransactionCount(ctx context.Context) (hexutil.Uint64, error) {
+	if err := chargeGraphQLCost(ctx, 1); err != nil {
+		return 0, err
+	}
+	nonce, err := a.r.core.PendingNonce(a.addr)
+	return hexutil.Uint64(nonce), err
+}
+
+func (a *gqlAccount) Code(ctx context.Context) (hexutil.Bytes, error) {
+	meta, err := a.meta(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return meta.GetContractByteCode(), nil
+}
+
+func (a *gqlAccount) Storage(ctx context.Context, args struct{ Slot common.Hash }) (common.Hash, error) {
+	if err := chargeGraphQLCost(ctx, 1); err != nil {
+		return common.Hash{}, err
+	}
+	value, err := a.r.core.ReadContractStorage(ctx, a.addr, args.Slot.Bytes())
+	if err != nil {
+		return common.Hash{}, err
+	}
+	return common.BytesToHash(value), nil
+}
+
+func (l *gqlLog) Index() int32 {
+	return int32(l.log.Index)
+}
+
+func (l *gqlLog) Account(args blockArgs) (*gqlAccount, error) {
+	return ioAddrToAccount(l.r, l.log.Address)
+}
+
+func (l *gqlLog) Topics() []common.Hash {
+	topics := make([]common.Hash, 0, len(l.log.Topics))
+	for _, topic := range l.log.Topics {
+		topics = append(topics, common.BytesToHash(topic[:]))
+	}
+	return topics
+}
+
+func (l *gqlLog) Data() hexutil.Bytes {
+	return l.log.Data
+}
+
+func (l *gqlLog) Transaction(ctx context.Context) (*gqlTransaction, error) {
+	return l.r.transactionByHash(ctx, l.log.ActionHash)
+}
+
+// ethTx returns the action as an ethereum transaction, or nil if it isn't compatible
+func (t *gqlTransaction) ethTx() *types.Transaction {
+	act, ok := t.selp.Action().(action.EthCompatibleAction)
+	if !ok {
+		return nil
+	}
+	tx, err := act.ToEthTx()
+	if err != nil {
+		return nil
+	}
+	return tx
+}
+
+func (t *gqlTransaction) Hash() common.Hash {
+	return common.BytesToHash(t.hash[:])
+}
+
+func (t *gqlTransaction) Nonce() hexutil.Uint64 {
+	return hexutil.Uint64(t.selp.Nonce())
+}
+
+func (t *gqlTransaction) Index() *int32 {
+	if t.receipt == nil {
+		return nil
+	}
+	index := int32(t.receipt.TxIndex)
+	return &index
+}
+
+func (t *gqlTransaction) From(args blockArgs) (*gqlAccount, error) {
+	return &gqlAccount{r: t.r, addr: t.selp.SrcPubkey().Address()}, nil
+}
+
+func (t *gqlTransaction) To(args blockArgs) (*gqlAccount, error) {
+	tx := t.ethTx()
+	if tx == nil || tx.To() == nil {
+		return nil, nil
+	}
+	return ethAddrToAccount(t.r, *tx.To())
+}
+
+func (t *gqlTransaction) Value() hexutil.Big {
+	tx := t.ethTx()
+	if tx == nil {
+		return hexutil.Big{}
+	}
+	return hexutil.Big(*tx.Value())
+}
+
+func (t *gqlTransaction) GasPrice() hexutil.Big {
+	return hexutil.Big(*t.selp.GasPrice())
+}
+
+func (t *gqlTransaction) Gas() hexutil.Uint64 {
+	return hexutil.Uint64(t.selp.GasLimit())
+}
+
+func (t *gqlTransaction) InputData() hexutil.Bytes {
+	tx := t.ethTx()
+	if tx == nil {
+		return hexutil.Bytes{}
+	}
+	return tx.Data()
+}
+
+func (t *gqlTransaction) Block(ctx context.Context) (*gqlBlock, error) {
+	if t.receipt == nil {
+		return nil, nil
+	}
+	if t.blk != nil {
+		return t.blk, nil
+	}
+	if err := chargeGraphQLCost(ctx, 1); err != nil {
+		return nil, err
+	}
+	return t.r.blockByHash(t.blkHash)
+}
+
+func (t *gqlTransaction) Status() *hexutil.Uint64 {
+	if t.receipt == nil {
+		return nil
+	}
+	status := hexutil.Uint64(t.receipt.Status)
+	return &status
+}
+
+func (t *gqlTransaction) GasUsed() *hexutil.Uint64 {
+	if t.receipt == nil {
+		return nil
+	}
+	gas := hexutil.Uint64(t.receipt.GasConsumed)
+	return &gas
+}
+
+// CumulativeGasUsed returns the gas used by the actions in the block up to this one
+func (t *gqlTransaction) CumulativeGasUsed(ctx context.Context) (*hexutil.Uint64, error) {
+	blk, err := t.Block(ctx)
+	if err != nil || blk == nil {
+		return nil, err
+	}
+	var gas hexutil.Uint64
+	for _, receipt := range blk.blk.Receipts {
+		gas += hexutil.Uint64(receipt.GasConsumed)
+		if receipt.ActionHash == t.hash {
+			return &gas, nil
+		}
+	}
+	return nil, errors.Errorf("the receipt of %x is not in the block", t.hash[:])
+}
+
+func (t *gqlTransaction) CreatedContract(args blockArgs) (*gqlAccount, error) {
+	if t.receipt == nil || t.receipt.ContractAddress == "" {
+		return nil, nil
+	}
+	if exec, ok := t.selp.Action().(*action.Execution); !ok || exec.Contract() != "" {
+		return nil, nil
+	}
+	return ioAddrToAccount(t.r, t.receipt.ContractAddress)
+}
+
+func (t *gqlTransaction) Logs() *[]*gqlLog {
+	if t.receipt == nil {
+		return nil
+	}
+	logs := make([]*gqlLog, 0, len(t.receipt.Logs()))
+	for _, l := range t.receipt.Logs() {
+		logs = append(logs, &gqlLog{r: t.r, log: l})
+	}
+	return &logs
+}
+
+// ActionType returns the name of the action type in lower camel case, e.g. transfer, createStake
+func (t *gqlTransaction) ActionType() string {
+	typ := reflect.TypeOf(t.selp.Action())
+	if typ.Kind() == reflect.Ptr {
+		typ = typ.Elem()
+	}
+	name := []rune(typ.Name())
+	if len(name) == 0 {
+		return ""
+	}
+	name[0] = unicode.ToLower(name[0])
+	return string(name)
+}
+
+func (b *gqlBlock) header() *block.Header {
+	return &b.blk.Block.Header
+}
+
+func (b *gqlBlock) Number() hexutil.Uint64 {
+	return hexutil.Uint64(b.blk.Block.Height())
+}
+
+func (b *gqlBlock) Hash() common.Hash {
+	if b.blk.Block.Height() == 0 {
+		h := block.GenesisHash()
+		return common.BytesToHash(h[:])
+	}
+	h := b.blk.Block.HashBlock()
+	return common.BytesToHash(h[:])
+}
+
+func (b *gqlBlock) Parent(ctx context.Context) (*gqlBlock, error) {
+	if b.blk.Block.Height() == 0 {
+		return nil, nil
+	}
+	if err := chargeGraphQLCost(ctx, 1); err != nil {
+		return nil, err
+	}
+	parent, err := b.r.blockByHeight(b.blk.Block.Height() - 1)
+	if errors.Cause(err) == ErrNotFound {
+		return nil, nil
+	}
+	return parent, err
+}
+
+func (b *gqlBlock) Nonce() hexutil.Bytes {
+	return make(hexutil.Bytes, 8)
+}
+
+func (b *gqlBlock) TransactionsRoot() common.Hash {
+	h := b.header().TxRoot()
+	return common.BytesToHash(h[:])
+}
+
+func (b *gqlBlock) TransactionCount() *int32 {
+	count := int32(len(b.blk.Block.Actions))
+	return &count
+}
+
+func (b *gqlBlock) StateRoot() common.Hash {
+	h := b.header().DeltaStateDigest()
+	return common.BytesToHash(h[:])
+}
+
+func (b *gqlBlock) ReceiptsRoot() common.Hash {
+	h := b.header().ReceiptRoot()
+	return common.BytesToHash(h[:])
+}
+
+func (b *gqlBlock) Miner(args blockArgs) (*gqlAccount, error) {
+	if b.blk.Block.Height() == 0 {
+		return ethAddrToAccount(b.r, common.Address{})
+	}
+	return ioAddrToAccount(b.r, b.header().ProducerAddress())
+}
+
+func (b *gqlBlock) ExtraData() hexutil.Bytes {
+	return hexutil.Bytes{}
+}
+
+func (b *gqlBlock) GasLimit() hexutil.Uint64 {
+	var gas uint64
+	for _, selp := range b.blk.Block.Actions {
+		gas += selp.GasLimit()
+	}
+	return hexutil.Uint64(gas)
+}
+
+func (b *gqlBlock) GasUsed() hexutil.Uint64 {
+	var gas uint64
+	for _, receipt := range b.blk.Receipts {
+		gas += receipt.GasConsumed
+	}
+	return hexutil.Uint64(gas)
+}
+
+func (b *gqlBlock) Timestamp() hexutil.Uint64 {
+	return hexutil.Uint64(b.header().Timestamp().Unix())
+}
+
+func (b *gqlBlock) LogsBloom() hexutil.Bytes {
+	if bloom := b.header().LogsBloomfilter(); bloom != nil {
+		return bloom.Bytes()
+	}
+	return make(hexutil.Bytes, types.BloomByteLength)
+}
+
+func (b *gqlBlock) MixHash() common.Hash {
+	return common.Hash{}
+}
+
+func (b *gqlBlock) Difficulty() hexutil.Big {
+	return hexutil.Big{}
+}
+
+func (b *gqlBlock) TotalDifficulty() hexutil.Big {
+	return hexutil.Big{}
+}
+
+func (b *gqlBlock) OmmerCount() *int32 {
+	count := int32(0)
+	return &count
+}
+
+func (b *gqlBlock) Ommers() *[]*gqlBlock {
+	return &[]*gqlBlock{}
+}
+
+func (b *gqlBlock) OmmerAt(args struct{ Index int32 }) *gqlBlock {
+	return nil
+}
+
+func (b *gqlBlock) OmmerHash() common.Hash {
+	return _emptyOmmerHash
+}
+
+func (b *gqlBlock) transaction(i int) (*gqlTransaction, error) {
+	selp := b.blk.Block.Actions[i]
+	h, err := selp.Hash()
+	if err != nil {
+		return nil, err
+	}
+	var receipt *action.Receipt
+	if i < len(b.blk.Receipts) {
+		receipt = b.blk.Receipts[i]
+	}
+	return &gqlTransaction{
+		r:         b.r,
+		hash:      h,
+		selp:      selp,
+		receipt:   receipt,
+		blkHash:   b.blk.Block.HashBlock(),
+		blkHeight: b.blk.Block.Height(),
+		blk:       b,
+	}, nil
+}
+
+func (b *gqlBlock) Transactions() (*[]*gqlTransaction, error) {
+	txs := make([]*gqlTransaction, 0, len(b.blk.Block.Actions))
+	for i := range b.blk.Block.Actions {
+		tx, err := b.transaction(i)
+		if err != nil {
+			return nil, err
+		}
+		txs = append(txs, tx)
+	}
+	return &txs, nil
+}
+
+func (b *gqlBlock) TransactionAt(args struct{ Index int32 }) (*gqlTransaction, error) {
+	if args.Index < 0 || int(args.Index) >= len(b.blk.Block.Actions) {
+		return nil, nil
+	}
+	return b.transaction(int(args.Index))
+}
+
+func (b *gqlBlock) Logs(ctx context.Context, args struct{ Filter blockFilterCriteria }) ([]*gqlLog, error) {
+	if err := chargeGraphQLCost(ctx, 1); err != nil {
+		return nil, err
+	}
+	filter, err := newLogFilterFrom(args.Filter.addresses(), args.Filter.topics())
+	if err != nil {
+		return nil, err
+	}
+	logs, err := b.r.core.LogsInBlockByHash(filter, b.blk.Block.HashBlock())
+	if err != nil {
+		return nil, err
+	}
+	ret := make([]*gqlLog, 0, len(logs))
+	for _, l := range logs {
+		ret = append(ret, &gqlLog{r: b.r, log: l})
+	}
+	return ret, nil
+}
+
+func (b *gqlBlock) Account(args struct{ Address common.Address }) (*gqlAccount, error) {
+	return ethAddrToAccount(b.r, args.Address)
+}
+
+func (b *gqlBlock) Call(ctx context.Context, args struct{ Data callData }) (*gqlCallResult, error) {
+	return b.r.call(ctx, args.Data)
+}
+
+func (b *gqlBlock) EstimateGas(ctx context.Context, args struct{ Data callData }) (hexutil.Uint64, error) {
+	return b.r.estimateGas(ctx, args.Data)
+}
+
+func (c *gqlCallResult) Data() hexutil.Bytes {
+	return c.data
+}
+
+func (c *gqlCallResult) GasUsed() hexutil.Uint64 {
+	return hexutil.Uint64(c.gasUsed)
+}
+
+func (c *gqlCallResult) Status() hexutil.Uint64 {
+	return hexutil.Uint64(c.status)
+}
+
+func (s *gqlSyncState) StartingBlock() hexutil.Uint64 {
+	return hexutil.Uint64(s.start)
+}
+
+func (s *gqlSyncState) CurrentBlock() hexutil.Uint64 {
+	return hexutil.Uint64(s.curr)
+}
+
+func (s *gqlSyncState) HighestBlock() hexutil.Uint64 {
+	return hexutil.Uint64(s.highest)
+}
+
+func (p *gqlPending) transactions(ctx context.Context) ([]*gqlTransaction, error) {
+	if err := chargeGraphQLCost(ctx, 1); err != nil {
+		return nil, err
+	}
+	selps, err := p.r.core.ActionsInActPool(nil)
+	if err != nil {
+		return nil, err
+	}
+	txs := make([]*gqlTransaction, 0, len(selps))
+	for _, selp := range selps {
+		h, err := selp.Hash()
+		if err != nil {
+			return nil, err
+		}
+		txs = append(txs, &gqlTransaction{r: p.r, hash: h, selp: selp})
+	}
+	return txs, nil
+}
+
+func (p *gqlPending) TransactionCount(ctx context.Context) (int32, error) {
+	txs, err := p.transactions(ctx)
+	return int32(len(txs)), err
+}
+
+func (p *gqlPending) Transactions(ctx context.Context) (*[]*gqlTransaction, error) {
+	txs, err := p.transactions(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return &txs, nil
+}
+
+func (p *gqlPending) Account(args struct{ Address common.Address }) (*gqlAccount, error) {
+	return ethAddrToAccount(p.r, args.Address)
+}
+
+func (p *gqlPending) Call(ctx context.Context, args struct{ Data callData }) (*gqlCallResult, error) {
+	return p.r.call(ctx, args.Data)
+}
+
+func (p *gqlPending) EstimateGas(ctx context.Context, args struct{ Data callData }) (hexutil.Uint64, error) {
+	return p.r.estimateGas(ctx, args.Data)
+}
+
+func (e *gqlEpoch) Number() hexutil.Uint64 {
+	return hexutil.Uint64(e.epoch.GetNum())
+}
+
+func (e *gqlEpoch) Height() hexutil.Uint64 {
+	return hexutil.Uint64(e.epoch.GetHeight())
+}
+
+func (e *gqlEpoch) GravityChainStartHeight() hexutil.Uint64 {
+	return hexutil.Uint64(e.epoch.GetGravityChainStartHeight())
+}
+
+func (e *gqlEpoch) NumBlocks() hexutil.Uint64 {
+	return hexutil.Uint64(e.numBlks)
+}
+
+func (e *gqlEpoch) Producers() []*gqlBlockProducer {
+	producers := make([]*gqlBlockProducer, 0, len(e.producers))
+	for _, p := range e.producers {
+		producers = append(producers, &gqlBlockProducer{p})
+	}
+	return producers
+}
+
+func (p *gqlBlockProducer) Address() string {
+	return p.producer.GetAddress()
+}
+
+func (p *gqlBlockProducer) Votes() (hexutil.Big, error) {
+	return decimalToBig(p.producer.GetVotes())
+}
+
+func (p *gqlBlockProducer) Active() bool {
+	return p.producer.GetActive()
+}
+
+func (p *gqlBlockProducer) Production() hexutil.Uint64 {
+	return hexutil.Uint64(p.producer.GetProduction())
+}
+
+func (c *gqlCandidate) Name() string {
+	return c.cand.GetName()
+}
+
+func (c *gqlCandidate) OwnerAddress() string {
+	return c.cand.GetOwnerAddress()
+}
+
+func (c *gqlCandidate) OperatorAddress() string {
+	return c.cand.GetOperatorAddress()
+}
+
+func (c *gqlCandidate) RewardAddress() string {
+	return c.cand.GetRewardAddress()
+}
+
+func (c *gqlCandidate) TotalWeightedVotes() (hexutil.Big, error) {
+	return decimalToBig(c.cand.GetTotalWeightedVotes())
+}
+
+func (c *gqlCandidate) SelfStakeBucketIdx() hexutil.Uint64 {
+	return hexutil.Uint64(c.cand.GetSelfStakeBucketIdx())
+}
+
+func (c *gqlCandidate) SelfStakingTokens() (hexutil.Big, error) {
+	return decimalToBig(c.cand.GetSelfStakingTokens())
+}
+
+func (c *gqlCandidate) Buckets(ctx context.Context, args pageArgs) ([]*gqlBucket, error) {
+	offset, limit, err := c.r.page(args)
+	if err != nil {
+		return nil, err
+	}
+	return c.r.buckets(ctx, iotexapi.ReadStakingDataMethod_BUCKETS_BY_CANDIDATE, &iotexapi.ReadStakingDataRequest{
+		Request: &iotexapi.ReadStakingDataRequest_BucketsByCandidate{
+			BucketsByCandidate: &iotexapi.ReadStakingDataRequest_VoteBucketsByCandidate{
+				CandName:   c.cand.GetName(),
+				Pagination: &iotexapi.PaginationParam{Offset: offset, Limit: limit},
+			},
+		},
+	})
+}
+
+func (b *gqlBucket) Index() hexutil.Uint64 {
+	return hexutil.Uint64(b.bucket.GetIndex())
+}
+
+func (b *gqlBucket) CandidateAddress() string {
+	return b.bucket.GetCandidateAddress()
+}
+
+func (b *gqlBucket) Owner() string {
+	return b.bucket.GetOwner()
+}
+
+func (b *gqlBucket) StakedAmount() (hexutil.Big, error) {
+	return decimalToBig(b.bucket.GetStakedAmount())
+}
+
+func (b *gqlBucket) StakedDuration() int32 {
+	return int32(b.bucket.GetStakedDuration())
+}
+
+func (b *gqlBucket) CreateTime() hexutil.Uint64 {
+	return hexutil.Uint64(b.bucket.GetCreateTime().GetSeconds())
+}
+
+func (b *gqlBucket) StakeStartTime() hexutil.Uint64 {
+	return hexutil.Uint64(b.bucket.GetStakeStartTime().GetSeconds())
+}
+
+func (b *gqlBucket) UnstakeStartTime() hexutil.Uint64 {
+	return hexutil.Uint64(b.bucket.GetUnstakeStartTime().GetSeconds())
+}
+
+func (b *gqlBucket) AutoStake() bool {
+	return b.bucket.GetAutoStake()
+}
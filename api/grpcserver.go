@@ -40,12 +40,16 @@ import (
 
 	"github.com/iotexproject/iotex-core/action"
 	"github.com/iotexproject/iotex-core/action/protocol"
+	"github.com/iotexproject/iotex-core/api/apipb"
 	"github.com/iotexproject/iotex-core/api/logfilter"
 	apitypes "github.com/iotexproject/iotex-core/api/types"
 	"github.com/iotexproject/iotex-core/blockchain/block"
+	"github.com/iotexproject/iotex-core/blockindex"
 	"github.com/iotexproject/iotex-core/pkg/log"
 	"github.com/iotexproject/iotex-core/pkg/recovery"
 	"github.com/iotexproject/iotex-core/pkg/tracer"
+	"github.com/iotexproject/iotex-core/state"
+	"github.com/iotexproject/iotex-core/state/factory"
 )
 
 type (
@@ -57,6 +61,7 @@ type (
 
 	// GRPCHandler contains the pointer to api coreservice
 	gRPCHandler struct {
+		apipb.UnimplementedAPIServiceExtServer
 		coreService CoreService
 	}
 )
@@ -81,30 +86,38 @@ func RecoveryInterceptor() grpc_recovery.Option {
 	})
 }
 
-// NewGRPCServer creates a new grpc server
-func NewGRPCServer(core CoreService, grpcPort int) *GRPCServer {
+// NewGRPCServer creates a new grpc server, the requests are limited by the limiter if it is not nil
+func NewGRPCServer(core CoreService, grpcPort int, limiter *APILimiter) *GRPCServer {
 	if grpcPort == 0 {
 		return nil
 	}
 
+	streamInterceptors := []grpc.StreamServerInterceptor{
+		grpc_prometheus.StreamServerInterceptor,
+		otelgrpc.StreamServerInterceptor(),
+		grpc_recovery.StreamServerInterceptor(RecoveryInterceptor()),
+	}
+	unaryInterceptors := []grpc.UnaryServerInterceptor{
+		grpc_prometheus.UnaryServerInterceptor,
+		otelgrpc.UnaryServerInterceptor(),
+		grpc_recovery.UnaryServerInterceptor(RecoveryInterceptor()),
+	}
+	if limiter != nil {
+		streamInterceptors = append(streamInterceptors, limiter.StreamServerInterceptor())
+		unaryInterceptors = append(unaryInterceptors, limiter.UnaryServerInterceptor())
+	}
 	gSvr := grpc.NewServer(
-		grpc.StreamInterceptor(grpc_middleware.ChainStreamServer(
-			grpc_prometheus.StreamServerInterceptor,
-			otelgrpc.StreamServerInterceptor(),
-			grpc_recovery.StreamServerInterceptor(RecoveryInterceptor()),
-		)),
-		grpc.UnaryInterceptor(grpc_middleware.ChainUnaryServer(
-			grpc_prometheus.UnaryServerInterceptor,
-			otelgrpc.UnaryServerInterceptor(),
-			grpc_recovery.UnaryServerInterceptor(RecoveryInterceptor()),
-		)),
+		grpc.StreamInterceptor(grpc_middleware.ChainStreamServer(streamInterceptors...)),
+		grpc.UnaryInterceptor(grpc_middleware.ChainUnaryServer(unaryInterceptors...)),
 		grpc.KeepaliveEnforcementPolicy(kaep),
 		grpc.KeepaliveParams(kasp),
 	)
 
 	//serviceName: grpc.health.v1.Health
 	grpc_health_v1.RegisterHealthServer(gSvr, health.NewServer())
-	iotexapi.RegisterAPIServiceServer(gSvr, newGRPCHandler(core))
+	handler := newGRPCHandler(core)
+	iotexapi.RegisterAPIServiceServer(gSvr, handler)
+	apipb.RegisterAPIServiceExtServer(gSvr, handler)
 	grpc_prometheus.Register(gSvr)
 	reflection.Register(gSvr)
 	return &GRPCServer{
@@ -193,10 +206,10 @@ func (svr *gRPCHandler) GetActions(ctx context.Context, in *iotexapi.GetActionsR
 		if err != nil {
 			return nil, err
 		}
-		ret, err = svr.coreService.ActionsByAddress(addr, request.Start, request.Count)
+		ret, err = svr.coreService.LegacyActionsByAddress(addr, request.Start, request.Count)
 	case in.GetUnconfirmedByAddr() != nil:
 		request := in.GetUnconfirmedByAddr()
-		ret, err = svr.coreService.UnconfirmedActionsByAddress(request.Address, request.Start, request.Count)
+		ret, err = svr.coreService.LegacyUnconfirmedActionsByAddress(request.Address, request.Start, request.Count)
 	case in.GetByBlk() != nil:
 		var (
 			request = in.GetByBlk()
@@ -504,6 +517,216 @@ func (svr *gRPCHandler) GetRawBlocks(ctx context.Context, in *iotexapi.GetRawBlo
 	return &iotexapi.GetRawBlocksResponse{Blocks: ret}, nil
 }
 
+// GetReceiptsByBlock returns the receipts of all actions in a block
+func (svr *gRPCHandler) GetReceiptsByBlock(ctx context.Context, in *apipb.GetReceiptsByBlockRequest) (*apipb.GetReceiptsByBlockResponse, error) {
+	var (
+		blk *apitypes.BlockWithReceipts
+		err error
+	)
+	switch in.GetLookup().(type) {
+	case *apipb.GetReceiptsByBlockRequest_BlockHeight:
+		blk, err = svr.coreService.BlockByHeight(in.GetBlockHeight())
+	case *apipb.GetReceiptsByBlockRequest_BlockHash:
+		blk, err = svr.coreService.BlockByHash(util.Remove0xPrefix(in.GetBlockHash()))
+	default:
+		return nil, status.Error(codes.InvalidArgument, "invalid GetReceiptsByBlockRequest type")
+	}
+	if err != nil {
+		if errors.Cause(err) == ErrNotFound {
+			return nil, status.Error(codes.NotFound, err.Error())
+		}
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+	blkHash := blk.Block.HashBlock()
+	receipts := make([]*iotextypes.Receipt, 0, len(blk.Receipts))
+	for _, r := range blk.Receipts {
+		receipts = append(receipts, r.ConvertToReceiptPb())
+	}
+	return &apipb.GetReceiptsByBlockResponse{
+		Receipts: receipts,
+		BlockIdentifier: &iotextypes.BlockIdentifier{
+			Hash:   hex.EncodeToString(blkHash[:]),
+			Height: blk.Block.Height(),
+		},
+	}, nil
+}
+
+// GetStateDiff returns the accounts, contract storage and other states changed by each action of a block
+func (svr *gRPCHandler) GetStateDiff(ctx context.Context, in *apipb.GetStateDiffRequest) (*apipb.GetStateDiffResponse, error) {
+	var (
+		height  uint64
+		actHash *hash.Hash256
+	)
+	switch in.GetLookup().(type) {
+	case *apipb.GetStateDiffRequest_BlockHeight:
+		height = in.GetBlockHeight()
+	case *apipb.GetStateDiffRequest_ActionHash:
+		h, err := hash.HexStringToHash256(util.Remove0xPrefix(in.GetActionHash()))
+		if err != nil {
+			return nil, status.Error(codes.InvalidArgument, err.Error())
+		}
+		_, _, actHeight, _, err := svr.coreService.ActionByActionHash(h)
+		if err != nil {
+			if errors.Cause(err) == ErrNotFound {
+				return nil, status.Error(codes.NotFound, err.Error())
+			}
+			return nil, status.Error(codes.Internal, err.Error())
+		}
+		height, actHash = actHeight, &h
+	default:
+		return nil, status.Error(codes.InvalidArgument, "invalid GetStateDiffRequest type")
+	}
+	diffs, err := svr.coreService.StateDiff(ctx, height)
+	if err != nil {
+		return nil, err
+	}
+	blkHash, err := svr.coreService.BlockHashByBlockHeight(height)
+	if err != nil {
+		return nil, status.Error(codes.NotFound, err.Error())
+	}
+	ret := make([]*apipb.ActionStateDiff, 0, len(diffs))
+	for _, d := range diffs {
+		if actHash != nil && d.ActionHash != *actHash {
+			continue
+		}
+		diffPb, err := toActionStateDiffPb(d)
+		if err != nil {
+			return nil, status.Error(codes.Internal, err.Error())
+		}
+		ret = append(ret, diffPb)
+	}
+	return &apipb.GetStateDiffResponse{
+		ActionStateDiffs: ret,
+		BlockIdentifier: &iotextypes.BlockIdentifier{
+			Hash:   hex.EncodeToString(blkHash[:]),
+			Height: height,
+		},
+	}, nil
+}
+
+// GetContractStorageRange pages through the storage slots of a contract in the ascending order of keys
+func (svr *gRPCHandler) GetContractStorageRange(ctx context.Context, in *apipb.GetContractStorageRangeRequest) (*apipb.GetContractStorageRangeResponse, error) {
+	addr, err := address.FromString(in.GetContractAddress())
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+	height := in.GetBlockHeight()
+	if height == 0 {
+		height = svr.coreService.TipHeight()
+	}
+	slots, next, err := svr.coreService.ContractStorageRange(ctx, addr, height, in.GetStart(), in.GetCount())
+	if err != nil {
+		return nil, err
+	}
+	blkHash, err := svr.coreService.BlockHashByBlockHeight(height)
+	if err != nil {
+		return nil, status.Error(codes.NotFound, err.Error())
+	}
+	ret := make([]*apipb.StorageSlot, 0, len(slots))
+	for _, slot := range slots {
+		ret = append(ret, &apipb.StorageSlot{
+			Key:      slot.Key[:],
+			Value:    slot.Value,
+			Preimage: slot.Preimage,
+		})
+	}
+	return &apipb.GetContractStorageRangeResponse{
+		Slots:   ret,
+		NextKey: next,
+		BlockIdentifier: &iotextypes.BlockIdentifier{
+			Hash:   hex.EncodeToString(blkHash[:]),
+			Height: height,
+		},
+	}, nil
+}
+
+// GetBalanceHistory lists the balance changes of an account recorded by the balance indexer
+func (svr *gRPCHandler) GetBalanceHistory(ctx context.Context, in *apipb.GetBalanceHistoryRequest) (*apipb.GetBalanceHistoryResponse, error) {
+	addr, err := address.FromString(in.GetAddress())
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+	changes, availableHeight, err := svr.coreService.BalanceHistory(addr, in.GetStartHeight(), in.GetCount())
+	if err != nil {
+		return nil, err
+	}
+	ret := make([]*apipb.BalanceChange, 0, len(changes))
+	for _, c := range changes {
+		ret = append(ret, &apipb.BalanceChange{
+			Height:  c.Height,
+			Balance: c.Balance.String(),
+		})
+	}
+	return &apipb.GetBalanceHistoryResponse{
+		Changes:         ret,
+		AvailableHeight: availableHeight,
+	}, nil
+}
+
+// GetBalanceAtHeight reads the balance of an account after a block from the balance indexer
+func (svr *gRPCHandler) GetBalanceAtHeight(ctx context.Context, in *apipb.GetBalanceAtHeightRequest) (*apipb.GetBalanceAtHeightResponse, error) {
+	addr, err := address.FromString(in.GetAddress())
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+	height := in.GetBlockHeight()
+	balance, err := svr.coreService.BalanceAt(addr, height)
+	if err != nil {
+		return nil, err
+	}
+	blkHash, err := svr.coreService.BlockHashByBlockHeight(height)
+	if err != nil {
+		return nil, status.Error(codes.NotFound, err.Error())
+	}
+	return &apipb.GetBalanceAtHeightResponse{
+		Balance: balance.String(),
+		BlockIdentifier: &iotextypes.BlockIdentifier{
+			Hash:   hex.EncodeToString(blkHash[:]),
+			Height: height,
+		},
+	}, nil
+}
+
+// ListActionsByAddress pages through the actions of an address with cursors and filters the actions on the server
+func (svr *gRPCHandler) ListActionsByAddress(ctx context.Context, in *apipb.ListActionsByAddressRequest) (*apipb.ListActionsByAddressResponse, error) {
+	var (
+		filter = &blockindex.ActionFilter{}
+		ret    []*iotexapi.ActionInfo
+		next   string
+		err    error
+	)
+	if f := in.GetFilter(); f != nil {
+		filter.Types = f.GetActionTypes()
+		filter.Direction = blockindex.ActionDirection(f.GetDirection())
+		filter.Status = blockindex.ActionStatus(f.GetStatus())
+		filter.StartHeight = f.GetStartHeight()
+		filter.EndHeight = f.GetEndHeight()
+	}
+	if filter.Direction > blockindex.Incoming || filter.Status > blockindex.Failed {
+		return nil, status.Error(codes.InvalidArgument, "invalid direction or status")
+	}
+	if filter.EndHeight > 0 && filter.StartHeight > filter.EndHeight {
+		return nil, status.Error(codes.InvalidArgument, "start height is greater than end height")
+	}
+	if in.GetUnconfirmed() {
+		ret, next, err = svr.coreService.UnconfirmedActionsByAddress(in.GetAddress(), filter, in.GetCursor(), in.GetDescending(), uint64(in.GetCount()))
+	} else {
+		var addr address.Address
+		addr, err = address.FromString(in.GetAddress())
+		if err != nil {
+			return nil, status.Error(codes.InvalidArgument, err.Error())
+		}
+		ret, next, err = svr.coreService.ActionsByAddress(addr, filter, in.GetCursor(), in.GetDescending(), uint64(in.GetCount()))
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &apipb.ListActionsByAddressResponse{
+		ActionInfo: ret,
+		NextCursor: next,
+	}, nil
+}
+
 // GetLogs get logs filtered by contract address and topics
 func (svr *gRPCHandler) GetLogs(ctx context.Context, in *iotexapi.GetLogsRequest) (*iotexapi.GetLogsResponse, error) {
 	if in.GetFilter() == nil {
@@ -537,6 +760,76 @@ func (svr *gRPCHandler) GetLogs(ctx context.Context, in *iotexapi.GetLogsRequest
 	return &iotexapi.GetLogsResponse{Logs: ret}, nil
 }
 
+// toActionStateDiffPb groups the changes of accounts and their storage by address, the other states are left raw
+func toActionStateDiffPb(d *factory.ActionStateDiff) (*apipb.ActionStateDiff, error) {
+	diffPb := &apipb.ActionStateDiff{}
+	if d.ActionHash != hash.ZeroHash256 {
+		diffPb.ActionHash = hex.EncodeToString(d.ActionHash[:])
+	}
+	accounts := make(map[hash.Hash160]*apipb.AccountDiff)
+	accountDiff := func(addrHash hash.Hash160) (*apipb.AccountDiff, error) {
+		if acct, ok := accounts[addrHash]; ok {
+			return acct, nil
+		}
+		addr, err := address.FromBytes(addrHash[:])
+		if err != nil {
+			return nil, err
+		}
+		acct := &apipb.AccountDiff{Address: addr.String()}
+		accounts[addrHash] = acct
+		diffPb.Accounts = append(diffPb.Accounts, acct)
+		return acct, nil
+	}
+	for _, s := range d.States {
+		if s.Namespace != factory.AccountKVNamespace || len(s.Key) != len(hash.Hash160{}) {
+			diffPb.States = append(diffPb.States, &apipb.StateDiff{
+				Namespace: s.Namespace,
+				Key:       s.Key,
+				Before:    s.Before,
+				After:     s.After,
+			})
+			continue
+		}
+		acct, err := accountDiff(hash.BytesToHash160(s.Key))
+		if err != nil {
+			return nil, err
+		}
+		if acct.Before, err = toAccountStatePb(s.Before); err != nil {
+			return nil, err
+		}
+		if acct.After, err = toAccountStatePb(s.After); err != nil {
+			return nil, err
+		}
+	}
+	for _, s := range d.Storage {
+		acct, err := accountDiff(s.Contract)
+		if err != nil {
+			return nil, err
+		}
+		acct.Storage = append(acct.Storage, &apipb.StorageDiff{
+			Key:    s.Key[:],
+			Before: s.Before,
+			After:  s.After,
+		})
+	}
+	return diffPb, nil
+}
+
+func toAccountStatePb(data []byte) (*apipb.AccountState, error) {
+	if data == nil {
+		return nil, nil
+	}
+	acct := &state.Account{}
+	if err := acct.Deserialize(data); err != nil {
+		return nil, errors.Wrap(err, "failed to deserialize account")
+	}
+	return &apipb.AccountState{
+		Balance:  acct.Balance.String(),
+		Nonce:    acct.PendingNonce(),
+		CodeHash: acct.CodeHash,
+	}, nil
+}
+
 func toLogPb(lg *action.Log, blkHash hash.Hash256) *iotextypes.Log {
 	logPb := lg.ConvertToLogPb()
 	logPb.BlkHash = blkHash[:]
@@ -33,6 +33,7 @@ import (
 	"github.com/iotexproject/iotex-core/action"
 	"github.com/iotexproject/iotex-core/action/protocol"
 	"github.com/iotexproject/iotex-core/action/protocol/poll"
+	"github.com/iotexproject/iotex-core/api/apipb"
 	"github.com/iotexproject/iotex-core/blockchain/block"
 	"github.com/iotexproject/iotex-core/blockchain/genesis"
 	"github.com/iotexproject/iotex-core/config"
@@ -1046,9 +1047,17 @@ func TestGrpcServer_GetActionsByAddressIntegrity(t *testing.T) {
 			require.True(prevRes.ActionInfo[len(prevRes.ActionInfo)-1].Timestamp.GetSeconds() <= res.ActionInfo[0].Timestamp.GetSeconds())
 		}
 	}
+
+	// a start past the end is rejected
+	_, err = grpcHandler.GetActions(context.Background(), &iotexapi.GetActionsRequest{
+		Lookup: &iotexapi.GetActionsRequest_ByAddr{
+			ByAddr: &iotexapi.GetActionsByAddressRequest{Address: identityset.Address(30).String(), Start: 9, Count: 1},
+		},
+	})
+	require.Equal(codes.NotFound, status.Code(err))
 }
 
-func TestGrpcServer_GetUnconfirmedActionsByAddressIntegrity(t *testing.T) {
+func TestGrpcServer_ListActionsByAddressIntegrity(t *testing.T) {
 	require := require.New(t)
 	cfg := newConfig()
 
@@ -1059,6 +1068,78 @@ func TestGrpcServer_GetUnconfirmedActionsByAddressIntegrity(t *testing.T) {
 		testutil.CleanupPath(bfIndexFile)
 	}()
 
+	list := func(req *apipb.ListActionsByAddressRequest) []string {
+		var hashes []string
+		for {
+			res, err := grpcHandler.ListActionsByAddress(context.Background(), req)
+			require.NoError(err)
+			for _, act := range res.ActionInfo {
+				hashes = append(hashes, act.ActHash)
+			}
+			if res.NextCursor == "" {
+				return hashes
+			}
+			req.Cursor = res.NextCursor
+		}
+	}
+	addr := identityset.Address(30).String()
+	all := list(&apipb.ListActionsByAddressRequest{Address: addr, Count: 100})
+	require.Len(all, 9)
+	// the pages of cursors have the same actions as a single page
+	require.Equal(all, list(&apipb.ListActionsByAddressRequest{Address: addr, Count: 2}))
+	desc := list(&apipb.ListActionsByAddressRequest{Address: addr, Count: 4, Descending: true})
+	for i := range desc {
+		require.Equal(all[len(all)-1-i], desc[i])
+	}
+	// the outgoing and incoming actions make up all actions, except the transfers to itself in both
+	out := list(&apipb.ListActionsByAddressRequest{
+		Address: addr,
+		Count:   3,
+		Filter:  &apipb.ActionFilter{Direction: apipb.ActionDirection_OUTGOING},
+	})
+	in := list(&apipb.ListActionsByAddressRequest{
+		Address: addr,
+		Count:   3,
+		Filter:  &apipb.ActionFilter{Direction: apipb.ActionDirection_INCOMING},
+	})
+	both := append(append([]string{}, out...), in...)
+	require.GreaterOrEqual(len(both), len(all))
+	require.Subset(both, all)
+
+	// the cursor must be in the order requested
+	res, err := grpcHandler.ListActionsByAddress(context.Background(), &apipb.ListActionsByAddressRequest{Address: addr, Count: 2})
+	require.NoError(err)
+	_, err = grpcHandler.ListActionsByAddress(context.Background(), &apipb.ListActionsByAddressRequest{
+		Address:    addr,
+		Count:      2,
+		Cursor:     res.NextCursor,
+		Descending: true,
+	})
+	require.Equal(codes.InvalidArgument, status.Code(err))
+
+	// the actions in actpool
+	pending := list(&apipb.ListActionsByAddressRequest{Address: identityset.Address(27).String(), Count: 3, Unconfirmed: true})
+	require.Len(pending, 4)
+	_, err = grpcHandler.ListActionsByAddress(context.Background(), &apipb.ListActionsByAddressRequest{
+		Address:     identityset.Address(27).String(),
+		Count:       3,
+		Unconfirmed: true,
+		Filter:      &apipb.ActionFilter{Status: apipb.ActionStatus_SUCCEEDED},
+	})
+	require.Equal(codes.InvalidArgument, status.Code(err))
+}
+
+func TestGrpcServer_GetUnconfirmedActionsByAddressIntegrity(t *testing.T) {
+	require := require.New(t)
+	cfg := newConfig()
+
+	svr, _, _, _, _, ap, bfIndexFile, err := createServerV2(cfg, true)
+	require.NoError(err)
+	grpcHandler := newGRPCHandler(svr.core)
+	defer func() {
+		testutil.CleanupPath(bfIndexFile)
+	}()
+
 	for _, test := range _getUnconfirmedActionsByAddressTests {
 		request := &iotexapi.GetActionsRequest{
 			Lookup: &iotexapi.GetActionsRequest_UnconfirmedByAddr{
@@ -1078,6 +1159,30 @@ func TestGrpcServer_GetUnconfirmedActionsByAddressIntegrity(t *testing.T) {
 		require.Equal(test.numActions, len(res.ActionInfo))
 		require.Equal(test.address, res.ActionInfo[0].Sender)
 	}
+
+	// the actions are listed in the order of actpool, from the offset
+	addr := identityset.Address(27).String()
+	selps := ap.GetUnconfirmedActs(addr)
+	res, err := grpcHandler.GetActions(context.Background(), &iotexapi.GetActionsRequest{
+		Lookup: &iotexapi.GetActionsRequest_UnconfirmedByAddr{
+			UnconfirmedByAddr: &iotexapi.GetUnconfirmedActionsByAddressRequest{Address: addr, Start: 1, Count: 10},
+		},
+	})
+	require.NoError(err)
+	require.Len(res.ActionInfo, len(selps)-1)
+	for i, act := range res.ActionInfo {
+		h, err := selps[i+1].Hash()
+		require.NoError(err)
+		require.Equal(hex.EncodeToString(h[:]), act.ActHash)
+	}
+	// a start past the end is rejected
+	_, err = grpcHandler.GetActions(context.Background(), &iotexapi.GetActionsRequest{
+		Lookup: &iotexapi.GetActionsRequest_UnconfirmedByAddr{
+			UnconfirmedByAddr: &iotexapi.GetUnconfirmedActionsByAddressRequest{Address: addr, Start: uint64(len(selps)), Count: 1},
+		},
+	})
+	require.Equal(codes.NotFound, status.Code(err))
+	require.Contains(err.Error(), "start exceeds the limit")
 }
 
 func TestGrpcServer_GetActionsByBlockIntegrity(t *testing.T) {
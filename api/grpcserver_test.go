@@ -18,11 +18,18 @@ import (
 	"github.com/iotexproject/iotex-proto/golang/iotextypes"
 	"github.com/pkg/errors"
 	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 
 	"github.com/iotexproject/iotex-core/action"
+	"github.com/iotexproject/iotex-core/action/protocol/execution/evm"
+	"github.com/iotexproject/iotex-core/api/apipb"
 	apitypes "github.com/iotexproject/iotex-core/api/types"
 	"github.com/iotexproject/iotex-core/blockchain/block"
+	"github.com/iotexproject/iotex-core/blockindex"
 	"github.com/iotexproject/iotex-core/pkg/version"
+	"github.com/iotexproject/iotex-core/state"
+	"github.com/iotexproject/iotex-core/state/factory"
 	"github.com/iotexproject/iotex-core/test/identityset"
 	"github.com/iotexproject/iotex-core/test/mock/mock_apicoreservice"
 	mock_apitypes "github.com/iotexproject/iotex-core/test/mock/mock_apiresponder"
@@ -109,7 +116,7 @@ func TestGrpcServer_GetActions(t *testing.T) {
 						},
 					},
 					call: func() {
-						core.EXPECT().ActionsByAddress(gomock.Any(), gomock.Any(), gomock.Any()).Return(response, nil)
+						core.EXPECT().LegacyActionsByAddress(gomock.Any(), gomock.Any(), gomock.Any()).Return(response, nil)
 					},
 				},
 				{
@@ -123,7 +130,7 @@ func TestGrpcServer_GetActions(t *testing.T) {
 						},
 					},
 					call: func() {
-						core.EXPECT().UnconfirmedActionsByAddress(gomock.Any(), gomock.Any(), gomock.Any()).Return(response, nil)
+						core.EXPECT().LegacyUnconfirmedActionsByAddress(gomock.Any(), gomock.Any(), gomock.Any()).Return(response, nil)
 					},
 				},
 				{
@@ -581,6 +588,289 @@ func TestGrpcServer_GetRawBlocks(t *testing.T) {
 
 }
 
+func TestGrpcServer_GetReceiptsByBlock(t *testing.T) {
+	require := require.New(t)
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	core := mock_apicoreservice.NewMockCoreService(ctrl)
+	grpcSvr := newGRPCHandler(core)
+	blk, err := block.NewTestingBuilder().
+		SetHeight(5).
+		SignAndBuild(identityset.PrivateKey(1))
+	require.NoError(err)
+	blkHash := blk.HashBlock()
+	receipts := []*action.Receipt{
+		{Status: 1, BlockHeight: 5, ActionHash: hash.BytesToHash256([]byte("a")), GasConsumed: 10},
+		{Status: 0, BlockHeight: 5, ActionHash: hash.BytesToHash256([]byte("b")), GasConsumed: 20, TxIndex: 1},
+	}
+
+	core.EXPECT().BlockByHeight(uint64(5)).Return(&apitypes.BlockWithReceipts{Block: &blk, Receipts: receipts}, nil)
+	res, err := grpcSvr.GetReceiptsByBlock(context.Background(), &apipb.GetReceiptsByBlockRequest{
+		Lookup: &apipb.GetReceiptsByBlockRequest_BlockHeight{BlockHeight: 5},
+	})
+	require.NoError(err)
+	require.Len(res.Receipts, 2)
+	require.Equal(receipts[1].ActionHash[:], res.Receipts[1].ActHash)
+	require.Equal(uint64(20), res.Receipts[1].GasConsumed)
+	require.Equal(uint64(5), res.BlockIdentifier.Height)
+	require.Equal(hex.EncodeToString(blkHash[:]), res.BlockIdentifier.Hash)
+
+	core.EXPECT().BlockByHash(hex.EncodeToString(blkHash[:])).Return(nil, ErrNotFound)
+	_, err = grpcSvr.GetReceiptsByBlock(context.Background(), &apipb.GetReceiptsByBlockRequest{
+		Lookup: &apipb.GetReceiptsByBlockRequest_BlockHash{BlockHash: "0x" + hex.EncodeToString(blkHash[:])},
+	})
+	require.Equal(codes.NotFound, status.Code(err))
+
+	_, err = grpcSvr.GetReceiptsByBlock(context.Background(), &apipb.GetReceiptsByBlockRequest{})
+	require.Equal(codes.InvalidArgument, status.Code(err))
+}
+
+func TestGrpcServer_GetStateDiff(t *testing.T) {
+	require := require.New(t)
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	core := mock_apicoreservice.NewMockCoreService(ctrl)
+	grpcSvr := newGRPCHandler(core)
+
+	acct, err := state.NewAccount()
+	require.NoError(err)
+	require.NoError(acct.AddBalance(big.NewInt(100)))
+	before, err := acct.Serialize()
+	require.NoError(err)
+	require.NoError(acct.SetPendingNonce(1))
+	require.NoError(acct.AddBalance(big.NewInt(20)))
+	after, err := acct.Serialize()
+	require.NoError(err)
+	addr := identityset.Address(1)
+	actHash := hash.BytesToHash256([]byte("a"))
+	blkHash := hash.BytesToHash256([]byte("blk"))
+	diffs := []*factory.ActionStateDiff{
+		{
+			ActionHash: actHash,
+			States: []*factory.StateChange{
+				{Namespace: factory.AccountKVNamespace, Key: addr.Bytes(), Before: before, After: after},
+				{Namespace: "Rewarding", Key: []byte("fund"), Before: []byte{1}, After: []byte{2}},
+			},
+			Storage: []*factory.StorageChange{
+				{Contract: hash.BytesToHash160(addr.Bytes()), Key: hash.BytesToHash256([]byte{1}), After: []byte{3}},
+			},
+		},
+		{
+			States: []*factory.StateChange{
+				{Namespace: "Rewarding", Key: []byte("fund"), Before: []byte{2}, After: []byte{3}},
+			},
+		},
+	}
+
+	core.EXPECT().StateDiff(gomock.Any(), uint64(5)).Return(diffs, nil).Times(2)
+	core.EXPECT().BlockHashByBlockHeight(uint64(5)).Return(blkHash, nil).Times(2)
+	res, err := grpcSvr.GetStateDiff(context.Background(), &apipb.GetStateDiffRequest{
+		Lookup: &apipb.GetStateDiffRequest_BlockHeight{BlockHeight: 5},
+	})
+	require.NoError(err)
+	require.Equal(hex.EncodeToString(blkHash[:]), res.BlockIdentifier.Hash)
+	require.Len(res.ActionStateDiffs, 2)
+	diff := res.ActionStateDiffs[0]
+	require.Equal(hex.EncodeToString(actHash[:]), diff.ActionHash)
+	require.Len(diff.Accounts, 1)
+	require.Equal(addr.String(), diff.Accounts[0].Address)
+	require.Equal("100", diff.Accounts[0].Before.Balance)
+	require.Equal("120", diff.Accounts[0].After.Balance)
+	require.Equal(uint64(1), diff.Accounts[0].After.Nonce)
+	require.Len(diff.Accounts[0].Storage, 1)
+	require.Nil(diff.Accounts[0].Storage[0].Before)
+	require.Len(diff.States, 1)
+	require.Equal("Rewarding", diff.States[0].Namespace)
+	require.Empty(res.ActionStateDiffs[1].ActionHash)
+
+	// lookup by action hash returns the diff of the action only
+	core.EXPECT().ActionByActionHash(actHash).Return(action.SealedEnvelope{}, blkHash, uint64(5), uint32(0), nil)
+	res, err = grpcSvr.GetStateDiff(context.Background(), &apipb.GetStateDiffRequest{
+		Lookup: &apipb.GetStateDiffRequest_ActionHash{ActionHash: hex.EncodeToString(actHash[:])},
+	})
+	require.NoError(err)
+	require.Len(res.ActionStateDiffs, 1)
+	require.Equal(hex.EncodeToString(actHash[:]), res.ActionStateDiffs[0].ActionHash)
+
+	core.EXPECT().StateDiff(gomock.Any(), uint64(6)).Return(nil, status.Error(codes.FailedPrecondition, "no archive data"))
+	_, err = grpcSvr.GetStateDiff(context.Background(), &apipb.GetStateDiffRequest{
+		Lookup: &apipb.GetStateDiffRequest_BlockHeight{BlockHeight: 6},
+	})
+	require.Equal(codes.FailedPrecondition, status.Code(err))
+
+	_, err = grpcSvr.GetStateDiff(context.Background(), &apipb.GetStateDiffRequest{})
+	require.Equal(codes.InvalidArgument, status.Code(err))
+}
+
+func TestGrpcServer_GetContractStorageRange(t *testing.T) {
+	require := require.New(t)
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	core := mock_apicoreservice.NewMockCoreService(ctrl)
+	grpcSvr := newGRPCHandler(core)
+
+	addr := identityset.Address(1)
+	blkHash := hash.BytesToHash256([]byte("blk"))
+	slots := []*evm.ContractStorageSlot{
+		{Key: hash.BytesToHash256([]byte{1}), Value: []byte{1}, Preimage: []byte("preimage")},
+		{Key: hash.BytesToHash256([]byte{2}), Value: []byte{2}},
+	}
+	next := hash.BytesToHash256([]byte{3})
+
+	// the storage at the tip is read if the height is absent
+	core.EXPECT().TipHeight().Return(uint64(10))
+	core.EXPECT().ContractStorageRange(gomock.Any(), addr, uint64(10), []byte{1}, uint32(2)).Return(slots, next[:], nil)
+	core.EXPECT().BlockHashByBlockHeight(uint64(10)).Return(blkHash, nil)
+	res, err := grpcSvr.GetContractStorageRange(context.Background(), &apipb.GetContractStorageRangeRequest{
+		ContractAddress: addr.String(),
+		Start:           []byte{1},
+		Count:           2,
+	})
+	require.NoError(err)
+	require.Equal(uint64(10), res.BlockIdentifier.Height)
+	require.Equal(hex.EncodeToString(blkHash[:]), res.BlockIdentifier.Hash)
+	require.Len(res.Slots, 2)
+	require.Equal(slots[0].Key[:], res.Slots[0].Key)
+	require.Equal([]byte{1}, res.Slots[0].Value)
+	require.Equal([]byte("preimage"), res.Slots[0].Preimage)
+	require.Nil(res.Slots[1].Preimage)
+	require.Equal(next[:], res.NextKey)
+
+	core.EXPECT().ContractStorageRange(gomock.Any(), addr, uint64(5), nil, uint32(2)).Return(nil, nil, status.Error(codes.FailedPrecondition, "no archive data"))
+	_, err = grpcSvr.GetContractStorageRange(context.Background(), &apipb.GetContractStorageRangeRequest{
+		ContractAddress: addr.String(),
+		BlockHeight:     5,
+		Count:           2,
+	})
+	require.Equal(codes.FailedPrecondition, status.Code(err))
+
+	_, err = grpcSvr.GetContractStorageRange(context.Background(), &apipb.GetContractStorageRangeRequest{
+		ContractAddress: "invalid",
+	})
+	require.Equal(codes.InvalidArgument, status.Code(err))
+}
+
+func TestGrpcServer_GetBalanceHistory(t *testing.T) {
+	require := require.New(t)
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	core := mock_apicoreservice.NewMockCoreService(ctrl)
+	grpcSvr := newGRPCHandler(core)
+
+	addr := identityset.Address(1)
+	changes := []*blockindex.BalanceChange{
+		{Height: 3, Balance: big.NewInt(100)},
+		{Height: 7, Balance: big.NewInt(0)},
+	}
+	core.EXPECT().BalanceHistory(addr, uint64(2), uint32(10)).Return(changes, uint64(1), nil)
+	res, err := grpcSvr.GetBalanceHistory(context.Background(), &apipb.GetBalanceHistoryRequest{
+		Address:     addr.String(),
+		StartHeight: 2,
+		Count:       10,
+	})
+	require.NoError(err)
+	require.Equal(uint64(1), res.AvailableHeight)
+	require.Len(res.Changes, 2)
+	require.Equal(uint64(3), res.Changes[0].Height)
+	require.Equal("100", res.Changes[0].Balance)
+	require.Equal(uint64(7), res.Changes[1].Height)
+	require.Equal("0", res.Changes[1].Balance)
+
+	core.EXPECT().BalanceHistory(addr, uint64(0), uint32(10)).Return(nil, uint64(0), status.Error(codes.FailedPrecondition, "balance indexer is not enabled"))
+	_, err = grpcSvr.GetBalanceHistory(context.Background(), &apipb.GetBalanceHistoryRequest{
+		Address: addr.String(),
+		Count:   10,
+	})
+	require.Equal(codes.FailedPrecondition, status.Code(err))
+
+	_, err = grpcSvr.GetBalanceHistory(context.Background(), &apipb.GetBalanceHistoryRequest{
+		Address: "invalid",
+	})
+	require.Equal(codes.InvalidArgument, status.Code(err))
+}
+
+func TestGrpcServer_GetBalanceAtHeight(t *testing.T) {
+	require := require.New(t)
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	core := mock_apicoreservice.NewMockCoreService(ctrl)
+	grpcSvr := newGRPCHandler(core)
+
+	addr := identityset.Address(1)
+	blkHash := hash.BytesToHash256([]byte("blk"))
+	core.EXPECT().BalanceAt(addr, uint64(5)).Return(big.NewInt(12345), nil)
+	core.EXPECT().BlockHashByBlockHeight(uint64(5)).Return(blkHash, nil)
+	res, err := grpcSvr.GetBalanceAtHeight(context.Background(), &apipb.GetBalanceAtHeightRequest{
+		Address:     addr.String(),
+		BlockHeight: 5,
+	})
+	require.NoError(err)
+	require.Equal("12345", res.Balance)
+	require.Equal(uint64(5), res.BlockIdentifier.Height)
+	require.Equal(hex.EncodeToString(blkHash[:]), res.BlockIdentifier.Hash)
+
+	core.EXPECT().BalanceAt(addr, uint64(1)).Return(nil, status.Error(codes.FailedPrecondition, "balance history not available"))
+	_, err = grpcSvr.GetBalanceAtHeight(context.Background(), &apipb.GetBalanceAtHeightRequest{
+		Address:     addr.String(),
+		BlockHeight: 1,
+	})
+	require.Equal(codes.FailedPrecondition, status.Code(err))
+}
+
+func TestGrpcServer_ListActionsByAddress(t *testing.T) {
+	require := require.New(t)
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	core := mock_apicoreservice.NewMockCoreService(ctrl)
+	grpcSvr := newGRPCHandler(core)
+
+	addr := identityset.Address(1)
+	acts := []*iotexapi.ActionInfo{{ActHash: "a"}, {ActHash: "b"}}
+	filter := &blockindex.ActionFilter{
+		Types:       []string{"transfer"},
+		Direction:   blockindex.Incoming,
+		Status:      blockindex.Failed,
+		StartHeight: 3,
+		EndHeight:   9,
+	}
+	core.EXPECT().ActionsByAddress(addr, filter, "cursor", true, uint64(2)).Return(acts, "next", nil)
+	res, err := grpcSvr.ListActionsByAddress(context.Background(), &apipb.ListActionsByAddressRequest{
+		Address: addr.String(),
+		Filter: &apipb.ActionFilter{
+			ActionTypes: []string{"transfer"},
+			Direction:   apipb.ActionDirection_INCOMING,
+			Status:      apipb.ActionStatus_FAILED,
+			StartHeight: 3,
+			EndHeight:   9,
+		},
+		Cursor:     "cursor",
+		Count:      2,
+		Descending: true,
+	})
+	require.NoError(err)
+	require.Equal(acts, res.ActionInfo)
+	require.Equal("next", res.NextCursor)
+
+	core.EXPECT().UnconfirmedActionsByAddress(addr.String(), &blockindex.ActionFilter{}, "", false, uint64(5)).Return(acts[:1], "", nil)
+	res, err = grpcSvr.ListActionsByAddress(context.Background(), &apipb.ListActionsByAddressRequest{
+		Address:     addr.String(),
+		Count:       5,
+		Unconfirmed: true,
+	})
+	require.NoError(err)
+	require.Equal(acts[:1], res.ActionInfo)
+	require.Empty(res.NextCursor)
+
+	for _, req := range []*apipb.ListActionsByAddressRequest{
+		{Address: "invalid", Count: 5},
+		{Address: addr.String(), Count: 5, Filter: &apipb.ActionFilter{StartHeight: 5, EndHeight: 4}},
+		{Address: addr.String(), Count: 5, Filter: &apipb.ActionFilter{Direction: 3}},
+	} {
+		_, err = grpcSvr.ListActionsByAddress(context.Background(), req)
+		require.Equal(codes.InvalidArgument, status.Code(err))
+	}
+}
+
 func TestGrpcServer_GetLogs(t *testing.T) {
 
 }
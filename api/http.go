@@ -68,10 +68,9 @@ func (handler *hTTPHandler) ServeHTTP(w http.ResponseWriter, req *http.Request)
 		return
 	}
 
-	if err := handler.msgHandler.HandlePOSTReq(req.Body,
+	if err := handler.msgHandler.HandlePOSTReq(req.Context(), req.Body,
 		apitypes.NewResponseWriter(
 			func(resp interface{}) error {
-				w.Header().Set("Access-Control-Allow-Origin", "*")
 				w.Header().Set("Content-Type", "application/json; charset=UTF-8")
 				return json.NewEncoder(w).Encode(resp)
 			}),
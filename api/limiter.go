@@ -0,0 +1,312 @@
+// Copyright (c) 2022 IoTeX Foundation
+// This is an alpha (internal) release and is not suitable for production. This source code is provided 'as is' and no
+// warranties are given as to title or non-infringement, merchantability or fitness for purpose and, to the extent
+// permitted by law, all liability for your use of the code is disclaimed. This source code is governed by Apache
+// License 2.0 that can be found in the LICENSE file.
+
+package api
+
+import (
+	"context"
+	"net"
+	"net/http"
+	"path"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"golang.org/x/time/rate"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+
+	"github.com/iotexproject/iotex-core/config"
+)
+
+const (
+	// _apiKeyHeader is the header (or the gRPC metadata in lower case) carrying the API key
+	_apiKeyHeader = "X-API-Key"
+	// _apiKeyQuery is the query parameter carrying the API key, which is used by the clients unable to set headers,
+	// e.g., websocket in browsers
+	_apiKeyQuery = "apikey"
+	// _anonymous is the name of the callers without API key in metrics
+	_anonymous = "anonymous"
+	// _ipQuotaTTL is the time an idle IP quota is kept
+	_ipQuotaTTL = 10 * time.Minute
+)
+
+var (
+	_apiQuotaMtc = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: "iotex_api_quota_metrics",
+		Help: "api requests per key.",
+	}, []string{"key", "result"})
+
+	errInvalidAPIKey = status.Error(codes.Unauthenticated, "invalid or missing API key")
+	errRateLimited   = status.Error(codes.ResourceExhausted, "rate limit exceeded")
+)
+
+type (
+	// APILimiter authenticates the API keys, limits the rate of the requests per key and per IP, and checks the
+	// origins of the requests. It works as the middleware of gRPC, web3 and websocket servers.
+	APILimiter struct {
+		cfg       config.APILimit
+		keys      map[string]*apiQuota
+		anyOrigin bool
+		origins   map[string]bool
+		mutex     sync.Mutex
+		ips       map[string]*ipQuota
+		lastSweep time.Time
+		ipRate    rate.Limit
+		ipBurst   int
+	}
+
+	// apiQuota is the token bucket of a caller, a nil bucket means unlimited
+	apiQuota struct {
+		name   string
+		bucket *rate.Limiter
+		costs  map[string]int
+	}
+
+	ipQuota struct {
+		*apiQuota
+		lastSeen time.Time
+	}
+
+	apiQuotaCtxKey struct{}
+)
+
+func init() {
+	prometheus.MustRegister(_apiQuotaMtc)
+}
+
+// NewAPILimiter creates an API limiter
+func NewAPILimiter(cfg config.APILimit) *APILimiter {
+	l := &APILimiter{
+		cfg:     cfg,
+		keys:    make(map[string]*apiQuota, len(cfg.APIKeys)),
+		origins: make(map[string]bool, len(cfg.AllowedOrigins)),
+		ips:     map[string]*ipQuota{},
+	}
+	maxCost := 1
+	for _, cost := range cfg.MethodCosts {
+		if cost > maxCost {
+			maxCost = cost
+		}
+	}
+	for _, key := range cfg.APIKeys {
+		l.keys[key.Key] = &apiQuota{
+			name:   key.Name,
+			bucket: newTokenBucket(key.Rate, key.Burst, maxCost),
+			costs:  cfg.MethodCosts,
+		}
+	}
+	if cfg.IPRate > 0 {
+		l.ipRate, l.ipBurst = rate.Limit(cfg.IPRate), cfg.IPBurst
+		if l.ipBurst < maxCost {
+			l.ipBurst = maxCost
+		}
+	}
+	for _, origin := range cfg.AllowedOrigins {
+		if origin == "*" {
+			l.anyOrigin = true
+		}
+		l.origins[strings.ToLower(origin)] = true
+	}
+	return l
+}
+
+// newTokenBucket creates a token bucket large enough for the most expensive method
+func newTokenBucket(r float64, burst int, maxCost int) *rate.Limiter {
+	if r <= 0 {
+		return nil
+	}
+	if burst < maxCost {
+		burst = maxCost
+	}
+	return rate.NewLimiter(rate.Limit(r), burst)
+}
+
+// quota returns the quota of the caller identified by the API key, or by the IP if the key is empty
+func (l *APILimiter) quota(key, ip string) (*apiQuota, error) {
+	if key != "" {
+		q, ok := l.keys[key]
+		if !ok {
+			_apiQuotaMtc.WithLabelValues(_anonymous, "unauthorized").Inc()
+			return nil, errInvalidAPIKey
+		}
+		return q, nil
+	}
+	if l.cfg.RequireAPIKey {
+		_apiQuotaMtc.WithLabelValues(_anonymous, "unauthorized").Inc()
+		return nil, errInvalidAPIKey
+	}
+	if l.ipRate == 0 {
+		return &apiQuota{name: _anonymous}, nil
+	}
+	now := time.Now()
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+	if now.Sub(l.lastSweep) > _ipQuotaTTL {
+		for k, q := range l.ips {
+			if now.Sub(q.lastSeen) > _ipQuotaTTL {
+				delete(l.ips, k)
+			}
+		}
+		l.lastSweep = now
+	}
+	q, ok := l.ips[ip]
+	if !ok {
+		q = &ipQuota{
+			apiQuota: &apiQuota{
+				name:   _anonymous,
+				bucket: rate.NewLimiter(l.ipRate, l.ipBurst),
+				costs:  l.cfg.MethodCosts,
+			},
+		}
+		l.ips[ip] = q
+	}
+	q.lastSeen = now
+	return q.apiQuota, nil
+}
+
+// allowOrigin returns true if the origin is allowed
+func (l *APILimiter) allowOrigin(origin string) bool {
+	return origin == "" || l.anyOrigin || l.origins[strings.ToLower(origin)]
+}
+
+// consume consumes the tokens of the method, and returns errRateLimited if the quota is used up
+func (q *apiQuota) consume(method string) error {
+	if q.bucket != nil {
+		cost, ok := q.costs[method]
+		if !ok {
+			cost = 1
+		}
+		if !q.bucket.AllowN(time.Now(), cost) {
+			_apiQuotaMtc.WithLabelValues(q.name, "limited").Inc()
+			return errRateLimited
+		}
+	}
+	_apiQuotaMtc.WithLabelValues(q.name, "allowed").Inc()
+	return nil
+}
+
+func withAPIQuota(ctx context.Context, q *apiQuota) context.Context {
+	return context.WithValue(ctx, apiQuotaCtxKey{}, q)
+}
+
+// consumeAPIQuota consumes the quota of the caller in the context, which is unlimited if there isn't any
+func consumeAPIQuota(ctx context.Context, method string) error {
+	q, ok := ctx.Value(apiQuotaCtxKey{}).(*apiQuota)
+	if !ok {
+		return nil
+	}
+	return q.consume(method)
+}
+
+// UnaryServerInterceptor returns the gRPC interceptor of unary requests
+func (l *APILimiter) UnaryServerInterceptor() grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		if err := l.checkGRPC(ctx, info.FullMethod); err != nil {
+			return nil, err
+		}
+		return handler(ctx, req)
+	}
+}
+
+// StreamServerInterceptor returns the gRPC interceptor of streams, which consumes the quota once per stream
+func (l *APILimiter) StreamServerInterceptor() grpc.StreamServerInterceptor {
+	return func(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		if err := l.checkGRPC(ss.Context(), info.FullMethod); err != nil {
+			return err
+		}
+		return handler(srv, ss)
+	}
+}
+
+func (l *APILimiter) checkGRPC(ctx context.Context, fullMethod string) error {
+	var key, ip string
+	if md, ok := metadata.FromIncomingContext(ctx); ok {
+		if values := md.Get(strings.ToLower(_apiKeyHeader)); len(values) > 0 {
+			key = values[0]
+		}
+		if l.cfg.TrustedProxies > 0 {
+			if values := md.Get("x-forwarded-for"); len(values) > 0 {
+				ip = forwardedIP(strings.Join(values, ","), l.cfg.TrustedProxies)
+			}
+		}
+	}
+	if p, ok := peer.FromContext(ctx); ok && ip == "" {
+		ip = hostOf(p.Addr.String())
+	}
+	q, err := l.quota(key, ip)
+	if err != nil {
+		return err
+	}
+	return q.consume(path.Base(fullMethod))
+}
+
+// HTTPMiddleware wraps a web3 or websocket handler. It checks the origin and the API key, answers CORS preflight
+// requests, and passes the quota of the caller in the request context, which is consumed per web3 method.
+func (l *APILimiter) HTTPMiddleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		origin := req.Header.Get("Origin")
+		if !l.allowOrigin(origin) {
+			http.Error(w, "origin not allowed", http.StatusForbidden)
+			return
+		}
+		if origin != "" {
+			if l.anyOrigin {
+				w.Header().Set("Access-Control-Allow-Origin", "*")
+			} else {
+				w.Header().Set("Access-Control-Allow-Origin", origin)
+				w.Header().Add("Vary", "Origin")
+			}
+		}
+		if req.Method == http.MethodOptions {
+			w.Header().Set("Access-Control-Allow-Methods", "GET, POST, OPTIONS")
+			w.Header().Set("Access-Control-Allow-Headers", "Content-Type, "+_apiKeyHeader)
+			w.WriteHeader(http.StatusNoContent)
+			return
+		}
+		key := req.Header.Get(_apiKeyHeader)
+		if key == "" {
+			key = req.URL.Query().Get(_apiKeyQuery)
+		}
+		ip := hostOf(req.RemoteAddr)
+		if l.cfg.TrustedProxies > 0 {
+			if forwarded := req.Header.Values("X-Forwarded-For"); len(forwarded) > 0 {
+				ip = forwardedIP(strings.Join(forwarded, ","), l.cfg.TrustedProxies)
+			}
+		}
+		q, err := l.quota(key, ip)
+		if err != nil {
+			http.Error(w, status.Convert(err).Message(), http.StatusUnauthorized)
+			return
+		}
+		next.ServeHTTP(w, req.WithContext(withAPIQuota(req.Context(), q)))
+	})
+}
+
+// forwardedIP returns the client IP in X-Forwarded-For header, which is the entry appended by the outermost of the
+// trusted proxies, i.e., the proxies-th entry from the right. The entries on the left of it are sent by the client,
+// which could be forged
+func forwardedIP(forwarded string, proxies int) string {
+	entries := strings.Split(forwarded, ",")
+	i := len(entries) - proxies
+	if i < 0 {
+		// passed through fewer proxies
+		i = 0
+	}
+	return strings.TrimSpace(entries[i])
+}
+
+func hostOf(addr string) string {
+	host, _, err := net.SplitHostPort(addr)
+	if err != nil {
+		return addr
+	}
+	return host
+}
@@ -0,0 +1,147 @@
+// Copyright (c) 2022 IoTeX Foundation
+// This is an alpha (internal) release and is not suitable for production. This source code is provided 'as is' and no
+// warranties are given as to title or non-infringement, merchantability or fitness for purpose and, to the extent
+// permitted by law, all liability for your use of the code is disclaimed. This source code is governed by Apache
+// License 2.0 that can be found in the LICENSE file.
+
+package api
+
+import (
+	"context"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+
+	"github.com/iotexproject/iotex-core/config"
+)
+
+func TestAPILimiter(t *testing.T) {
+	require := require.New(t)
+	cfg := config.APILimit{
+		APIKeys: []config.APIKey{
+			{Name: "limited", Key: "key1", Rate: 0.001, Burst: 5},
+			{Name: "unlimited", Key: "key2"},
+		},
+		IPRate:      0.001,
+		IPBurst:     2,
+		MethodCosts: map[string]int{"eth_getLogs": 3, "GetLogs": 3},
+	}
+
+	t.Run("quota", func(t *testing.T) {
+		l := NewAPILimiter(cfg)
+		_, err := l.quota("key3", "")
+		require.Equal(errInvalidAPIKey, err)
+		q, err := l.quota("key1", "")
+		require.NoError(err)
+		require.NoError(q.consume("eth_getLogs"))
+		require.NoError(q.consume("eth_blockNumber"))
+		require.NoError(q.consume("eth_blockNumber"))
+		require.Equal(errRateLimited, q.consume("eth_blockNumber"))
+		q, err = l.quota("key2", "")
+		require.NoError(err)
+		for i := 0; i < 10; i++ {
+			require.NoError(q.consume("eth_getLogs"))
+		}
+		// the burst of IP is raised to the max cost
+		q, err = l.quota("", "1.1.1.1")
+		require.NoError(err)
+		require.NoError(q.consume("eth_getLogs"))
+		require.Equal(errRateLimited, q.consume("eth_blockNumber"))
+		q, err = l.quota("", "2.2.2.2")
+		require.NoError(err)
+		require.NoError(q.consume("eth_blockNumber"))
+
+		cfg := cfg
+		cfg.RequireAPIKey = true
+		l = NewAPILimiter(cfg)
+		_, err = l.quota("", "1.1.1.1")
+		require.Equal(errInvalidAPIKey, err)
+	})
+
+	t.Run("grpc", func(t *testing.T) {
+		l := NewAPILimiter(cfg)
+		info := &grpc.UnaryServerInfo{FullMethod: "/iotexapi.APIService/GetLogs"}
+		handler := func(context.Context, interface{}) (interface{}, error) { return nil, nil }
+		ctx := peer.NewContext(context.Background(), &peer.Peer{Addr: &net.TCPAddr{IP: net.ParseIP("1.1.1.1"), Port: 1000}})
+		interceptor := l.UnaryServerInterceptor()
+		_, err := interceptor(ctx, nil, info, handler)
+		require.NoError(err)
+		_, err = interceptor(ctx, nil, info, handler)
+		require.Equal(errRateLimited, err)
+		ctx = metadata.NewIncomingContext(ctx, metadata.Pairs("x-api-key", "key2"))
+		_, err = interceptor(ctx, nil, info, handler)
+		require.NoError(err)
+		ctx = metadata.NewIncomingContext(ctx, metadata.Pairs("x-api-key", "key3"))
+		_, err = interceptor(ctx, nil, info, handler)
+		require.Equal(errInvalidAPIKey, err)
+	})
+
+	t.Run("http", func(t *testing.T) {
+		cfg := cfg
+		cfg.AllowedOrigins = []string{"https://iotex.io"}
+		l := NewAPILimiter(cfg)
+		var consumed []error
+		svr := l.HTTPMiddleware(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+			consumed = append(consumed, consumeAPIQuota(req.Context(), "eth_getLogs"))
+		}))
+		for _, test := range []struct {
+			method, origin, key string
+			code                int
+			allowOrigin         string
+		}{
+			{http.MethodOptions, "https://iotex.io", "", http.StatusNoContent, "https://iotex.io"},
+			{http.MethodPost, "https://evil.io", "", http.StatusForbidden, ""},
+			{http.MethodPost, "https://iotex.io", "key3", http.StatusUnauthorized, "https://iotex.io"},
+			{http.MethodPost, "https://iotex.io", "key1", http.StatusOK, "https://iotex.io"},
+			{http.MethodPost, "", "", http.StatusOK, ""},
+		} {
+			req := httptest.NewRequest(test.method, "http://url.com?apikey="+test.key, strings.NewReader(`{}`))
+			if test.origin != "" {
+				req.Header.Set("Origin", test.origin)
+			}
+			resp := httptest.NewRecorder()
+			svr.ServeHTTP(resp, req)
+			require.Equal(test.code, resp.Code)
+			require.Equal(test.allowOrigin, resp.Header().Get("Access-Control-Allow-Origin"))
+		}
+		require.Equal([]error{nil, nil}, consumed)
+		require.NoError(consumeAPIQuota(context.Background(), "eth_getLogs"))
+	})
+
+	t.Run("forwarded", func(t *testing.T) {
+		require.Equal("3.3.3.3", forwardedIP("1.1.1.1, 2.2.2.2, 3.3.3.3", 1))
+		require.Equal("2.2.2.2", forwardedIP("1.1.1.1, 2.2.2.2, 3.3.3.3", 2))
+		require.Equal("1.1.1.1", forwardedIP("1.1.1.1", 2))
+
+		cfg := cfg
+		cfg.TrustedProxies = 1
+		l := NewAPILimiter(cfg)
+		var consumed []error
+		svr := l.HTTPMiddleware(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+			consumed = append(consumed, consumeAPIQuota(req.Context(), "eth_getLogs"))
+		}))
+		// the client forges a new IP in each request, while the proxy appends the real one
+		for _, spoofed := range []string{"4.4.4.4", "5.5.5.5"} {
+			req := httptest.NewRequest(http.MethodPost, "http://url.com", strings.NewReader(`{}`))
+			req.Header.Set("X-Forwarded-For", spoofed+", 1.1.1.1")
+			svr.ServeHTTP(httptest.NewRecorder(), req)
+		}
+		require.Equal([]error{nil, errRateLimited}, consumed)
+
+		info := &grpc.UnaryServerInfo{FullMethod: "/iotexapi.APIService/GetLogs"}
+		handler := func(context.Context, interface{}) (interface{}, error) { return nil, nil }
+		ctx := peer.NewContext(context.Background(), &peer.Peer{Addr: &net.TCPAddr{IP: net.ParseIP("9.9.9.9"), Port: 1000}})
+		interceptor := l.UnaryServerInterceptor()
+		_, err := interceptor(metadata.NewIncomingContext(ctx, metadata.Pairs("x-forwarded-for", "6.6.6.6, 2.2.2.2")), nil, info, handler)
+		require.NoError(err)
+		_, err = interceptor(metadata.NewIncomingContext(ctx, metadata.Pairs("x-forwarded-for", "7.7.7.7, 2.2.2.2")), nil, info, handler)
+		require.Equal(errRateLimited, err)
+	})
+}
@@ -0,0 +1,172 @@
+// Copyright (c) 2022 IoTeX Foundation
+// This is an alpha (internal) release and is not suitable for production. This source code is provided 'as is' and no
+// warranties are given as to title or non-infringement, merchantability or fitness for purpose and, to the extent
+// permitted by law, all liability for your use of the code is disclaimed. This source code is governed by Apache
+// License 2.0 that can be found in the LICENSE file.
+
+package api
+
+import (
+	"google.golang.org/protobuf/reflect/protoreflect"
+)
+
+const _openAPIRPCStatus = "google.rpc.Status"
+
+// _openAPIWellKnownTypes are the schemas of the well-known types, which are encoded specially in protojson
+var _openAPIWellKnownTypes = map[protoreflect.FullName]map[string]interface{}{
+	"google.protobuf.Timestamp": {"type": "string", "format": "date-time"},
+	"google.protobuf.Duration":  {"type": "string"},
+	"google.protobuf.Empty":     {"type": "object"},
+	"google.protobuf.Any":       {"type": "object", "additionalProperties": true},
+	"google.protobuf.Struct":    {"type": "object", "additionalProperties": true},
+	"google.protobuf.Value":     {},
+}
+
+// newOpenAPIDoc generates the OpenAPI 3.0 document of the routes of REST gateway from the service descriptor
+func newOpenAPIDoc(service protoreflect.ServiceDescriptor) map[string]interface{} {
+	var (
+		paths   = map[string]interface{}{}
+		schemas = map[string]interface{}{
+			_openAPIRPCStatus: map[string]interface{}{
+				"type": "object",
+				"properties": map[string]interface{}{
+					"code":    map[string]interface{}{"type": "integer", "format": "int32"},
+					"message": map[string]interface{}{"type": "string"},
+					"details": map[string]interface{}{
+						"type":  "array",
+						"items": map[string]interface{}{"type": "object", "additionalProperties": true},
+					},
+				},
+			},
+		}
+	)
+	for i := 0; i < service.Methods().Len(); i++ {
+		method := service.Methods().Get(i)
+		if method.IsStreamingClient() || method.IsStreamingServer() {
+			continue
+		}
+		addOpenAPISchema(schemas, method.Input())
+		addOpenAPISchema(schemas, method.Output())
+		responses := map[string]interface{}{
+			"200": map[string]interface{}{
+				"description": "A successful response.",
+				"content":     openAPIJSONContent(method.Output().FullName()),
+			},
+			"default": map[string]interface{}{
+				"description": "An error response.",
+				"content":     openAPIJSONContent(_openAPIRPCStatus),
+			},
+		}
+		name := string(method.Name())
+		paths[_restPathPrefix+name] = map[string]interface{}{
+			"post": map[string]interface{}{
+				"operationId": name,
+				"tags":        []string{string(service.Name())},
+				"requestBody": map[string]interface{}{
+					"content": openAPIJSONContent(method.Input().FullName()),
+				},
+				"responses": responses,
+			},
+			"get": map[string]interface{}{
+				"operationId": name + "_GET",
+				"tags":        []string{string(service.Name())},
+				"description": "The fields of the request are given as query parameters, named in dot-separated " +
+					"path for nested messages, e.g., byIndex.start",
+				"responses": responses,
+			},
+		}
+	}
+	return map[string]interface{}{
+		"openapi": "3.0.3",
+		"info": map[string]interface{}{
+			"title":   "IoTeX " + string(service.Name()),
+			"version": "v1",
+		},
+		"paths": paths,
+		"components": map[string]interface{}{
+			"schemas": schemas,
+		},
+	}
+}
+
+func openAPIJSONContent(name protoreflect.FullName) map[string]interface{} {
+	return map[string]interface{}{
+		"application/json": map[string]interface{}{
+			"schema": openAPIRef(name),
+		},
+	}
+}
+
+func openAPIRef(name protoreflect.FullName) map[string]interface{} {
+	return map[string]interface{}{"$ref": "#/components/schemas/" + string(name)}
+}
+
+// addOpenAPISchema adds the schemas of the message and the messages and enums it refers to
+func addOpenAPISchema(schemas map[string]interface{}, msg protoreflect.MessageDescriptor) {
+	name := string(msg.FullName())
+	if _, ok := schemas[name]; ok {
+		return
+	}
+	if schema, ok := _openAPIWellKnownTypes[msg.FullName()]; ok {
+		schemas[name] = schema
+		return
+	}
+	properties := map[string]interface{}{}
+	schemas[name] = map[string]interface{}{
+		"type":       "object",
+		"properties": properties,
+	}
+	fields := msg.Fields()
+	for i := 0; i < fields.Len(); i++ {
+		fd := fields.Get(i)
+		switch {
+		case fd.IsMap():
+			properties[fd.JSONName()] = map[string]interface{}{
+				"type":                 "object",
+				"additionalProperties": openAPIFieldSchema(schemas, fd.MapValue()),
+			}
+		case fd.IsList():
+			properties[fd.JSONName()] = map[string]interface{}{
+				"type":  "array",
+				"items": openAPIFieldSchema(schemas, fd),
+			}
+		default:
+			properties[fd.JSONName()] = openAPIFieldSchema(schemas, fd)
+		}
+	}
+}
+
+// openAPIFieldSchema returns the schema of a single value of the field, following the json mapping of protojson
+func openAPIFieldSchema(schemas map[string]interface{}, fd protoreflect.FieldDescriptor) map[string]interface{} {
+	switch fd.Kind() {
+	case protoreflect.BoolKind:
+		return map[string]interface{}{"type": "boolean"}
+	case protoreflect.Int32Kind, protoreflect.Sint32Kind, protoreflect.Sfixed32Kind:
+		return map[string]interface{}{"type": "integer", "format": "int32"}
+	case protoreflect.Uint32Kind, protoreflect.Fixed32Kind:
+		return map[string]interface{}{"type": "integer", "format": "int64", "minimum": 0}
+	case protoreflect.Int64Kind, protoreflect.Sint64Kind, protoreflect.Sfixed64Kind:
+		return map[string]interface{}{"type": "string", "format": "int64"}
+	case protoreflect.Uint64Kind, protoreflect.Fixed64Kind:
+		return map[string]interface{}{"type": "string", "format": "uint64"}
+	case protoreflect.FloatKind:
+		return map[string]interface{}{"type": "number", "format": "float"}
+	case protoreflect.DoubleKind:
+		return map[string]interface{}{"type": "number", "format": "double"}
+	case protoreflect.StringKind:
+		return map[string]interface{}{"type": "string"}
+	case protoreflect.BytesKind:
+		return map[string]interface{}{"type": "string", "format": "byte"}
+	case protoreflect.EnumKind:
+		values := fd.Enum().Values()
+		names := make([]string, 0, values.Len())
+		for i := 0; i < values.Len(); i++ {
+			names = append(names, string(values.Get(i).Name()))
+		}
+		return map[string]interface{}{"type": "string", "enum": names}
+	case protoreflect.MessageKind, protoreflect.GroupKind:
+		addOpenAPISchema(schemas, fd.Message())
+		return openAPIRef(fd.Message().FullName())
+	}
+	return map[string]interface{}{}
+}
@@ -0,0 +1,119 @@
+// Copyright (c) 2022 IoTeX Foundation
+// This is an alpha (internal) release and is not suitable for production. This source code is provided 'as is' and no
+// warranties are given as to title or non-infringement, merchantability or fitness for purpose and, to the extent
+// permitted by law, all liability for your use of the code is disclaimed. This source code is governed by Apache
+// License 2.0 that can be found in the LICENSE file.
+
+package api
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/iotexproject/go-pkgs/crypto"
+	"github.com/pkg/errors"
+
+	"github.com/iotexproject/iotex-core/action"
+	"github.com/iotexproject/iotex-core/action/protocol"
+	"github.com/iotexproject/iotex-core/actpool"
+	"github.com/iotexproject/iotex-core/blockchain"
+	"github.com/iotexproject/iotex-core/blockchain/block"
+	"github.com/iotexproject/iotex-core/state/factory"
+)
+
+// pendingBlock is the view of the block the node would produce on top of the tip, built by running the best
+// actions in the actpool through the block builder of the state factory. The view is rebuilt lazily, when the
+// tip moves or the last one is older than the interval, so that bursts of pending queries share one simulation
+type pendingBlock struct {
+	mutex    sync.Mutex
+	interval time.Duration
+	bc       blockchain.Blockchain
+	sf       factory.Factory
+	ap       actpool.ActPool
+	// sk signs the post system actions and the pending block, which never leave the API
+	sk      crypto.PrivateKey
+	builtAt time.Time
+	blk     *block.Block
+	sm      protocol.StateManager
+}
+
+func newPendingBlock(interval time.Duration, bc blockchain.Blockchain, sf factory.Factory, ap actpool.ActPool) (*pendingBlock, error) {
+	sk, err := crypto.GenerateKey()
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to generate the key of pending block")
+	}
+	return &pendingBlock{
+		interval: interval,
+		bc:       bc,
+		sf:       sf,
+		ap:       ap,
+		sk:       sk,
+	}, nil
+}
+
+// Block returns the pending block
+func (pb *pendingBlock) Block() (*block.Block, error) {
+	var blk *block.Block
+	if err := pb.Run(func(_ context.Context, b *block.Block, _ protocol.StateManager) error {
+		blk = b
+		return nil
+	}); err != nil {
+		return nil, err
+	}
+	return blk, nil
+}
+
+// Run calls f with the pending block and the state after running its actions. The changes f makes to the state
+// are reverted after it returns
+func (pb *pendingBlock) Run(f func(context.Context, *block.Block, protocol.StateManager) error) error {
+	pb.mutex.Lock()
+	defer pb.mutex.Unlock()
+	ctx, err := pb.bc.Context(context.Background())
+	if err != nil {
+		return err
+	}
+	if err := pb.refresh(ctx); err != nil {
+		return err
+	}
+	snapshot := pb.sm.Snapshot()
+	defer func() {
+		if err := pb.sm.Revert(snapshot); err != nil {
+			// the state cannot be trusted anymore, rebuild it in next run
+			pb.blk, pb.sm = nil, nil
+		}
+	}()
+	return f(pb.withBlockCtx(ctx, pb.blk.Height(), pb.blk.Timestamp()), pb.blk, pb.sm)
+}
+
+func (pb *pendingBlock) refresh(ctx context.Context) error {
+	height := protocol.MustGetBlockchainCtx(ctx).Tip.Height + 1
+	if pb.blk != nil && pb.blk.Height() == height && time.Since(pb.builtAt) < pb.interval {
+		return nil
+	}
+	builder, sm, err := pb.sf.NewPendingBlockBuilder(
+		pb.withBlockCtx(ctx, height, time.Now()),
+		pb.ap,
+		func(elp action.Envelope) (action.SealedEnvelope, error) {
+			return action.Sign(elp, pb.sk)
+		},
+	)
+	if err != nil {
+		return errors.Wrapf(err, "failed to build pending block at height %d", height)
+	}
+	blk, err := builder.SignAndBuild(pb.sk)
+	if err != nil {
+		return errors.Wrapf(err, "failed to sign pending block at height %d", height)
+	}
+	pb.builtAt, pb.blk, pb.sm = time.Now(), &blk, sm
+	return nil
+}
+
+func (pb *pendingBlock) withBlockCtx(ctx context.Context, height uint64, timestamp time.Time) context.Context {
+	return protocol.WithFeatureCtx(protocol.WithBlockCtx(ctx, protocol.BlockCtx{
+		BlockHeight:    height,
+		BlockTimeStamp: timestamp,
+		Producer:       pb.sk.PublicKey().Address(),
+		GasLimit:       pb.bc.Genesis().BlockGasLimit,
+	}))
+}
@@ -0,0 +1,275 @@
+// Copyright (c) 2022 IoTeX Foundation
+// This is an alpha (internal) release and is not suitable for production. This source code is provided 'as is' and no
+// warranties are given as to title or non-infringement, merchantability or fitness for purpose and, to the extent
+// permitted by law, all liability for your use of the code is disclaimed. This source code is governed by Apache
+// License 2.0 that can be found in the LICENSE file.
+
+package api
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/url"
+	"reflect"
+	"strconv"
+	"strings"
+
+	"github.com/grpc-ecosystem/grpc-gateway/runtime"
+	"github.com/iotexproject/iotex-proto/golang/iotexapi"
+	"github.com/pkg/errors"
+	"go.uber.org/zap"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/reflect/protoregistry"
+
+	"github.com/iotexproject/iotex-core/pkg/log"
+	"github.com/iotexproject/iotex-core/pkg/recovery"
+)
+
+const (
+	// _restPathPrefix is the prefix of the routes of REST gateway, e.g., /v1/GetAccount
+	_restPathPrefix = "/v1/"
+	// _openAPIPath is the route of the OpenAPI document of REST gateway
+	_openAPIPath = _restPathPrefix + "openapi.json"
+	// _restMaxBodySize is the max size of the body of a REST request
+	_restMaxBodySize = 10 << 20
+)
+
+type (
+	// RESTGateway maps the unary methods of APIService to the routes /v1/{method}, in which the request is decoded
+	// from the protojson body of POST or the query parameters of GET, and the response is encoded in protojson
+	RESTGateway struct {
+		methods map[string]*restMethod
+		openAPI map[string]interface{}
+	}
+
+	restMethod struct {
+		desc   protoreflect.MethodDescriptor
+		input  protoreflect.MessageType
+		invoke reflect.Value
+	}
+)
+
+// NewRESTGateway creates a REST gateway of the gRPC APIService backed by core service
+func NewRESTGateway(core CoreService) *RESTGateway {
+	var (
+		handler = reflect.ValueOf(newGRPCHandler(core))
+		service = iotexapi.File_proto_api_api_proto.Services().ByName("APIService")
+		methods = make(map[string]*restMethod)
+	)
+	for i := 0; i < service.Methods().Len(); i++ {
+		desc := service.Methods().Get(i)
+		if desc.IsStreamingClient() || desc.IsStreamingServer() {
+			continue
+		}
+		input, err := protoregistry.GlobalTypes.FindMessageByName(desc.Input().FullName())
+		if err != nil {
+			log.L().Panic("failed to find the request type.", zap.String("method", string(desc.Name())), zap.Error(err))
+		}
+		methods[string(desc.Name())] = &restMethod{
+			desc:   desc,
+			input:  input,
+			invoke: handler.MethodByName(string(desc.Name())),
+		}
+	}
+	return &RESTGateway{
+		methods: methods,
+		openAPI: newOpenAPIDoc(service),
+	}
+}
+
+func (gw *RESTGateway) ServeHTTP(w http.ResponseWriter, req *http.Request) {
+	if req.URL.Path == _openAPIPath && req.Method == http.MethodGet {
+		w.Header().Set("Content-Type", "application/json; charset=UTF-8")
+		if err := json.NewEncoder(w).Encode(gw.openAPI); err != nil {
+			log.Logger("api").Warn("fail to respond openapi document.", zap.Error(err))
+		}
+		return
+	}
+	name := strings.TrimPrefix(req.URL.Path, _restPathPrefix)
+	method, ok := gw.methods[name]
+	if !ok {
+		writeRESTError(w, status.Errorf(codes.NotFound, "method %s not found", req.URL.Path))
+		return
+	}
+	in := method.input.New().Interface()
+	switch req.Method {
+	case http.MethodGet:
+		if err := populateQueryParameters(in, req.URL.Query()); err != nil {
+			writeRESTError(w, status.Error(codes.InvalidArgument, err.Error()))
+			return
+		}
+	case http.MethodPost:
+		body, err := io.ReadAll(http.MaxBytesReader(w, req.Body, _restMaxBodySize))
+		if err != nil {
+			writeRESTError(w, status.Error(codes.InvalidArgument, err.Error()))
+			return
+		}
+		if len(body) > 0 {
+			if err := protojson.Unmarshal(body, in); err != nil {
+				writeRESTError(w, status.Error(codes.InvalidArgument, err.Error()))
+				return
+			}
+		}
+	default:
+		w.WriteHeader(http.StatusMethodNotAllowed)
+		return
+	}
+	ctx := req.Context()
+	if err := consumeAPIQuota(ctx, name); err != nil {
+		writeRESTError(w, err)
+		return
+	}
+	out, err := method.call(ctx, in)
+	if err != nil {
+		writeRESTError(w, err)
+		return
+	}
+	data, err := protojson.Marshal(out)
+	if err != nil {
+		writeRESTError(w, status.Error(codes.Internal, err.Error()))
+		return
+	}
+	w.Header().Set("Content-Type", "application/json; charset=UTF-8")
+	if _, err := w.Write(data); err != nil {
+		log.Logger("api").Warn("fail to respond rest request.", zap.Error(err))
+	}
+}
+
+// call calls the method of gRPC handler, the panic of which is converted to an error like the gRPC server
+func (m *restMethod) call(ctx context.Context, in proto.Message) (out proto.Message, err error) {
+	defer func() {
+		if p := recover(); p != nil {
+			recovery.LogCrash(p)
+			out, err = nil, status.Errorf(codes.Unknown, "rest gateway triggered crash: %v", p)
+		}
+	}()
+	rets := m.invoke.Call([]reflect.Value{reflect.ValueOf(ctx), reflect.ValueOf(in)})
+	if !rets[1].IsNil() {
+		return nil, rets[1].Interface().(error)
+	}
+	return rets[0].Interface().(proto.Message), nil
+}
+
+// writeRESTError writes the error as the protojson of google.rpc.Status, with the http status mapped from the code
+func writeRESTError(w http.ResponseWriter, err error) {
+	s := status.Convert(err)
+	w.Header().Set("Content-Type", "application/json; charset=UTF-8")
+	w.WriteHeader(runtime.HTTPStatusFromCode(s.Code()))
+	data, err := protojson.Marshal(s.Proto())
+	if err != nil {
+		log.Logger("api").Warn("fail to marshal rest error.", zap.Error(err))
+		return
+	}
+	if _, err := w.Write(data); err != nil {
+		log.Logger("api").Warn("fail to respond rest request.", zap.Error(err))
+	}
+}
+
+// populateQueryParameters sets the fields of msg from the query parameters, in which a field is named by its json
+// name or proto name, and the fields of a nested message are named in dot-separated path, e.g., byIndex.start
+func populateQueryParameters(msg proto.Message, values url.Values) error {
+	for key, vals := range values {
+		if key == _apiKeyQuery {
+			continue
+		}
+		m := msg.ProtoReflect()
+		path := strings.Split(key, ".")
+		for i, name := range path {
+			fd := m.Descriptor().Fields().ByJSONName(name)
+			if fd == nil {
+				fd = m.Descriptor().Fields().ByName(protoreflect.Name(name))
+			}
+			if fd == nil {
+				return errors.Errorf("unknown parameter %s", key)
+			}
+			if i < len(path)-1 {
+				if fd.Kind() != protoreflect.MessageKind || fd.IsList() || fd.IsMap() {
+					return errors.Errorf("parameter %s is not a nested message", key)
+				}
+				m = m.Mutable(fd).Message()
+				continue
+			}
+			if err := setField(m, fd, vals); err != nil {
+				return errors.Wrapf(err, "invalid parameter %s", key)
+			}
+		}
+	}
+	return nil
+}
+
+func setField(m protoreflect.Message, fd protoreflect.FieldDescriptor, vals []string) error {
+	if fd.IsMap() || fd.Kind() == protoreflect.MessageKind || fd.Kind() == protoreflect.GroupKind {
+		return errors.New("message or map can't be set by query parameter")
+	}
+	if !fd.IsList() {
+		if len(vals) != 1 {
+			return errors.New("too many values")
+		}
+		v, err := parseScalar(fd, vals[0])
+		if err != nil {
+			return err
+		}
+		m.Set(fd, v)
+		return nil
+	}
+	list := m.Mutable(fd).List()
+	for _, val := range vals {
+		v, err := parseScalar(fd, val)
+		if err != nil {
+			return err
+		}
+		list.Append(v)
+	}
+	return nil
+}
+
+func parseScalar(fd protoreflect.FieldDescriptor, val string) (protoreflect.Value, error) {
+	switch fd.Kind() {
+	case protoreflect.BoolKind:
+		v, err := strconv.ParseBool(val)
+		return protoreflect.ValueOfBool(v), err
+	case protoreflect.Int32Kind, protoreflect.Sint32Kind, protoreflect.Sfixed32Kind:
+		v, err := strconv.ParseInt(val, 10, 32)
+		return protoreflect.ValueOfInt32(int32(v)), err
+	case protoreflect.Int64Kind, protoreflect.Sint64Kind, protoreflect.Sfixed64Kind:
+		v, err := strconv.ParseInt(val, 10, 64)
+		return protoreflect.ValueOfInt64(v), err
+	case protoreflect.Uint32Kind, protoreflect.Fixed32Kind:
+		v, err := strconv.ParseUint(val, 10, 32)
+		return protoreflect.ValueOfUint32(uint32(v)), err
+	case protoreflect.Uint64Kind, protoreflect.Fixed64Kind:
+		v, err := strconv.ParseUint(val, 10, 64)
+		return protoreflect.ValueOfUint64(v), err
+	case protoreflect.FloatKind:
+		v, err := strconv.ParseFloat(val, 32)
+		return protoreflect.ValueOfFloat32(float32(v)), err
+	case protoreflect.DoubleKind:
+		v, err := strconv.ParseFloat(val, 64)
+		return protoreflect.ValueOfFloat64(v), err
+	case protoreflect.StringKind:
+		return protoreflect.ValueOfString(val), nil
+	case protoreflect.BytesKind:
+		v, err := base64.StdEncoding.DecodeString(val)
+		if err != nil {
+			v, err = base64.URLEncoding.DecodeString(val)
+		}
+		return protoreflect.ValueOfBytes(v), err
+	case protoreflect.EnumKind:
+		if ev := fd.Enum().Values().ByName(protoreflect.Name(val)); ev != nil {
+			return protoreflect.ValueOfEnum(ev.Number()), nil
+		}
+		v, err := strconv.ParseInt(val, 10, 32)
+		if err != nil || fd.Enum().Values().ByNumber(protoreflect.EnumNumber(v)) == nil {
+			return protoreflect.Value{}, errors.Errorf("invalid enum value %s", val)
+		}
+		return protoreflect.ValueOfEnum(protoreflect.EnumNumber(v)), nil
+	}
+	return protoreflect.Value{}, errors.Errorf("unsupported kind %s", fd.Kind())
+}
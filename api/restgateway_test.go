@@ -0,0 +1,100 @@
+// Copyright (c) 2022 IoTeX Foundation
+// This is an alpha (internal) release and is not suitable for production. This source code is provided 'as is' and no
+// warranties are given as to title or non-infringement, merchantability or fitness for purpose and, to the extent
+// permitted by law, all liability for your use of the code is disclaimed. This source code is governed by Apache
+// License 2.0 that can be found in the LICENSE file.
+
+package api
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/iotexproject/iotex-proto/golang/iotexapi"
+	"github.com/iotexproject/iotex-proto/golang/iotextypes"
+	"github.com/stretchr/testify/require"
+	"github.com/tidwall/gjson"
+
+	"github.com/iotexproject/iotex-core/test/identityset"
+	"github.com/iotexproject/iotex-core/test/mock/mock_apicoreservice"
+)
+
+func TestRESTGateway(t *testing.T) {
+	require := require.New(t)
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	core := mock_apicoreservice.NewMockCoreService(ctrl)
+	gw := NewRESTGateway(core)
+	serve := func(method, target, body string) (int, gjson.Result) {
+		resp := httptest.NewRecorder()
+		gw.ServeHTTP(resp, httptest.NewRequest(method, target, strings.NewReader(body)))
+		return resp.Code, gjson.Parse(resp.Body.String())
+	}
+	addr := identityset.Address(1)
+	core.EXPECT().Account(addr).Return(&iotextypes.AccountMeta{Address: addr.String(), Balance: "100"},
+		&iotextypes.BlockIdentifier{Height: 5}, nil).Times(2)
+
+	// GET with query parameters
+	code, res := serve(http.MethodGet, "/v1/GetAccount?address="+addr.String(), "")
+	require.Equal(http.StatusOK, code)
+	require.Equal("100", res.Get("accountMeta.balance").String())
+	require.Equal("5", res.Get("blockIdentifier.height").String())
+
+	// POST with protojson body
+	code, res = serve(http.MethodPost, "/v1/GetAccount", `{"address":"`+addr.String()+`"}`)
+	require.Equal(http.StatusOK, code)
+	require.Equal(addr.String(), res.Get("accountMeta.address").String())
+
+	// errors
+	code, res = serve(http.MethodGet, "/v1/GetAccount?foo=1", "")
+	require.Equal(http.StatusBadRequest, code)
+	require.Equal(int64(3), res.Get("code").Int())
+	code, _ = serve(http.MethodPost, "/v1/GetAccount", `{"address":`)
+	require.Equal(http.StatusBadRequest, code)
+	code, _ = serve(http.MethodGet, "/v1/StreamBlocks", "")
+	require.Equal(http.StatusNotFound, code)
+	code, _ = serve(http.MethodDelete, "/v1/GetAccount", "")
+	require.Equal(http.StatusMethodNotAllowed, code)
+
+	// OpenAPI document
+	code, res = serve(http.MethodGet, _openAPIPath, "")
+	require.Equal(http.StatusOK, code)
+	require.True(res.Get(`paths./v1/GetAccount.post`).Exists())
+	require.True(res.Get(`paths./v1/ReadState.get`).Exists())
+	require.False(res.Get(`paths./v1/StreamLogs`).Exists())
+	require.Equal("#/components/schemas/iotextypes.AccountMeta",
+		res.Get(`components.schemas.iotexapi\.GetAccountResponse.properties.accountMeta.$ref`).String())
+}
+
+func TestPopulateQueryParameters(t *testing.T) {
+	require := require.New(t)
+	var req iotexapi.GetActionsRequest
+	require.NoError(populateQueryParameters(&req, url.Values{
+		"byIndex.start": {"3"},
+		"byIndex.count": {"10"},
+		"apikey":        {"key"},
+	}))
+	require.Equal(uint64(3), req.GetByIndex().GetStart())
+	require.Equal(uint64(10), req.GetByIndex().GetCount())
+
+	var logs iotexapi.GetLogsRequest
+	require.NoError(populateQueryParameters(&logs, url.Values{
+		"filter.address":    {"io1", "io2"},
+		"byBlock.blockHash": {"AQI="},
+	}))
+	require.Equal([]string{"io1", "io2"}, logs.GetFilter().GetAddress())
+	require.Equal([]byte{1, 2}, logs.GetByBlock().GetBlockHash())
+
+	for _, values := range []url.Values{
+		{"byIndex": {"3"}},
+		{"byIndex.start": {"-1"}},
+		{"byIndex.start.x": {"1"}},
+		{"byIndex.start": {"1", "2"}},
+	} {
+		require.Error(populateQueryParameters(&iotexapi.GetActionsRequest{}, values), values)
+	}
+}
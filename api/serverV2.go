@@ -30,6 +30,8 @@ type ServerV2 struct {
 	grpcServer   *GRPCServer
 	httpSvr      *HTTPServer
 	websocketSvr *HTTPServer
+	graphqlSvr   *HTTPServer
+	restSvr      *HTTPServer
 	tracer       *tracesdk.TracerProvider
 }
 
@@ -62,11 +64,14 @@ func NewServerV2(
 		return nil, errors.Wrapf(err, "cannot config tracer provider")
 	}
 
+	limiter := NewAPILimiter(cfg.Limit)
 	return &ServerV2{
 		core:         coreAPI,
-		grpcServer:   NewGRPCServer(coreAPI, cfg.GRPCPort),
-		httpSvr:      NewHTTPServer("", cfg.HTTPPort, newHTTPHandler(web3Handler)),
-		websocketSvr: NewHTTPServer("", cfg.WebSocketPort, NewWebsocketHandler(web3Handler)),
+		grpcServer:   NewGRPCServer(coreAPI, cfg.GRPCPort, limiter),
+		httpSvr:      NewHTTPServer("", cfg.HTTPPort, limiter.HTTPMiddleware(newHTTPHandler(web3Handler))),
+		websocketSvr: NewHTTPServer("", cfg.WebSocketPort, limiter.HTTPMiddleware(NewWebsocketHandler(web3Handler))),
+		graphqlSvr:   NewHTTPServer("", cfg.GraphQLPort, limiter.HTTPMiddleware(NewGraphQLHandler(coreAPI, cfg))),
+		restSvr:      NewHTTPServer("", cfg.RESTPort, limiter.HTTPMiddleware(NewRESTGateway(coreAPI))),
 		tracer:       tp,
 	}, nil
 }
@@ -91,6 +96,16 @@ func (svr *ServerV2) Start(ctx context.Context) error {
 			return err
 		}
 	}
+	if svr.graphqlSvr != nil {
+		if err := svr.graphqlSvr.Start(ctx); err != nil {
+			return err
+		}
+	}
+	if svr.restSvr != nil {
+		if err := svr.restSvr.Start(ctx); err != nil {
+			return err
+		}
+	}
 	return nil
 }
 
@@ -101,6 +116,16 @@ func (svr *ServerV2) Stop(ctx context.Context) error {
 			return errors.Wrap(err, "failed to shutdown api tracer")
 		}
 	}
+	if svr.restSvr != nil {
+		if err := svr.restSvr.Stop(ctx); err != nil {
+			return err
+		}
+	}
+	if svr.graphqlSvr != nil {
+		if err := svr.graphqlSvr.Stop(ctx); err != nil {
+			return err
+		}
+	}
 	if svr.websocketSvr != nil {
 		if err := svr.websocketSvr.Stop(ctx); err != nil {
 			return err
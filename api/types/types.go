@@ -31,8 +31,24 @@ type (
 		Block    *block.Block
 		Receipts []*action.Receipt
 	}
+
+	// EstimateConfig is the config of gas estimation
+	EstimateConfig struct {
+		// WithAccessList applies the access list created for the execution when searching the gas limit
+		WithAccessList bool
+	}
+
+	// EstimateOption sets the config of gas estimation
+	EstimateOption func(*EstimateConfig)
 )
 
+// EstimateWithAccessList makes gas estimation take the access list of the execution into account
+func EstimateWithAccessList() EstimateOption {
+	return func(cfg *EstimateConfig) {
+		cfg.WithAccessList = true
+	}
+}
+
 // responseWriter for server
 type responseWriter struct {
 	writeHandler func(interface{}) error
@@ -8,8 +8,10 @@ import (
 	"io"
 	"math/big"
 	"strconv"
+	"strings"
 	"time"
 
+	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/core/types"
 	"github.com/iotexproject/go-pkgs/hash"
 	"github.com/iotexproject/go-pkgs/util"
@@ -21,6 +23,7 @@ import (
 	"go.uber.org/zap"
 
 	"github.com/iotexproject/iotex-core/action"
+	"github.com/iotexproject/iotex-core/api/apipb"
 	apitypes "github.com/iotexproject/iotex-core/api/types"
 	"github.com/iotexproject/iotex-core/pkg/log"
 	"github.com/iotexproject/iotex-core/pkg/util/addrutil"
@@ -33,7 +36,7 @@ const (
 type (
 	// Web3Handler handle JRPC request
 	Web3Handler interface {
-		HandlePOSTReq(io.Reader, apitypes.Web3ResponseWriter) error
+		HandlePOSTReq(context.Context, io.Reader, apitypes.Web3ResponseWriter) error
 	}
 
 	web3Handler struct {
@@ -50,6 +53,8 @@ type (
 		ToBlock    string     `json:"toBlock,omitempty"`
 		Address    []string   `json:"address,omitempty"`
 		Topics     [][]string `json:"topics,omitempty"`
+		// PendingActions are the hashes of the pending actions whose logs have been returned by the filter
+		PendingActions []string `json:"pendingActions,omitempty"`
 	}
 )
 
@@ -85,26 +90,26 @@ func NewWeb3Handler(core CoreService, cacheURL string) Web3Handler {
 }
 
 // HandlePOSTReq handles web3 request
-func (svr *web3Handler) HandlePOSTReq(reader io.Reader, writer apitypes.Web3ResponseWriter) error {
+func (svr *web3Handler) HandlePOSTReq(ctx context.Context, reader io.Reader, writer apitypes.Web3ResponseWriter) error {
 	web3Reqs, err := parseWeb3Reqs(reader)
 	if err != nil {
 		err := errors.Wrap(err, "failed to parse web3 requests.")
 		return writer.Write(&web3Response{err: err})
 	}
 	if !web3Reqs.IsArray() {
-		return svr.handleWeb3Req(&web3Reqs, writer)
+		return svr.handleWeb3Req(ctx, &web3Reqs, writer)
 	}
 	batchWriter := apitypes.NewBatchWriter(writer)
 	web3ReqArr := web3Reqs.Array()
 	for i := range web3ReqArr {
-		if err := svr.handleWeb3Req(&web3ReqArr[i], batchWriter); err != nil {
+		if err := svr.handleWeb3Req(ctx, &web3ReqArr[i], batchWriter); err != nil {
 			return err
 		}
 	}
 	return batchWriter.Flush()
 }
 
-func (svr *web3Handler) handleWeb3Req(web3Req *gjson.Result, writer apitypes.Web3ResponseWriter) error {
+func (svr *web3Handler) handleWeb3Req(ctx context.Context, web3Req *gjson.Result, writer apitypes.Web3ResponseWriter) error {
 	var (
 		res    interface{}
 		err    error
@@ -113,6 +118,12 @@ func (svr *web3Handler) handleWeb3Req(web3Req *gjson.Result, writer apitypes.Web
 	log.Logger("api").Debug("web3Debug", zap.String("requestParams", fmt.Sprintf("%+v", web3Req)))
 	_web3ServerMtc.WithLabelValues(method.(string)).Inc()
 	_web3ServerMtc.WithLabelValues("requests_total").Inc()
+	if err := consumeAPIQuota(ctx, method.(string)); err != nil {
+		return writer.Write(&web3Response{
+			id:  int(web3Req.Get("id").Int()),
+			err: err,
+		})
+	}
 	switch method {
 	case "eth_accounts":
 		res, err = svr.ethAccounts()
@@ -130,6 +141,8 @@ func (svr *web3Handler) handleWeb3Req(web3Req *gjson.Result, writer apitypes.Web
 		res, err = svr.getTransactionCount(web3Req)
 	case "eth_call":
 		res, err = svr.call(web3Req)
+	case "eth_createAccessList":
+		res, err = svr.createAccessList(web3Req)
 	case "eth_getCode":
 		res, err = svr.getCode(web3Req)
 	case "eth_protocolVersion":
@@ -172,8 +185,16 @@ func (svr *web3Handler) handleWeb3Req(web3Req *gjson.Result, writer apitypes.Web
 		res, err = svr.getBlockTransactionCountByNumber(web3Req)
 	case "eth_getTransactionReceipt":
 		res, err = svr.getTransactionReceipt(web3Req)
+	case "eth_getBlockReceipts":
+		res, err = svr.getBlockReceipts(web3Req)
 	case "eth_getStorageAt":
 		res, err = svr.getStorageAt(web3Req)
+	case "trace_replayBlockTransactions":
+		res, err = svr.replayBlockTransactions(web3Req)
+	case "trace_replayTransaction":
+		res, err = svr.replayTransaction(web3Req)
+	case "debug_storageRangeAt":
+		res, err = svr.storageRangeAt(web3Req)
 	case "eth_getFilterLogs":
 		res, err = svr.getFilterLogs(web3Req)
 	case "eth_getFilterChanges":
@@ -258,12 +279,20 @@ func (svr *web3Handler) getBlockByNumber(in *gjson.Result) (interface{}, error)
 	if !blkNum.Exists() || !isDetailed.Exists() {
 		return nil, errInvalidFormat
 	}
-	num, err := svr.parseBlockNumber(blkNum.String())
-	if err != nil {
-		return nil, err
+	var (
+		blk *apitypes.BlockWithReceipts
+		err error
+	)
+	if blkNum.String() == _pendingBlockNumber {
+		blk, err = svr.coreService.PendingBlock()
+	} else {
+		var num uint64
+		num, err = svr.parseBlockNumber(blkNum.String())
+		if err != nil {
+			return nil, err
+		}
+		blk, err = svr.coreService.BlockByHeight(num)
 	}
-
-	blk, err := svr.coreService.BlockByHeight(num)
 	if err != nil {
 		if errors.Cause(err) == ErrNotFound {
 			return nil, nil
@@ -282,6 +311,13 @@ func (svr *web3Handler) getBalance(in *gjson.Result) (interface{}, error) {
 	if err != nil {
 		return nil, err
 	}
+	if in.Get("params.1").String() == _pendingBlockNumber {
+		acct, err := svr.coreService.PendingAccount(ioAddr)
+		if err != nil {
+			return nil, err
+		}
+		return "0x" + acct.Balance.Text(16), nil
+	}
 	accountMeta, _, err := svr.coreService.Account(ioAddr)
 	if err != nil {
 		return nil, err
@@ -299,6 +335,13 @@ func (svr *web3Handler) getTransactionCount(in *gjson.Result) (interface{}, erro
 	if err != nil {
 		return nil, err
 	}
+	if in.Get("params.1").String() == _pendingBlockNumber {
+		acct, err := svr.coreService.PendingAccount(ioAddr)
+		if err != nil {
+			return nil, err
+		}
+		return uint64ToHex(acct.PendingNonce()), nil
+	}
 	// TODO (liuhaai): returns the nonce in given block height after archive mode is supported
 	// blkNum, err := getStringFromArray(in, 1)
 	pendingNonce, err := svr.coreService.PendingNonce(ioAddr)
@@ -317,7 +360,12 @@ func (svr *web3Handler) call(in *gjson.Result) (interface{}, error) {
 		return nil, nil
 	}
 	exec, _ := action.NewExecution(to, 0, value, gasLimit, big.NewInt(0), data)
-	ret, _, err := svr.coreService.ReadContract(context.Background(), callerAddr, exec)
+	var ret string
+	if in.Get("params.1").String() == _pendingBlockNumber {
+		ret, _, err = svr.coreService.ReadPendingContract(context.Background(), callerAddr, exec)
+	} else {
+		ret, _, err = svr.coreService.ReadContract(context.Background(), callerAddr, exec)
+	}
 	if err != nil {
 		return nil, err
 	}
@@ -329,20 +377,49 @@ func (svr *web3Handler) estimateGas(in *gjson.Result) (interface{}, error) {
 	if err != nil {
 		return nil, err
 	}
+	accessList, err := parseCallAccessList(in)
+	if err != nil {
+		return nil, err
+	}
+	estimatedGas, err := svr.estimateCallGas(from, to, gasLimit, value, data, accessList)
+	if err != nil {
+		return nil, err
+	}
+	return uint64ToHex(estimatedGas), nil
+}
 
-	var tx *types.Transaction
-	if len(to) == 0 {
-		tx = types.NewContractCreation(0, value, gasLimit, big.NewInt(0), data)
-	} else {
-		toAddr, err := addrutil.IoAddrToEvmAddr(to)
+// estimateCallGas estimates the gas of the action built from the call object, which is at least 21000
+func (svr *web3Handler) estimateCallGas(from address.Address, to string, gasLimit uint64, value *big.Int, data []byte, accessList types.AccessList) (uint64, error) {
+	var toAddr *common.Address
+	if len(to) != 0 {
+		addr, err := addrutil.IoAddrToEvmAddr(to)
 		if err != nil {
-			return "", err
+			return 0, err
 		}
-		tx = types.NewTransaction(0, toAddr, value, gasLimit, big.NewInt(0), data)
+		toAddr = &addr
+	}
+	var tx *types.Transaction
+	if len(accessList) == 0 {
+		tx = types.NewTx(&types.LegacyTx{
+			To:       toAddr,
+			Value:    value,
+			Gas:      gasLimit,
+			GasPrice: big.NewInt(0),
+			Data:     data,
+		})
+	} else {
+		tx = types.NewTx(&types.AccessListTx{
+			To:         toAddr,
+			Value:      value,
+			Gas:        gasLimit,
+			GasPrice:   big.NewInt(0),
+			Data:       data,
+			AccessList: accessList,
+		})
 	}
 	elp, err := svr.ethTxToEnvelope(tx)
 	if err != nil {
-		return nil, err
+		return 0, err
 	}
 
 	var estimatedGas uint64
@@ -352,12 +429,47 @@ func (svr *web3Handler) estimateGas(in *gjson.Result) (interface{}, error) {
 		estimatedGas, err = svr.coreService.EstimateGasForNonExecution(elp.Action())
 	}
 	if err != nil {
-		return nil, err
+		return 0, err
 	}
 	if estimatedGas < 21000 {
 		estimatedGas = 21000
 	}
-	return uint64ToHex(estimatedGas), nil
+	return estimatedGas, nil
+}
+
+// createAccessList returns the access list of the call, and the gas used by the call with the list applied
+func (svr *web3Handler) createAccessList(in *gjson.Result) (interface{}, error) {
+	from, to, gasLimit, value, data, err := parseCallObject(in)
+	if err != nil {
+		return nil, err
+	}
+	accessList, err := parseCallAccessList(in)
+	if err != nil {
+		return nil, err
+	}
+	exec, err := action.NewExecutionWithAccessList(to, 0, value, gasLimit, big.NewInt(0), data, accessList)
+	if err != nil {
+		return nil, err
+	}
+	accessList, receipt, err := svr.coreService.CreateAccessList(context.Background(), from, exec)
+	if err != nil {
+		return nil, err
+	}
+	if accessList == nil {
+		accessList = types.AccessList{}
+	}
+	ret := &createAccessListResult{
+		AccessList: accessList,
+		GasUsed:    uint64ToHex(receipt.GasConsumed),
+	}
+	if receipt.Status != uint64(iotextypes.ReceiptStatus_Success) {
+		if msg := receipt.ExecutionRevertMsg(); msg != "" {
+			ret.Error = "execution reverted: " + msg
+		} else {
+			ret.Error = fmt.Sprintf("execution failed with status %d", receipt.Status)
+		}
+	}
+	return ret, nil
 }
 
 func (svr *web3Handler) sendRawTransaction(in *gjson.Result) (interface{}, error) {
@@ -365,14 +477,22 @@ func (svr *web3Handler) sendRawTransaction(in *gjson.Result) (interface{}, error
 	if !dataStr.Exists() {
 		return nil, errInvalidFormat
 	}
-	// parse raw data string from json request
-	tx, sig, pubkey, err := action.DecodeRawTx(dataStr.String(), svr.coreService.EVMNetworkID())
+	actionHash, err := svr.sendEthRawTx(dataStr.String())
 	if err != nil {
 		return nil, err
 	}
+	return "0x" + actionHash, nil
+}
+
+// sendEthRawTx sends the RLP-encoded signed ethereum transaction, and returns the action hash
+func (svr *web3Handler) sendEthRawTx(rawTx string) (string, error) {
+	tx, sig, pubkey, err := action.DecodeRawTx(rawTx, svr.coreService.EVMNetworkID())
+	if err != nil {
+		return "", err
+	}
 	elp, err := svr.ethTxToEnvelope(tx)
 	if err != nil {
-		return nil, err
+		return "", err
 	}
 	req := &iotextypes.Action{
 		Core:         elp.Proto(),
@@ -380,11 +500,7 @@ func (svr *web3Handler) sendRawTransaction(in *gjson.Result) (interface{}, error
 		Signature:    sig,
 		Encoding:     iotextypes.Encoding_ETHEREUM_RLP,
 	}
-	actionHash, err := svr.coreService.SendAction(context.Background(), req)
-	if err != nil {
-		return nil, err
-	}
-	return "0x" + actionHash, nil
+	return svr.coreService.SendAction(context.Background(), req)
 }
 
 func (svr *web3Handler) getCode(in *gjson.Result) (interface{}, error) {
@@ -503,7 +619,15 @@ func (svr *web3Handler) getLogs(filter *filterObject) (interface{}, error) {
 	if err != nil {
 		return nil, err
 	}
-	return svr.getLogsWithFilter(from, to, filter.Address, filter.Topics)
+	logs, err := svr.getLogsWithFilter(from, to, filter.Address, filter.Topics)
+	if err != nil || filter.ToBlock != _pendingBlockNumber {
+		return logs, err
+	}
+	pendingLogs, err := svr.getPendingLogsWithFilter(filter.Address, filter.Topics)
+	if err != nil {
+		return nil, err
+	}
+	return append(logs, pendingLogs...), nil
 }
 
 func (svr *web3Handler) getTransactionReceipt(in *gjson.Result) (interface{}, error) {
@@ -532,32 +656,137 @@ func (svr *web3Handler) getTransactionReceipt(in *gjson.Result) (interface{}, er
 		}
 		return nil, err
 	}
-	to, contractAddr, err := getRecipientAndContractAddrFromAction(selp, receipt)
+
+	// the cumulative gas used is summed up along the receipts of the block
+	blk, err := svr.coreService.BlockByHash(hex.EncodeToString(blockHash[:]))
 	if err != nil {
 		return nil, err
 	}
+	var cumulativeGasUsed uint64
+	for _, r := range blk.Receipts {
+		cumulativeGasUsed += r.GasConsumed
+		if r.ActionHash == actHash {
+			break
+		}
+	}
+	return newReceiptResult(blockHash, selp, receipt, cumulativeGasUsed)
+}
 
-	// acquire logsBloom from blockMeta
-	blkHash := hex.EncodeToString(blockHash[:])
-	blk, err := svr.coreService.BlockByHash(blkHash)
+func (svr *web3Handler) getBlockReceipts(in *gjson.Result) (interface{}, error) {
+	blkParam := in.Get("params.0")
+	if !blkParam.Exists() {
+		return nil, errInvalidFormat
+	}
+	var (
+		blk *apitypes.BlockWithReceipts
+		err error
+	)
+	if str := blkParam.String(); len(str) == 66 && strings.HasPrefix(str, "0x") {
+		blk, err = svr.coreService.BlockByHash(util.Remove0xPrefix(str))
+	} else {
+		var num uint64
+		if num, err = svr.parseBlockNumber(str); err != nil {
+			return nil, err
+		}
+		blk, err = svr.coreService.BlockByHeight(num)
+	}
 	if err != nil {
+		if errors.Cause(err) == ErrNotFound {
+			return nil, nil
+		}
 		return nil, err
 	}
+	return getReceiptsFromBlock(blk)
+}
 
-	var logsBloomStr string
-	if logsBloom := blk.Block.LogsBloomfilter(); logsBloom != nil {
-		logsBloomStr = hex.EncodeToString(logsBloom.Bytes())
+func (svr *web3Handler) replayBlockTransactions(in *gjson.Result) (interface{}, error) {
+	blkNum, traceTypes := in.Get("params.0"), in.Get("params.1")
+	if !blkNum.Exists() || !traceTypes.Exists() {
+		return nil, errInvalidFormat
 	}
+	withStateDiff, err := parseTraceTypes(traceTypes)
+	if err != nil {
+		return nil, err
+	}
+	num, err := svr.parseBlockNumber(blkNum.String())
+	if err != nil {
+		return nil, err
+	}
+	blk, err := svr.coreService.BlockByHeight(num)
+	if err != nil {
+		if errors.Cause(err) == ErrNotFound {
+			return nil, nil
+		}
+		return nil, err
+	}
+	diffs, err := svr.actionStateDiffs(num, withStateDiff)
+	if err != nil {
+		return nil, err
+	}
+	ret := make([]*replayTraceResult, 0, len(blk.Block.Actions))
+	for _, selp := range blk.Block.Actions {
+		actHash, err := selp.Hash()
+		if err != nil {
+			return nil, err
+		}
+		res, err := newReplayTraceResult(diffs[actHash], withStateDiff)
+		if err != nil {
+			return nil, err
+		}
+		res.TransactionHash = "0x" + hex.EncodeToString(actHash[:])
+		ret = append(ret, res)
+	}
+	return ret, nil
+}
 
-	return &getReceiptResult{
-		blockHash:       blockHash,
-		from:            selp.SenderAddress(),
-		to:              to,
-		contractAddress: contractAddr,
-		logsBloom:       logsBloomStr,
-		receipt:         receipt,
-	}, nil
+func (svr *web3Handler) replayTransaction(in *gjson.Result) (interface{}, error) {
+	h, traceTypes := in.Get("params.0"), in.Get("params.1")
+	if !h.Exists() || !traceTypes.Exists() {
+		return nil, errInvalidFormat
+	}
+	withStateDiff, err := parseTraceTypes(traceTypes)
+	if err != nil {
+		return nil, err
+	}
+	actHash, err := hash.HexStringToHash256(util.Remove0xPrefix(h.String()))
+	if err != nil {
+		return nil, err
+	}
+	_, _, height, _, err := svr.coreService.ActionByActionHash(actHash)
+	if err != nil {
+		if errors.Cause(err) == ErrNotFound {
+			return nil, nil
+		}
+		return nil, err
+	}
+	diffs, err := svr.actionStateDiffs(height, withStateDiff)
+	if err != nil {
+		return nil, err
+	}
+	return newReplayTraceResult(diffs[actHash], withStateDiff)
+}
 
+// actionStateDiffs returns the state diffs of the actions in the block at height
+func (svr *web3Handler) actionStateDiffs(height uint64, withStateDiff bool) (map[hash.Hash256]*apipb.ActionStateDiff, error) {
+	ret := map[hash.Hash256]*apipb.ActionStateDiff{}
+	if !withStateDiff {
+		return ret, nil
+	}
+	diffs, err := svr.coreService.StateDiff(context.Background(), height)
+	if err != nil {
+		return nil, err
+	}
+	for _, d := range diffs {
+		if d.ActionHash == hash.ZeroHash256 {
+			continue
+		}
+		diffPb, err := toActionStateDiffPb(d)
+		if err != nil {
+			return nil, err
+		}
+		ret[d.ActionHash] = diffPb
+	}
+	return ret, nil
 }
 
 func (svr *web3Handler) getBlockTransactionCountByNumber(in *gjson.Result) (interface{}, error) {
@@ -646,6 +875,59 @@ func (svr *web3Handler) getStorageAt(in *gjson.Result) (interface{}, error) {
 	return "0x" + hex.EncodeToString(val), nil
 }
 
+// storageRangeAt returns the storage of a contract before the transaction of txIndex in the block. Only the states at
+// the beginning and the end of a block are available, i.e., txIndex is either 0 or the number of transactions
+func (svr *web3Handler) storageRangeAt(in *gjson.Result) (interface{}, error) {
+	blkHash, txIndex, ethAddr, keyStart, maxResult := in.Get("params.0"), in.Get("params.1"), in.Get("params.2"), in.Get("params.3"), in.Get("params.4")
+	if !blkHash.Exists() || !txIndex.Exists() || !ethAddr.Exists() || !keyStart.Exists() || !maxResult.Exists() {
+		return nil, errInvalidFormat
+	}
+	blk, err := svr.coreService.BlockByHash(util.Remove0xPrefix(blkHash.String()))
+	if err != nil {
+		return nil, err
+	}
+	height := blk.Block.Height()
+	switch txIndex.Uint() {
+	case uint64(len(blk.Block.Actions)):
+	case 0:
+		height--
+	default:
+		return nil, errors.Wrapf(errNotImplemented, "storage at transaction %d of block %d", txIndex.Uint(), height)
+	}
+	contractAddr, err := address.FromHex(ethAddr.String())
+	if err != nil {
+		return nil, err
+	}
+	start, err := hexToBytes(keyStart.String())
+	if err != nil {
+		return nil, err
+	}
+	slots, next, err := svr.coreService.ContractStorageRange(context.Background(), contractAddr, height, start, uint32(maxResult.Uint()))
+	if err != nil {
+		return nil, err
+	}
+	ret := &storageRangeResult{
+		Storage: make(map[string]*storageEntry, len(slots)),
+	}
+	for _, slot := range slots {
+		key := "0x" + hex.EncodeToString(slot.Key[:])
+		entry := &storageEntry{
+			Key:   key,
+			Value: "0x" + hex.EncodeToString(slot.Value),
+		}
+		if len(slot.Preimage) > 0 {
+			preimage := "0x" + hex.EncodeToString(slot.Preimage)
+			entry.Preimage = &preimage
+		}
+		ret.Storage[key] = entry
+	}
+	if next != nil {
+		nextKey := "0x" + hex.EncodeToString(next)
+		ret.NextKey = &nextKey
+	}
+	return ret, nil
+}
+
 func (svr *web3Handler) newFilter(filter *filterObject) (interface{}, error) {
 	//check the validity of filter before caching
 	if filter == nil {
@@ -722,21 +1004,44 @@ func (svr *web3Handler) getFilterChanges(in *gjson.Result) (interface{}, error)
 	)
 	switch filterObj.FilterType {
 	case "log":
-		if filterObj.LogHeight > tipHeight {
-			return []*getLogsResult{}, nil
-		}
-		from, to, hasNewLogs, err := svr.getLogQueryRange(filterObj.FromBlock, filterObj.ToBlock, filterObj.LogHeight)
-		if err != nil {
-			return nil, err
-		}
-		if !hasNewLogs {
-			return []*getLogsResult{}, nil
+		logs := []*getLogsResult{}
+		newLogHeight = filterObj.LogHeight
+		if filterObj.LogHeight <= tipHeight {
+			from, to, hasNewLogs, err := svr.getLogQueryRange(filterObj.FromBlock, filterObj.ToBlock, filterObj.LogHeight)
+			if err != nil {
+				return nil, err
+			}
+			if hasNewLogs {
+				if logs, err = svr.getLogsWithFilter(from, to, filterObj.Address, filterObj.Topics); err != nil {
+					return nil, err
+				}
+				newLogHeight = tipHeight + 1
+			}
 		}
-		logs, err := svr.getLogsWithFilter(from, to, filterObj.Address, filterObj.Topics)
-		if err != nil {
-			return nil, err
+		if filterObj.ToBlock == _pendingBlockNumber {
+			// the logs of a pending action are returned once while it stays pending, since the pending block is
+			// rebuilt and gets a new hash periodically, and are returned again once the action gets mined
+			pendingLogs, err := svr.getPendingLogsWithFilter(filterObj.Address, filterObj.Topics)
+			if err != nil {
+				return nil, err
+			}
+			returned := make(map[string]bool, len(filterObj.PendingActions))
+			for _, h := range filterObj.PendingActions {
+				returned[h] = true
+			}
+			pendingActions := []string{}
+			for _, l := range pendingLogs {
+				h := hex.EncodeToString(l.log.ActionHash[:])
+				if !returned[h] {
+					logs = append(logs, l)
+				}
+				if len(pendingActions) == 0 || pendingActions[len(pendingActions)-1] != h {
+					pendingActions = append(pendingActions, h)
+				}
+			}
+			filterObj.PendingActions = pendingActions
 		}
-		ret, newLogHeight = logs, tipHeight+1
+		ret = logs
 	case "block":
 		if filterObj.LogHeight > tipHeight {
 			return []string{}, nil
@@ -778,11 +1083,7 @@ func (svr *web3Handler) getFilterLogs(in *gjson.Result) (interface{}, error) {
 	if filterObj.FilterType != "log" {
 		return nil, errInvalidFilterID
 	}
-	from, to, err := svr.parseBlockRange(filterObj.FromBlock, filterObj.ToBlock)
-	if err != nil {
-		return nil, err
-	}
-	return svr.getLogsWithFilter(from, to, filterObj.Address, filterObj.Topics)
+	return svr.getLogs(&filterObj)
 }
 
 func (svr *web3Handler) subscribe(in *gjson.Result, writer apitypes.Web3ResponseWriter) (interface{}, error) {
@@ -14,6 +14,7 @@ import (
 	"math/big"
 	"math/rand"
 	"strconv"
+	"strings"
 	"testing"
 	"time"
 
@@ -26,9 +27,12 @@ import (
 	"google.golang.org/protobuf/proto"
 
 	"github.com/iotexproject/iotex-core/action"
+	"github.com/iotexproject/iotex-core/action/protocol"
 	"github.com/iotexproject/iotex-core/actpool"
+	apitypes "github.com/iotexproject/iotex-core/api/types"
 	"github.com/iotexproject/iotex-core/blockchain"
 	"github.com/iotexproject/iotex-core/blockchain/blockdao"
+	"github.com/iotexproject/iotex-core/config"
 	"github.com/iotexproject/iotex-core/test/identityset"
 	"github.com/iotexproject/iotex-core/testutil"
 )
@@ -227,6 +231,219 @@ func TestEstimateGasIntegrity(t *testing.T) {
 	}
 }
 
+func TestCreateAccessListIntegrity(t *testing.T) {
+	require := require.New(t)
+	cfg := newConfig()
+	// activate access list after the testing blocks
+	cfg.Genesis.HawaiiBlockHeight = 0
+	cfg.Genesis.IcelandBlockHeight = 0
+	cfg.Genesis.JutlandBlockHeight = 0
+	cfg.Genesis.KamchatkaBlockHeight = 0
+	cfg.Genesis.LordHoweBlockHeight = 0
+	cfg.Genesis.MidwayBlockHeight = 0
+	cfg.Genesis.NewfoundlandBlockHeight = 0
+	cfg.Genesis.OkhotskBlockHeight = 5
+	svr, bc, dao, actPool, cleanCallback := setupTestWeb3ServerWithConfig(cfg)
+	defer cleanCallback()
+
+	// the contract stores a number in slot 0 with set(uint256), and returns it with get()
+	contractCode := "608060405234801561001057600080fd5b50610150806100206000396000f3fe608060405234801561001057600080fd5b50600436106100365760003560e01c806360fe47b11461003b5780636d4ce63c14610057575b600080fd5b6100556004803603810190610050919061009d565b610075565b005b61005f61007f565b60405161006c91906100d9565b60405180910390f35b8060008190555050565b60008054905090565b60008135905061009781610103565b92915050565b6000602082840312156100b3576100b26100fe565b5b60006100c184828501610088565b91505092915050565b6100d3816100f4565b82525050565b60006020820190506100ee60008301846100ca565b92915050565b6000819050919050565b600080fd5b61010c816100f4565b811461011757600080fd5b5056fea2646970667358221220c86a8c4dd175f55f5732b75b721d714ceb38a835b87c6cf37cf28c790813e19064736f6c63430008070033"
+	contract, err := deployContractV2(bc, dao, actPool, identityset.PrivateKey(13), 1, bc.TipHeight(), contractCode)
+	require.NoError(err)
+	fromAddr, _ := ioAddrToEthAddr(identityset.Address(0).String())
+	contractAddr, _ := ioAddrToEthAddr(contract)
+	input := gjson.Parse(fmt.Sprintf(`{"params": [{"from": "%s", "to": "%s", "data": "0x6d4ce63c"}, "latest"]}`,
+		fromAddr, contractAddr))
+
+	ret, err := svr.createAccessList(&input)
+	require.NoError(err)
+	res := ret.(*createAccessListResult)
+	require.Empty(res.Error)
+	require.Len(res.AccessList, 1)
+	require.Equal(contractAddr, res.AccessList[0].Address.Hex())
+	require.Equal([]common.Hash{{}}, res.AccessList[0].StorageKeys)
+	gasUsed, err := hexStringToNumber(res.GasUsed)
+	require.NoError(err)
+
+	// eth_estimateGas takes the access list in call object
+	input = gjson.Parse(fmt.Sprintf(`{"params": [{"from": "%s", "to": "%s", "data": "0x6d4ce63c", "accessList": [{"address": "%s", "storageKeys": ["%s"]}]}, "latest"]}`,
+		fromAddr, contractAddr, contractAddr, common.Hash{}.Hex()))
+	_, err = svr.estimateGas(&input)
+	require.NoError(err)
+
+	// the gas estimated with the access list applied matches the gas used
+
+	caller := identityset.Address(0)
+	exec, err := action.NewExecution(contract, 0, big.NewInt(0), 0, big.NewInt(0), common.FromHex("0x6d4ce63c"))
+	require.NoError(err)
+	gasWithoutList, err := svr.coreService.EstimateExecutionGasConsumption(context.Background(), exec, caller)
+	require.NoError(err)
+	exec, err = action.NewExecution(contract, 0, big.NewInt(0), 0, big.NewInt(0), common.FromHex("0x6d4ce63c"))
+	require.NoError(err)
+	gasWithList, err := svr.coreService.EstimateExecutionGasConsumption(context.Background(), exec, caller, apitypes.EstimateWithAccessList())
+	require.NoError(err)
+	require.Equal(gasUsed, gasWithList)
+	require.NotEqual(gasWithoutList, gasWithList)
+
+	// eth_estimateGas estimates exactly what the caller sent, no list is created for an access list transaction
+	setData := "0x60fe47b1" + strings.Repeat("0", 63) + "1"
+	exec, err = action.NewExecution(contract, 0, big.NewInt(0), 0, big.NewInt(0), common.FromHex(setData))
+	require.NoError(err)
+	gasWithoutList, err = svr.coreService.EstimateExecutionGasConsumption(context.Background(), exec, caller)
+	require.NoError(err)
+	exec, err = action.NewExecution(contract, 0, big.NewInt(0), 0, big.NewInt(0), common.FromHex(setData))
+	require.NoError(err)
+	gasWithList, err = svr.coreService.EstimateExecutionGasConsumption(context.Background(), exec, caller, apitypes.EstimateWithAccessList())
+	require.NoError(err)
+	require.NotEqual(gasWithoutList, gasWithList)
+	input = gjson.Parse(fmt.Sprintf(`{"params": [{"from": "%s", "to": "%s", "data": "%s", "type": "0x1"}, "latest"]}`,
+		fromAddr, contractAddr, setData))
+	ret, err = svr.estimateGas(&input)
+	require.NoError(err)
+	require.Equal(uint64ToHex(gasWithoutList), ret)
+	input = gjson.Parse(fmt.Sprintf(`{"params": [{"from": "%s", "to": "%s", "data": "%s"}, "latest"]}`,
+		fromAddr, contractAddr, setData))
+	ret, err = svr.estimateGas(&input)
+	require.NoError(err)
+	require.Equal(uint64ToHex(gasWithoutList), ret)
+}
+
+func TestReplayBlockTransactionsIntegrity(t *testing.T) {
+	require := require.New(t)
+	// the contract stores a number in slot 0 with set(uint256), and returns it with get()
+	contractCode := "608060405234801561001057600080fd5b50610150806100206000396000f3fe608060405234801561001057600080fd5b50600436106100365760003560e01c806360fe47b11461003b5780636d4ce63c14610057575b600080fd5b6100556004803603810190610050919061009d565b610075565b005b61005f61007f565b60405161006c91906100d9565b60405180910390f35b8060008190555050565b60008054905090565b60008135905061009781610103565b92915050565b6000602082840312156100b3576100b26100fe565b5b60006100c184828501610088565b91505092915050565b6100d3816100f4565b82525050565b60006020820190506100ee60008301846100ca565b92915050565b6000819050919050565b600080fd5b61010c816100f4565b811461011757600080fd5b5056fea2646970667358221220c86a8c4dd175f55f5732b75b721d714ceb38a835b87c6cf37cf28c790813e19064736f6c63430008070033"
+	for _, enableIndex := range []bool{false, true} {
+		cfg := newConfig()
+		// the state diffs are either replayed on the archive data, or read from the state diff index
+		cfg.Chain.EnableArchiveMode = !enableIndex
+		cfg.Chain.EnableStateDiffIndex = enableIndex
+		svr, bc, dao, actPool, cleanCallback := setupTestWeb3ServerWithConfig(cfg)
+
+		contract, err := deployContractV2(bc, dao, actPool, identityset.PrivateKey(13), 1, bc.TipHeight(), contractCode)
+		require.NoError(err)
+		deployHeight := bc.TipHeight()
+		contractAddr, _ := ioAddrToEthAddr(contract)
+		callerAddr, _ := ioAddrToEthAddr(identityset.Address(13).String())
+		// set(42)
+		ex, err := action.SignedExecution(contract, identityset.PrivateKey(13), 2, big.NewInt(0), 500000,
+			big.NewInt(1), common.FromHex("0x60fe47b1"+common.BigToHash(big.NewInt(42)).Hex()[2:]))
+		require.NoError(err)
+		require.NoError(actPool.Add(context.Background(), ex))
+		blk, err := bc.MintNewBlock(testutil.TimestampNow())
+		require.NoError(err)
+		require.NoError(bc.CommitBlock(blk))
+		actPool.Reset()
+		exHash, err := ex.Hash()
+		require.NoError(err)
+
+		input := gjson.Parse(fmt.Sprintf(`{"params": ["%s", ["stateDiff"]]}`, uint64ToHex(bc.TipHeight())))
+		ret, err := svr.replayBlockTransactions(&input)
+		require.NoError(err)
+		results := ret.([]*replayTraceResult)
+		// the execution and the grant of block reward
+		require.Len(results, 2)
+		res := results[0]
+		require.Equal("0x"+hex.EncodeToString(exHash[:]), res.TransactionHash)
+		contractDiff := res.StateDiff[contractAddr]
+		require.NotNil(contractDiff)
+		require.Equal("=", contractDiff.Balance)
+		require.Equal("=", contractDiff.Code)
+		require.Equal(map[string]interface{}{
+			common.Hash{}.Hex(): map[string]string{"+": common.BigToHash(big.NewInt(42)).Hex()},
+		}, contractDiff.Storage)
+		callerDiff := res.StateDiff[callerAddr]
+		require.NotNil(callerDiff)
+		require.Equal(map[string]map[string]string{"*": {"from": "0x2", "to": "0x3"}}, callerDiff.Nonce)
+		require.Contains(callerDiff.Balance, "*")
+		require.Empty(callerDiff.Storage)
+
+		// the contract is born in the deployment
+		input = gjson.Parse(fmt.Sprintf(`{"params": ["%s", ["stateDiff"]]}`, uint64ToHex(deployHeight)))
+		ret, err = svr.replayBlockTransactions(&input)
+		require.NoError(err)
+		contractDiff = ret.([]*replayTraceResult)[0].StateDiff[contractAddr]
+		require.NotNil(contractDiff)
+		require.Equal(map[string]string{"+": "0x0"}, contractDiff.Balance)
+		code, ok := contractDiff.Code.(map[string]string)
+		require.True(ok)
+		require.Contains(contractCode, util.Remove0xPrefix(code["+"]))
+
+		// a single action is replayed by its hash
+		input = gjson.Parse(fmt.Sprintf(`{"params": ["0x%s", ["stateDiff"]]}`, hex.EncodeToString(exHash[:])))
+		ret, err = svr.replayTransaction(&input)
+		require.NoError(err)
+		require.Equal(res.StateDiff, ret.(*replayTraceResult).StateDiff)
+
+		input = gjson.Parse(fmt.Sprintf(`{"params": ["0x%s", ["vmTrace"]]}`, hex.EncodeToString(exHash[:])))
+		_, err = svr.replayTransaction(&input)
+		require.Error(err)
+		cleanCallback()
+	}
+
+	// the state diffs are not available without archive data or index
+	svr, bc, _, _, cleanCallback := setupTestWeb3Server()
+	defer cleanCallback()
+	input := gjson.Parse(fmt.Sprintf(`{"params": ["%s", ["stateDiff"]]}`, uint64ToHex(bc.TipHeight())))
+	_, err := svr.replayBlockTransactions(&input)
+	require.Error(err)
+}
+
+func TestStorageRangeAtIntegrity(t *testing.T) {
+	require := require.New(t)
+	// the contract stores a number in slot 0 with set(uint256), and returns it with get()
+	contractCode := "608060405234801561001057600080fd5b50610150806100206000396000f3fe608060405234801561001057600080fd5b50600436106100365760003560e01c806360fe47b11461003b5780636d4ce63c14610057575b600080fd5b6100556004803603810190610050919061009d565b610075565b005b61005f61007f565b60405161006c91906100d9565b60405180910390f35b8060008190555050565b60008054905090565b60008135905061009781610103565b92915050565b6000602082840312156100b3576100b26100fe565b5b60006100c184828501610088565b91505092915050565b6100d3816100f4565b82525050565b60006020820190506100ee60008301846100ca565b92915050565b6000819050919050565b600080fd5b61010c816100f4565b811461011757600080fd5b5056fea2646970667358221220c86a8c4dd175f55f5732b75b721d714ceb38a835b87c6cf37cf28c790813e19064736f6c63430008070033"
+	for _, archive := range []bool{false, true} {
+		cfg := newConfig()
+		cfg.Chain.EnableArchiveMode = archive
+		svr, bc, dao, actPool, cleanCallback := setupTestWeb3ServerWithConfig(cfg)
+
+		contract, err := deployContractV2(bc, dao, actPool, identityset.PrivateKey(13), 1, bc.TipHeight(), contractCode)
+		require.NoError(err)
+		contractAddr, _ := ioAddrToEthAddr(contract)
+		// set(42)
+		ex, err := action.SignedExecution(contract, identityset.PrivateKey(13), 2, big.NewInt(0), 500000,
+			big.NewInt(0), common.FromHex("0x60fe47b1"+common.BigToHash(big.NewInt(42)).Hex()[2:]))
+		require.NoError(err)
+		require.NoError(actPool.Add(context.Background(), ex))
+		blk, err := bc.MintNewBlock(testutil.TimestampNow())
+		require.NoError(err)
+		require.NoError(bc.CommitBlock(blk))
+		actPool.Reset()
+		blkHash := blk.HashBlock()
+
+		// the storage at the end of the block, i.e., at the tip
+		input := gjson.Parse(fmt.Sprintf(`{"params": ["0x%s", %d, "%s", "0x00", 10]}`,
+			hex.EncodeToString(blkHash[:]), len(blk.Actions), contractAddr))
+		ret, err := svr.storageRangeAt(&input)
+		require.NoError(err)
+		slot := common.Hash{}.Hex()
+		require.Equal(&storageRangeResult{
+			Storage: map[string]*storageEntry{
+				slot: {Key: slot, Value: common.BigToHash(big.NewInt(42)).Hex()},
+			},
+		}, ret)
+
+		// the storage at the beginning of the block is read from the archive data
+		input = gjson.Parse(fmt.Sprintf(`{"params": ["0x%s", 0, "%s", "0x00", 10]}`,
+			hex.EncodeToString(blkHash[:]), contractAddr))
+		ret, err = svr.storageRangeAt(&input)
+		if archive {
+			require.NoError(err)
+			require.Empty(ret.(*storageRangeResult).Storage)
+			require.Nil(ret.(*storageRangeResult).NextKey)
+		} else {
+			require.Error(err)
+		}
+
+		// the storage in the middle of the block is not available
+		input = gjson.Parse(fmt.Sprintf(`{"params": ["0x%s", 1, "%s", "0x00", 10]}`,
+			hex.EncodeToString(blkHash[:]), contractAddr))
+		_, err = svr.storageRangeAt(&input)
+		require.Error(err)
+		cleanCallback()
+	}
+}
+
 func TestSendRawTransactionIntegrity(t *testing.T) {
 	require := require.New(t)
 	svr, _, _, _, cleanCallback := setupTestWeb3Server()
@@ -356,6 +573,7 @@ func TestGetTransactionReceiptIntegrity(t *testing.T) {
 	require.Equal(toAddr, *ans.to)
 	require.Nil(nil, ans.contractAddress)
 	require.Equal(uint64(10000), ans.receipt.GasConsumed)
+	require.Equal(uint64(10000), ans.cumulativeGasUsed)
 	require.Equal(uint64(1), ans.receipt.BlockHeight)
 
 	testData2 := gjson.Parse(`{"params": ["0x58df1e9cb0572fea48e8ce9d9b787ae557c304657d01890f4fc5ea88a1f44c3e", 1]}`)
@@ -364,6 +582,37 @@ func TestGetTransactionReceiptIntegrity(t *testing.T) {
 	require.Nil(ret)
 }
 
+func TestGetBlockReceiptsIntegrity(t *testing.T) {
+	require := require.New(t)
+	svr, _, _, _, cleanCallback := setupTestWeb3Server()
+	defer cleanCallback()
+
+	testData := gjson.Parse(`{"params": ["0x1"]}`)
+	ret, err := svr.getBlockReceipts(&testData)
+	require.NoError(err)
+	receipts, ok := ret.([]*getReceiptResult)
+	require.True(ok)
+	require.NotEmpty(receipts)
+	require.Equal(_transferHash1, receipts[0].receipt.ActionHash)
+	var cumulativeGasUsed uint64
+	for _, r := range receipts {
+		cumulativeGasUsed += r.receipt.GasConsumed
+		require.Equal(cumulativeGasUsed, r.cumulativeGasUsed)
+		require.Equal(uint64(1), r.receipt.BlockHeight)
+	}
+
+	// by block hash
+	testData = gjson.Parse(fmt.Sprintf(`{"params": ["0x%s"]}`, hex.EncodeToString(receipts[0].blockHash[:])))
+	ret, err = svr.getBlockReceipts(&testData)
+	require.NoError(err)
+	require.Len(ret.([]*getReceiptResult), len(receipts))
+
+	testData = gjson.Parse(`{"params": ["0x10000"]}`)
+	ret, err = svr.getBlockReceipts(&testData)
+	require.NoError(err)
+	require.Nil(ret)
+}
+
 func TestGetBlockTransactionCountByNumberIntegrity(t *testing.T) {
 	require := require.New(t)
 	svr, _, _, _, cleanCallback := setupTestWeb3Server()
@@ -546,9 +795,85 @@ func TestGetNetworkIDIntegrity(t *testing.T) {
 	require.Equal(fmt.Sprintf("%d", _evmNetworkID), res)
 }
 
+func TestPendingBlockIntegrity(t *testing.T) {
+	require := require.New(t)
+	svr, _, _, ap, cleanCallback := setupTestWeb3Server()
+	defer cleanCallback()
+
+	getCount := func(addr string, blkNum string) interface{} {
+		data := gjson.Parse(fmt.Sprintf(`{"params": ["%s", "%s"]}`, addr, blkNum))
+		ret, err := svr.getTransactionCount(&data)
+		require.NoError(err)
+		return ret
+	}
+	getBalance := func(addr string, blkNum string) interface{} {
+		data := gjson.Parse(fmt.Sprintf(`{"params": ["%s", "%s"]}`, addr, blkNum))
+		ret, err := svr.getBalance(&data)
+		require.NoError(err)
+		return ret
+	}
+	getBlock := func() *getBlockResult {
+		data := gjson.Parse(`{"params": ["pending", false]}`)
+		ret, err := svr.getBlockByNumber(&data)
+		require.NoError(err)
+		return ret.(*getBlockResult)
+	}
+	sender, recipient := identityset.Address(27).Hex(), identityset.Address(28).Hex()
+
+	// with an empty actpool, the pending block only has the reward action
+	blk := getBlock()
+	require.EqualValues(5, blk.blk.Height())
+	require.Len(blk.transactions, 1)
+	require.Equal(getCount(sender, "latest"), getCount(sender, "pending"))
+	require.Equal(getBalance(recipient, "latest"), getBalance(recipient, "pending"))
+
+	ctx := protocol.WithRegistry(context.Background(), svr.coreService.(*coreService).registry)
+	require.NoError(addActsToActPool(ctx, ap))
+	// the pending block is not rebuilt within the interval
+	require.Len(getBlock().transactions, 1)
+	svr.coreService.(*coreService).pendingBlock.interval = 0
+	blk = getBlock()
+	require.EqualValues(5, blk.blk.Height())
+	require.Len(blk.transactions, 5)
+	require.Equal(uint64ToHex(6), getCount(sender, "pending"))
+	balance, ok := new(big.Int).SetString(util.Remove0xPrefix(getBalance(recipient, "latest").(string)), 16)
+	require.True(ok)
+	pendingBalance := "0x" + balance.Add(balance, big.NewInt(20)).Text(16)
+	require.Equal(pendingBalance, getBalance(recipient, "pending"))
+
+	// eth_call runs on top of the pending state, which is not changed by the call
+	callData := gjson.Parse(fmt.Sprintf(`{"params": [{"from": "%s", "to": "%s", "value": "0x1"}, "pending"]}`,
+		sender, recipient))
+	_, err := svr.call(&callData)
+	require.NoError(err)
+	require.Equal(pendingBalance, getBalance(recipient, "pending"))
+
+	// pending log filters
+	logs, err := svr.getLogs(&filterObject{FromBlock: "0x1", ToBlock: "pending"})
+	require.NoError(err)
+	require.Len(logs, 4)
+	filterID, err := svr.newFilter(&filterObject{FromBlock: "0x1", ToBlock: "pending"})
+	require.NoError(err)
+	filterIDReq := gjson.Parse(fmt.Sprintf(`{"params":["%s"]}`, filterID.(string)))
+	ret, err := svr.getFilterChanges(&filterIDReq)
+	require.NoError(err)
+	require.Len(ret, 4)
+	// the pending block rebuilt gets a new hash, while the logs of the actions still pending are not returned again
+	pendingHash := getBlock().blk.HashBlock()
+	ret, err = svr.getFilterChanges(&filterIDReq)
+	require.NoError(err)
+	require.Len(ret, 0)
+	require.NotEqual(pendingHash, getBlock().blk.HashBlock())
+	ret, err = svr.getFilterChanges(&filterIDReq)
+	require.NoError(err)
+	require.Len(ret, 0)
+}
+
 func setupTestWeb3Server() (*web3Handler, blockchain.Blockchain, blockdao.BlockDAO, actpool.ActPool, func()) {
-	cfg := newConfig()
+	return setupTestWeb3ServerWithConfig(newConfig())
+}
 
+func setupTestWeb3ServerWithConfig(cfg config.Config) (*web3Handler, blockchain.Blockchain, blockdao.BlockDAO, actpool.ActPool, func()) {
 	// TODO (zhi): revise
 	bc, dao, indexer, bfIndexer, sf, ap, registry, bfIndexFile, err := setupChain(cfg)
 	if err != nil {
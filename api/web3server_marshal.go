@@ -3,6 +3,7 @@ package api
 import (
 	"encoding/hex"
 	"encoding/json"
+	"math/big"
 
 	"github.com/ethereum/go-ethereum/core/types"
 	"github.com/iotexproject/go-pkgs/crypto"
@@ -57,12 +58,14 @@ type (
 	}
 
 	getReceiptResult struct {
-		blockHash       hash.Hash256
-		from            address.Address
-		to              *string
-		contractAddress *string
-		logsBloom       string
-		receipt         *action.Receipt
+		blockHash         hash.Hash256
+		from              address.Address
+		to                *string
+		contractAddress   *string
+		effectiveGasPrice *big.Int
+		cumulativeGasUsed uint64
+		txType            uint8
+		receipt           *action.Receipt
 	}
 
 	getLogsResult struct {
@@ -75,6 +78,42 @@ type (
 		CurrentBlock  string `json:"currentBlock"`
 		HighestBlock  string `json:"highestBlock"`
 	}
+
+	createAccessListResult struct {
+		AccessList types.AccessList `json:"accessList"`
+		GasUsed    string           `json:"gasUsed"`
+		Error      string           `json:"error,omitempty"`
+	}
+
+	replayTraceResult struct {
+		Output          string                       `json:"output"`
+		StateDiff       map[string]*accountStateDiff `json:"stateDiff"`
+		Trace           []interface{}                `json:"trace"`
+		VMTrace         interface{}                  `json:"vmTrace"`
+		TransactionHash string                       `json:"transactionHash,omitempty"`
+	}
+
+	// accountStateDiff follows the state diff of parity traces, each field is either "=" if unchanged, or a
+	// map of "+" to the value born, "-" to the value died, or "*" to the values changed from and to
+	accountStateDiff struct {
+		Balance interface{}            `json:"balance"`
+		Nonce   interface{}            `json:"nonce"`
+		Code    interface{}            `json:"code"`
+		Storage map[string]interface{} `json:"storage"`
+	}
+
+	// storageRangeResult follows the result of debug_storageRangeAt, the storage is keyed by the keys of the
+	// storage trie, which are the slots themselves
+	storageRangeResult struct {
+		Storage map[string]*storageEntry `json:"storage"`
+		NextKey *string                  `json:"nextKey"`
+	}
+
+	storageEntry struct {
+		Key      string  `json:"key"`
+		Value    string  `json:"value"`
+		Preimage *string `json:"preimage,omitempty"`
+	}
 )
 
 var (
@@ -263,6 +302,14 @@ func (obj *getReceiptResult) MarshalJSON() ([]byte, error) {
 	for _, v := range obj.receipt.Logs() {
 		logs = append(logs, &getLogsResult{obj.blockHash, v})
 	}
+	logsBloom, err := receiptLogsBloom(obj.receipt)
+	if err != nil {
+		return nil, err
+	}
+	effectiveGasPrice := "0x0"
+	if obj.effectiveGasPrice != nil {
+		effectiveGasPrice = "0x" + obj.effectiveGasPrice.Text(16)
+	}
 
 	return json.Marshal(&struct {
 		TransactionIndex  string           `json:"transactionIndex"`
@@ -273,10 +320,12 @@ func (obj *getReceiptResult) MarshalJSON() ([]byte, error) {
 		To                *string          `json:"to"`
 		CumulativeGasUsed string           `json:"cumulativeGasUsed"`
 		GasUsed           string           `json:"gasUsed"`
+		EffectiveGasPrice string           `json:"effectiveGasPrice"`
 		ContractAddress   *string          `json:"contractAddress"`
 		LogsBloom         string           `json:"logsBloom"`
 		Logs              []*getLogsResult `json:"logs"`
 		Status            string           `json:"status"`
+		Type              string           `json:"type"`
 	}{
 		TransactionIndex:  uint64ToHex(uint64(obj.receipt.TxIndex)),
 		TransactionHash:   "0x" + hex.EncodeToString(obj.receipt.ActionHash[:]),
@@ -284,15 +333,33 @@ func (obj *getReceiptResult) MarshalJSON() ([]byte, error) {
 		BlockNumber:       uint64ToHex(obj.receipt.BlockHeight),
 		From:              obj.from.Hex(),
 		To:                obj.to,
-		CumulativeGasUsed: uint64ToHex(obj.receipt.GasConsumed),
+		CumulativeGasUsed: uint64ToHex(obj.cumulativeGasUsed),
 		GasUsed:           uint64ToHex(obj.receipt.GasConsumed),
+		EffectiveGasPrice: effectiveGasPrice,
 		ContractAddress:   obj.contractAddress,
-		LogsBloom:         getLogsBloomHex(obj.logsBloom),
+		LogsBloom:         logsBloom,
 		Logs:              logs,
 		Status:            uint64ToHex(obj.receipt.Status),
+		Type:              uint64ToHex(uint64(obj.txType)),
 	})
 }
 
+// receiptLogsBloom returns the ethereum bloom filter of the addresses and topics of the logs in receipt
+func receiptLogsBloom(receipt *action.Receipt) (string, error) {
+	var bloom types.Bloom
+	for _, l := range receipt.Logs() {
+		addr, err := address.FromString(l.Address)
+		if err != nil {
+			return "", err
+		}
+		bloom.Add(addr.Bytes())
+		for _, topic := range l.Topics {
+			bloom.Add(topic[:])
+		}
+	}
+	return "0x" + hex.EncodeToString(bloom[:]), nil
+}
+
 func (obj *getLogsResult) MarshalJSON() ([]byte, error) {
 	if obj.log == nil {
 		return nil, errInvalidObject
@@ -7,6 +7,7 @@ import (
 	"testing"
 	"time"
 
+	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/core/types"
 	"github.com/iotexproject/go-pkgs/crypto"
 	"github.com/iotexproject/go-pkgs/hash"
@@ -271,12 +272,13 @@ func TestReceiptObjectMarshal(t *testing.T) {
 	t.Run("ContractCreation", func(t *testing.T) {
 		contractEthaddr, _ := ioAddrToEthAddr(_testContractIoAddr)
 		res, err := json.Marshal(&getReceiptResult{
-			blockHash:       _testBlkHash,
-			from:            _testSenderIoAddr,
-			to:              nil,
-			contractAddress: &contractEthaddr,
-			logsBloom:       "00008000000100000400000000000040000000000000000000000000000000000000000001000200000400000000000000000000001000000000000000001000000000001000000000200000004000000000000000000101000000000000000008000008000208000000000000400000000000000000000000000000000000000000080010000000000200010000000000000500000000000000000000000000004080000000000000001000000800020000000000000000000000000000000000000000000000000000000000000000000800000000000000000000000000000000000000000000000400000000000000000000000000080000400010200000",
-			receipt:         receipt,
+			blockHash:         _testBlkHash,
+			from:              _testSenderIoAddr,
+			to:                nil,
+			contractAddress:   &contractEthaddr,
+			effectiveGasPrice: big.NewInt(1000000000000),
+			cumulativeGasUsed: 42000,
+			receipt:           receipt,
 		})
 		require.NoError(err)
 		require.JSONEq(`
@@ -287,14 +289,16 @@ func TestReceiptObjectMarshal(t *testing.T) {
 			"blockNumber":"0x10",
 			"from":"0xa576c141e5659137ddda4223d209d4744b2106be",
 			"to":null,
-			"cumulativeGasUsed":"0x5208",
+			"cumulativeGasUsed":"0xa410",
 			"gasUsed":"0x5208",
+			"effectiveGasPrice":"0xe8d4a51000",
 			"contractAddress":"0x19088c581273F5E53f082CB4BB396119b959231D",
-			"logsBloom":"0x00008000000100000400000000000040000000000000000000000000000000000000000001000200000400000000000000000000001000000000000000001000000000001000000000200000004000000000000000000101000000000000000008000008000208000000000000400000000000000000000000000000000000000000080010000000000200010000000000000500000000000000000000000000004080000000000000001000000800020000000000000000000000000000000000000000000000000000000000000000000800000000000000000000000000000000000000000000000400000000000000000000000000080000400010200000",
+			"logsBloom":"0x00000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000",
 			"logs":[
 			   
 			],
-			"status":"0x1"
+			"status":"0x1",
+			"type":"0x0"
 		 }
 		`, string(res))
 	})
@@ -312,12 +316,13 @@ func TestReceiptObjectMarshal(t *testing.T) {
 		})
 		contractEthaddr, _ := ioAddrToEthAddr(_testContractIoAddr)
 		res, err := json.Marshal(&getReceiptResult{
-			blockHash:       _testBlkHash,
-			from:            _testSenderIoAddr,
-			to:              &contractEthaddr,
-			contractAddress: nil,
-			logsBloom:       "00008000000100000400000000000040000000000000000000000000000000000000000001000200000400000000000000000000001000000000000000001000000000001000000000200000004000000000000000000101000000000000000008000008000208000000000000400000000000000000000000000000000000000000080010000000000200010000000000000500000000000000000000000000004080000000000000001000000800020000000000000000000000000000000000000000000000000000000000000000000800000000000000000000000000000000000000000000000400000000000000000000000000080000400010200000",
-			receipt:         receipt,
+			blockHash:         _testBlkHash,
+			from:              _testSenderIoAddr,
+			to:                &contractEthaddr,
+			contractAddress:   nil,
+			effectiveGasPrice: big.NewInt(1000000000000),
+			cumulativeGasUsed: 42000,
+			receipt:           receipt,
 		})
 		require.NoError(err)
 		require.JSONEq(`
@@ -328,10 +333,11 @@ func TestReceiptObjectMarshal(t *testing.T) {
 			"blockNumber":"0x10",
 			"from":"0xa576c141e5659137ddda4223d209d4744b2106be",
 			"to":"0x19088c581273F5E53f082CB4BB396119b959231D",
-			"cumulativeGasUsed":"0x5208",
+			"cumulativeGasUsed":"0xa410",
 			"gasUsed":"0x5208",
+			"effectiveGasPrice":"0xe8d4a51000",
 			"contractAddress":null,
-			"logsBloom":"0x00008000000100000400000000000040000000000000000000000000000000000000000001000200000400000000000000000000001000000000000000001000000000001000000000200000004000000000000000000101000000000000000008000008000208000000000000400000000000000000000000000000000000000000080010000000000200010000000000000500000000000000000000000000004080000000000000001000000800020000000000000000000000000000000000000000000000000000000000000000000800000000000000000000000000000000000000000000000400000000000000000000000000080000400010200000",
+			"logsBloom":"0x00000000000000040000000000000000000000000000080000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000008000000000000000000000000000000000000001000000000000000000000000000000000000008000000000000000010000000000008000000000000000000000000000000000000000000000000000000000000000000000000000000000040008000000000000000000000000000000000000000000002000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000800000004000000000000",
 			"logs":[
 			   {
 				  "removed":false,
@@ -349,10 +355,36 @@ func TestReceiptObjectMarshal(t *testing.T) {
 				  ]
 			   }
 			],
-			"status":"0x1"
+			"status":"0x1",
+			"type":"0x0"
 		 }
 		`, string(res))
 	})
+
+	t.Run("AccessList", func(t *testing.T) {
+		contractEthaddr, _ := ioAddrToEthAddr(_testContractIoAddr)
+		exec, err := action.NewExecution(_testContractIoAddr, 1, big.NewInt(0), 21000, big.NewInt(0), nil)
+		require.NoError(err)
+		require.Equal(types.LegacyTxType, int(ethTxType(exec)))
+		exec, err = action.NewExecutionWithAccessList(_testContractIoAddr, 1, big.NewInt(0), 21000, big.NewInt(0), nil,
+			types.AccessList{{Address: common.HexToAddress(contractEthaddr), StorageKeys: []common.Hash{{}}}})
+		require.NoError(err)
+		res, err := json.Marshal(&getReceiptResult{
+			blockHash:         _testBlkHash,
+			from:              _testSenderIoAddr,
+			to:                &contractEthaddr,
+			effectiveGasPrice: big.NewInt(1000000000000),
+			cumulativeGasUsed: 42000,
+			txType:            ethTxType(exec),
+			receipt:           receipt,
+		})
+		require.NoError(err)
+		var ret struct {
+			Type string `json:"type"`
+		}
+		require.NoError(json.Unmarshal(res, &ret))
+		require.Equal("0x1", ret.Type)
+	})
 }
 
 func TestLogsObjectMarshal(t *testing.T) {
@@ -1,6 +1,7 @@
 package api
 
 import (
+	"bytes"
 	"context"
 	"encoding/hex"
 	"encoding/json"
@@ -22,7 +23,10 @@ import (
 	"go.uber.org/zap"
 
 	"github.com/iotexproject/iotex-core/action"
+	"github.com/iotexproject/iotex-core/action/protocol/execution/evm"
+	"github.com/iotexproject/iotex-core/api/apipb"
 	logfilter "github.com/iotexproject/iotex-core/api/logfilter"
+	apitypes "github.com/iotexproject/iotex-core/api/types"
 	"github.com/iotexproject/iotex-core/blockchain/block"
 	"github.com/iotexproject/iotex-core/pkg/log"
 	"github.com/iotexproject/iotex-core/pkg/util/addrutil"
@@ -146,6 +150,62 @@ func getRecipientAndContractAddrFromAction(selp action.SealedEnvelope, receipt *
 	return &toTmp, nil, nil
 }
 
+func newReceiptResult(blkHash hash.Hash256, selp action.SealedEnvelope, receipt *action.Receipt, cumulativeGasUsed uint64) (*getReceiptResult, error) {
+	to, contractAddr, err := getRecipientAndContractAddrFromAction(selp, receipt)
+	if err != nil {
+		return nil, err
+	}
+	return &getReceiptResult{
+		blockHash:         blkHash,
+		from:              selp.SenderAddress(),
+		to:                to,
+		contractAddress:   contractAddr,
+		effectiveGasPrice: selp.GasPrice(),
+		cumulativeGasUsed: cumulativeGasUsed,
+		txType:            ethTxType(selp.Action()),
+		receipt:           receipt,
+	}, nil
+}
+
+// ethTxType returns the type of the ethereum transaction the action is converted from, an execution with access list
+// is an EIP-2930 transaction
+func ethTxType(act action.Action) uint8 {
+	if exec, ok := act.(*action.Execution); ok && len(exec.AccessList()) != 0 {
+		return types.AccessListTxType
+	}
+	return types.LegacyTxType
+}
+
+// getReceiptsFromBlock returns the receipts of the eth compatible actions in the block, while the cumulative gas used
+// counts all receipts of the block
+func getReceiptsFromBlock(blk *apitypes.BlockWithReceipts) ([]*getReceiptResult, error) {
+	if blk == nil || blk.Block == nil || len(blk.Receipts) != len(blk.Block.Actions) {
+		return nil, errInvalidBlock
+	}
+	var (
+		blkHash           = blk.Block.HashBlock()
+		cumulativeGasUsed uint64
+		receipts          = make([]*getReceiptResult, 0, len(blk.Receipts))
+	)
+	for i, receipt := range blk.Receipts {
+		cumulativeGasUsed += receipt.GasConsumed
+		selp := blk.Block.Actions[i]
+		actHash, err := selp.Hash()
+		if err != nil || actHash != receipt.ActionHash {
+			return nil, errors.Errorf("the action %s of receipt doesn't match", hex.EncodeToString(actHash[:]))
+		}
+		res, err := newReceiptResult(blkHash, selp, receipt, cumulativeGasUsed)
+		if err != nil {
+			if errors.Cause(err) == errUnsupportedAction {
+				continue
+			}
+			return nil, err
+		}
+		receipts = append(receipts, res)
+	}
+	return receipts, nil
+}
+
 func (svr *web3Handler) parseBlockNumber(str string) (uint64, error) {
 	switch str {
 	case _earliestBlockNumber:
@@ -214,6 +274,25 @@ func (svr *web3Handler) getLogsWithFilter(from uint64, to uint64, addrs []string
 	return ret, nil
 }
 
+// getPendingLogsWithFilter returns the logs in the pending block
+func (svr *web3Handler) getPendingLogsWithFilter(addrs []string, topics [][]string) ([]*getLogsResult, error) {
+	filter, err := newLogFilterFrom(addrs, topics)
+	if err != nil {
+		return nil, err
+	}
+	blk, err := svr.coreService.PendingBlock()
+	if err != nil {
+		return nil, err
+	}
+	blkHash := blk.Block.HashBlock()
+	logs := filter.MatchLogs(blk.Receipts)
+	ret := make([]*getLogsResult, 0, len(logs))
+	for _, l := range logs {
+		ret = append(ret, &getLogsResult{blkHash, l})
+	}
+	return ret, nil
+}
+
 // construct filter topics and addresses
 func newLogFilterFrom(addrs []string, topics [][]string) (*logfilter.LogFilter, error) {
 	filter := iotexapi.LogsFilter{}
@@ -238,6 +317,121 @@ func newLogFilterFrom(addrs []string, topics [][]string) (*logfilter.LogFilter,
 	return logfilter.NewLogFilter(&filter), nil
 }
 
+// parseTraceTypes returns whether the state diff is asked for, the other types of parity traces are not supported
+func parseTraceTypes(in gjson.Result) (bool, error) {
+	if !in.IsArray() {
+		return false, errInvalidFormat
+	}
+	withStateDiff := false
+	for _, t := range in.Array() {
+		switch t.String() {
+		case "stateDiff":
+			withStateDiff = true
+		case "trace", "vmTrace":
+			return false, errors.Errorf("trace type %s is not supported", t.String())
+		default:
+			return false, errors.Errorf("invalid trace type %s", t.String())
+		}
+	}
+	return withStateDiff, nil
+}
+
+func newReplayTraceResult(diff *apipb.ActionStateDiff, withStateDiff bool) (*replayTraceResult, error) {
+	ret := &replayTraceResult{
+		Output: "0x",
+		Trace:  []interface{}{},
+	}
+	if !withStateDiff {
+		return ret, nil
+	}
+	ret.StateDiff = map[string]*accountStateDiff{}
+	if diff == nil {
+		return ret, nil
+	}
+	// the code of contracts created is stored by its hash along with the account
+	codes := map[string]string{}
+	for _, s := range diff.States {
+		if s.Namespace == evm.CodeKVNameSpace && s.After != nil {
+			codes[hex.EncodeToString(s.Key)] = byteToHex(s.After)
+		}
+	}
+	code := func(acct *apipb.AccountState) *string {
+		if acct == nil {
+			return nil
+		}
+		c, ok := codes[hex.EncodeToString(acct.CodeHash)]
+		if !ok {
+			c = "0x"
+		}
+		return &c
+	}
+	for _, acct := range diff.Accounts {
+		ethAddr, err := ioAddrToEthAddr(acct.Address)
+		if err != nil {
+			return nil, err
+		}
+		var (
+			balanceBefore, balanceAfter *string
+			nonceBefore, nonceAfter     *string
+		)
+		if acct.Before != nil {
+			balance, err := intStrToHex(acct.Before.Balance)
+			if err != nil {
+				return nil, err
+			}
+			nonce := uint64ToHex(acct.Before.Nonce)
+			balanceBefore, nonceBefore = &balance, &nonce
+		}
+		if acct.After != nil {
+			balance, err := intStrToHex(acct.After.Balance)
+			if err != nil {
+				return nil, err
+			}
+			nonce := uint64ToHex(acct.After.Nonce)
+			balanceAfter, nonceAfter = &balance, &nonce
+		}
+		accountDiff := &accountStateDiff{
+			Balance: diffValue(balanceBefore, balanceAfter),
+			Nonce:   diffValue(nonceBefore, nonceAfter),
+			Code:    "=",
+			Storage: map[string]interface{}{},
+		}
+		if acct.Before == nil || acct.After == nil || !bytes.Equal(acct.Before.CodeHash, acct.After.CodeHash) {
+			accountDiff.Code = diffValue(code(acct.Before), code(acct.After))
+		}
+		for _, slot := range acct.Storage {
+			var before, after *string
+			if len(slot.Before) > 0 {
+				v := byteToHex(slot.Before)
+				before = &v
+			}
+			if len(slot.After) > 0 {
+				v := byteToHex(slot.After)
+				after = &v
+			}
+			accountDiff.Storage[byteToHex(slot.Key)] = diffValue(before, after)
+		}
+		ret.StateDiff[ethAddr] = accountDiff
+	}
+	return ret, nil
+}
+
+// diffValue returns the diff of a value in parity traces, nil stands for the value which does not exist
+func diffValue(before, after *string) interface{} {
+	switch {
+	case before == nil && after == nil:
+		return "="
+	case before == nil:
+		return map[string]string{"+": *after}
+	case after == nil:
+		return map[string]string{"-": *before}
+	case *before == *after:
+		return "="
+	default:
+		return map[string]map[string]string{"*": {"from": *before, "to": *after}}
+	}
+}
+
 func byteToHex(b []byte) string {
 	return "0x" + hex.EncodeToString(b)
 }
@@ -322,6 +516,19 @@ func parseCallObject(in *gjson.Result) (address.Address, string, uint64, *big.In
 	return from, to, gasLimit, value, data, nil
 }
 
+// parseCallAccessList parses the optional access list of the call object
+func parseCallAccessList(in *gjson.Result) (types.AccessList, error) {
+	raw := in.Get("params.0.accessList")
+	if !raw.Exists() {
+		return nil, nil
+	}
+	var accessList types.AccessList
+	if err := json.Unmarshal([]byte(raw.Raw), &accessList); err != nil {
+		return nil, errors.Wrapf(errUnkownType, "accessList: %s", raw.Raw)
+	}
+	return accessList, nil
+}
+
 func (svr *web3Handler) getLogQueryRange(fromStr, toStr string, logHeight uint64) (from uint64, to uint64, hasNewLogs bool, err error) {
 	if from, to, err = svr.parseBlockRange(fromStr, toStr); err != nil {
 		return
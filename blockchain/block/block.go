@@ -72,7 +72,7 @@ func (b *Block) RunnableActions() RunnableActions {
 
 // Finalize creates a footer for the block
 func (b *Block) Finalize(endorsements []*endorsement.Endorsement, ts time.Time) error {
-	if len(b.endorsements) != 0 {
+	if len(b.endorsements) != 0 || b.aggregate != nil {
 		return errors.New("the block has been finalized")
 	}
 	b.endorsements = endorsements
@@ -81,6 +81,17 @@ func (b *Block) Finalize(endorsements []*endorsement.Endorsement, ts time.Time)
 	return nil
 }
 
+// FinalizeWithAggregate creates a footer for the block with the aggregate commit endorsement
+func (b *Block) FinalizeWithAggregate(agg *endorsement.AggregateEndorsement, ts time.Time) error {
+	if len(b.endorsements) != 0 || b.aggregate != nil {
+		return errors.New("the block has been finalized")
+	}
+	b.aggregate = agg
+	b.commitTime = ts
+
+	return nil
+}
+
 // TransactionLog returns transaction logs in the block
 func (b *Block) TransactionLog() *BlkTransactionLog {
 	if len(b.Receipts) == 0 {
@@ -10,12 +10,12 @@ import (
 	"time"
 
 	"github.com/iotexproject/go-pkgs/bloom"
-	"github.com/iotexproject/go-pkgs/crypto"
 	"github.com/iotexproject/go-pkgs/hash"
 	"github.com/pkg/errors"
 
 	"github.com/iotexproject/iotex-core/action"
 	"github.com/iotexproject/iotex-core/pkg/version"
+	"github.com/iotexproject/iotex-core/signer"
 )
 
 // Builder is used to construct Block.
@@ -85,7 +85,7 @@ func (b *Builder) SetLogsBloom(f bloom.BloomFilter) *Builder {
 }
 
 // SignAndBuild signs and then builds a block.
-func (b *Builder) SignAndBuild(signerPrvKey crypto.PrivateKey) (Block, error) {
+func (b *Builder) SignAndBuild(signerPrvKey signer.HashSigner) (Block, error) {
 	b.blk.Header.pubkey = signerPrvKey.PublicKey()
 	h := b.blk.Header.HashHeaderCore()
 	sig, err := signerPrvKey.Sign(h[:])
@@ -12,6 +12,7 @@ import (
 	"google.golang.org/protobuf/proto"
 	"google.golang.org/protobuf/types/known/timestamppb"
 
+	"github.com/iotexproject/iotex-core/blockchain/block/blockpb"
 	"github.com/iotexproject/iotex-core/endorsement"
 	"github.com/iotexproject/iotex-proto/golang/iotextypes"
 )
@@ -19,15 +20,46 @@ import (
 // Footer defines a set of proof of this block
 type Footer struct {
 	endorsements []*endorsement.Endorsement
+	aggregate    *endorsement.AggregateEndorsement
 	commitTime   time.Time
 }
 
 // ConvertToBlockFooterPb converts BlockFooter
 func (f *Footer) ConvertToBlockFooterPb() (*iotextypes.BlockFooter, error) {
-	pb := iotextypes.BlockFooter{}
-	commitTime := timestamppb.New(f.commitTime)
-	pb.Timestamp = commitTime
-	pb.Endorsements = []*iotextypes.Endorsement{}
+	// the footer is encoded as blockpb.BlockFooter, the aggregate endorsement is kept in the unknown fields of
+	// iotextypes.BlockFooter, so that it is transparent to the nodes which don't recognize it
+	ser, err := f.Serialize()
+	if err != nil {
+		return nil, err
+	}
+	pb := &iotextypes.BlockFooter{}
+	if err := proto.Unmarshal(ser, pb); err != nil {
+		return nil, err
+	}
+	return pb, nil
+}
+
+// ConvertFromBlockFooterPb converts BlockFooter to BlockFooter
+func (f *Footer) ConvertFromBlockFooterPb(pb *iotextypes.BlockFooter) error {
+	if pb == nil {
+		return nil
+	}
+	ext := &blockpb.BlockFooter{}
+	if err := proto.Unmarshal(pb.ProtoReflect().GetUnknown(), ext); err != nil {
+		return err
+	}
+	return f.loadBlockFooterProto(&blockpb.BlockFooter{
+		Endorsements:         pb.GetEndorsements(),
+		Timestamp:            pb.GetTimestamp(),
+		AggregateEndorsement: ext.GetAggregateEndorsement(),
+	})
+}
+
+func (f *Footer) blockFooterProto() (*blockpb.BlockFooter, error) {
+	pb := &blockpb.BlockFooter{
+		Timestamp:    timestamppb.New(f.commitTime),
+		Endorsements: []*iotextypes.Endorsement{},
+	}
 	for _, en := range f.endorsements {
 		ePb, err := en.Proto()
 		if err != nil {
@@ -35,19 +67,24 @@ func (f *Footer) ConvertToBlockFooterPb() (*iotextypes.BlockFooter, error) {
 		}
 		pb.Endorsements = append(pb.Endorsements, ePb)
 	}
-	return &pb, nil
+	if f.aggregate != nil {
+		pb.AggregateEndorsement = f.aggregate.Proto()
+	}
+	return pb, nil
 }
 
-// ConvertFromBlockFooterPb converts BlockFooter to BlockFooter
-func (f *Footer) ConvertFromBlockFooterPb(pb *iotextypes.BlockFooter) error {
-	if pb == nil {
-		return nil
-	}
+func (f *Footer) loadBlockFooterProto(pb *blockpb.BlockFooter) error {
 	if err := pb.GetTimestamp().CheckValid(); err != nil {
 		return err
 	}
 	commitTime := pb.GetTimestamp().AsTime()
 	f.commitTime = commitTime
+	if aggPb := pb.GetAggregateEndorsement(); aggPb != nil {
+		f.aggregate = &endorsement.AggregateEndorsement{}
+		if err := f.aggregate.LoadProto(aggPb); err != nil {
+			return err
+		}
+	}
 	pbEndorsements := pb.GetEndorsements()
 	if pbEndorsements == nil {
 		return nil
@@ -74,9 +111,15 @@ func (f *Footer) Endorsements() []*endorsement.Endorsement {
 	return f.endorsements
 }
 
+// AggregateEndorsement returns the aggregate commit endorsement from delegates, which is nil if the commit
+// endorsements are not aggregated
+func (f *Footer) AggregateEndorsement() *endorsement.AggregateEndorsement {
+	return f.aggregate
+}
+
 // Serialize returns the serialized byte stream of the block footer
 func (f *Footer) Serialize() ([]byte, error) {
-	pb, err := f.ConvertToBlockFooterPb()
+	pb, err := f.blockFooterProto()
 	if err != nil {
 		return nil, err
 	}
@@ -85,9 +128,9 @@ func (f *Footer) Serialize() ([]byte, error) {
 
 // Deserialize loads from the serialized byte stream
 func (f *Footer) Deserialize(buf []byte) error {
-	pb := &iotextypes.BlockFooter{}
+	pb := &blockpb.BlockFooter{}
 	if err := proto.Unmarshal(buf, pb); err != nil {
 		return err
 	}
-	return f.ConvertFromBlockFooterPb(pb)
+	return f.loadBlockFooterProto(pb)
 }
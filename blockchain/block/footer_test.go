@@ -13,6 +13,7 @@ import (
 	"github.com/golang/protobuf/ptypes/timestamp"
 	"github.com/iotexproject/iotex-proto/golang/iotextypes"
 	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/proto"
 
 	"github.com/iotexproject/iotex-core/endorsement"
 	"github.com/iotexproject/iotex-core/test/identityset"
@@ -20,7 +21,7 @@ import (
 
 func TestConvertToBlockFooterPb(t *testing.T) {
 	require := require.New(t)
-	footer := &Footer{nil, time.Now()}
+	footer := &Footer{commitTime: time.Now()}
 	blockFooter, err := footer.ConvertToBlockFooterPb()
 	require.NoError(err)
 	require.NotNil(blockFooter)
@@ -46,7 +47,7 @@ func TestConvertFromBlockFooterPb(t *testing.T) {
 
 func TestSerDesFooter(t *testing.T) {
 	require := require.New(t)
-	footer := &Footer{nil, time.Now()}
+	footer := &Footer{commitTime: time.Now()}
 	ser, err := footer.Serialize()
 	require.NoError(err)
 	require.NoError(footer.Deserialize(ser))
@@ -60,10 +61,39 @@ func TestSerDesFooter(t *testing.T) {
 	require.Equal(1, len(footer.endorsements))
 }
 
+func TestSerDesAggregateFooter(t *testing.T) {
+	require := require.New(t)
+	sk, err := endorsement.GenerateBLSPrivateKey()
+	require.NoError(err)
+	sig, err := sk.Sign([]byte("block hash"))
+	require.NoError(err)
+	footer := &Footer{
+		aggregate:  endorsement.NewAggregateEndorsement([]byte{0x05}, sig),
+		commitTime: time.Now(),
+	}
+	ser, err := footer.Serialize()
+	require.NoError(err)
+	footer = &Footer{}
+	require.NoError(footer.Deserialize(ser))
+	require.Equal(0, len(footer.endorsements))
+	agg := footer.AggregateEndorsement()
+	require.NotNil(agg)
+	require.Equal([]byte{0x05}, agg.Bitmap())
+	require.Equal(sig, agg.Signature())
+	require.Equal(2, agg.NumSigners())
+
+	// the aggregate endorsement is preserved by the nodes which don't recognize it
+	pb := &iotextypes.BlockFooter{}
+	require.NoError(proto.Unmarshal(ser, pb))
+	ser2, err := proto.Marshal(pb)
+	require.NoError(err)
+	require.Equal(ser, ser2)
+}
+
 func makeFooter() (f *Footer) {
 	endors := make([]*endorsement.Endorsement, 0)
 	endor := endorsement.NewEndorsement(time.Now(), identityset.PrivateKey(27).PublicKey(), nil)
 	endors = append(endors, endor)
-	f = &Footer{endors, time.Now()}
+	f = &Footer{endorsements: endors, commitTime: time.Now()}
 	return
 }
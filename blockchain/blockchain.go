@@ -14,11 +14,13 @@ import (
 	"time"
 
 	"github.com/facebookgo/clock"
+	"github.com/iotexproject/go-pkgs/cache"
 	"github.com/iotexproject/go-pkgs/hash"
 	"github.com/iotexproject/iotex-address/address"
 	"github.com/pkg/errors"
 	"github.com/prometheus/client_golang/prometheus"
 	"go.uber.org/zap"
+	"google.golang.org/protobuf/proto"
 
 	"github.com/iotexproject/iotex-core/action"
 	"github.com/iotexproject/iotex-core/action/protocol"
@@ -29,6 +31,7 @@ import (
 	"github.com/iotexproject/iotex-core/pkg/lifecycle"
 	"github.com/iotexproject/iotex-core/pkg/log"
 	"github.com/iotexproject/iotex-core/pkg/prometheustimer"
+	"github.com/iotexproject/iotex-core/signer"
 )
 
 // const
@@ -95,6 +98,9 @@ type (
 		CommitBlock(blk *block.Block) error
 		// ValidateBlock validates a new block before adding it to the blockchain
 		ValidateBlock(blk *block.Block) error
+		// PrevalidateBlock validates the parts of a block which don't depend on the state, so that it could be done
+		// ahead of committing the preceding blocks
+		PrevalidateBlock(blk *block.Block) error
 
 		// AddSubscriber make you listen to every single produced block
 		AddSubscriber(BlockCreationSubscriber) error
@@ -120,6 +126,9 @@ type (
 		clk            clock.Clock
 		pubSubManager  PubSubManager
 		timerFactory   *prometheustimer.TimerFactory
+		signer         signer.Signer
+		sv             *action.SignatureVerifier
+		prevalidated   cache.LRUCache
 
 		// used by account-based model
 		bbf BlockBuilderFactory
@@ -160,6 +169,23 @@ func ClockOption(clk clock.Clock) Option {
 	}
 }
 
+// SignerOption sets the signer of the blocks and system actions produced, which overrides the producer private key
+func SignerOption(s signer.Signer) Option {
+	return func(bc *blockchain) error {
+		bc.signer = s
+		return nil
+	}
+}
+
+// SignatureVerifierOption sets the signature verifier which remembers the action signatures verified when the
+// blocks are prevalidated
+func SignatureVerifierOption(sv *action.SignatureVerifier) Option {
+	return func(bc *blockchain) error {
+		bc.sv = sv
+		return nil
+	}
+}
+
 // NewBlockchain creates a new blockchain and DB instance
 func NewBlockchain(cfg Config, g genesis.Genesis, dao blockdao.BlockDAO, bbf BlockBuilderFactory, opts ...Option) Blockchain {
 	// create the Blockchain
@@ -186,6 +212,10 @@ func NewBlockchain(cfg Config, g genesis.Genesis, dao blockdao.BlockDAO, bbf Blo
 		log.L().Panic("Failed to generate prometheus timer factory.", zap.Error(err))
 	}
 	chain.timerFactory = timerFactory
+	if cfg.PipelineDepth > 0 {
+		// the blocks prevalidated are kept until they are validated, which are at most twice of the pipeline depth
+		chain.prevalidated = cache.NewThreadSafeLruCache(2 * cfg.PipelineDepth)
+	}
 	if chain.dao == nil {
 		log.L().Panic("blockdao is nil")
 	}
@@ -291,12 +321,14 @@ func (bc *blockchain) ValidateBlock(blk *block.Block) error {
 		)
 	}
 
-	if !blk.Header.VerifySignature() {
-		return errors.Errorf("failed to verify block's signature with public key: %x", blk.PublicKey())
-	}
+	// the tx root is always verified, such that the actions prevalidated are exactly the ones in the block
 	if err := blk.VerifyTxRoot(); err != nil {
 		return err
 	}
+	prevalidated := bc.isPrevalidated(blk)
+	if !prevalidated && !blk.Header.VerifySignature() {
+		return errors.Errorf("failed to verify block's signature with public key: %x", blk.PublicKey())
+	}
 
 	producerAddr := blk.PublicKey().Address()
 	if producerAddr == nil {
@@ -322,6 +354,45 @@ func (bc *blockchain) ValidateBlock(blk *block.Block) error {
 	return bc.blockValidator.Validate(ctx, blk)
 }
 
+// PrevalidateBlock verifies the signature and the tx root of a block, and the signatures and sanity of its actions,
+// without locking the blockchain. None of them depends on the state, and the block signature is skipped by
+// ValidateBlock afterwards, while the action signatures are remembered by the signature verifier. The actions are
+// still validated against the state by ValidateBlock.
+func (bc *blockchain) PrevalidateBlock(blk *block.Block) error {
+	if blk == nil {
+		return ErrInvalidBlock
+	}
+	if bc.prevalidated == nil || bc.isPrevalidated(blk) {
+		return nil
+	}
+	timer := bc.timerFactory.NewTimer("PrevalidateBlock")
+	defer timer.End()
+	if !blk.Header.VerifySignature() {
+		return errors.Errorf("failed to verify block's signature with public key: %x", blk.PublicKey())
+	}
+	if err := blk.VerifyTxRoot(); err != nil {
+		return err
+	}
+	if err := bc.sv.VerifyBatch(blk.Actions); err != nil {
+		return err
+	}
+	for _, selp := range blk.Actions {
+		if err := selp.Action().SanityCheck(); err != nil {
+			return errors.Wrap(err, "failed to validate action")
+		}
+	}
+	bc.prevalidated.Add(blk.HashBlock(), struct{}{})
+	return nil
+}
+
+func (bc *blockchain) isPrevalidated(blk *block.Block) bool {
+	if bc.prevalidated == nil {
+		return false
+	}
+	_, ok := bc.prevalidated.Get(blk.HashBlock())
+	return ok
+}
+
 func (bc *blockchain) Context(ctx context.Context) (context.Context, error) {
 	bc.mu.RLock()
 	defer bc.mu.RUnlock()
@@ -378,20 +449,36 @@ func (bc *blockchain) MintNewBlock(timestamp time.Time) (*block.Block, error) {
 	if err != nil {
 		return nil, err
 	}
-	ctx = bc.contextWithBlock(ctx, bc.config.ProducerAddress(), newblockHeight, timestamp)
+	minter := bc.signer
+	if minter == nil {
+		minter = signer.NewLocalSigner(bc.config.ProducerPrivateKey())
+	}
+	ctx = bc.contextWithBlock(ctx, minter.PublicKey().Address(), newblockHeight, timestamp)
 	ctx = protocol.WithFeatureCtx(ctx)
 	// run execution and update state trie root hash
-	minterPrivateKey := bc.config.ProducerPrivateKey()
 	blockBuilder, err := bc.bbf.NewBlockBuilder(
 		ctx,
 		func(elp action.Envelope) (action.SealedEnvelope, error) {
-			return action.Sign(elp, minterPrivateKey)
+			msg, err := proto.Marshal(elp.Proto())
+			if err != nil {
+				return action.SealedEnvelope{}, err
+			}
+			return action.Sign(elp, signer.Bind(context.Background(), minter, signer.Request{
+				Kind:    signer.ActionKind,
+				Height:  newblockHeight,
+				Message: msg,
+			}))
 		},
 	)
 	if err != nil {
 		return nil, errors.Wrapf(err, "failed to create block builder at new block height %d", newblockHeight)
 	}
-	blk, err := blockBuilder.SignAndBuild(minterPrivateKey)
+	header := blockBuilder.GetCurrentBlockHeader()
+	blk, err := blockBuilder.SignAndBuild(signer.Bind(context.Background(), minter, signer.Request{
+		Kind:    signer.BlockKind,
+		Height:  newblockHeight,
+		Message: header.SerializeCore(),
+	}))
 	if err != nil {
 		return nil, errors.Wrapf(err, "failed to create block")
 	}
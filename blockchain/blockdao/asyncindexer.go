@@ -0,0 +1,124 @@
+// Copyright (c) 2022 IoTeX Foundation
+// This is an alpha (internal) release and is not suitable for production. This source code is provided 'as is' and no
+// warranties are given as to title or non-infringement, merchantability or fitness for purpose and, to the extent
+// permitted by law, all liability for your use of the code is disclaimed. This source code is governed by Apache
+// License 2.0 that can be found in the LICENSE file.
+
+package blockdao
+
+import (
+	"context"
+	"sync"
+
+	"github.com/pkg/errors"
+	"go.uber.org/zap"
+
+	"github.com/iotexproject/iotex-core/blockchain/block"
+	"github.com/iotexproject/iotex-core/pkg/log"
+)
+
+type (
+	asyncIndexTask struct {
+		ctx     context.Context
+		blk     *block.Block
+		flushed chan struct{}
+	}
+
+	// asyncIndexer puts blocks into the indexer it wraps in the background while catching up, such that indexing a
+	// block overlaps with executing the next ones. The blocks queued but not yet indexed when the node crashes are
+	// indexed again by BlockIndexerChecker on restart, as the indexer height lags behind the dao height
+	asyncIndexer struct {
+		indexer    BlockIndexer
+		size       int
+		catchingUp func() bool
+		mu         sync.RWMutex
+		tasks      chan asyncIndexTask
+		done       chan struct{}
+		err        error
+	}
+)
+
+// NewAsyncIndexer wraps an indexer to index blocks asynchronously while catchingUp returns true, with at most
+// queueSize blocks pending. Otherwise, PutBlock waits for the blocks pending and indexes the block synchronously,
+// such that the indexer is up to date at the tip. PutBlock blocks once the queue is full, and returns the error of a
+// previous failed asynchronous indexing
+func NewAsyncIndexer(indexer BlockIndexer, queueSize int, catchingUp func() bool) BlockIndexer {
+	return &asyncIndexer{
+		indexer:    indexer,
+		size:       queueSize,
+		catchingUp: catchingUp,
+	}
+}
+
+func (ai *asyncIndexer) Start(ctx context.Context) error {
+	if err := ai.indexer.Start(ctx); err != nil {
+		return err
+	}
+	ai.tasks = make(chan asyncIndexTask, ai.size)
+	ai.done = make(chan struct{})
+	go ai.run()
+	return nil
+}
+
+func (ai *asyncIndexer) Stop(ctx context.Context) error {
+	close(ai.tasks)
+	<-ai.done
+	return ai.indexer.Stop(ctx)
+}
+
+func (ai *asyncIndexer) Height() (uint64, error) {
+	return ai.indexer.Height()
+}
+
+func (ai *asyncIndexer) PutBlock(ctx context.Context, blk *block.Block) error {
+	if err := ai.error(); err != nil {
+		return err
+	}
+	if ai.catchingUp != nil && ai.catchingUp() {
+		ai.tasks <- asyncIndexTask{ctx: ctx, blk: blk}
+		return nil
+	}
+	if err := ai.flush(); err != nil {
+		return err
+	}
+	return ai.indexer.PutBlock(ctx, blk)
+}
+
+func (ai *asyncIndexer) DeleteTipBlock(ctx context.Context, blk *block.Block) error {
+	if err := ai.flush(); err != nil {
+		return err
+	}
+	return ai.indexer.DeleteTipBlock(ctx, blk)
+}
+
+// flush waits for the blocks queued to be indexed
+func (ai *asyncIndexer) flush() error {
+	flushed := make(chan struct{})
+	ai.tasks <- asyncIndexTask{flushed: flushed}
+	<-flushed
+	return ai.error()
+}
+
+func (ai *asyncIndexer) run() {
+	defer close(ai.done)
+	for task := range ai.tasks {
+		if task.flushed != nil {
+			close(task.flushed)
+			continue
+		}
+		if ai.error() == nil {
+			if err := ai.indexer.PutBlock(task.ctx, task.blk); err != nil {
+				log.L().Error("Failed to index block asynchronously.", zap.Uint64("height", task.blk.Height()), zap.Error(err))
+				ai.mu.Lock()
+				ai.err = errors.Wrapf(err, "failed to index block %d", task.blk.Height())
+				ai.mu.Unlock()
+			}
+		}
+	}
+}
+
+func (ai *asyncIndexer) error() error {
+	ai.mu.RLock()
+	defer ai.mu.RUnlock()
+	return ai.err
+}
@@ -0,0 +1,154 @@
+// Copyright (c) 2022 IoTeX Foundation
+// This is an alpha (internal) release and is not suitable for production. This source code is provided 'as is' and no
+// warranties are given as to title or non-infringement, merchantability or fitness for purpose and, to the extent
+// permitted by law, all liability for your use of the code is disclaimed. This source code is governed by Apache
+// License 2.0 that can be found in the LICENSE file.
+
+package blockdao
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/require"
+
+	"github.com/iotexproject/iotex-core/action/protocol"
+	"github.com/iotexproject/iotex-core/blockchain/block"
+	"github.com/iotexproject/iotex-core/blockchain/genesis"
+)
+
+type testIndexer struct {
+	mu     sync.Mutex
+	height uint64
+	gate   chan struct{}
+	err    error
+}
+
+func (ti *testIndexer) Start(context.Context) error { return nil }
+
+func (ti *testIndexer) Stop(context.Context) error { return nil }
+
+func (ti *testIndexer) Height() (uint64, error) {
+	ti.mu.Lock()
+	defer ti.mu.Unlock()
+	return ti.height, nil
+}
+
+func (ti *testIndexer) PutBlock(_ context.Context, blk *block.Block) error {
+	if ti.gate != nil {
+		<-ti.gate
+	}
+	ti.mu.Lock()
+	defer ti.mu.Unlock()
+	if ti.err != nil {
+		return ti.err
+	}
+	if blk.Height() != ti.height+1 {
+		return errors.Errorf("invalid block height %d, %d expected", blk.Height(), ti.height+1)
+	}
+	ti.height = blk.Height()
+	return nil
+}
+
+func (ti *testIndexer) DeleteTipBlock(_ context.Context, blk *block.Block) error {
+	ti.mu.Lock()
+	defer ti.mu.Unlock()
+	ti.height--
+	return nil
+}
+
+func TestAsyncIndexer(t *testing.T) {
+	ctx := genesis.WithGenesisContext(
+		protocol.WithBlockchainCtx(context.Background(), protocol.BlockchainCtx{}),
+		genesis.Default,
+	)
+	blks := getTestBlocks(t)
+	catchingUp := func() bool { return true }
+
+	t.Run("back pressure", func(t *testing.T) {
+		require := require.New(t)
+		indexer := &testIndexer{gate: make(chan struct{})}
+		dao := NewBlockDAOInMemForTest([]BlockIndexer{NewAsyncIndexer(indexer, 1, catchingUp)})
+		require.NoError(dao.Start(ctx))
+		// the 1st block is being indexed and the 2nd one is queued
+		require.NoError(dao.PutBlock(ctx, blks[0]))
+		require.NoError(dao.PutBlock(ctx, blks[1]))
+		putDone := make(chan error)
+		go func() {
+			putDone <- dao.PutBlock(ctx, blks[2])
+		}()
+		select {
+		case <-putDone:
+			require.FailNow("the 3rd block should wait for the queue")
+		case <-time.After(100 * time.Millisecond):
+		}
+		height, err := dao.Height()
+		require.NoError(err)
+		require.EqualValues(3, height)
+		close(indexer.gate)
+		require.NoError(<-putDone)
+		// delete tip block waits for the blocks queued
+		require.NoError(dao.DeleteBlockToTarget(2))
+		height, err = indexer.Height()
+		require.NoError(err)
+		require.EqualValues(2, height)
+		require.NoError(dao.Stop(ctx))
+	})
+
+	t.Run("failure and recovery", func(t *testing.T) {
+		require := require.New(t)
+		indexer := &testIndexer{}
+		ai := NewAsyncIndexer(indexer, 2, catchingUp)
+		dao := NewBlockDAOInMemForTest([]BlockIndexer{ai})
+		require.NoError(dao.Start(ctx))
+		require.NoError(dao.PutBlock(ctx, blks[0]))
+		require.Eventually(func() bool {
+			height, err := indexer.Height()
+			return err == nil && height == 1
+		}, time.Second, 10*time.Millisecond)
+		indexer.mu.Lock()
+		indexer.err = errors.New("disk full")
+		indexer.mu.Unlock()
+		require.NoError(dao.PutBlock(ctx, blks[1]))
+		require.NoError(dao.PutBlock(ctx, blks[2]))
+		// the failure is returned once it is noticed
+		require.Eventually(func() bool {
+			return ai.PutBlock(ctx, blks[2]) != nil
+		}, time.Second, 10*time.Millisecond)
+		height, err := indexer.Height()
+		require.NoError(err)
+		require.EqualValues(1, height)
+		// the indexer lagging behind catches up by the checker after restarting
+		indexer.mu.Lock()
+		indexer.err = nil
+		indexer.mu.Unlock()
+		recovered := NewAsyncIndexer(indexer, 2, catchingUp)
+		require.NoError(recovered.Start(ctx))
+		require.NoError(NewBlockIndexerChecker(dao).CheckIndexer(ctx, recovered, 0, nil))
+		require.NoError(recovered.Stop(ctx))
+		height, err = indexer.Height()
+		require.NoError(err)
+		require.EqualValues(3, height)
+		require.NoError(dao.Stop(ctx))
+	})
+
+	t.Run("synchronous at tip", func(t *testing.T) {
+		require := require.New(t)
+		indexer := &testIndexer{}
+		synced := false
+		dao := NewBlockDAOInMemForTest([]BlockIndexer{NewAsyncIndexer(indexer, 2, func() bool { return !synced })})
+		require.NoError(dao.Start(ctx))
+		require.NoError(dao.PutBlock(ctx, blks[0]))
+		require.NoError(dao.PutBlock(ctx, blks[1]))
+		// the blocks queued are indexed before the block at tip
+		synced = true
+		require.NoError(dao.PutBlock(ctx, blks[2]))
+		height, err := indexer.Height()
+		require.NoError(err)
+		require.EqualValues(3, height)
+		require.NoError(dao.Stop(ctx))
+	})
+}
@@ -8,17 +8,25 @@ package blockdao
 
 import (
 	"context"
+	"math/big"
 	"time"
 
+	"github.com/iotexproject/go-pkgs/hash"
 	"github.com/pkg/errors"
 	"go.uber.org/zap"
 
+	"github.com/iotexproject/iotex-core/action"
 	"github.com/iotexproject/iotex-core/action/protocol"
 	"github.com/iotexproject/iotex-core/blockchain/block"
+	"github.com/iotexproject/iotex-core/blockchain/filedao"
 	"github.com/iotexproject/iotex-core/blockchain/genesis"
+	"github.com/iotexproject/iotex-core/db"
 	"github.com/iotexproject/iotex-core/pkg/log"
 )
 
+// _checkIndexerBatchSize is the number of blocks put into a batch block indexer at once when catching up
+const _checkIndexerBatchSize = 5000
+
 type (
 	// BlockIndexer defines an interface to accept block to build index
 	BlockIndexer interface {
@@ -29,6 +37,13 @@ type (
 		DeleteTipBlock(context.Context, *block.Block) error
 	}
 
+	// BatchBlockIndexer is a block indexer which could put a batch of blocks at once, it catches up with block dao
+	// in batches rather than block by block
+	BatchBlockIndexer interface {
+		BlockIndexer
+		PutBlocks(context.Context, []*block.Block) error
+	}
+
 	// BlockIndexerChecker defines a checker of block indexer
 	BlockIndexerChecker struct {
 		dao BlockDAO
@@ -68,6 +83,9 @@ func (bic *BlockIndexerChecker) CheckIndexer(ctx context.Context, indexer BlockI
 	if targetHeight == 0 || targetHeight > daoTip {
 		targetHeight = daoTip
 	}
+	if batchIndexer, ok := indexer.(BatchBlockIndexer); ok {
+		return bic.checkBatchIndexer(ctx, batchIndexer, tipHeight, targetHeight, progressReporter)
+	}
 	for i := tipHeight + 1; i <= targetHeight; i++ {
 		blk, err := bic.dao.GetBlockByHeight(i)
 		if err != nil {
@@ -116,3 +134,70 @@ func (bic *BlockIndexerChecker) CheckIndexer(ctx context.Context, indexer BlockI
 	}
 	return nil
 }
+
+func (bic *BlockIndexerChecker) checkBatchIndexer(ctx context.Context, indexer BatchBlockIndexer, tipHeight, targetHeight uint64, progressReporter func(uint64)) error {
+	blks := make([]*block.Block, 0, _checkIndexerBatchSize)
+	for i := tipHeight + 1; i <= targetHeight; i++ {
+		blk, err := bic.dao.GetBlockByHeight(i)
+		if err != nil {
+			return err
+		}
+		if blk.Receipts == nil {
+			blk.Receipts, err = bic.dao.GetReceipts(i)
+			if err != nil {
+				return err
+			}
+			if err := bic.fillTransactionLogs(blk); err != nil {
+				return err
+			}
+		}
+		blks = append(blks, blk)
+		if i%_checkIndexerBatchSize != 0 && i < targetHeight {
+			continue
+		}
+		if err := indexer.PutBlocks(ctx, blks); err != nil {
+			return err
+		}
+		blks = blks[:0]
+		if progressReporter != nil {
+			progressReporter(i)
+		}
+	}
+	return nil
+}
+
+// fillTransactionLogs adds the transaction logs stored in dao to the receipts of the block, which are not stored
+// along with the receipts
+func (bic *BlockIndexerChecker) fillTransactionLogs(blk *block.Block) error {
+	sysLog, err := bic.dao.TransactionLogs(blk.Height())
+	switch errors.Cause(err) {
+	case nil:
+	case filedao.ErrNotSupported, db.ErrNotExist, db.ErrBucketNotExist:
+		return nil
+	default:
+		return err
+	}
+	receipts := make(map[hash.Hash256]*action.Receipt, len(blk.Receipts))
+	for _, r := range blk.Receipts {
+		receipts[r.ActionHash] = r
+	}
+	for _, l := range sysLog.GetLogs() {
+		r, ok := receipts[hash.BytesToHash256(l.ActionHash)]
+		if !ok {
+			continue
+		}
+		for _, tx := range l.Transactions {
+			amount, ok := new(big.Int).SetString(tx.Amount, 10)
+			if !ok {
+				return errors.Errorf("invalid amount %s of transaction log", tx.Amount)
+			}
+			r.AddTransactionLogs(&action.TransactionLog{
+				Type:      tx.Type,
+				Amount:    amount,
+				Sender:    tx.Sender,
+				Recipient: tx.Recipient,
+			})
+		}
+	}
+	return nil
+}
@@ -19,7 +19,9 @@ import (
 	"go.uber.org/zap"
 
 	"github.com/iotexproject/iotex-core/db"
+	"github.com/iotexproject/iotex-core/endorsement"
 	"github.com/iotexproject/iotex-core/pkg/log"
+	"github.com/iotexproject/iotex-core/signer"
 )
 
 type (
@@ -32,11 +34,15 @@ type (
 		BloomfilterIndexDBPath string           `yaml:"bloomfilterIndexDBPath"`
 		CandidateIndexDBPath   string           `yaml:"candidateIndexDBPath"`
 		StakingIndexDBPath     string           `yaml:"stakingIndexDBPath"`
+		AnalyticsIndexDBPath   string           `yaml:"analyticsIndexDBPath"`
+		BalanceIndexDBPath     string           `yaml:"balanceIndexDBPath"`
 		ID                     uint32           `yaml:"id"`
 		EVMNetworkID           uint32           `yaml:"evmNetworkID"`
 		Address                string           `yaml:"address"`
 		ProducerPrivKey        string           `yaml:"producerPrivKey"`
 		ProducerPrivKeySchema  string           `yaml:"producerPrivKeySchema"`
+		ProducerBLSPrivKey     string           `yaml:"producerBLSPrivKey"`
+		ProducerSigner         signer.Config    `yaml:"producerSigner"`
 		SignatureScheme        []string         `yaml:"signatureScheme"`
 		EmptyGenesis           bool             `yaml:"emptyGenesis"`
 		GravityChainDB         db.Config        `yaml:"gravityChainDB"`
@@ -55,6 +61,15 @@ type (
 		EnableStakingProtocol bool `yaml:"enableStakingProtocol"`
 		// EnableStakingIndexer enables staking indexer
 		EnableStakingIndexer bool `yaml:"enableStakingIndexer"`
+		// EnableAnalyticsIndexer enables writing the chain data into the sqlite3 db of AnalyticsIndexDBPath, against
+		// which ad-hoc sql queries could be run
+		EnableAnalyticsIndexer bool `yaml:"enableAnalyticsIndexer"`
+		// EnableStateDiffIndex enables persisting the states changed by each action of the blocks committed, which
+		// disables the parallel execution of actions
+		EnableStateDiffIndex bool `yaml:"enableStateDiffIndex"`
+		// EnableBalanceIndexer enables recording the balances of the accounts touched by each block committed, the
+		// history is only complete if the indexer is enabled since the genesis
+		EnableBalanceIndexer bool `yaml:"enableBalanceIndexer"`
 		// AllowedBlockGasResidue is the amount of gas remained when block producer could stop processing more actions
 		AllowedBlockGasResidue uint64 `yaml:"allowedBlockGasResidue"`
 		// MaxCacheSize is the max number of blocks that will be put into an LRU cache. 0 means disabled
@@ -67,6 +82,18 @@ type (
 		WorkingSetCacheSize uint64 `yaml:"workingSetCacheSize"`
 		// StreamingBlockBufferSize
 		StreamingBlockBufferSize uint64 `yaml:"streamingBlockBufferSize"`
+		// ParallelExecutionWorkers is the number of workers executing actions speculatively in parallel when
+		// producing and validating blocks, 0 or 1 executes actions one by one
+		ParallelExecutionWorkers int `yaml:"parallelExecutionWorkers"`
+		// PipelineDepth is the number of blocks which are allowed to be validated statelessly ahead of, and to be
+		// indexed behind, the block being executed when catching up, 0 disables the pipeline
+		PipelineDepth int `yaml:"pipelineDepth"`
+		// SignatureCacheSize is the max number of actions whose signatures are remembered as verified, 0 disables the
+		// cache
+		SignatureCacheSize int `yaml:"signatureCacheSize"`
+		// SignatureVerificationWorkers is the number of workers verifying the signatures of a block's actions in
+		// parallel, 0 means the number of CPUs
+		SignatureVerificationWorkers int `yaml:"signatureVerificationWorkers"`
 	}
 )
 
@@ -80,10 +107,13 @@ var (
 		BloomfilterIndexDBPath: "/var/data/bloomfilter.index.db",
 		CandidateIndexDBPath:   "/var/data/candidate.index.db",
 		StakingIndexDBPath:     "/var/data/staking.index.db",
+		AnalyticsIndexDBPath:   "/var/data/analytics.index.db",
+		BalanceIndexDBPath:     "/var/data/balance.index.db",
 		ID:                     1,
 		EVMNetworkID:           4689,
 		Address:                "",
 		ProducerPrivKey:        generateRandomKey(SigP256k1),
+		ProducerSigner:         signer.DefaultConfig,
 		SignatureScheme:        []string{SigP256k1},
 		EmptyGenesis:           false,
 		GravityChainDB:         db.Config{DbPath: "/var/data/poll.db", NumRetries: 10},
@@ -97,12 +127,16 @@ var (
 		EnableSystemLogIndexer:        false,
 		EnableStakingProtocol:         true,
 		EnableStakingIndexer:          false,
+		EnableAnalyticsIndexer:        false,
+		EnableStateDiffIndex:          false,
+		EnableBalanceIndexer:          false,
 		AllowedBlockGasResidue:        10000,
 		MaxCacheSize:                  0,
 		PollInitialCandidatesInterval: 10 * time.Second,
 		StateDBCacheSize:              1000,
 		WorkingSetCacheSize:           20,
 		StreamingBlockBufferSize:      200,
+		SignatureCacheSize:            50000,
 	}
 
 	// ErrConfig config error
@@ -111,14 +145,28 @@ var (
 
 // ProducerAddress returns the configured producer address derived from key
 func (cfg *Config) ProducerAddress() address.Address {
-	sk := cfg.ProducerPrivateKey()
-	addr := sk.PublicKey().Address()
+	addr := cfg.ProducerPublicKey().Address()
 	if addr == nil {
 		log.L().Panic("Error when constructing producer address")
 	}
 	return addr
 }
 
+// ProducerPublicKey returns the public key of the producer, which is held by the remote signer if it is configured
+func (cfg *Config) ProducerPublicKey() crypto.PublicKey {
+	if cfg.ProducerSigner.Endpoint == "" {
+		return cfg.ProducerPrivateKey().PublicKey()
+	}
+	pk, err := crypto.HexStringToPublicKey(cfg.ProducerSigner.PublicKey)
+	if err != nil {
+		log.L().Panic(
+			"Error when decoding the public key of remote signer",
+			zap.Error(err),
+		)
+	}
+	return pk
+}
+
 // ProducerPrivateKey returns the configured private key
 func (cfg *Config) ProducerPrivateKey() crypto.PrivateKey {
 	sk, err := crypto.HexStringToPrivateKey(cfg.ProducerPrivKey)
@@ -135,6 +183,22 @@ func (cfg *Config) ProducerPrivateKey() crypto.PrivateKey {
 	return sk
 }
 
+// ProducerBLSPrivateKey returns the configured BLS private key to sign aggregatable commit endorsements, or nil if
+// it is not configured
+func (cfg *Config) ProducerBLSPrivateKey() *endorsement.BLSPrivateKey {
+	if cfg.ProducerBLSPrivKey == "" {
+		return nil
+	}
+	sk, err := endorsement.HexStringToBLSPrivateKey(cfg.ProducerBLSPrivKey)
+	if err != nil {
+		log.L().Panic(
+			"Error when decoding BLS private key",
+			zap.Error(err),
+		)
+	}
+	return sk
+}
+
 // SetProducerPrivKey set producer privKey by PrivKeyConfigFile info
 func (cfg *Config) SetProducerPrivKey() error {
 	switch cfg.ProducerPrivKeySchema {
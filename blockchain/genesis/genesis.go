@@ -70,6 +70,9 @@ func defaultConfig() Genesis {
 			MidwayBlockHeight:       16509241,
 			NewfoundlandBlockHeight: 17662681,
 			OkhotskBlockHeight:      37662681,
+			BLSAggregateBlockHeight: math.MaxUint64,
+			BLSDelegates:            []BLSDelegate{},
+			SlashingBlockHeight:     math.MaxUint64,
 			ToBeEnabledBlockHeight:  math.MaxUint64,
 		},
 		Account: Account{
@@ -110,6 +113,7 @@ func defaultConfig() Genesis {
 			WithdrawWaitingPeriod: 3 * 24 * time.Hour,
 			MinStakeAmount:        unit.ConvertIotxToRau(100).String(),
 			BootstrapCandidates:   []BootstrapCandidate{},
+			DoubleSignSlashRate:   10,
 		},
 	}
 }
@@ -220,10 +224,28 @@ type (
 		// 3. fix gas and nonce update
 		// 4. fix unproductive delegates in staking protocol
 		OkhotskBlockHeight uint64 `yaml:"okhotskHeight"`
+		// BLSAggregateBlockHeight is the start height of aggregating the commit endorsements in block footer into a
+		// single BLS signature
+		BLSAggregateBlockHeight uint64 `yaml:"blsAggregateHeight"`
+		// BLSDelegates is the list of the BLS public keys of delegates, which are used to verify the aggregate
+		// commit endorsements. A block carries the individual commit endorsements instead, if the active delegates
+		// in the list could not reach majority
+		BLSDelegates []BLSDelegate `yaml:"blsDelegates"`
+		// SlashingBlockHeight is the start height of including double sign evidences into blocks, and slashing the
+		// self-stake of the offenders
+		SlashingBlockHeight uint64 `yaml:"slashingHeight"`
 		// ToBeEnabledBlockHeight is a fake height that acts as a gating factor for WIP features
 		// upon next release, change IsToBeEnabled() to IsNextHeight() for features to be released
 		ToBeEnabledBlockHeight uint64 `yaml:"toBeEnabledHeight"`
 	}
+	// BLSDelegate defines the BLS public key of a delegate
+	BLSDelegate struct {
+		OperatorAddrStr string `yaml:"operatorAddr"`
+		// PubKeyStr is the BLS public key in hex string
+		PubKeyStr string `yaml:"blsPubKey"`
+		// ProofStr is the proof of possession of the BLS private key in hex string
+		ProofStr string `yaml:"blsProof"`
+	}
 	// Account contains the configs for account protocol
 	Account struct {
 		// InitBalanceMap is the address and initial balance mapping before the first block.
@@ -311,6 +333,8 @@ type (
 		WithdrawWaitingPeriod time.Duration        `yaml:"withdrawWaitingPeriod"`
 		MinStakeAmount        string               `yaml:"minStakeAmount"`
 		BootstrapCandidates   []BootstrapCandidate `yaml:"bootstrapCandidates"`
+		// DoubleSignSlashRate is the percentage of the self-stake to be slashed from a double signing delegate
+		DoubleSignSlashRate uint64 `yaml:"doubleSignSlashRate"`
 	}
 
 	// VoteWeightCalConsts contains the configs for calculating vote weight
@@ -535,6 +559,16 @@ func (g *Blockchain) IsOkhotsk(height uint64) bool {
 	return g.isPost(g.OkhotskBlockHeight, height)
 }
 
+// IsBLSAggregate checks whether height is equal to or larger than BLS aggregate height
+func (g *Blockchain) IsBLSAggregate(height uint64) bool {
+	return g.isPost(g.BLSAggregateBlockHeight, height)
+}
+
+// IsSlashing checks whether height is equal to or larger than slashing height
+func (g *Blockchain) IsSlashing(height uint64) bool {
+	return g.isPost(g.SlashingBlockHeight, height)
+}
+
 // IsToBeEnabled checks whether height is equal to or larger than toBeEnabled height
 func (g *Blockchain) IsToBeEnabled(height uint64) bool {
 	return g.isPost(g.ToBeEnabledBlockHeight, height)
@@ -34,6 +34,7 @@ import (
 	"github.com/iotexproject/iotex-core/action/protocol/poll"
 	"github.com/iotexproject/iotex-core/action/protocol/rewarding"
 	"github.com/iotexproject/iotex-core/action/protocol/rolldpos"
+	"github.com/iotexproject/iotex-core/action/protocol/staking"
 	"github.com/iotexproject/iotex-core/action/protocol/vote/candidatesutil"
 	"github.com/iotexproject/iotex-core/actpool"
 	"github.com/iotexproject/iotex-core/blockchain"
@@ -1376,6 +1377,165 @@ func TestLoadBlockchainfromDB(t *testing.T) {
 	})
 }
 
+func createTestChain(t *testing.T, setConfig func(*config.Config)) (blockchain.Blockchain, blockdao.BlockDAO, actpool.ActPool, config.Config) {
+	require := require.New(t)
+	ctx := genesis.WithGenesisContext(context.Background(), config.Default.Genesis)
+	cfg := config.Default
+	testTriePath, err := testutil.PathOfTempFile("trie")
+	require.NoError(err)
+	testDBPath, err := testutil.PathOfTempFile("db")
+	require.NoError(err)
+	t.Cleanup(func() {
+		testutil.CleanupPath(testTriePath)
+		testutil.CleanupPath(testDBPath)
+	})
+	cfg.Chain.TrieDBPath = testTriePath
+	cfg.Chain.ChainDBPath = testDBPath
+	cfg.Genesis.EnableGravityChainVoting = false
+	cfg.ActPool.MinGasPriceStr = "0"
+	setConfig(&cfg)
+
+	registry := protocol.NewRegistry()
+	db2, err := db.CreateKVStore(cfg.DB, cfg.Chain.TrieDBPath)
+	require.NoError(err)
+	sf, err := factory.NewFactory(factory.GenerateConfig(cfg.Chain, cfg.Genesis), db2, factory.RegistryOption(registry))
+	require.NoError(err)
+	ap, err := actpool.NewActPool(cfg.Genesis, sf, cfg.ActPool)
+	require.NoError(err)
+	require.NoError(account.NewProtocol(rewarding.DepositGas).Register(registry))
+	rp := rolldpos.NewProtocol(cfg.Genesis.NumCandidateDelegates, cfg.Genesis.NumDelegates, cfg.Genesis.NumSubEpochs)
+	require.NoError(rp.Register(registry))
+	cfg.DB.DbPath = cfg.Chain.ChainDBPath
+	dao := blockdao.NewBlockDAO([]blockdao.BlockIndexer{sf}, cfg.DB, block.NewDeserializer(cfg.Chain.EVMNetworkID))
+	bc := blockchain.NewBlockchain(
+		cfg.Chain,
+		cfg.Genesis,
+		dao,
+		factory.NewMinter(sf, ap),
+		blockchain.BlockValidatorOption(block.NewValidator(
+			sf,
+			protocol.NewGenericValidator(sf, accountutil.AccountState),
+		)),
+	)
+	require.NoError(execution.NewProtocol(dao.GetBlockHash, rewarding.DepositGas).Register(registry))
+	require.NoError(rewarding.NewProtocol(cfg.Genesis.Rewarding).Register(registry))
+	sp, err := staking.NewProtocol(rewarding.DepositGas, cfg.Genesis.Staking, nil, cfg.Genesis.GreenlandBlockHeight)
+	require.NoError(err)
+	require.NoError(sp.Register(registry))
+	require.NoError(bc.Start(ctx))
+	t.Cleanup(func() {
+		require.NoError(bc.Stop(ctx))
+	})
+	return bc, dao, ap, cfg
+}
+
+// addTestingStakingBlock adds a block in which a candidate is registered and staked on, such that the staking
+// actions conflict with each other on the candidate. The candidate is registered first for a higher gas price.
+func addTestingStakingBlock(cfg config.Config, bc blockchain.Blockchain, ap actpool.ActPool) error {
+	ctx := genesis.WithGenesisContext(context.Background(), cfg.Genesis)
+	register, err := action.SignedCandidateRegister(1, "cand", identityset.Address(10).String(),
+		identityset.Address(10).String(), identityset.Address(10).String(), cfg.Genesis.Staking.RegistrationConsts.MinSelfStake,
+		0, false, nil, testutil.TestGasLimit, big.NewInt(testutil.TestGasPriceInt64+1), identityset.PrivateKey(10))
+	if err != nil {
+		return err
+	}
+	if err := ap.Add(ctx, register); err != nil {
+		return err
+	}
+	for _, sender := range []int{11, 12} {
+		stake, err := action.SignedCreateStake(1, "cand", cfg.Genesis.Staking.MinStakeAmount, 0, false, nil,
+			testutil.TestGasLimit, big.NewInt(testutil.TestGasPriceInt64), identityset.PrivateKey(sender))
+		if err != nil {
+			return err
+		}
+		if err := ap.Add(ctx, stake); err != nil {
+			return err
+		}
+	}
+	blk, err := bc.MintNewBlock(testutil.TimestampNow())
+	if err != nil {
+		return err
+	}
+	if err := bc.ValidateBlock(blk); err != nil {
+		return err
+	}
+	return bc.CommitBlock(blk)
+}
+
+func TestParallelExecution(t *testing.T) {
+	require := require.New(t)
+
+	// the blocks produced serially are validated in parallel, and vice versa
+	for _, workers := range [][2]int{{0, 4}, {4, 0}} {
+		bc, dao, ap, cfg := createTestChain(t, func(cfg *config.Config) {
+			cfg.Chain.ParallelExecutionWorkers = workers[0]
+		})
+		require.NoError(addTestingTsfBlocks(cfg, bc, dao, ap))
+		require.NoError(addTestingStakingBlock(cfg, bc, ap))
+		bc2, dao2, _, _ := createTestChain(t, func(cfg *config.Config) {
+			cfg.Chain.ParallelExecutionWorkers = workers[1]
+		})
+		for h := uint64(1); h <= bc.TipHeight(); h++ {
+			blk, err := dao.GetBlockByHeight(h)
+			require.NoError(err)
+			// the staking actions, which defer the deposit of gas fee into the rewarding fund, and the grant reward
+			if h == bc.TipHeight() {
+				require.Len(blk.Actions, 4)
+				_, ok := blk.Actions[3].Action().(*action.GrantReward)
+				require.True(ok)
+			}
+			require.NoError(bc2.ValidateBlock(blk))
+			require.NoError(bc2.CommitBlock(blk))
+			receipts, err := dao.GetReceipts(h)
+			require.NoError(err)
+			receipts2, err := dao2.GetReceipts(h)
+			require.NoError(err)
+			require.Equal(receipts, receipts2)
+			if h == bc.TipHeight() {
+				for _, r := range receipts2 {
+					require.EqualValues(iotextypes.ReceiptStatus_Success, r.Status)
+				}
+			}
+		}
+	}
+}
+
+func TestPrevalidateBlock(t *testing.T) {
+	require := require.New(t)
+
+	bc, dao, ap, cfg := createTestChain(t, func(*config.Config) {})
+	require.NoError(addTestingTsfBlocks(cfg, bc, dao, ap))
+	bc2, _, _, _ := createTestChain(t, func(cfg *config.Config) {
+		cfg.Chain.PipelineDepth = 4
+	})
+	var blks []*block.Block
+	for h := uint64(1); h <= bc.TipHeight(); h++ {
+		blk, err := dao.GetBlockByHeight(h)
+		require.NoError(err)
+		blks = append(blks, blk)
+	}
+	// a block with the actions mismatching the tx root is rejected ahead
+	tampered := *blks[1]
+	tampered.Actions = blks[2].Actions
+	require.ErrorIs(bc2.PrevalidateBlock(&tampered), block.ErrTxRootMismatch)
+	// the next block is prevalidated while the current one is being committed
+	require.NoError(bc2.PrevalidateBlock(blks[0]))
+	for i, blk := range blks {
+		errs := make(chan error, 1)
+		if i+1 < len(blks) {
+			go func() {
+				errs <- bc2.PrevalidateBlock(blks[i+1])
+			}()
+		} else {
+			errs <- nil
+		}
+		require.NoError(bc2.ValidateBlock(blk))
+		require.NoError(bc2.CommitBlock(blk))
+		require.NoError(<-errs)
+	}
+	require.Equal(bc.TipHash(), bc2.TipHash())
+}
+
 // verify the block contains all tx/log indices up to txIndex and logIndex
 func verifyTxLogIndex(r *require.Assertions, dao blockdao.BlockDAO, blk *block.Block, txIndex int, logIndex uint32) {
 	r.Equal(txIndex, len(blk.Actions))
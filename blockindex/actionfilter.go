@@ -0,0 +1,144 @@
+// Copyright (c) 2022 IoTeX Foundation
+// This is an alpha (internal) release and is not suitable for production. This source code is provided 'as is' and no
+// warranties are given as to title or non-infringement, merchantability or fitness for purpose and, to the extent
+// permitted by law, all liability for your use of the code is disclaimed. This source code is governed by Apache
+// License 2.0 that can be found in the LICENSE file.
+
+package blockindex
+
+import (
+	"bytes"
+
+	"github.com/iotexproject/go-pkgs/hash"
+	"github.com/iotexproject/iotex-proto/golang/iotextypes"
+	"github.com/pkg/errors"
+
+	"github.com/iotexproject/iotex-core/action"
+)
+
+// ActionDirection is the direction of the actions of an address
+type ActionDirection uint8
+
+// ActionStatus is the status of the receipts of the actions
+type ActionStatus uint8
+
+const (
+	// AnyDirection selects the actions sent or received by the address
+	AnyDirection ActionDirection = iota
+	// Outgoing selects the actions sent by the address
+	Outgoing
+	// Incoming selects the actions whose recipient is the address
+	Incoming
+)
+
+const (
+	// AnyStatus selects the actions regardless of the status
+	AnyStatus ActionStatus = iota
+	// Succeeded selects the actions with successful receipts
+	Succeeded
+	// Failed selects the actions with failed receipts
+	Failed
+)
+
+// _maxActionsScanned is the max number of actions scanned by a call of FilterActionsByAddress, so that a filter
+// matching few actions of a large account still returns in time
+const _maxActionsScanned = 10000
+
+// ErrStaleActionCursor indicates the actions before the cursor have been reverted since it was made
+var ErrStaleActionCursor = errors.New("action cursor is stale")
+
+type (
+	// ActionFilter selects the actions of an address, the zero value selects all of them
+	ActionFilter struct {
+		// Types are the names of action types in lower camel case, e.g. transfer, execution and createStake
+		Types     []string
+		Direction ActionDirection
+		Status    ActionStatus
+		// StartHeight and EndHeight are the inclusive range of block heights, EndHeight 0 means no upper bound
+		StartHeight uint64
+		EndHeight   uint64
+	}
+
+	// ActionCursor is the position of listing the actions of an address. Position is the number of the actions
+	// scanned in the ascending order, or the number of the actions not yet scanned in the descending order, which
+	// starts from the latest action if Position is larger than the number of actions. Anchor is the hash of the last
+	// action scanned, to detect the actions reverted since the cursor was made
+	ActionCursor struct {
+		Position uint64
+		Desc     bool
+		Anchor   []byte
+	}
+
+	// AddressAction is an action of an address found by FilterActionsByAddress, Unverified is true if the action was
+	// indexed before its details are recorded, so only its height is checked against the filter
+	AddressAction struct {
+		Hash        hash.Hash256
+		BlockHeight uint64
+		Unverified  bool
+	}
+)
+
+// HeightOnly returns true if the filter only checks the heights of actions
+func (f *ActionFilter) HeightOnly() bool {
+	return len(f.Types) == 0 && f.Direction == AnyDirection && f.Status == AnyStatus
+}
+
+// MatchAction returns whether the action of addr in the block at height matches the filter, the receipt of the action
+// is needed if the filter checks the status
+func (f *ActionFilter) MatchAction(addr hash.Hash160, height uint64, selp action.SealedEnvelope, receipt *action.Receipt) (bool, error) {
+	a, err := newActionIndex(height, selp, receipt, true)
+	if err != nil {
+		return false, err
+	}
+	matched, _ := f.match(addr, a)
+	return matched, nil
+}
+
+// inRange returns whether the height is in the range of the filter
+func (f *ActionFilter) inRange(height uint64) bool {
+	return height >= f.StartHeight && (f.EndHeight == 0 || height <= f.EndHeight)
+}
+
+// match returns whether the indexed action of addr matches the filter, verified is false if the details needed are
+// not indexed, in which case the action is matched by the details available
+func (f *ActionFilter) match(addr hash.Hash160, a *actionIndex) (matched bool, verified bool) {
+	if !f.inRange(a.blkHeight) {
+		return false, true
+	}
+	if f.HeightOnly() {
+		return true, true
+	}
+	if len(a.sender) == 0 {
+		return true, false
+	}
+	switch f.Direction {
+	case Outgoing:
+		if !bytes.Equal(a.sender, addr[:]) {
+			return false, true
+		}
+	case Incoming:
+		if !bytes.Equal(a.recipient, addr[:]) {
+			return false, true
+		}
+	}
+	if len(f.Types) > 0 && !f.hasType(a.actType) {
+		return false, true
+	}
+	if f.Status == AnyStatus {
+		return true, true
+	}
+	if !a.hasStatus {
+		return true, false
+	}
+	succeeded := a.status == uint64(iotextypes.ReceiptStatus_Success)
+	return succeeded == (f.Status == Succeeded), true
+}
+
+func (f *ActionFilter) hasType(actType string) bool {
+	for _, t := range f.Types {
+		if t == actType {
+			return true
+		}
+	}
+	return false
+}
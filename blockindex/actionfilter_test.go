@@ -0,0 +1,61 @@
+// Copyright (c) 2022 IoTeX Foundation
+// This is an alpha (internal) release and is not suitable for production. This source code is provided 'as is' and no
+// warranties are given as to title or non-infringement, merchantability or fitness for purpose and, to the extent
+// permitted by law, all liability for your use of the code is disclaimed. This source code is governed by Apache
+// License 2.0 that can be found in the LICENSE file.
+
+package blockindex
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/iotexproject/go-pkgs/hash"
+	"github.com/iotexproject/iotex-proto/golang/iotextypes"
+	"github.com/stretchr/testify/require"
+
+	"github.com/iotexproject/iotex-core/action"
+	"github.com/iotexproject/iotex-core/test/identityset"
+	"github.com/iotexproject/iotex-core/testutil"
+)
+
+func TestActionFilter(t *testing.T) {
+	require := require.New(t)
+	addr1 := hash.BytesToHash160(identityset.Address(1).Bytes())
+	addr2 := hash.BytesToHash160(identityset.Address(2).Bytes())
+	tsf, err := action.SignedTransfer(identityset.Address(2).String(), identityset.PrivateKey(1), 1, big.NewInt(1), nil, testutil.TestGasLimit, big.NewInt(0))
+	require.NoError(err)
+	failed := &action.Receipt{Status: uint64(iotextypes.ReceiptStatus_ErrExecutionReverted)}
+
+	for _, v := range []struct {
+		filter  *ActionFilter
+		addr    hash.Hash160
+		receipt *action.Receipt
+		matched bool
+	}{
+		{&ActionFilter{}, addr1, nil, true},
+		{&ActionFilter{Direction: Outgoing}, addr1, nil, true},
+		{&ActionFilter{Direction: Outgoing}, addr2, nil, false},
+		{&ActionFilter{Direction: Incoming}, addr2, nil, true},
+		{&ActionFilter{Types: []string{"execution", "transfer"}}, addr1, nil, true},
+		{&ActionFilter{Types: []string{"createStake"}}, addr1, nil, false},
+		{&ActionFilter{Status: Failed}, addr1, failed, true},
+		{&ActionFilter{Status: Succeeded}, addr1, failed, false},
+		{&ActionFilter{StartHeight: 5, EndHeight: 10}, addr1, nil, true},
+		{&ActionFilter{StartHeight: 11}, addr1, nil, false},
+		{&ActionFilter{EndHeight: 4}, addr1, nil, false},
+	} {
+		matched, err := v.filter.MatchAction(v.addr, 5, tsf, v.receipt)
+		require.NoError(err)
+		require.Equal(v.matched, matched)
+	}
+
+	// the actions indexed without details are matched by heights only
+	legacy := &actionIndex{blkHeight: 5}
+	matched, verified := (&ActionFilter{Direction: Incoming}).match(addr1, legacy)
+	require.True(matched)
+	require.False(verified)
+	matched, verified = (&ActionFilter{Direction: Incoming, StartHeight: 6}).match(addr1, legacy)
+	require.False(matched)
+	require.True(verified)
+}
@@ -10,12 +10,49 @@ import (
 	"github.com/pkg/errors"
 	"google.golang.org/protobuf/proto"
 
+	"github.com/iotexproject/iotex-address/address"
+	"github.com/iotexproject/iotex-core/action"
 	"github.com/iotexproject/iotex-core/blockindex/indexpb"
 	"github.com/iotexproject/iotex-core/pkg/util/byteutil"
 )
 
 type actionIndex struct {
 	blkHeight uint64
+	// the details below are empty for the actions indexed before they are recorded
+	sender    []byte
+	recipient []byte
+	actType   string
+	hasStatus bool
+	status    uint64
+}
+
+// newActionIndex creates the index of an action in the block at height, receipt is nil if it is not available
+func newActionIndex(height uint64, selp action.SealedEnvelope, receipt *action.Receipt, tolerateLegacyAddress bool) (*actionIndex, error) {
+	a := &actionIndex{
+		blkHeight: height,
+		sender:    selp.SrcPubkey().Hash(),
+		actType:   actionTypeName(selp.Action()),
+	}
+	if dst, ok := selp.Destination(); ok && dst != "" {
+		var (
+			dstAddr address.Address
+			err     error
+		)
+		if tolerateLegacyAddress {
+			dstAddr, err = address.FromStringLegacy(dst)
+		} else {
+			dstAddr, err = address.FromString(dst)
+		}
+		if err != nil {
+			return nil, err
+		}
+		a.recipient = dstAddr.Bytes()
+	}
+	if receipt != nil {
+		a.hasStatus = true
+		a.status = receipt.Status
+	}
+	return a, nil
 }
 
 // Height returns the block height of action
@@ -40,7 +77,12 @@ func (a *actionIndex) Deserialize(buf []byte) error {
 // toProto converts to protobuf
 func (a *actionIndex) toProto() *indexpb.ActionIndex {
 	return &indexpb.ActionIndex{
-		BlkHeight: a.blkHeight,
+		BlkHeight:  a.blkHeight,
+		Sender:     a.sender,
+		Recipient:  a.recipient,
+		ActionType: a.actType,
+		HasStatus:  a.hasStatus,
+		Status:     a.status,
 	}
 }
 
@@ -50,5 +92,10 @@ func (a *actionIndex) fromProto(pbIndex *indexpb.ActionIndex) error {
 		return errors.New("empty protobuf")
 	}
 	a.blkHeight = pbIndex.BlkHeight
+	a.sender = pbIndex.Sender
+	a.recipient = pbIndex.Recipient
+	a.actType = pbIndex.ActionType
+	a.hasStatus = pbIndex.HasStatus
+	a.status = pbIndex.Status
 	return nil
 }
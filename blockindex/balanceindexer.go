@@ -0,0 +1,444 @@
+// Copyright (c) 2022 IoTeX Foundation
+// This is an alpha (internal) release and is not suitable for production. This source code is provided 'as is' and no
+// warranties are given as to title or non-infringement, merchantability or fitness for purpose and, to the extent
+// permitted by law, all liability for your use of the code is disclaimed. This source code is governed by Apache
+// License 2.0 that can be found in the LICENSE file.
+
+package blockindex
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"math/big"
+	"sort"
+	"sync"
+
+	"github.com/iotexproject/go-pkgs/hash"
+	"github.com/pkg/errors"
+	"go.uber.org/zap"
+
+	"github.com/iotexproject/iotex-core/blockchain/block"
+	"github.com/iotexproject/iotex-core/db"
+	"github.com/iotexproject/iotex-core/db/batch"
+	"github.com/iotexproject/iotex-core/pkg/log"
+	"github.com/iotexproject/iotex-core/pkg/util/byteutil"
+)
+
+// the balance history of an address is a counting index named by the address, whose entries are the heights and the
+// balances after the blocks, in the ascending order of heights
+const (
+	_balanceMetaNS    = "bm"
+	_balanceTouchedNS = "bt"
+)
+
+var (
+	_balanceTipKey   = []byte("tip")
+	_balanceStartKey = []byte("start")
+	// ErrBalanceHistoryNA indicates the balance history at the height is not available
+	ErrBalanceHistoryNA = errors.New("balance history not available")
+)
+
+type (
+	// BalanceChange is the balance of an account after the block at height
+	BalanceChange struct {
+		Height  uint64
+		Balance *big.Int
+	}
+
+	// BalanceIndexer records the balances of the accounts touched by each block, which are received from the state
+	// factory once the block is committed, such that the balance of an account at any height since the indexer
+	// starts could be read without the archived states
+	BalanceIndexer interface {
+		Start(context.Context) error
+		Stop(context.Context) error
+		Height() (uint64, error)
+		PutBlock(context.Context, *block.Block) error
+		DeleteTipBlock(context.Context, *block.Block) error
+		// ReceiveBalances records the balances of the accounts touched by the block at height
+		ReceiveBalances(uint64, map[hash.Hash160]*big.Int)
+		// StartHeight returns the lowest height of which the balances are available
+		StartHeight() uint64
+		// BalanceAt returns the balance of an address after the block at height
+		BalanceAt(hash.Hash160, uint64) (*big.Int, error)
+		// BalanceHistory returns at most count balance changes of an address from startHeight
+		BalanceHistory(hash.Hash160, uint64, uint64) ([]*BalanceChange, error)
+	}
+
+	balanceIndexer struct {
+		mutex   sync.RWMutex
+		kvStore db.KVStoreWithRange
+		tip     uint64
+		start   uint64
+		fresh   bool
+		genesis map[hash.Hash160]*big.Int
+		// pending are the balances of the block following tip which failed to be written, and are written again
+		// when the block is put
+		pending map[hash.Hash160]*big.Int
+	}
+
+	// balanceHistory is the balance history of an address being written in a batch, along with its last change, as
+	// the changes in the batch are not readable before the batch is written
+	balanceHistory struct {
+		db.CountingIndex
+		last *BalanceChange
+	}
+)
+
+// NewBalanceIndexer creates a new balance indexer
+func NewBalanceIndexer(kv db.KVStore) (BalanceIndexer, error) {
+	if kv == nil {
+		return nil, errors.New("empty kvStore")
+	}
+	kvRange, ok := kv.(db.KVStoreWithRange)
+	if !ok {
+		return nil, errors.New("balance indexer can only be created from KVStoreWithRange")
+	}
+	return &balanceIndexer{kvStore: kvRange}, nil
+}
+
+// Start starts the indexer
+func (x *balanceIndexer) Start(ctx context.Context) error {
+	if err := x.kvStore.Start(ctx); err != nil {
+		return err
+	}
+	x.mutex.Lock()
+	defer x.mutex.Unlock()
+	start, err := x.kvStore.Get(_balanceMetaNS, _balanceStartKey)
+	switch errors.Cause(err) {
+	case nil:
+		x.start = byteutil.BytesToUint64BigEndian(start)
+	case db.ErrNotExist, db.ErrBucketNotExist:
+		x.fresh = true
+		return nil
+	default:
+		return err
+	}
+	tip, err := x.kvStore.Get(_balanceMetaNS, _balanceTipKey)
+	if err != nil {
+		return err
+	}
+	x.tip = byteutil.BytesToUint64BigEndian(tip)
+	return nil
+}
+
+// Stop stops the indexer
+func (x *balanceIndexer) Stop(ctx context.Context) error {
+	return x.kvStore.Stop(ctx)
+}
+
+// Height returns the height of the last block indexed
+func (x *balanceIndexer) Height() (uint64, error) {
+	x.mutex.RLock()
+	defer x.mutex.RUnlock()
+	return x.tip, nil
+}
+
+// StartHeight returns the lowest height of which the balances are available
+func (x *balanceIndexer) StartHeight() uint64 {
+	x.mutex.RLock()
+	defer x.mutex.RUnlock()
+	return x.start
+}
+
+// ReceiveBalances records the balances of the accounts touched by the block at height. The balances of the genesis
+// states are kept until the first block is indexed. The balances failed to be written are kept, and written again
+// when the block is put
+func (x *balanceIndexer) ReceiveBalances(height uint64, balances map[hash.Hash160]*big.Int) {
+	x.mutex.Lock()
+	defer x.mutex.Unlock()
+	if height == 0 {
+		x.genesis = balances
+		return
+	}
+	if height != x.tip+1 {
+		log.L().Debug("Ignored the balances of unexpected height.", zap.Uint64("height", height), zap.Uint64("tip", x.tip))
+		return
+	}
+	if err := x.putBalances(height, balances); err != nil {
+		log.L().Error("Failed to index balances.", zap.Uint64("height", height), zap.Error(err))
+		x.pending = balances
+	}
+}
+
+// PutBlock writes the balances of the block failed to be written before. If the balances have not been received,
+// the block is marked as indexed only if no balance history is available yet, i.e., the indexer starts after the
+// block, otherwise the history would be broken
+func (x *balanceIndexer) PutBlock(_ context.Context, blk *block.Block) error {
+	x.mutex.Lock()
+	defer x.mutex.Unlock()
+	height := blk.Height()
+	if height <= x.tip {
+		// indexed by the balances received
+		return nil
+	}
+	if height != x.tip+1 {
+		return errors.Wrapf(db.ErrInvalid, "wrong block height %d, expecting %d", height, x.tip+1)
+	}
+	if x.pending != nil {
+		if err := x.putBalances(height, x.pending); err != nil {
+			return errors.Wrapf(err, "failed to index balances at height %d", height)
+		}
+		return nil
+	}
+	if !x.fresh && x.start <= x.tip {
+		return errors.Wrapf(
+			ErrBalanceHistoryNA,
+			"balances of block %d are missing, the balance index has to be rebuilt",
+			height,
+		)
+	}
+	b := batch.NewBatch()
+	x.putMeta(b, height, height+1)
+	if err := x.kvStore.WriteBatch(b); err != nil {
+		return err
+	}
+	x.tip, x.start, x.fresh, x.genesis = height, height+1, false, nil
+	return nil
+}
+
+// DeleteTipBlock removes the balances of the tip block
+func (x *balanceIndexer) DeleteTipBlock(_ context.Context, blk *block.Block) error {
+	x.mutex.Lock()
+	defer x.mutex.Unlock()
+	height := blk.Height()
+	if height != x.tip {
+		return errors.Wrapf(db.ErrInvalid, "wrong block height %d, expecting %d", height, x.tip)
+	}
+	if height == 0 {
+		return errors.Wrap(db.ErrInvalid, "cannot delete genesis block")
+	}
+	key := byteutil.Uint64ToBytesBigEndian(height)
+	touched, err := x.kvStore.Get(_balanceTouchedNS, key)
+	switch errors.Cause(err) {
+	case nil:
+	case db.ErrNotExist, db.ErrBucketNotExist:
+		touched = nil
+	default:
+		return err
+	}
+	for i := 0; i+len(hash.ZeroHash160) <= len(touched); i += len(hash.ZeroHash160) {
+		history, err := db.GetCountingIndex(x.kvStore, touched[i:i+len(hash.ZeroHash160)])
+		if err != nil {
+			return err
+		}
+		if err := history.Revert(1); err != nil {
+			return err
+		}
+	}
+	start := x.start
+	if start > height-1 {
+		// the balances before the tip block are unknown
+		start = height
+	}
+	b := batch.NewBatch()
+	b.Delete(_balanceTouchedNS, key, fmt.Sprintf("failed to delete accounts touched at height %d", height))
+	x.putMeta(b, height-1, start)
+	if err := x.kvStore.WriteBatch(b); err != nil {
+		return err
+	}
+	x.tip, x.start, x.pending = height-1, start, nil
+	return nil
+}
+
+// BalanceAt returns the balance of an address after the block at height
+func (x *balanceIndexer) BalanceAt(addr hash.Hash160, height uint64) (*big.Int, error) {
+	x.mutex.RLock()
+	defer x.mutex.RUnlock()
+	if height < x.start || height > x.tip || x.fresh {
+		return nil, errors.Wrapf(ErrBalanceHistoryNA, "height %d is out of range [%d, %d]", height, x.start, x.tip)
+	}
+	history, size, err := x.history(addr)
+	if err != nil {
+		return nil, err
+	}
+	// the index of the first change after height
+	var searchErr error
+	i := sort.Search(int(size), func(i int) bool {
+		if searchErr != nil {
+			return true
+		}
+		change, err := x.change(history, uint64(i))
+		if err != nil {
+			searchErr = err
+			return true
+		}
+		return change.Height > height
+	})
+	if searchErr != nil {
+		return nil, searchErr
+	}
+	if i == 0 {
+		if x.start > 0 {
+			return nil, errors.Wrapf(ErrBalanceHistoryNA, "no balance of %x recorded since height %d", addr, x.start)
+		}
+		return big.NewInt(0), nil
+	}
+	change, err := x.change(history, uint64(i-1))
+	if err != nil {
+		return nil, err
+	}
+	if change.Height < x.start {
+		return nil, errors.Wrapf(ErrBalanceHistoryNA, "no balance of %x recorded since height %d", addr, x.start)
+	}
+	return change.Balance, nil
+}
+
+// BalanceHistory returns at most count balance changes of an address, from the first one not lower than startHeight
+func (x *balanceIndexer) BalanceHistory(addr hash.Hash160, startHeight, count uint64) ([]*BalanceChange, error) {
+	x.mutex.RLock()
+	defer x.mutex.RUnlock()
+	history, size, err := x.history(addr)
+	if err != nil {
+		return nil, err
+	}
+	var searchErr error
+	i := sort.Search(int(size), func(i int) bool {
+		if searchErr != nil {
+			return true
+		}
+		change, err := x.change(history, uint64(i))
+		if err != nil {
+			searchErr = err
+			return true
+		}
+		return change.Height >= startHeight
+	})
+	if searchErr != nil {
+		return nil, searchErr
+	}
+	first := uint64(i)
+	if first+count > size {
+		count = size - first
+	}
+	if count == 0 {
+		return []*BalanceChange{}, nil
+	}
+	values, err := history.Range(first, count)
+	if err != nil {
+		return nil, err
+	}
+	changes := make([]*BalanceChange, 0, len(values))
+	for _, v := range values {
+		changes = append(changes, deserializeBalanceChange(v))
+	}
+	return changes, nil
+}
+
+// putBalances writes the balances of the block at height along with the meta in one batch, the balances not changed
+// are left out
+func (x *balanceIndexer) putBalances(height uint64, balances map[hash.Hash160]*big.Int) error {
+	var (
+		b         = batch.NewBatch()
+		histories = map[hash.Hash160]*balanceHistory{}
+		start     = x.start
+	)
+	if x.fresh {
+		start = height
+		if x.genesis != nil {
+			// the balances are complete since the genesis
+			start = 0
+			if err := x.putChanges(b, histories, 0, x.genesis, start); err != nil {
+				return err
+			}
+		}
+	}
+	if err := x.putChanges(b, histories, height, balances, start); err != nil {
+		return err
+	}
+	x.putMeta(b, height, start)
+	if err := x.kvStore.WriteBatch(b); err != nil {
+		return err
+	}
+	x.tip, x.start, x.fresh, x.genesis, x.pending = height, start, false, nil, nil
+	return nil
+}
+
+func (x *balanceIndexer) putChanges(
+	b batch.KVStoreBatch,
+	histories map[hash.Hash160]*balanceHistory,
+	height uint64,
+	balances map[hash.Hash160]*big.Int,
+	start uint64,
+) error {
+	addrs := make([]hash.Hash160, 0, len(balances))
+	for addr := range balances {
+		addrs = append(addrs, addr)
+	}
+	sort.Slice(addrs, func(i, j int) bool {
+		return bytes.Compare(addrs[i][:], addrs[j][:]) < 0
+	})
+	var touched []byte
+	for _, addr := range addrs {
+		history, ok := histories[addr]
+		if !ok {
+			index, err := db.NewCountingIndexNX(x.kvStore, addr[:])
+			if err != nil {
+				return err
+			}
+			history = &balanceHistory{CountingIndex: index}
+			if size := index.Size(); size > 0 {
+				if history.last, err = x.change(index, size-1); err != nil {
+					return err
+				}
+			}
+			histories[addr] = history
+		}
+		if history.last != nil && history.last.Height >= start && history.last.Balance.Cmp(balances[addr]) == 0 {
+			continue
+		}
+		change := &BalanceChange{Height: height, Balance: balances[addr]}
+		if err := history.UseBatch(b); err != nil {
+			return err
+		}
+		if err := history.Add(change.serialize(), true); err != nil {
+			return err
+		}
+		if err := history.Finalize(); err != nil {
+			return err
+		}
+		history.last = change
+		touched = append(touched, addr[:]...)
+	}
+	if len(touched) > 0 {
+		b.Put(_balanceTouchedNS, byteutil.Uint64ToBytesBigEndian(height), touched, fmt.Sprintf("failed to put accounts touched at height %d", height))
+	}
+	return nil
+}
+
+func (x *balanceIndexer) putMeta(b batch.KVStoreBatch, tip, start uint64) {
+	b.Put(_balanceMetaNS, _balanceTipKey, byteutil.Uint64ToBytesBigEndian(tip), "failed to put tip height")
+	b.Put(_balanceMetaNS, _balanceStartKey, byteutil.Uint64ToBytesBigEndian(start), "failed to put start height")
+}
+
+// history returns the balance history of an address and its size, which is empty if the address is never touched
+func (x *balanceIndexer) history(addr hash.Hash160) (db.CountingIndex, uint64, error) {
+	history, err := db.GetCountingIndex(x.kvStore, addr[:])
+	switch errors.Cause(err) {
+	case nil:
+		return history, history.Size(), nil
+	case db.ErrNotExist, db.ErrBucketNotExist:
+		return nil, 0, nil
+	default:
+		return nil, 0, err
+	}
+}
+
+func (x *balanceIndexer) change(history db.CountingIndex, i uint64) (*BalanceChange, error) {
+	v, err := history.Get(i)
+	if err != nil {
+		return nil, err
+	}
+	return deserializeBalanceChange(v), nil
+}
+
+func (c *BalanceChange) serialize() []byte {
+	return append(byteutil.Uint64ToBytesBigEndian(c.Height), c.Balance.Bytes()...)
+}
+
+func deserializeBalanceChange(v []byte) *BalanceChange {
+	return &BalanceChange{
+		Height:  byteutil.BytesToUint64BigEndian(v[:8]),
+		Balance: new(big.Int).SetBytes(v[8:]),
+	}
+}
@@ -0,0 +1,166 @@
+// Copyright (c) 2022 IoTeX Foundation
+// This is an alpha (internal) release and is not suitable for production. This source code is provided 'as is' and no
+// warranties are given as to title or non-infringement, merchantability or fitness for purpose and, to the extent
+// permitted by law, all liability for your use of the code is disclaimed. This source code is governed by Apache
+// License 2.0 that can be found in the LICENSE file.
+
+package blockindex
+
+import (
+	"context"
+	"math/big"
+	"testing"
+
+	"github.com/iotexproject/go-pkgs/hash"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/require"
+
+	"github.com/iotexproject/iotex-core/db"
+	"github.com/iotexproject/iotex-core/db/batch"
+	"github.com/iotexproject/iotex-core/test/identityset"
+)
+
+func TestBalanceIndexer(t *testing.T) {
+	require := require.New(t)
+	ctx := context.Background()
+	blks := getTestBlocks(t)
+	a, b, c := hash.BytesToHash160(identityset.Address(28).Bytes()),
+		hash.BytesToHash160(identityset.Address(29).Bytes()),
+		hash.BytesToHash160(identityset.Address(30).Bytes())
+
+	t.Run("since genesis", func(t *testing.T) {
+		kv := db.NewMemKVStore()
+		indexer, err := NewBalanceIndexer(kv)
+		require.NoError(err)
+		// the genesis balances are received before the indexer starts
+		indexer.ReceiveBalances(0, map[hash.Hash160]*big.Int{a: big.NewInt(100), b: big.NewInt(100)})
+		require.NoError(indexer.Start(ctx))
+
+		indexer.ReceiveBalances(1, map[hash.Hash160]*big.Int{a: big.NewInt(90), b: big.NewInt(100)})
+		require.NoError(indexer.PutBlock(ctx, blks[0]))
+		indexer.ReceiveBalances(2, map[hash.Hash160]*big.Int{b: big.NewInt(80), c: big.NewInt(20)})
+		require.NoError(indexer.PutBlock(ctx, blks[1]))
+		height, err := indexer.Height()
+		require.NoError(err)
+		require.EqualValues(2, height)
+		require.Zero(indexer.StartHeight())
+
+		for _, v := range []struct {
+			addr    hash.Hash160
+			height  uint64
+			balance int64
+		}{
+			{a, 0, 100}, {a, 1, 90}, {a, 2, 90},
+			{b, 0, 100}, {b, 1, 100}, {b, 2, 80},
+			{c, 0, 0}, {c, 1, 0}, {c, 2, 20},
+		} {
+			balance, err := indexer.BalanceAt(v.addr, v.height)
+			require.NoError(err)
+			require.Equal(big.NewInt(v.balance), balance)
+		}
+		_, err = indexer.BalanceAt(a, 3)
+		require.Equal(ErrBalanceHistoryNA, errors.Cause(err))
+
+		// the unchanged balance of b at height 1 is left out
+		changes, err := indexer.BalanceHistory(b, 0, 10)
+		require.NoError(err)
+		require.Equal([]*BalanceChange{{0, big.NewInt(100)}, {2, big.NewInt(80)}}, changes)
+		changes, err = indexer.BalanceHistory(b, 1, 10)
+		require.NoError(err)
+		require.Equal([]*BalanceChange{{2, big.NewInt(80)}}, changes)
+		changes, err = indexer.BalanceHistory(a, 0, 1)
+		require.NoError(err)
+		require.Equal([]*BalanceChange{{0, big.NewInt(100)}}, changes)
+		changes, err = indexer.BalanceHistory(c, 3, 10)
+		require.NoError(err)
+		require.Empty(changes)
+
+		// delete the tip block
+		require.NoError(indexer.DeleteTipBlock(ctx, blks[1]))
+		height, err = indexer.Height()
+		require.NoError(err)
+		require.EqualValues(1, height)
+		changes, err = indexer.BalanceHistory(b, 0, 10)
+		require.NoError(err)
+		require.Equal([]*BalanceChange{{0, big.NewInt(100)}}, changes)
+		_, err = indexer.BalanceAt(c, 2)
+		require.Equal(ErrBalanceHistoryNA, errors.Cause(err))
+
+		// the meta is restored after restart
+		require.NoError(indexer.Stop(ctx))
+		indexer, err = NewBalanceIndexer(kv)
+		require.NoError(err)
+		require.NoError(indexer.Start(ctx))
+		height, err = indexer.Height()
+		require.NoError(err)
+		require.EqualValues(1, height)
+		balance, err := indexer.BalanceAt(a, 1)
+		require.NoError(err)
+		require.Equal(big.NewInt(90), balance)
+	})
+
+	t.Run("missing balances", func(t *testing.T) {
+		indexer, err := NewBalanceIndexer(db.NewMemKVStore())
+		require.NoError(err)
+		require.NoError(indexer.Start(ctx))
+
+		// the indexer is enabled after block 1
+		require.NoError(indexer.PutBlock(ctx, blks[0]))
+		require.EqualValues(2, indexer.StartHeight())
+		indexer.ReceiveBalances(2, map[hash.Hash160]*big.Int{a: big.NewInt(90)})
+		require.NoError(indexer.PutBlock(ctx, blks[1]))
+		require.EqualValues(2, indexer.StartHeight())
+		// the balances of unexpected height are ignored
+		indexer.ReceiveBalances(4, map[hash.Hash160]*big.Int{b: big.NewInt(80)})
+		// the block missing balances is refused, which would otherwise break the history
+		require.Equal(ErrBalanceHistoryNA, errors.Cause(indexer.PutBlock(ctx, blks[2])))
+		require.EqualValues(2, indexer.StartHeight())
+		height, err := indexer.Height()
+		require.NoError(err)
+		require.EqualValues(2, height)
+		balance, err := indexer.BalanceAt(a, 2)
+		require.NoError(err)
+		require.Equal(big.NewInt(90), balance)
+		_, err = indexer.BalanceAt(b, 2)
+		require.Equal(ErrBalanceHistoryNA, errors.Cause(err))
+	})
+
+	t.Run("write failure", func(t *testing.T) {
+		kv := &failingKVStore{KVStoreWithRange: db.NewMemKVStore().(db.KVStoreWithRange)}
+		indexer, err := NewBalanceIndexer(kv)
+		require.NoError(err)
+		require.NoError(indexer.Start(ctx))
+		indexer.ReceiveBalances(0, map[hash.Hash160]*big.Int{a: big.NewInt(100)})
+		kv.err = errors.New("disk full")
+		indexer.ReceiveBalances(1, map[hash.Hash160]*big.Int{a: big.NewInt(90)})
+		height, err := indexer.Height()
+		require.NoError(err)
+		require.Zero(height)
+		// the balances failed to be written are written again when the block is put
+		require.Equal(kv.err, errors.Cause(indexer.PutBlock(ctx, blks[0])))
+		kv.err = nil
+		changes, err := indexer.BalanceHistory(a, 0, 10)
+		require.NoError(err)
+		require.Empty(changes)
+		require.NoError(indexer.PutBlock(ctx, blks[0]))
+		height, err = indexer.Height()
+		require.NoError(err)
+		require.EqualValues(1, height)
+		require.Zero(indexer.StartHeight())
+		changes, err = indexer.BalanceHistory(a, 0, 10)
+		require.NoError(err)
+		require.Equal([]*BalanceChange{{0, big.NewInt(100)}, {1, big.NewInt(90)}}, changes)
+	})
+}
+
+type failingKVStore struct {
+	db.KVStoreWithRange
+	err error
+}
+
+func (kv *failingKVStore) WriteBatch(b batch.KVStoreBatch) error {
+	if kv.err != nil {
+		return kv.err
+	}
+	return kv.KVStoreWithRange.WriteBatch(b)
+}
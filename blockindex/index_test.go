@@ -6,14 +6,24 @@ import (
 	"testing"
 
 	"github.com/stretchr/testify/require"
+
+	"github.com/iotexproject/iotex-core/test/identityset"
 )
 
 func TestActionIndex(t *testing.T) {
 	require := require.New(t)
 
 	ad := []*actionIndex{
-		{1048000},
-		{1048001},
+		{blkHeight: 1048000},
+		{blkHeight: 1048001},
+		{
+			blkHeight: 1048002,
+			sender:    identityset.Address(1).Bytes(),
+			recipient: identityset.Address(2).Bytes(),
+			actType:   "transfer",
+			hasStatus: true,
+			status:    1,
+		},
 	}
 
 	for i := range ad {
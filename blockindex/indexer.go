@@ -11,12 +11,12 @@ import (
 	"context"
 	"fmt"
 	"math/big"
+	"sort"
 	"sync"
 
 	"github.com/iotexproject/go-pkgs/hash"
 	"github.com/pkg/errors"
 
-	"github.com/iotexproject/iotex-address/address"
 	"github.com/iotexproject/iotex-core/action"
 	"github.com/iotexproject/iotex-core/action/protocol"
 	"github.com/iotexproject/iotex-core/blockchain/block"
@@ -60,6 +60,7 @@ type (
 		GetActionHashFromIndex(uint64, uint64) ([][]byte, error)
 		GetActionCountByAddress(hash.Hash160) (uint64, error)
 		GetActionsByAddress(hash.Hash160, uint64, uint64) ([][]byte, error)
+		FilterActionsByAddress(hash.Hash160, *ActionFilter, *ActionCursor, uint64) ([]*AddressAction, *ActionCursor, error)
 	}
 
 	// blockIndexer implements the Indexer interface
@@ -172,7 +173,11 @@ func (x *blockIndexer) DeleteTipBlock(ctx context.Context, blk *block.Block) err
 			return err
 		}
 		x.batch.Delete(_actionToBlockHashNS, actHash[_hashOffset:], fmt.Sprintf("failed to delete action hash %x", actHash))
-		if err := x.indexAction(actHash, selp, false, fCtx.TolerateLegacyAddress); err != nil {
+		ad, err := newActionIndex(height, selp, nil, fCtx.TolerateLegacyAddress)
+		if err != nil {
+			return err
+		}
+		if err := x.indexAction(actHash, ad, false); err != nil {
 			return err
 		}
 	}
@@ -239,6 +244,10 @@ func (x *blockIndexer) GetActionIndex(h []byte) (*actionIndex, error) {
 	x.mutex.RLock()
 	defer x.mutex.RUnlock()
 
+	return x.getActionIndex(h)
+}
+
+func (x *blockIndexer) getActionIndex(h []byte) (*actionIndex, error) {
 	v, err := x.kvStore.Get(_actionToBlockHashNS, h[_hashOffset:])
 	if err != nil {
 		return nil, err
@@ -299,6 +308,149 @@ func (x *blockIndexer) GetActionsByAddress(addrBytes hash.Hash160, start, count
 	return addr.Range(start, count)
 }
 
+// FilterActionsByAddress scans the actions of an address from the cursor for at most count actions matching the
+// filter, and returns the cursor to continue, which is nil once all actions in the range of the filter are scanned
+func (x *blockIndexer) FilterActionsByAddress(addrBytes hash.Hash160, filter *ActionFilter, cursor *ActionCursor, count uint64) ([]*AddressAction, *ActionCursor, error) {
+	x.mutex.RLock()
+	defer x.mutex.RUnlock()
+
+	if filter == nil {
+		filter = &ActionFilter{}
+	}
+	if cursor == nil {
+		cursor = &ActionCursor{}
+	}
+	addr, err := db.GetCountingIndex(x.kvStore, addrBytes[:])
+	switch errors.Cause(err) {
+	case nil:
+	case db.ErrBucketNotExist, db.ErrNotExist:
+		return []*AddressAction{}, nil, nil
+	default:
+		return nil, nil, err
+	}
+	var (
+		total = addr.Size()
+		pos   = cursor.Position
+		desc  = cursor.Desc
+	)
+	if len(cursor.Anchor) > 0 {
+		if err := x.checkAnchor(addr, cursor); err != nil {
+			return nil, nil, err
+		}
+	} else if pos > total {
+		if !desc {
+			return []*AddressAction{}, nil, nil
+		}
+		pos = total
+	}
+	// skip the actions out of the height range
+	if pos, err = x.seekHeight(addr, filter, pos, total, desc); err != nil {
+		return nil, nil, err
+	}
+
+	var (
+		ret    = []*AddressAction{}
+		anchor []byte
+	)
+	for scanned := 0; uint64(len(ret)) < count && scanned < _maxActionsScanned; scanned++ {
+		if (desc && pos == 0) || (!desc && pos >= total) {
+			return ret, nil, nil
+		}
+		i := pos
+		if desc {
+			i = pos - 1
+		}
+		h, err := addr.Get(i)
+		if err != nil {
+			return nil, nil, err
+		}
+		ad, err := x.getActionIndex(h)
+		if err != nil {
+			return nil, nil, err
+		}
+		if (desc && ad.blkHeight < filter.StartHeight) || (!desc && filter.EndHeight > 0 && ad.blkHeight > filter.EndHeight) {
+			return ret, nil, nil
+		}
+		if desc {
+			pos--
+		} else {
+			pos++
+		}
+		anchor = h
+		if matched, verified := filter.match(addrBytes, ad); matched {
+			ret = append(ret, &AddressAction{
+				Hash:        hash.BytesToHash256(h),
+				BlockHeight: ad.blkHeight,
+				Unverified:  !verified,
+			})
+		}
+	}
+	if (desc && pos == 0) || (!desc && pos >= total) {
+		return ret, nil, nil
+	}
+	return ret, &ActionCursor{Position: pos, Desc: desc, Anchor: anchor}, nil
+}
+
+// checkAnchor checks the action last scanned before the cursor is still there
+func (x *blockIndexer) checkAnchor(addr db.CountingIndex, cursor *ActionCursor) error {
+	i := cursor.Position
+	if !cursor.Desc {
+		if i == 0 {
+			return errors.Wrap(ErrStaleActionCursor, "invalid position 0 with anchor")
+		}
+		i--
+	}
+	if i >= addr.Size() {
+		return errors.Wrapf(ErrStaleActionCursor, "position %d exceeds %d actions", cursor.Position, addr.Size())
+	}
+	h, err := addr.Get(i)
+	if err != nil {
+		return err
+	}
+	if !bytes.Equal(h, cursor.Anchor) {
+		return errors.Wrapf(ErrStaleActionCursor, "action %x at %d is changed", cursor.Anchor, i)
+	}
+	return nil
+}
+
+// seekHeight moves the position forward to the first action not lower than the start height in the ascending order,
+// or backward to the first action not higher than the end height in the descending order
+func (x *blockIndexer) seekHeight(addr db.CountingIndex, filter *ActionFilter, pos, total uint64, desc bool) (uint64, error) {
+	var (
+		lo, hi = pos, total
+		target = filter.StartHeight
+	)
+	if desc {
+		if filter.EndHeight == 0 {
+			return pos, nil
+		}
+		lo, hi, target = 0, pos, filter.EndHeight+1
+	} else if filter.StartHeight == 0 {
+		return pos, nil
+	}
+	var searchErr error
+	i := sort.Search(int(hi-lo), func(i int) bool {
+		if searchErr != nil {
+			return true
+		}
+		h, err := addr.Get(lo + uint64(i))
+		if err != nil {
+			searchErr = err
+			return true
+		}
+		ad, err := x.getActionIndex(h)
+		if err != nil {
+			searchErr = err
+			return true
+		}
+		return ad.blkHeight >= target
+	})
+	if searchErr != nil {
+		return 0, searchErr
+	}
+	return lo + uint64(i), nil
+}
+
 func (x *blockIndexer) putBlock(ctx context.Context, blk *block.Block) error {
 	// the block to be indexed must be exactly current top + 1, otherwise counting index would not work correctly
 	height := blk.Height()
@@ -307,12 +459,12 @@ func (x *blockIndexer) putBlock(ctx context.Context, blk *block.Block) error {
 	}
 
 	// index hash --> height
-	hash := blk.HashBlock()
-	x.batch.Put(_blockHashToHeightNS, hash[_hashOffset:], byteutil.Uint64ToBytesBigEndian(height), "failed to put hash -> height mapping")
+	blkHash := blk.HashBlock()
+	x.batch.Put(_blockHashToHeightNS, blkHash[_hashOffset:], byteutil.Uint64ToBytesBigEndian(height), "failed to put hash -> height mapping")
 
 	// index height --> block hash, number of actions, and total transfer amount
 	bd := &blockIndex{
-		hash:      hash[:],
+		hash:      blkHash[:],
 		numAction: uint32(len(blk.Actions)),
 		tsfAmount: blk.CalculateTransferAmount()}
 	if err := x.tbk.UseBatch(x.batch); err != nil {
@@ -322,9 +474,6 @@ func (x *blockIndexer) putBlock(ctx context.Context, blk *block.Block) error {
 		return errors.Wrapf(err, "failed to put block %d index", height)
 	}
 
-	// store height of the block, so getReceiptByActionHash() can use height to directly pull receipts
-	ad := (&actionIndex{
-		blkHeight: blk.Height()}).Serialize()
 	if err := x.tac.UseBatch(x.batch); err != nil {
 		return err
 	}
@@ -332,17 +481,27 @@ func (x *blockIndexer) putBlock(ctx context.Context, blk *block.Block) error {
 	fCtx := protocol.MustGetFeatureCtx(protocol.WithFeatureCtx(protocol.WithBlockCtx(ctx, protocol.BlockCtx{
 		BlockHeight: blk.Height(),
 	})))
+	receipts := make(map[hash.Hash256]*action.Receipt, len(blk.Receipts))
+	for _, r := range blk.Receipts {
+		receipts[r.ActionHash] = r
+	}
 	for _, selp := range blk.Actions {
 		actHash, err := selp.Hash()
 		if err != nil {
 			return err
 		}
-		x.batch.Put(_actionToBlockHashNS, actHash[_hashOffset:], ad, fmt.Sprintf("failed to put action hash %x", actHash))
+		// store height of the block, so getReceiptByActionHash() can use height to directly pull receipts, and the
+		// details to filter the actions of an address
+		ad, err := newActionIndex(height, selp, receipts[actHash], fCtx.TolerateLegacyAddress)
+		if err != nil {
+			return err
+		}
+		x.batch.Put(_actionToBlockHashNS, actHash[_hashOffset:], ad.Serialize(), fmt.Sprintf("failed to put action hash %x", actHash))
 		// add to total account index
 		if err := x.tac.Add(actHash[:], true); err != nil {
 			return err
 		}
-		if err := x.indexAction(actHash, selp, true, fCtx.TolerateLegacyAddress); err != nil {
+		if err := x.indexAction(actHash, ad, true); err != nil {
 			return err
 		}
 	}
@@ -401,10 +560,9 @@ func (x *blockIndexer) getIndexerForAddr(addr []byte, batch bool) (db.CountingIn
 }
 
 // indexAction builds index for an action
-func (x *blockIndexer) indexAction(actHash hash.Hash256, elp action.SealedEnvelope, insert, tolerateLegacyAddress bool) error {
+func (x *blockIndexer) indexAction(actHash hash.Hash256, ad *actionIndex, insert bool) error {
 	// add to sender's index
-	callerAddrBytes := elp.SrcPubkey().Hash()
-	sender, err := x.getIndexerForAddr(callerAddrBytes, insert)
+	sender, err := x.getIndexerForAddr(ad.sender, insert)
 	if err != nil {
 		return err
 	}
@@ -417,29 +575,16 @@ func (x *blockIndexer) indexAction(actHash hash.Hash256, elp action.SealedEnvelo
 		return err
 	}
 
-	dst, ok := elp.Destination()
-	if !ok || dst == "" {
+	if len(ad.recipient) == 0 {
 		return nil
 	}
-
-	var dstAddr address.Address
-	if tolerateLegacyAddress {
-		dstAddr, err = address.FromStringLegacy(dst)
-	} else {
-		dstAddr, err = address.FromString(dst)
-	}
-	if err != nil {
-		return err
-	}
-	dstAddrBytes := dstAddr.Bytes()
-
-	if bytes.Equal(dstAddrBytes, callerAddrBytes) {
+	if bytes.Equal(ad.recipient, ad.sender) {
 		// recipient is same as sender
 		return nil
 	}
 
 	// add to recipient's index
-	recipient, err := x.getIndexerForAddr(dstAddrBytes, insert)
+	recipient, err := x.getIndexerForAddr(ad.recipient, insert)
 	if err != nil {
 		return err
 	}
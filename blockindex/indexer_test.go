@@ -9,6 +9,7 @@ package blockindex
 import (
 	"context"
 	"hash/fnv"
+	"math"
 	"math/big"
 	"testing"
 
@@ -16,6 +17,7 @@ import (
 	"github.com/stretchr/testify/require"
 
 	"github.com/iotexproject/go-pkgs/hash"
+	"github.com/iotexproject/iotex-proto/golang/iotextypes"
 
 	"github.com/iotexproject/iotex-core/action"
 	"github.com/iotexproject/iotex-core/blockchain/block"
@@ -334,3 +336,101 @@ func TestIndexer(t *testing.T) {
 		testDelete(db.NewBoltDB(cfg), t)
 	})
 }
+
+func TestFilterActionsByAddress(t *testing.T) {
+	require := require.New(t)
+	ctx := genesis.WithGenesisContext(context.Background(), genesis.Default)
+
+	blks := getTestBlocks(t)
+	t1Hash, _ := blks[0].Actions[0].Hash()
+	t4Hash, _ := blks[0].Actions[1].Hash()
+	e1Hash, _ := blks[0].Actions[2].Hash()
+	t6Hash, _ := blks[2].Actions[1].Hash()
+	// the receipts of the last block are not available
+	blks[0].Receipts = []*action.Receipt{
+		{ActionHash: t1Hash, Status: uint64(iotextypes.ReceiptStatus_Success)},
+		{ActionHash: t4Hash, Status: uint64(iotextypes.ReceiptStatus_Failure)},
+		{ActionHash: e1Hash, Status: uint64(iotextypes.ReceiptStatus_Success)},
+	}
+	addr28 := hash.BytesToHash160(identityset.Address(28).Bytes())
+
+	indexer, err := NewIndexer(db.NewMemKVStore(), hash.ZeroHash256)
+	require.NoError(err)
+	require.NoError(indexer.Start(ctx))
+	defer func() {
+		require.NoError(indexer.Stop(ctx))
+	}()
+	for _, blk := range blks {
+		require.NoError(indexer.PutBlock(ctx, blk))
+	}
+
+	hashes := func(actions []*AddressAction) []hash.Hash256 {
+		ret := make([]hash.Hash256, 0, len(actions))
+		for _, a := range actions {
+			ret = append(ret, a.Hash)
+		}
+		return ret
+	}
+
+	// page through all actions in the ascending order
+	actions, cursor, err := indexer.FilterActionsByAddress(addr28, nil, nil, 2)
+	require.NoError(err)
+	require.Equal([]hash.Hash256{t1Hash, t4Hash}, hashes(actions))
+	require.Equal(&ActionCursor{Position: 2, Anchor: t4Hash[:]}, cursor)
+	actions, cursor, err = indexer.FilterActionsByAddress(addr28, nil, cursor, 2)
+	require.NoError(err)
+	require.Equal([]hash.Hash256{e1Hash, t6Hash}, hashes(actions))
+	require.Nil(cursor)
+
+	// page through all actions in the descending order
+	actions, cursor, err = indexer.FilterActionsByAddress(addr28, nil, &ActionCursor{Position: math.MaxUint64, Desc: true}, 3)
+	require.NoError(err)
+	require.Equal([]hash.Hash256{t6Hash, e1Hash, t4Hash}, hashes(actions))
+	require.Equal(&ActionCursor{Position: 1, Desc: true, Anchor: t4Hash[:]}, cursor)
+	actions, cursor, err = indexer.FilterActionsByAddress(addr28, nil, cursor, 3)
+	require.NoError(err)
+	require.Equal([]hash.Hash256{t1Hash}, hashes(actions))
+	require.Nil(cursor)
+
+	for _, v := range []struct {
+		filter     *ActionFilter
+		desc       bool
+		expected   []hash.Hash256
+		unverified []bool
+	}{
+		{&ActionFilter{Direction: Outgoing}, false, []hash.Hash256{t1Hash, t4Hash, e1Hash}, nil},
+		{&ActionFilter{Direction: Incoming}, false, []hash.Hash256{t1Hash, t6Hash}, nil},
+		{&ActionFilter{Types: []string{"execution"}}, false, []hash.Hash256{e1Hash}, nil},
+		{&ActionFilter{Status: Failed}, false, []hash.Hash256{t4Hash, t6Hash}, []bool{false, true}},
+		{&ActionFilter{Status: Succeeded, Direction: Incoming}, false, []hash.Hash256{t1Hash, t6Hash}, []bool{false, true}},
+		{&ActionFilter{StartHeight: 2}, false, []hash.Hash256{t6Hash}, nil},
+		{&ActionFilter{EndHeight: 1}, true, []hash.Hash256{e1Hash, t4Hash, t1Hash}, nil},
+		{&ActionFilter{StartHeight: 2, EndHeight: 2}, false, []hash.Hash256{}, nil},
+	} {
+		c := &ActionCursor{Desc: v.desc}
+		if v.desc {
+			c.Position = math.MaxUint64
+		}
+		actions, cursor, err := indexer.FilterActionsByAddress(addr28, v.filter, c, 10)
+		require.NoError(err)
+		require.Nil(cursor)
+		require.Equal(v.expected, hashes(actions))
+		for i, a := range actions {
+			require.Equal(v.unverified != nil && v.unverified[i], a.Unverified)
+		}
+	}
+
+	// no actions of an address never touched
+	actions, cursor, err = indexer.FilterActionsByAddress(hash.BytesToHash160(identityset.Address(13).Bytes()), nil, nil, 10)
+	require.NoError(err)
+	require.Empty(actions)
+	require.Nil(cursor)
+
+	// the cursor is stale once the actions before it are reverted
+	cursor = &ActionCursor{Position: 4, Anchor: t6Hash[:]}
+	_, _, err = indexer.FilterActionsByAddress(addr28, nil, cursor, 10)
+	require.NoError(err)
+	require.NoError(indexer.DeleteTipBlock(ctx, blks[2]))
+	_, _, err = indexer.FilterActionsByAddress(addr28, nil, cursor, 10)
+	require.Equal(ErrStaleActionCursor, errors.Cause(err))
+}
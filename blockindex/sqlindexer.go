@@ -0,0 +1,392 @@
+// Copyright (c) 2022 IoTeX Foundation
+// This is an alpha (internal) release and is not suitable for production. This source code is provided 'as is' and no
+// warranties are given as to title or non-infringement, merchantability or fitness for purpose and, to the extent
+// permitted by law, all liability for your use of the code is disclaimed. This source code is governed by Apache
+// License 2.0 that can be found in the LICENSE file.
+
+package blockindex
+
+import (
+	"context"
+	"database/sql"
+	"encoding/hex"
+	"math/big"
+	"reflect"
+	"sync"
+	"unicode"
+
+	"github.com/iotexproject/go-pkgs/hash"
+	"github.com/iotexproject/iotex-address/address"
+	"github.com/iotexproject/iotex-proto/golang/iotextypes"
+	"github.com/pkg/errors"
+
+	"github.com/iotexproject/iotex-core/action"
+	"github.com/iotexproject/iotex-core/action/protocol/staking"
+	"github.com/iotexproject/iotex-core/blockchain/block"
+	sqlstore "github.com/iotexproject/iotex-core/db/sql"
+	"github.com/iotexproject/iotex-core/pkg/util/byteutil"
+)
+
+// _sqlIndexerTables are the tables written by sql indexer, each of which has the column block_height
+var _sqlIndexerTables = []string{
+	"blocks", "actions", "receipts", "logs", "evm_transfers", "staking_events",
+}
+
+// _sqlIndexerSchema creates the tables of sql indexer. The log_index of logs is the index of the log in the block,
+// which is 0 before the tx log index is corrected at Midway height, so the logs are keyed by the position in receipt
+var _sqlIndexerSchema = []string{
+	`CREATE TABLE IF NOT EXISTS blocks (
+		block_height INTEGER PRIMARY KEY,
+		hash TEXT NOT NULL,
+		producer TEXT NOT NULL,
+		timestamp INTEGER NOT NULL,
+		num_actions INTEGER NOT NULL,
+		gas_used INTEGER NOT NULL
+	)`,
+	`CREATE TABLE IF NOT EXISTS actions (
+		action_hash TEXT PRIMARY KEY,
+		block_height INTEGER NOT NULL,
+		tx_index INTEGER NOT NULL,
+		sender TEXT NOT NULL,
+		recipient TEXT NOT NULL,
+		type TEXT NOT NULL,
+		nonce INTEGER NOT NULL,
+		amount TEXT NOT NULL,
+		gas_limit INTEGER NOT NULL,
+		gas_price TEXT NOT NULL
+	)`,
+	`CREATE TABLE IF NOT EXISTS receipts (
+		action_hash TEXT PRIMARY KEY,
+		block_height INTEGER NOT NULL,
+		status INTEGER NOT NULL,
+		gas_consumed INTEGER NOT NULL,
+		contract_address TEXT NOT NULL,
+		revert_message TEXT NOT NULL
+	)`,
+	`CREATE TABLE IF NOT EXISTS logs (
+		block_height INTEGER NOT NULL,
+		action_hash TEXT NOT NULL,
+		receipt_log_index INTEGER NOT NULL,
+		log_index INTEGER NOT NULL,
+		address TEXT NOT NULL,
+		topic0 TEXT,
+		topic1 TEXT,
+		topic2 TEXT,
+		topic3 TEXT,
+		data TEXT NOT NULL,
+		PRIMARY KEY (action_hash, receipt_log_index)
+	)`,
+	`CREATE TABLE IF NOT EXISTS evm_transfers (
+		block_height INTEGER NOT NULL,
+		action_hash TEXT NOT NULL,
+		transfer_index INTEGER NOT NULL,
+		sender TEXT NOT NULL,
+		recipient TEXT NOT NULL,
+		amount TEXT NOT NULL
+	)`,
+	`CREATE TABLE IF NOT EXISTS staking_events (
+		block_height INTEGER NOT NULL,
+		action_hash TEXT NOT NULL,
+		log_index INTEGER NOT NULL,
+		event TEXT NOT NULL,
+		bucket_index INTEGER,
+		owner TEXT,
+		candidate TEXT,
+		to_candidate TEXT
+	)`,
+	`CREATE INDEX IF NOT EXISTS actions_block_height ON actions (block_height)`,
+	`CREATE INDEX IF NOT EXISTS actions_sender ON actions (sender)`,
+	`CREATE INDEX IF NOT EXISTS actions_recipient ON actions (recipient)`,
+	`CREATE INDEX IF NOT EXISTS receipts_block_height ON receipts (block_height)`,
+	`CREATE INDEX IF NOT EXISTS logs_address ON logs (address)`,
+	`CREATE INDEX IF NOT EXISTS evm_transfers_block_height ON evm_transfers (block_height)`,
+	`CREATE INDEX IF NOT EXISTS staking_events_block_height ON staking_events (block_height)`,
+	`CREATE INDEX IF NOT EXISTS staking_events_bucket_index ON staking_events (bucket_index)`,
+}
+
+// _stakingEventTopics are the columns of the topics following the event name in staking logs
+var _stakingEventTopics = map[string][]string{
+	staking.HandleCreateStake:       {"bucket_index", "candidate"},
+	staking.HandleUnstake:           {"bucket_index", "candidate"},
+	staking.HandleWithdrawStake:     {"bucket_index", "candidate"},
+	staking.HandleChangeCandidate:   {"bucket_index", "candidate", "to_candidate"},
+	staking.HandleTransferStake:     {"bucket_index", "owner", "candidate"},
+	staking.HandleDepositToStake:    {"bucket_index", "owner", "candidate"},
+	staking.HandleRestake:           {"bucket_index", "candidate"},
+	staking.HandleCandidateRegister: {"bucket_index", "owner"},
+	staking.HandleCandidateUpdate:   {"owner"},
+	staking.HandleDoubleSign:        {"bucket_index", "candidate"},
+}
+
+type (
+	// SQLIndexer writes the blocks, actions, receipts, logs, evm transfers and staking events into the normalized
+	// tables of a sql store, against which ad-hoc sql queries could be run
+	SQLIndexer struct {
+		mutex sync.Mutex
+		store sqlstore.Store
+	}
+
+	// stakingEvent is a row of table staking_events
+	stakingEvent struct {
+		event       string
+		bucketIndex sql.NullInt64
+		owner       sql.NullString
+		candidate   sql.NullString
+		toCandidate sql.NullString
+	}
+)
+
+// NewSQLIndexer creates a new sql indexer
+func NewSQLIndexer(store sqlstore.Store) (*SQLIndexer, error) {
+	if store == nil {
+		return nil, errors.New("empty sql store")
+	}
+	return &SQLIndexer{store: store}, nil
+}
+
+// Start starts the indexer and creates the tables if not exist
+func (x *SQLIndexer) Start(ctx context.Context) error {
+	if err := x.store.Start(ctx); err != nil {
+		return err
+	}
+	return x.store.Transact(func(tx *sql.Tx) error {
+		for _, stmt := range _sqlIndexerSchema {
+			if _, err := tx.Exec(stmt); err != nil {
+				return errors.Wrap(err, "failed to create tables of sql indexer")
+			}
+		}
+		return nil
+	})
+}
+
+// Stop stops the indexer
+func (x *SQLIndexer) Stop(ctx context.Context) error {
+	return x.store.Stop(ctx)
+}
+
+// Height returns the height of the tip block indexed
+func (x *SQLIndexer) Height() (uint64, error) {
+	return queryTipHeight(x.store.GetDB())
+}
+
+// PutBlock writes the block into the tables
+func (x *SQLIndexer) PutBlock(ctx context.Context, blk *block.Block) error {
+	return x.PutBlocks(ctx, []*block.Block{blk})
+}
+
+// PutBlocks writes the blocks into the tables in one transaction, the blocks already indexed are skipped
+func (x *SQLIndexer) PutBlocks(_ context.Context, blks []*block.Block) error {
+	x.mutex.Lock()
+	defer x.mutex.Unlock()
+
+	return x.store.Transact(func(tx *sql.Tx) error {
+		tipHeight, err := queryTipHeight(tx)
+		if err != nil {
+			return err
+		}
+		for _, blk := range blks {
+			height := blk.Height()
+			if height <= tipHeight {
+				continue
+			}
+			if height != tipHeight+1 {
+				return errors.Errorf("invalid block height %d, expecting %d", height, tipHeight+1)
+			}
+			if err := putBlock(tx, blk); err != nil {
+				return errors.Wrapf(err, "failed to index block %d", height)
+			}
+			tipHeight = height
+		}
+		return nil
+	})
+}
+
+// DeleteTipBlock deletes the rows of the tip block from the tables
+func (x *SQLIndexer) DeleteTipBlock(_ context.Context, blk *block.Block) error {
+	x.mutex.Lock()
+	defer x.mutex.Unlock()
+
+	return x.store.Transact(func(tx *sql.Tx) error {
+		tipHeight, err := queryTipHeight(tx)
+		if err != nil {
+			return err
+		}
+		if blk.Height() != tipHeight {
+			return errors.Errorf("invalid block height %d, expecting tip height %d", blk.Height(), tipHeight)
+		}
+		for _, table := range _sqlIndexerTables {
+			if _, err := tx.Exec("DELETE FROM "+table+" WHERE block_height = ?", tipHeight); err != nil {
+				return errors.Wrapf(err, "failed to delete block %d from %s", tipHeight, table)
+			}
+		}
+		return nil
+	})
+}
+
+func queryTipHeight(q interface {
+	QueryRow(string, ...interface{}) *sql.Row
+}) (uint64, error) {
+	var height uint64
+	if err := q.QueryRow("SELECT COALESCE(MAX(block_height), 0) FROM blocks").Scan(&height); err != nil {
+		return 0, errors.Wrap(err, "failed to query the tip height of sql indexer")
+	}
+	return height, nil
+}
+
+func putBlock(tx *sql.Tx, blk *block.Block) error {
+	var (
+		height  = blk.Height()
+		blkHash = blk.HashBlock()
+		gasUsed uint64
+	)
+	for _, r := range blk.Receipts {
+		gasUsed += r.GasConsumed
+	}
+	if _, err := tx.Exec("INSERT INTO blocks (block_height, hash, producer, timestamp, num_actions, gas_used) "+
+		"VALUES (?, ?, ?, ?, ?, ?)",
+		height, hex.EncodeToString(blkHash[:]), blk.PublicKey().Address().String(), blk.Timestamp().Unix(),
+		len(blk.Actions), gasUsed,
+	); err != nil {
+		return err
+	}
+	for i, selp := range blk.Actions {
+		if err := putAction(tx, height, uint32(i), selp); err != nil {
+			return err
+		}
+	}
+	for _, r := range blk.Receipts {
+		if err := putReceipt(tx, r); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func putAction(tx *sql.Tx, height uint64, index uint32, selp action.SealedEnvelope) error {
+	actHash, err := selp.Hash()
+	if err != nil {
+		return err
+	}
+	var (
+		recipient string
+		amount    = big.NewInt(0)
+		act       = selp.Action()
+	)
+	if dst, ok := act.(interface{ Destination() string }); ok {
+		recipient = dst.Destination()
+	}
+	if amt, ok := act.(interface{ Amount() *big.Int }); ok && amt.Amount() != nil {
+		amount = amt.Amount()
+	}
+	_, err = tx.Exec("INSERT INTO actions (action_hash, block_height, tx_index, sender, recipient, type, nonce, "+
+		"amount, gas_limit, gas_price) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?)",
+		hex.EncodeToString(actHash[:]), height, index, selp.SrcPubkey().Address().String(), recipient,
+		actionTypeName(act), selp.Nonce(), amount.String(), selp.GasLimit(), selp.GasPrice().String(),
+	)
+	return err
+}
+
+func putReceipt(tx *sql.Tx, r *action.Receipt) error {
+	actHash := hex.EncodeToString(r.ActionHash[:])
+	if _, err := tx.Exec("INSERT INTO receipts (action_hash, block_height, status, gas_consumed, contract_address, "+
+		"revert_message) VALUES (?, ?, ?, ?, ?, ?)",
+		actHash, r.BlockHeight, r.Status, r.GasConsumed, r.ContractAddress, r.ExecutionRevertMsg(),
+	); err != nil {
+		return err
+	}
+	for i, l := range r.Logs() {
+		topics := make([]sql.NullString, 4)
+		for j := 0; j < len(l.Topics) && j < len(topics); j++ {
+			topics[j] = sql.NullString{String: hex.EncodeToString(l.Topics[j][:]), Valid: true}
+		}
+		if _, err := tx.Exec("INSERT INTO logs (block_height, action_hash, receipt_log_index, log_index, address, "+
+			"topic0, topic1, topic2, topic3, data) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?)",
+			r.BlockHeight, actHash, i, l.Index, l.Address, topics[0], topics[1], topics[2], topics[3],
+			hex.EncodeToString(l.Data),
+		); err != nil {
+			return err
+		}
+		if l.Address != address.StakingProtocolAddr {
+			continue
+		}
+		if e := decodeStakingEvent(l); e != nil {
+			if _, err := tx.Exec("INSERT INTO staking_events (block_height, action_hash, log_index, event, "+
+				"bucket_index, owner, candidate, to_candidate) VALUES (?, ?, ?, ?, ?, ?, ?, ?)",
+				r.BlockHeight, actHash, l.Index, e.event, e.bucketIndex, e.owner, e.candidate, e.toCandidate,
+			); err != nil {
+				return err
+			}
+		}
+	}
+	var idx int
+	for _, l := range r.TransactionLogs() {
+		if l.Type != iotextypes.TransactionLogType_IN_CONTRACT_TRANSFER {
+			continue
+		}
+		if _, err := tx.Exec("INSERT INTO evm_transfers (block_height, action_hash, transfer_index, sender, "+
+			"recipient, amount) VALUES (?, ?, ?, ?, ?, ?)",
+			r.BlockHeight, actHash, idx, l.Sender, l.Recipient, l.Amount.String(),
+		); err != nil {
+			return err
+		}
+		idx++
+	}
+	return nil
+}
+
+// decodeStakingEvent decodes the log of staking protocol, in which the topics are the event name, the bucket index
+// and the addresses, see staking.BucketIndexFromReceiptLog. The logs before the new staking receipt format only
+// carry the event name, whose topic is the hash of the name.
+func decodeStakingEvent(l *action.Log) *stakingEvent {
+	if len(l.Topics) == 0 {
+		return nil
+	}
+	for name, columns := range _stakingEventTopics {
+		if l.Topics[0] == hash.Hash256b([]byte(name)) {
+			return &stakingEvent{event: name}
+		}
+		if l.Topics[0] != hash.BytesToHash256([]byte(name)) {
+			continue
+		}
+		e := &stakingEvent{event: name}
+		for i, column := range columns {
+			if i+1 >= len(l.Topics) {
+				break
+			}
+			topic := l.Topics[i+1]
+			if column == "bucket_index" {
+				e.bucketIndex = sql.NullInt64{Int64: int64(byteutil.BytesToUint64BigEndian(topic[24:])), Valid: true}
+				continue
+			}
+			addr, err := address.FromBytes(topic[12:])
+			if err != nil {
+				continue
+			}
+			value := sql.NullString{String: addr.String(), Valid: true}
+			switch column {
+			case "owner":
+				e.owner = value
+			case "candidate":
+				e.candidate = value
+			case "to_candidate":
+				e.toCandidate = value
+			}
+		}
+		return e
+	}
+	return nil
+}
+
+// actionTypeName returns the name of the action type in lower camel case, e.g. transfer, createStake
+func actionTypeName(act action.Action) string {
+	typ := reflect.TypeOf(act)
+	if typ.Kind() == reflect.Ptr {
+		typ = typ.Elem()
+	}
+	name := []rune(typ.Name())
+	if len(name) == 0 {
+		return ""
+	}
+	name[0] = unicode.ToLower(name[0])
+	return string(name)
+}
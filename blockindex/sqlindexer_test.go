@@ -0,0 +1,170 @@
+// Copyright (c) 2022 IoTeX Foundation
+// This is an alpha (internal) release and is not suitable for production. This source code is provided 'as is' and no
+// warranties are given as to title or non-infringement, merchantability or fitness for purpose and, to the extent
+// permitted by law, all liability for your use of the code is disclaimed. This source code is governed by Apache
+// License 2.0 that can be found in the LICENSE file.
+
+package blockindex
+
+import (
+	"context"
+	"math/big"
+	"testing"
+
+	"github.com/iotexproject/go-pkgs/hash"
+	"github.com/iotexproject/iotex-address/address"
+	"github.com/iotexproject/iotex-proto/golang/iotextypes"
+	"github.com/stretchr/testify/require"
+
+	"github.com/iotexproject/iotex-core/action"
+	"github.com/iotexproject/iotex-core/action/protocol"
+	"github.com/iotexproject/iotex-core/action/protocol/staking"
+	"github.com/iotexproject/iotex-core/blockchain/block"
+	"github.com/iotexproject/iotex-core/blockchain/blockdao"
+	"github.com/iotexproject/iotex-core/blockchain/genesis"
+	sqlstore "github.com/iotexproject/iotex-core/db/sql"
+	"github.com/iotexproject/iotex-core/pkg/util/byteutil"
+	"github.com/iotexproject/iotex-core/test/identityset"
+	"github.com/iotexproject/iotex-core/testutil"
+)
+
+func getTestBlocksWithReceipts(t *testing.T) []*block.Block {
+	blks := getTestBlocks(t)
+	for _, blk := range blks {
+		for i, selp := range blk.Actions {
+			actHash, err := selp.Hash()
+			require.NoError(t, err)
+			blk.Receipts = append(blk.Receipts, &action.Receipt{
+				Status:      uint64(iotextypes.ReceiptStatus_Success),
+				BlockHeight: blk.Height(),
+				ActionHash:  actHash,
+				GasConsumed: 10000,
+				TxIndex:     uint32(i),
+			})
+		}
+	}
+	// a staking event in the 1st action and an evm transfer in the 3rd action of the 1st block
+	r := blks[0].Receipts[0]
+	r.AddLogs(&action.Log{
+		Address: address.StakingProtocolAddr,
+		Topics: action.Topics{
+			hash.BytesToHash256([]byte(staking.HandleCreateStake)),
+			hash.BytesToHash256(byteutil.Uint64ToBytesBigEndian(7)),
+			hash.BytesToHash256(identityset.Address(1).Bytes()),
+		},
+		BlockHeight: 1,
+		ActionHash:  r.ActionHash,
+	})
+	r = blks[0].Receipts[2]
+	r.AddLogs(&action.Log{
+		Address:     identityset.Address(31).String(),
+		Topics:      action.Topics{hash.Hash256b([]byte("Transfer"))},
+		Data:        []byte{1, 2},
+		BlockHeight: 1,
+		ActionHash:  r.ActionHash,
+	})
+	r.AddTransactionLogs(&action.TransactionLog{
+		Type:      iotextypes.TransactionLogType_IN_CONTRACT_TRANSFER,
+		Amount:    big.NewInt(5),
+		Sender:    identityset.Address(31).String(),
+		Recipient: identityset.Address(2).String(),
+	})
+	blks[0].Receipts[0].UpdateIndex(0, 0)
+	blks[0].Receipts[2].UpdateIndex(2, 1)
+	// the logs before Midway height all have index 0
+	r = blks[1].Receipts[0]
+	for i := 0; i < 2; i++ {
+		r.AddLogs(&action.Log{
+			Address:     identityset.Address(31).String(),
+			Topics:      action.Topics{hash.Hash256b([]byte("Transfer"))},
+			Data:        []byte{byte(i)},
+			BlockHeight: 2,
+			ActionHash:  r.ActionHash,
+		})
+	}
+	return blks
+}
+
+func TestSQLIndexer(t *testing.T) {
+	require := require.New(t)
+	ctx := protocol.WithBlockchainCtx(
+		genesis.WithGenesisContext(context.Background(), genesis.Default),
+		protocol.BlockchainCtx{},
+	)
+	blks := getTestBlocksWithReceipts(t)
+
+	newIndexer := func(t *testing.T) *SQLIndexer {
+		testPath, err := testutil.PathOfTempFile("analytics.db")
+		require.NoError(err)
+		t.Cleanup(func() { testutil.CleanupPath(testPath) })
+		indexer, err := NewSQLIndexer(sqlstore.NewSQLite3(sqlstore.CQLITE3{SQLite3File: testPath}))
+		require.NoError(err)
+		require.NoError(indexer.Start(ctx))
+		t.Cleanup(func() { require.NoError(indexer.Stop(ctx)) })
+		return indexer
+	}
+	count := func(indexer *SQLIndexer, query string, args ...interface{}) int {
+		var n int
+		require.NoError(indexer.store.GetDB().QueryRow(query, args...).Scan(&n))
+		return n
+	}
+
+	t.Run("put and delete", func(t *testing.T) {
+		indexer := newIndexer(t)
+		height, err := indexer.Height()
+		require.NoError(err)
+		require.Zero(height)
+		require.ErrorContains(indexer.PutBlock(ctx, blks[1]), "invalid block height 2")
+		require.NoError(indexer.PutBlock(ctx, blks[0]))
+		// the blocks already indexed are skipped
+		require.NoError(indexer.PutBlocks(ctx, blks))
+		height, err = indexer.Height()
+		require.NoError(err)
+		require.EqualValues(3, height)
+
+		require.Equal(3, count(indexer, "SELECT COUNT(*) FROM blocks"))
+		require.Equal(9, count(indexer, "SELECT COUNT(*) FROM actions"))
+		require.Equal(3, count(indexer, "SELECT COUNT(*) FROM actions WHERE type = 'execution'"))
+		require.Equal(9, count(indexer, "SELECT COUNT(*) FROM receipts"))
+		require.Equal(30000, count(indexer, "SELECT gas_used FROM blocks WHERE block_height = 1"))
+		require.Equal(4, count(indexer, "SELECT COUNT(*) FROM logs"))
+		require.Equal(2, count(indexer, "SELECT COUNT(DISTINCT receipt_log_index) FROM logs WHERE block_height = 2 "+
+			"AND log_index = 0"))
+		require.Equal(1, count(indexer, "SELECT COUNT(*) FROM evm_transfers WHERE amount = '5' AND recipient = ?",
+			identityset.Address(2).String()))
+		require.Equal(1, count(indexer, "SELECT COUNT(*) FROM staking_events WHERE event = ? AND bucket_index = 7 "+
+			"AND candidate = ? AND owner IS NULL", staking.HandleCreateStake, identityset.Address(1).String()))
+
+		require.ErrorContains(indexer.DeleteTipBlock(ctx, blks[1]), "expecting tip height 3")
+		require.NoError(indexer.DeleteTipBlock(ctx, blks[2]))
+		require.NoError(indexer.DeleteTipBlock(ctx, blks[1]))
+		height, err = indexer.Height()
+		require.NoError(err)
+		require.EqualValues(1, height)
+		require.Equal(3, count(indexer, "SELECT COUNT(*) FROM actions"))
+		require.Equal(3, count(indexer, "SELECT COUNT(*) FROM receipts"))
+		require.NoError(indexer.DeleteTipBlock(ctx, blks[0]))
+		for _, table := range _sqlIndexerTables {
+			require.Zero(count(indexer, "SELECT COUNT(*) FROM "+table), table)
+		}
+	})
+
+	t.Run("catch up in batch", func(t *testing.T) {
+		dao := blockdao.NewBlockDAOInMemForTest(nil)
+		require.NoError(dao.Start(ctx))
+		defer func() {
+			require.NoError(dao.Stop(ctx))
+		}()
+		for _, blk := range blks {
+			require.NoError(dao.PutBlock(ctx, blk))
+		}
+		indexer := newIndexer(t)
+		require.NoError(blockdao.NewBlockIndexerChecker(dao).CheckIndexer(ctx, indexer, 0, nil))
+		height, err := indexer.Height()
+		require.NoError(err)
+		require.EqualValues(3, height)
+		require.Equal(9, count(indexer, "SELECT COUNT(*) FROM receipts"))
+		require.Equal(1, count(indexer, "SELECT COUNT(*) FROM staking_events"))
+		require.Equal(1, count(indexer, "SELECT COUNT(*) FROM evm_transfers"))
+	})
+}
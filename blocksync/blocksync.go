@@ -40,6 +40,11 @@ type (
 	BlockByHeight func(uint64) (*block.Block, error)
 	// CommitBlock commits a block to blockchain
 	CommitBlock func(*block.Block) error
+	// PrevalidateBlock validates a block ahead of committing it
+	PrevalidateBlock func(*block.Block) error
+
+	// Option sets an optional parameter of block syncer
+	Option func(*blockSyncer)
 
 	// BlockSync defines the interface of blocksyncer
 	BlockSync interface {
@@ -65,6 +70,7 @@ type (
 		tipHeightHandler     TipHeight
 		blockByHeightHandler BlockByHeight
 		commitBlockHandler   CommitBlock
+		prevalidateHandler   PrevalidateBlock
 		p2pNeighbor          Neighbors
 		unicastOutbound      UniCastOutbound
 		blockP2pPeer         BlockPeer
@@ -75,6 +81,11 @@ type (
 		syncStageHeight   uint64
 		syncBlockIncrease uint64
 
+		pipelineDepth      uint64
+		prevalidateQueue   chan *block.Block
+		prevalidatedHeight uint64
+		quit               chan struct{}
+
 		startingHeight    uint64 // block number this node started to synchronise from
 		lastTip           uint64
 		lastTipUpdateTime time.Time
@@ -124,6 +135,15 @@ func (*dummyBlockSync) SyncStatus() (uint64, uint64, uint64, string) {
 	return 0, 0, 0, ""
 }
 
+// PrevalidateBlockOption sets the handler to validate the blocks buffered up to depth heights above the block being
+// committed, such that the validation of the following blocks overlaps with committing the current one
+func PrevalidateBlockOption(depth uint64, prevalidateHandler PrevalidateBlock) Option {
+	return func(bs *blockSyncer) {
+		bs.pipelineDepth = depth
+		bs.prevalidateHandler = prevalidateHandler
+	}
+}
+
 // NewBlockSyncer returns a new block syncer instance
 func NewBlockSyncer(
 	cfg config.BlockSync,
@@ -133,6 +153,7 @@ func NewBlockSyncer(
 	p2pNeighbor Neighbors,
 	uniCastHandler UniCastOutbound,
 	blockP2pPeer BlockPeer,
+	opts ...Option,
 ) (BlockSync, error) {
 	bs := &blockSyncer{
 		cfg:                  cfg,
@@ -146,6 +167,12 @@ func NewBlockSyncer(
 		blockP2pPeer:         blockP2pPeer,
 		targetHeight:         0,
 	}
+	for _, opt := range opts {
+		opt(bs)
+	}
+	if bs.prevalidateHandler != nil && bs.pipelineDepth > 0 {
+		bs.prevalidateQueue = make(chan *block.Block, bs.pipelineDepth)
+	}
 	if bs.cfg.Interval != 0 {
 		bs.syncTask = routine.NewRecurringTask(bs.sync, bs.cfg.Interval)
 		bs.syncStageTask = routine.NewRecurringTask(bs.syncStageChecker, bs.cfg.Interval)
@@ -169,6 +196,45 @@ func (bs *blockSyncer) commitBlocks(blks []*peerBlock) bool {
 	return false
 }
 
+// prevalidate queues the buffered blocks above the given height to be prevalidated, until there is a gap in the
+// buffer or the queue is full
+func (bs *blockSyncer) prevalidate(height uint64) {
+	if bs.prevalidateQueue == nil {
+		return
+	}
+	start := height + 1
+	if bs.prevalidatedHeight >= start {
+		start = bs.prevalidatedHeight + 1
+	}
+	for h := start; h <= height+bs.pipelineDepth; h++ {
+		blks := bs.buf.Peek(h)
+		if len(blks) == 0 {
+			return
+		}
+		for _, blk := range blks {
+			select {
+			case bs.prevalidateQueue <- blk.block:
+			default:
+				return
+			}
+		}
+		bs.prevalidatedHeight = h
+	}
+}
+
+func (bs *blockSyncer) runPrevalidation(quit <-chan struct{}) {
+	for {
+		select {
+		case <-quit:
+			return
+		case blk := <-bs.prevalidateQueue:
+			if err := bs.prevalidateHandler(blk); err != nil {
+				log.L().Debug("failed to prevalidate block", zap.Error(err), zap.Uint64("height", blk.Height()))
+			}
+		}
+	}
+}
+
 func (bs *blockSyncer) flushInfo() (time.Time, uint64) {
 	bs.mu.Lock()
 	defer bs.mu.Unlock()
@@ -233,6 +299,12 @@ func (bs *blockSyncer) TargetHeight() uint64 {
 // Start starts a block syncer
 func (bs *blockSyncer) Start(ctx context.Context) error {
 	log.L().Debug("Starting block syncer.")
+	if bs.prevalidateQueue != nil {
+		bs.quit = make(chan struct{})
+		for i := uint64(0); i < bs.pipelineDepth; i++ {
+			go bs.runPrevalidation(bs.quit)
+		}
+	}
 	if bs.syncTask != nil {
 		if err := bs.syncTask.Start(ctx); err != nil {
 			return err
@@ -247,6 +319,10 @@ func (bs *blockSyncer) Start(ctx context.Context) error {
 // Stop stops a block syncer
 func (bs *blockSyncer) Stop(ctx context.Context) error {
 	log.L().Debug("Stopping block syncer.")
+	if bs.quit != nil {
+		close(bs.quit)
+		bs.quit = nil
+	}
 	if bs.syncStageTask != nil {
 		if err := bs.syncStageTask.Stop(ctx); err != nil {
 			return err
@@ -277,7 +353,17 @@ func (bs *blockSyncer) ProcessBlock(ctx context.Context, peer string, blk *block
 	}
 	syncedHeight := tip
 	for {
-		if !bs.commitBlocks(bs.buf.Pop(syncedHeight + 1)) {
+		bs.prevalidate(syncedHeight + 1)
+		blks := bs.buf.Pop(syncedHeight + 1)
+		if len(blks) == 0 {
+			break
+		}
+		if !bs.commitBlocks(blks) {
+			// the blocks failed to commit have been dropped from the buffer, so the ones received again above
+			// the tip have to be prevalidated
+			if bs.prevalidatedHeight > syncedHeight {
+				bs.prevalidatedHeight = syncedHeight
+			}
 			break
 		}
 		syncedHeight++
@@ -514,6 +514,119 @@ func newTestConfig() (config.Config, error) {
 	return cfg, nil
 }
 
+func TestBlockSyncerPrevalidate(t *testing.T) {
+	require := require.New(t)
+
+	var (
+		tip         uint64
+		committed   []uint64
+		prevalidate = make(chan uint64, 10)
+		blks        []*block.Block
+	)
+	for i := uint64(1); i <= 4; i++ {
+		blk, err := block.NewTestingBuilder().
+			SetHeight(i).
+			SetTimeStamp(testutil.TimestampNow()).
+			SignAndBuild(identityset.PrivateKey(27))
+		require.NoError(err)
+		blks = append(blks, &blk)
+	}
+	cfg := config.Default.BlockSync
+	cfg.Interval = 0
+	bs, err := NewBlockSyncer(cfg,
+		func() uint64 { return tip },
+		nil,
+		func(blk *block.Block) error {
+			committed = append(committed, blk.Height())
+			tip = blk.Height()
+			return nil
+		},
+		nil,
+		nil,
+		nil,
+		PrevalidateBlockOption(2, func(blk *block.Block) error {
+			prevalidate <- blk.Height()
+			return nil
+		}),
+	)
+	require.NoError(err)
+	ctx := context.Background()
+	require.NoError(bs.ProcessBlock(ctx, "peer", blks[3]))
+	require.NoError(bs.ProcessBlock(ctx, "peer", blks[2]))
+	// blocks 2 and 3 above the tip are queued to prevalidate, and block 4 is skipped as the queue is full
+	require.NoError(bs.ProcessBlock(ctx, "peer", blks[1]))
+	require.Empty(committed)
+	require.NoError(bs.ProcessBlock(ctx, "peer", blks[0]))
+	require.Equal([]uint64{1, 2, 3, 4}, committed)
+
+	require.NoError(bs.Start(ctx))
+	defer func() {
+		require.NoError(bs.Stop(ctx))
+	}()
+	heights := map[uint64]bool{}
+	for i := 0; i < 2; i++ {
+		select {
+		case h := <-prevalidate:
+			heights[h] = true
+		case <-time.After(time.Second):
+			require.FailNow("blocks are not prevalidated")
+		}
+	}
+	require.Equal(map[uint64]bool{2: true, 3: true}, heights)
+}
+
+func TestBlockSyncerPrevalidateDropped(t *testing.T) {
+	require := require.New(t)
+
+	var (
+		tip       uint64
+		committed []uint64
+		blks      []*block.Block
+		failed    bool
+	)
+	for i := uint64(1); i <= 3; i++ {
+		blk, err := block.NewTestingBuilder().
+			SetHeight(i).
+			SetTimeStamp(testutil.TimestampNow()).
+			SignAndBuild(identityset.PrivateKey(27))
+		require.NoError(err)
+		blks = append(blks, &blk)
+	}
+	cfg := config.Default.BlockSync
+	cfg.Interval = 0
+	bs, err := NewBlockSyncer(cfg,
+		func() uint64 { return tip },
+		nil,
+		func(blk *block.Block) error {
+			// block 2 fails to commit for the first time
+			if blk.Height() == 2 && !failed {
+				failed = true
+				return errors.New("invalid block")
+			}
+			committed = append(committed, blk.Height())
+			tip = blk.Height()
+			return nil
+		},
+		nil,
+		nil,
+		func(string) {},
+		PrevalidateBlockOption(2, func(*block.Block) error {
+			return nil
+		}),
+	)
+	require.NoError(err)
+	ctx := context.Background()
+	require.NoError(bs.ProcessBlock(ctx, "peer", blks[1]))
+	require.NoError(bs.ProcessBlock(ctx, "peer", blks[2]))
+	require.EqualValues(3, bs.(*blockSyncer).prevalidatedHeight)
+	// block 2 is dropped, and the blocks received again above the tip are prevalidated
+	require.NoError(bs.ProcessBlock(ctx, "peer", blks[0]))
+	require.Equal([]uint64{1}, committed)
+	require.EqualValues(1, bs.(*blockSyncer).prevalidatedHeight)
+	require.NoError(bs.ProcessBlock(ctx, "peer", blks[1]))
+	require.Equal([]uint64{1, 2, 3}, committed)
+}
+
 func TestDummyBlockSync(t *testing.T) {
 	require := require.New(t)
 	bs := NewDummyBlockSyncer()
@@ -48,6 +48,17 @@ func (b *blockBuffer) Pop(height uint64) []*peerBlock {
 	return blks
 }
 
+// Peek returns the blocks of given height without removing them from buffer
+func (b *blockBuffer) Peek(height uint64) []*peerBlock {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+	queue, ok := b.blockQueues[height]
+	if !ok {
+		return nil
+	}
+	return append([]*peerBlock{}, queue.blocks...)
+}
+
 func (b *blockBuffer) Cleanup(height uint64) {
 	b.mu.Lock()
 	defer b.mu.Unlock()
@@ -13,6 +13,7 @@ import (
 
 	"github.com/iotexproject/iotex-address/address"
 	"github.com/iotexproject/iotex-core/action"
+	"github.com/iotexproject/iotex-core/action/evidence"
 	"github.com/iotexproject/iotex-core/action/protocol"
 	"github.com/iotexproject/iotex-core/action/protocol/account"
 	accountutil "github.com/iotexproject/iotex-core/action/protocol/account/util"
@@ -31,8 +32,10 @@ import (
 	"github.com/iotexproject/iotex-core/config"
 	"github.com/iotexproject/iotex-core/consensus"
 	"github.com/iotexproject/iotex-core/db"
+	"github.com/iotexproject/iotex-core/db/sql"
 	"github.com/iotexproject/iotex-core/p2p"
 	"github.com/iotexproject/iotex-core/pkg/log"
+	"github.com/iotexproject/iotex-core/signer"
 	"github.com/iotexproject/iotex-core/state/factory"
 	"github.com/iotexproject/iotex-election/committee"
 	"github.com/pkg/errors"
@@ -125,6 +128,13 @@ func (builder *Builder) createInstance() {
 	if builder.cs == nil {
 		builder.cs = &ChainService{}
 	}
+	if builder.cs.signatureVerifier == nil {
+		// the verifier is shared by actpool and block validation
+		builder.cs.signatureVerifier = action.NewSignatureVerifier(
+			builder.cfg.Chain.SignatureCacheSize,
+			builder.cfg.Chain.SignatureVerificationWorkers,
+		)
+	}
 }
 
 func (builder *Builder) buildFactory(forTest bool) error {
@@ -144,13 +154,17 @@ func (builder *Builder) createFactory(forTest bool) (factory.Factory, error) {
 	}
 	factoryCfg := factory.GenerateConfig(builder.cfg.Chain, builder.cfg.Genesis)
 	if builder.cfg.Chain.EnableTrielessStateDB {
+		opts := []factory.StateDBOption{factory.RegistryStateDBOption(builder.cs.registry)}
+		if builder.cs.balanceIndexer != nil {
+			opts = append(opts, factory.BalanceSubscriberStateDBOption(builder.cs.balanceIndexer))
+		}
 		if forTest {
-			return factory.NewStateDB(factoryCfg, db.NewMemKVStore(), factory.RegistryStateDBOption(builder.cs.registry))
+			return factory.NewStateDB(factoryCfg, db.NewMemKVStore(), opts...)
 		}
-		opts := []factory.StateDBOption{
-			factory.RegistryStateDBOption(builder.cs.registry),
+		opts = append(opts,
 			factory.DefaultPatchOption(),
-		}
+			factory.SignatureVerifierStateDBOption(builder.cs.signatureVerifier),
+		)
 		if builder.cfg.Chain.EnableStateDBCaching {
 			dao, err = db.CreateKVStoreWithCache(builder.cfg.DB, builder.cfg.Chain.TrieDBPath, builder.cfg.Chain.StateDBCacheSize)
 		} else {
@@ -161,8 +175,12 @@ func (builder *Builder) createFactory(forTest bool) (factory.Factory, error) {
 		}
 		return factory.NewStateDB(factoryCfg, dao, opts...)
 	}
+	opts := []factory.Option{factory.RegistryOption(builder.cs.registry)}
+	if builder.cs.balanceIndexer != nil {
+		opts = append(opts, factory.BalanceSubscriberOption(builder.cs.balanceIndexer))
+	}
 	if forTest {
-		return factory.NewFactory(factoryCfg, db.NewMemKVStore(), factory.RegistryOption(builder.cs.registry))
+		return factory.NewFactory(factoryCfg, db.NewMemKVStore(), opts...)
 	}
 	dao, err = db.CreateKVStore(builder.cfg.DB, builder.cfg.Chain.TrieDBPath)
 	if err != nil {
@@ -171,11 +189,33 @@ func (builder *Builder) createFactory(forTest bool) (factory.Factory, error) {
 	return factory.NewFactory(
 		factoryCfg,
 		dao,
-		factory.RegistryOption(builder.cs.registry),
-		factory.DefaultTriePatchOption(),
+		append(opts,
+			factory.DefaultTriePatchOption(),
+			factory.SignatureVerifierOption(builder.cs.signatureVerifier),
+		)...,
 	)
 }
 
+func (builder *Builder) buildBalanceIndexer(forTest bool) error {
+	if !builder.cfg.Chain.EnableBalanceIndexer || builder.cs.balanceIndexer != nil {
+		return nil
+	}
+	var kv db.KVStore
+	if forTest {
+		kv = db.NewMemKVStore()
+	} else {
+		dbConfig := builder.cfg.DB
+		dbConfig.DbPath = builder.cfg.Chain.BalanceIndexDBPath
+		kv = db.NewBoltDB(dbConfig)
+	}
+	indexer, err := blockindex.NewBalanceIndexer(kv)
+	if err != nil {
+		return errors.Wrap(err, "failed to create balance indexer")
+	}
+	builder.cs.balanceIndexer = indexer
+	return nil
+}
+
 func (builder *Builder) buildElectionCommittee() error {
 	ec, err := builder.createElectionCommittee()
 	if err != nil {
@@ -233,7 +273,11 @@ func (builder *Builder) buildActionPool() error {
 	}
 	// Add action validators
 	builder.cs.actpool.AddActionEnvelopeValidators(
-		protocol.NewGenericValidator(builder.cs.factory, accountutil.AccountState),
+		protocol.NewGenericValidator(
+			builder.cs.factory,
+			accountutil.AccountState,
+			protocol.SignatureVerifierOption(builder.cs.signatureVerifier),
+		),
 	)
 
 	return nil
@@ -252,6 +296,34 @@ func (builder *Builder) buildBlockDAO(forTest bool) error {
 	if builder.cs.bfIndexer != nil {
 		indexers = append(indexers, builder.cs.bfIndexer)
 	}
+	if depth := builder.cfg.Chain.PipelineDepth; depth > 0 {
+		// the state factory has to be up to date to execute the next block, while the other indexers could lag
+		// behind when catching up, and catch up on restart
+		catchingUp := func() bool {
+			if builder.cs.blocksync == nil {
+				return false
+			}
+			_, current, target, _ := builder.cs.blocksync.SyncStatus()
+			return target > current+uint64(depth)
+		}
+		for i := 1; i < len(indexers); i++ {
+			indexers[i] = blockdao.NewAsyncIndexer(indexers[i], depth, catchingUp)
+		}
+	}
+	if builder.cfg.Chain.EnableAnalyticsIndexer && !forTest {
+		// the analytics indexer catches up in batches, so it is not wrapped into async indexer
+		sqlIndexer, err := blockindex.NewSQLIndexer(sql.NewSQLite3(sql.CQLITE3{
+			SQLite3File: builder.cfg.Chain.AnalyticsIndexDBPath,
+		}))
+		if err != nil {
+			return errors.Wrap(err, "failed to create analytics indexer")
+		}
+		indexers = append(indexers, sqlIndexer)
+	}
+	if builder.cs.balanceIndexer != nil {
+		// the balance indexer receives the balances from the state factory, so it is never behind the factory
+		indexers = append(indexers, builder.cs.balanceIndexer)
+	}
 	if forTest {
 		builder.cs.blockdao = blockdao.NewBlockDAOInMemForTest(indexers)
 	} else {
@@ -363,11 +435,42 @@ func (builder *Builder) buildBlockchain(forSubChain, forTest bool) error {
 	return nil
 }
 
+func (builder *Builder) buildSigner() error {
+	if builder.cs.signer != nil {
+		return nil
+	}
+	if builder.cfg.Chain.ProducerSigner.Endpoint == "" {
+		if builder.cfg.Chain.ProducerSigner.GuardPath == "" {
+			builder.cs.signer = signer.NewLocalSigner(builder.cfg.Chain.ProducerPrivateKey())
+			return nil
+		}
+		localSigner, err := signer.NewPersistentLocalSigner(
+			builder.cfg.Chain.ProducerPrivateKey(),
+			builder.cfg.Chain.ProducerSigner.GuardPath,
+		)
+		if err != nil {
+			return errors.Wrap(err, "failed to create local signer")
+		}
+		builder.cs.signer = localSigner
+		return nil
+	}
+	remoteSigner, err := signer.NewRemoteSigner(builder.cfg.Chain.ProducerSigner)
+	if err != nil {
+		return errors.Wrap(err, "failed to create remote signer")
+	}
+	builder.cs.signer = remoteSigner
+	builder.cs.lifecycle.Add(remoteSigner)
+	return nil
+}
+
 func (builder *Builder) createBlockchain(forSubChain, forTest bool) blockchain.Blockchain {
 	if builder.cs.chain != nil {
 		return builder.cs.chain
 	}
-	var chainOpts []blockchain.Option
+	chainOpts := []blockchain.Option{
+		blockchain.SignerOption(builder.cs.signer),
+		blockchain.SignatureVerifierOption(builder.cs.signatureVerifier),
+	}
 	if !forSubChain {
 		chainOpts = append(chainOpts, blockchain.BlockValidatorOption(block.NewValidator(builder.cs.factory, builder.cs.actpool)))
 	} else {
@@ -432,6 +535,7 @@ func (builder *Builder) buildBlockSyncer() error {
 		p2pAgent.ConnectedPeers,
 		p2pAgent.UnicastOutbound,
 		p2pAgent.BlockPeer,
+		blocksync.PrevalidateBlockOption(uint64(builder.cfg.Chain.PipelineDepth), chain.PrevalidateBlock),
 	)
 	if err != nil {
 		return errors.Wrap(err, "failed to create block syncer")
@@ -456,6 +560,7 @@ func (builder *Builder) registerStakingProtocol() error {
 	if err != nil {
 		return err
 	}
+	stakingProtocol.SetEvidencePool(builder.cs.evidencePool)
 
 	return stakingProtocol.Register(builder.cs.registry)
 }
@@ -551,6 +656,8 @@ func (builder *Builder) buildConsensusComponent() error {
 	if pollProtocol := poll.FindProtocol(builder.cs.registry); pollProtocol != nil {
 		copts = append(copts, consensus.WithPollProtocol(pollProtocol))
 	}
+	copts = append(copts, consensus.WithEvidencePool(builder.cs.evidencePool))
+	copts = append(copts, consensus.WithSigner(builder.cs.signer))
 
 	// TODO: explorer dependency deleted at #1085, need to revive by migrating to api
 	component, err := consensus.NewConsensus(builder.cfg, builder.cs.chain, builder.cs.factory, copts...)
@@ -565,9 +672,16 @@ func (builder *Builder) buildConsensusComponent() error {
 
 func (builder *Builder) build(forSubChain, forTest bool) (*ChainService, error) {
 	builder.cs.registry = protocol.NewRegistry()
+	builder.cs.evidencePool = evidence.NewPool()
 	if builder.cs.p2pAgent == nil {
 		builder.cs.p2pAgent = p2p.NewDummyAgent()
 	}
+	if err := builder.buildSigner(); err != nil {
+		return nil, err
+	}
+	if err := builder.buildBalanceIndexer(forTest); err != nil {
+		return nil, err
+	}
 	if err := builder.buildFactory(forTest); err != nil {
 		return nil, err
 	}
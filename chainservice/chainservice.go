@@ -21,6 +21,7 @@ import (
 	"github.com/iotexproject/iotex-proto/golang/iotextypes"
 
 	"github.com/iotexproject/iotex-core/action"
+	"github.com/iotexproject/iotex-core/action/evidence"
 	"github.com/iotexproject/iotex-core/action/protocol"
 	"github.com/iotexproject/iotex-core/action/protocol/poll"
 	"github.com/iotexproject/iotex-core/action/protocol/staking"
@@ -36,6 +37,7 @@ import (
 	"github.com/iotexproject/iotex-core/p2p"
 	"github.com/iotexproject/iotex-core/pkg/lifecycle"
 	"github.com/iotexproject/iotex-core/pkg/log"
+	"github.com/iotexproject/iotex-core/signer"
 	"github.com/iotexproject/iotex-core/state/factory"
 )
 
@@ -83,9 +85,13 @@ type ChainService struct {
 	// TODO: explorer dependency deleted at #1085, need to api related params
 	indexer            blockindex.Indexer
 	bfIndexer          blockindex.BloomFilterIndexer
+	balanceIndexer     blockindex.BalanceIndexer
 	candidateIndexer   *poll.CandidateIndexer
 	candBucketsIndexer *staking.CandidatesBucketsIndexer
+	evidencePool       *evidence.Pool
+	signer             signer.Signer
 	registry           *protocol.Registry
+	signatureVerifier  *action.SignatureVerifier
 }
 
 // Start starts the server
@@ -213,6 +219,9 @@ func (cs *ChainService) NewAPIServer(cfg config.API, plugins map[int]interface{}
 		}),
 		api.WithNativeElection(cs.electionCommittee),
 	}
+	if cs.balanceIndexer != nil {
+		apiServerOptions = append(apiServerOptions, api.WithBalanceIndexer(cs.balanceIndexer))
+	}
 
 	svr, err := api.NewServerV2(
 		cfg,
@@ -110,7 +110,32 @@ var (
 				DefaultGas:         uint64(unit.Qev),
 				Percentile:         60,
 			},
-			RangeQueryLimit: 1000,
+			RangeQueryLimit:      1000,
+			PendingBlockInterval: 2 * time.Second,
+			Limit: APILimit{
+				APIKeys: []APIKey{},
+				MethodCosts: map[string]int{
+					"eth_getLogs":                   10,
+					"eth_getFilterLogs":             10,
+					"GetLogs":                       10,
+					"eth_getBlockReceipts":          5,
+					"eth_createAccessList":          5,
+					"GetReceiptsByBlock":            5,
+					"trace_replayBlockTransactions": 20,
+					"trace_replayTransaction":       20,
+					"GetStateDiff":                  20,
+					"debug_storageRangeAt":          10,
+					"GetContractStorageRange":       10,
+					"GetBalanceHistory":             5,
+					"ListActionsByAddress":          5,
+					"TraceTransactionStructLogs":    20,
+				},
+				AllowedOrigins: []string{"*"},
+			},
+			GraphQL: GraphQL{
+				MaxDepth: 10,
+				MaxCost:  1000,
+			},
 		},
 		System: System{
 			Active:                true,
@@ -178,6 +203,9 @@ type (
 		ToleratedOvertime time.Duration   `yaml:"toleratedOvertime"`
 		Delay             time.Duration   `yaml:"delay"`
 		ConsensusDBPath   string          `yaml:"consensusDBPath"`
+		// ConsensusWALPath is the path of the consensus write-ahead log, which defaults to the path of the
+		// consensus db with the extension .wal
+		ConsensusWALPath string `yaml:"consensusWALPath"`
 	}
 
 	// ConsensusTiming defines a set of time durations used in fsm and event queue size
@@ -197,11 +225,56 @@ type (
 		GRPCPort        int           `yaml:"port"`
 		HTTPPort        int           `yaml:"web3port"`
 		WebSocketPort   int           `yaml:"webSocketPort"`
+		GraphQLPort     int           `yaml:"graphqlPort"`
+		RESTPort        int           `yaml:"restPort"`
 		RedisCacheURL   string        `yaml:"redisCacheURL"`
 		TpsWindow       int           `yaml:"tpsWindow"`
 		GasStation      GasStation    `yaml:"gasStation"`
 		RangeQueryLimit uint64        `yaml:"rangeQueryLimit"`
 		Tracer          tracer.Config `yaml:"tracer"`
+		Limit           APILimit      `yaml:"limit"`
+		GraphQL         GraphQL       `yaml:"graphql"`
+		// PendingBlockInterval is the min interval to rebuild the pending block served to "pending" queries,
+		// unless the tip has moved since last build
+		PendingBlockInterval time.Duration `yaml:"pendingBlockInterval"`
+	}
+
+	// GraphQL is the config of the query-cost limits of GraphQL server, which is disabled if GraphQLPort is 0
+	GraphQL struct {
+		// MaxDepth is the max depth of the fields nested in a query
+		MaxDepth int `yaml:"maxDepth"`
+		// MaxCost is the max cost of a query, in which loading a block, an action, an account or a page of staking
+		// data costs 1, and querying the logs costs 1 per block in the range
+		MaxCost int `yaml:"maxCost"`
+	}
+
+	// APILimit is the config of API keys, rate limits and allowed origins shared by gRPC, web3 and websocket servers
+	APILimit struct {
+		// RequireAPIKey rejects the requests without a valid API key
+		RequireAPIKey bool     `yaml:"requireAPIKey"`
+		APIKeys       []APIKey `yaml:"apiKeys"`
+		// IPRate is the number of tokens refilled per second for each IP sending requests without API key, 0 means
+		// unlimited
+		IPRate  float64 `yaml:"ipRate"`
+		IPBurst int     `yaml:"ipBurst"`
+		// MethodCosts are the tokens consumed by the methods, the cost of a method not listed is 1. gRPC methods are
+		// named without service name, e.g., GetLogs, and web3 methods are named as is, e.g., eth_getLogs
+		MethodCosts map[string]int `yaml:"methodCosts"`
+		// AllowedOrigins are the origins allowed to access web3 and websocket servers, "*" allows any origin
+		AllowedOrigins []string `yaml:"allowedOrigins"`
+		// TrustedProxies is the number of proxies in front of the node, the client IP is taken from X-Forwarded-For
+		// header as the entry appended by the outermost of them. 0 ignores the header
+		TrustedProxies int `yaml:"trustedProxies"`
+	}
+
+	// APIKey is an API key with its own quota
+	APIKey struct {
+		// Name is the name of the key used in metrics
+		Name string `yaml:"name"`
+		Key  string `yaml:"key"`
+		// Rate is the number of tokens refilled per second, 0 means unlimited
+		Rate  float64 `yaml:"rate"`
+		Burst int     `yaml:"burst"`
 	}
 
 	// GasStation is the gas station config
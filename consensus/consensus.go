@@ -13,6 +13,7 @@ import (
 	"github.com/pkg/errors"
 	"go.uber.org/zap"
 
+	"github.com/iotexproject/iotex-core/action/evidence"
 	"github.com/iotexproject/iotex-core/action/protocol"
 	"github.com/iotexproject/iotex-core/action/protocol/poll"
 	rp "github.com/iotexproject/iotex-core/action/protocol/rolldpos"
@@ -24,6 +25,7 @@ import (
 	"github.com/iotexproject/iotex-core/consensus/scheme/rolldpos"
 	"github.com/iotexproject/iotex-core/pkg/lifecycle"
 	"github.com/iotexproject/iotex-core/pkg/log"
+	"github.com/iotexproject/iotex-core/signer"
 	"github.com/iotexproject/iotex-core/state"
 	"github.com/iotexproject/iotex-core/state/factory"
 	"github.com/iotexproject/iotex-proto/golang/iotextypes"
@@ -51,6 +53,8 @@ type optionParams struct {
 	broadcastHandler scheme.Broadcast
 	pp               poll.Protocol
 	rp               *rp.Protocol
+	evidencePool     *evidence.Pool
+	signer           signer.Signer
 }
 
 // Option sets Consensus construction parameter.
@@ -80,6 +84,22 @@ func WithPollProtocol(pp poll.Protocol) Option {
 	}
 }
 
+// WithSigner is an option to sign consensus messages with the signer instead of the producer private key
+func WithSigner(s signer.Signer) Option {
+	return func(ops *optionParams) error {
+		ops.signer = s
+		return nil
+	}
+}
+
+// WithEvidencePool is an option to collect double sign evidences into the pool
+func WithEvidencePool(pool *evidence.Pool) Option {
+	return func(ops *optionParams) error {
+		ops.evidencePool = pool
+		return nil
+	}
+}
+
 // NewConsensus creates a IotxConsensus struct.
 func NewConsensus(
 	cfg config.Config,
@@ -99,14 +119,20 @@ func NewConsensus(
 	var err error
 	switch cfg.Consensus.Scheme {
 	case config.RollDPoSScheme:
+		producerSigner := ops.signer
+		if producerSigner == nil {
+			producerSigner = signer.NewLocalSigner(cfg.Chain.ProducerPrivateKey())
+		}
 		bd := rolldpos.NewRollDPoSBuilder().
 			SetAddr(cfg.Chain.ProducerAddress().String()).
-			SetPriKey(cfg.Chain.ProducerPrivateKey()).
+			SetBLSPriKey(cfg.Chain.ProducerBLSPrivateKey()).
 			SetConfig(cfg).
 			SetChainManager(rolldpos.NewChainManager(bc)).
 			SetBlockDeserializer(block.NewDeserializer(bc.EvmNetworkID())).
 			SetClock(clock).
 			SetBroadcast(ops.broadcastHandler).
+			SetEvidencePool(ops.evidencePool).
+			SetSigner(producerSigner).
 			SetDelegatesByEpochFunc(func(epochNum uint64) ([]string, error) {
 				re := protocol.NewRegistry()
 				if err := ops.rp.Register(re); err != nil {
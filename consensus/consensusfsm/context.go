@@ -30,6 +30,7 @@ type Context interface {
 	Broadcast(interface{})
 
 	Prepare() error
+	Replay() error
 	IsDelegate() bool
 	Proposal() (interface{}, error)
 	WaitUntilRoundStart() time.Duration
@@ -94,6 +94,8 @@ type ConsensusFSM struct {
 	clock clock.Clock
 	ctx   Context
 	wg    sync.WaitGroup
+	// replayed indicates whether the logged decisions have been replayed since start
+	replayed bool
 }
 
 // NewConsensusFSM returns a new fsm
@@ -401,6 +403,14 @@ func (m *ConsensusFSM) prepare(evt fsm.Event) (fsm.State, error) {
 		m.ctx.Logger().Error("Error during prepare", zap.Error(err))
 		return m.BackToPrepare(0)
 	}
+	if !m.replayed {
+		// replay the decisions made before restart, once the round to continue is known
+		if err := m.ctx.Replay(); err != nil {
+			m.ctx.Logger().Error("Error during replaying consensus WAL", zap.Error(err))
+			return m.BackToPrepare(0)
+		}
+		m.replayed = true
+	}
 	m.ctx.Logger().Debug("Start a new round")
 	proposal, err := m.ctx.Proposal()
 	if err != nil {
@@ -31,6 +31,7 @@ func TestBackdoorEvt(t *testing.T) {
 	mockCtx.EXPECT().EventChanSize().Return(uint(10)).AnyTimes()
 	mockCtx.EXPECT().Logger().Return(log.Logger("consensus")).AnyTimes()
 	mockCtx.EXPECT().Prepare().Return(nil).AnyTimes()
+	mockCtx.EXPECT().Replay().Return(nil).AnyTimes()
 	mockCtx.EXPECT().NewConsensusEvent(gomock.Any(), gomock.Any()).DoAndReturn(
 		func(eventType fsm.EventType, data interface{}) *ConsensusEvent {
 			return &ConsensusEvent{
@@ -98,7 +99,22 @@ func TestStateTransitionFunctions(t *testing.T) {
 			evt := <-cfsm.evtq
 			require.Equal(ePrepare, evt.Type())
 		})
+		t.Run("replay-with-error", func(t *testing.T) {
+			mockCtx.EXPECT().Prepare().Return(nil).Times(1)
+			mockCtx.EXPECT().Replay().Return(errors.New("some error")).Times(1)
+			mockCtx.EXPECT().Active().Return(true).Times(1)
+			state, err := cfsm.prepare(evt)
+			require.NoError(err)
+			require.Equal(sPrepare, state)
+			require.False(cfsm.replayed)
+			time.Sleep(100 * time.Millisecond)
+			mockClock.Add(10 * time.Second)
+			evt := <-cfsm.evtq
+			require.Equal(ePrepare, evt.Type())
+		})
 		t.Run("stand-by-or-is-not-delegate", func(t *testing.T) {
+			// the decisions are replayed only once
+			mockCtx.EXPECT().Replay().Return(nil).Times(1)
 			mockCtx.EXPECT().Prepare().Return(nil).Times(2)
 			mockCtx.EXPECT().Proposal().Return(nil, nil).Times(2)
 			mockCtx.EXPECT().WaitUntilRoundStart().Return(time.Duration(0)).Times(2)
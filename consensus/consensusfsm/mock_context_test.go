@@ -415,6 +415,20 @@ func (mr *MockContextMockRecorder) UnmatchedEventTTL(arg0 interface{}) *gomock.C
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UnmatchedEventTTL", reflect.TypeOf((*MockContext)(nil).UnmatchedEventTTL), arg0)
 }
 
+// Replay mocks base method.
+func (m *MockContext) Replay() error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Replay")
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Replay indicates an expected call of Replay.
+func (mr *MockContextMockRecorder) Replay() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Replay", reflect.TypeOf((*MockContext)(nil).Replay))
+}
+
 // WaitUntilRoundStart mocks base method.
 func (m *MockContext) WaitUntilRoundStart() time.Duration {
 	m.ctrl.T.Helper()
@@ -0,0 +1,323 @@
+// Copyright (c) 2022 IoTeX Foundation
+// This is an alpha (internal) release and is not suitable for production. This source code is provided 'as is' and no
+// warranties are given as to title or non-infringement, merchantability or fitness for purpose and, to the extent
+// permitted by law, all liability for your use of the code is disclaimed. This source code is governed by Apache
+// License 2.0 that can be found in the LICENSE file.
+
+package consensusfsm
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/binary"
+	"hash/crc32"
+	"io"
+	"os"
+	"path/filepath"
+	"sync"
+
+	"github.com/pkg/errors"
+	"go.uber.org/zap"
+	"google.golang.org/protobuf/proto"
+
+	"github.com/iotexproject/iotex-core/consensus/consensusfsm/walpb"
+	"github.com/iotexproject/iotex-core/pkg/log"
+)
+
+const (
+	// _walHeaderSize is the size of the header of a record, which consists of the length and the checksum of the
+	// payload
+	_walHeaderSize = 8
+	// _walMaxRecordSize is the max size of a record, a larger length in header indicates a corrupted record
+	_walMaxRecordSize = 128 << 20
+)
+
+// ErrConflictDecision indicates that a decision contradicts the one logged in the WAL
+var ErrConflictDecision = errors.New("decision conflicts with the logged one")
+
+type (
+	// Decision is a decision made by the delegate in a round, i.e., a block proposal or a vote
+	Decision struct {
+		Height uint64
+		Round  uint32
+		// Kind is the kind of the decision defined by the consensus scheme, e.g., the topic of a vote
+		Kind      uint32
+		BlockHash []byte
+		// Message is the serialized endorsed message of the decision
+		Message []byte
+	}
+
+	// WAL is the write-ahead log of the decisions made by the delegate. A decision is persisted before the
+	// corresponding message is broadcast, such that a delegate restarted in the middle of a round never contradicts
+	// what it has sent out before the crash.
+	WAL struct {
+		mutex     sync.RWMutex
+		path      string
+		file      *os.File
+		decisions []*Decision
+	}
+)
+
+// NewWAL creates a write-ahead log at the given path
+func NewWAL(path string) *WAL {
+	return &WAL{path: path}
+}
+
+// Start opens the log file and loads the logged decisions
+func (w *WAL) Start(_ context.Context) error {
+	w.mutex.Lock()
+	defer w.mutex.Unlock()
+	file, err := os.OpenFile(w.path, os.O_CREATE|os.O_RDWR, 0600)
+	if err != nil {
+		return errors.Wrapf(err, "failed to open consensus WAL %s", w.path)
+	}
+	decisions, size, err := readDecisions(file)
+	if err != nil {
+		file.Close()
+		return err
+	}
+	// drop the record torn by a crash
+	if err := file.Truncate(size); err != nil {
+		file.Close()
+		return errors.Wrap(err, "failed to truncate consensus WAL")
+	}
+	if _, err := file.Seek(size, io.SeekStart); err != nil {
+		file.Close()
+		return errors.Wrap(err, "failed to seek consensus WAL")
+	}
+	w.file = file
+	w.decisions = decisions
+	return nil
+}
+
+// Stop closes the log file
+func (w *WAL) Stop(_ context.Context) error {
+	w.mutex.Lock()
+	defer w.mutex.Unlock()
+	if w.file == nil {
+		return nil
+	}
+	err := w.file.Close()
+	w.file = nil
+	return err
+}
+
+// Check returns ErrConflictDecision if a different block has been decided at the same height, round and kind
+func (w *WAL) Check(d *Decision) error {
+	w.mutex.RLock()
+	defer w.mutex.RUnlock()
+	_, err := w.check(d)
+	return err
+}
+
+// Append checks and persists a decision. It is a no-op if the same decision has been logged.
+func (w *WAL) Append(d *Decision) error {
+	w.mutex.Lock()
+	defer w.mutex.Unlock()
+	if w.file == nil {
+		return errors.New("consensus WAL is not started")
+	}
+	logged, err := w.check(d)
+	if err != nil || logged {
+		return err
+	}
+	if err := writeDecision(w.file, d); err != nil {
+		return err
+	}
+	if err := w.file.Sync(); err != nil {
+		return errors.Wrap(err, "failed to sync consensus WAL")
+	}
+	w.decisions = append(w.decisions, d)
+	return nil
+}
+
+// Decisions returns the logged decisions at the given height, in the order they are made
+func (w *WAL) Decisions(height uint64) []*Decision {
+	w.mutex.RLock()
+	defer w.mutex.RUnlock()
+	decisions := []*Decision{}
+	for _, d := range w.decisions {
+		if d.Height == height {
+			decisions = append(decisions, d)
+		}
+	}
+	return decisions
+}
+
+// Decision returns the logged decision of the given kind at the given height and round, or nil if not found
+func (w *WAL) Decision(height uint64, round uint32, kind uint32) *Decision {
+	w.mutex.RLock()
+	defer w.mutex.RUnlock()
+	for _, d := range w.decisions {
+		if d.Height == height && d.Round == round && d.Kind == kind {
+			return d
+		}
+	}
+	return nil
+}
+
+// Prune removes the decisions below the given height
+func (w *WAL) Prune(height uint64) error {
+	w.mutex.Lock()
+	defer w.mutex.Unlock()
+	if w.file == nil {
+		return errors.New("consensus WAL is not started")
+	}
+	remaining := []*Decision{}
+	for _, d := range w.decisions {
+		if d.Height >= height {
+			remaining = append(remaining, d)
+		}
+	}
+	if len(remaining) == len(w.decisions) {
+		return nil
+	}
+	// rewrite the remaining decisions into a new file, and replace the log with it
+	tmpPath := w.path + ".tmp"
+	tmp, err := os.OpenFile(tmpPath, os.O_CREATE|os.O_TRUNC|os.O_WRONLY, 0600)
+	if err != nil {
+		return errors.Wrap(err, "failed to create consensus WAL")
+	}
+	for _, d := range remaining {
+		if err := writeDecision(tmp, d); err != nil {
+			tmp.Close()
+			return err
+		}
+	}
+	if err := tmp.Sync(); err != nil {
+		tmp.Close()
+		return errors.Wrap(err, "failed to sync consensus WAL")
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+	if err := os.Rename(tmpPath, w.path); err != nil {
+		return errors.Wrap(err, "failed to replace consensus WAL")
+	}
+	// persist the rename, otherwise the pruned decisions could come back after a crash
+	if err := syncDir(filepath.Dir(w.path)); err != nil {
+		return err
+	}
+	if err := w.file.Close(); err != nil {
+		log.L().Warn("failed to close the pruned consensus WAL", zap.Error(err))
+	}
+	if w.file, err = os.OpenFile(w.path, os.O_RDWR|os.O_APPEND, 0600); err != nil {
+		return errors.Wrap(err, "failed to open consensus WAL")
+	}
+	w.decisions = remaining
+	return nil
+}
+
+// syncDir flushes the entries of the directory to disk
+func syncDir(dir string) error {
+	d, err := os.Open(dir)
+	if err != nil {
+		return errors.Wrap(err, "failed to open consensus WAL directory")
+	}
+	defer d.Close()
+	if err := d.Sync(); err != nil {
+		return errors.Wrap(err, "failed to sync consensus WAL directory")
+	}
+	return nil
+}
+
+// check returns true if the same decision has been logged
+func (w *WAL) check(d *Decision) (bool, error) {
+	for _, logged := range w.decisions {
+		if logged.Height != d.Height || logged.Round != d.Round || logged.Kind != d.Kind {
+			continue
+		}
+		if !bytes.Equal(logged.BlockHash, d.BlockHash) {
+			return false, errors.Wrapf(
+				ErrConflictDecision,
+				"block %x has been decided at height %d round %d kind %d",
+				logged.BlockHash,
+				d.Height,
+				d.Round,
+				d.Kind,
+			)
+		}
+		return true, nil
+	}
+	return false, nil
+}
+
+func (d *Decision) toProto() *walpb.Decision {
+	return &walpb.Decision{
+		Height:    d.Height,
+		Round:     d.Round,
+		Kind:      d.Kind,
+		BlockHash: d.BlockHash,
+		Message:   d.Message,
+	}
+}
+
+func (d *Decision) fromProto(pb *walpb.Decision) {
+	d.Height = pb.GetHeight()
+	d.Round = pb.GetRound()
+	d.Kind = pb.GetKind()
+	d.BlockHash = pb.GetBlockHash()
+	d.Message = pb.GetMessage()
+}
+
+func writeDecision(w io.Writer, d *Decision) error {
+	payload, err := proto.Marshal(d.toProto())
+	if err != nil {
+		return errors.Wrap(err, "failed to serialize decision")
+	}
+	record := make([]byte, _walHeaderSize, _walHeaderSize+len(payload))
+	binary.BigEndian.PutUint32(record, uint32(len(payload)))
+	binary.BigEndian.PutUint32(record[4:], crc32.ChecksumIEEE(payload))
+	if _, err := w.Write(append(record, payload...)); err != nil {
+		return errors.Wrap(err, "failed to write consensus WAL")
+	}
+	return nil
+}
+
+// readDecisions reads the decisions from the beginning of the file, and returns the size of the valid records
+func readDecisions(file *os.File) ([]*Decision, int64, error) {
+	if _, err := file.Seek(0, io.SeekStart); err != nil {
+		return nil, 0, errors.Wrap(err, "failed to seek consensus WAL")
+	}
+	var (
+		reader    = bufio.NewReader(file)
+		header    = make([]byte, _walHeaderSize)
+		decisions = []*Decision{}
+		size      int64
+	)
+	for {
+		if _, err := io.ReadFull(reader, header); err != nil {
+			if err != io.EOF && err != io.ErrUnexpectedEOF {
+				return nil, 0, errors.Wrap(err, "failed to read consensus WAL")
+			}
+			break
+		}
+		length := binary.BigEndian.Uint32(header)
+		if length > _walMaxRecordSize {
+			break
+		}
+		payload := make([]byte, length)
+		if _, err := io.ReadFull(reader, payload); err != nil {
+			if err != io.EOF && err != io.ErrUnexpectedEOF {
+				return nil, 0, errors.Wrap(err, "failed to read consensus WAL")
+			}
+			break
+		}
+		if crc32.ChecksumIEEE(payload) != binary.BigEndian.Uint32(header[4:]) {
+			break
+		}
+		pb := &walpb.Decision{}
+		if err := proto.Unmarshal(payload, pb); err != nil {
+			break
+		}
+		d := &Decision{}
+		d.fromProto(pb)
+		decisions = append(decisions, d)
+		size += int64(_walHeaderSize + len(payload))
+	}
+	if stat, err := file.Stat(); err == nil && stat.Size() > size {
+		log.L().Warn("drop the corrupted tail of consensus WAL", zap.Int64("size", stat.Size()), zap.Int64("valid", size))
+	}
+	return decisions, size, nil
+}
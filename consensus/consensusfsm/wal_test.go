@@ -0,0 +1,77 @@
+// Copyright (c) 2022 IoTeX Foundation
+// This is an alpha (internal) release and is not suitable for production. This source code is provided 'as is' and no
+// warranties are given as to title or non-infringement, merchantability or fitness for purpose and, to the extent
+// permitted by law, all liability for your use of the code is disclaimed. This source code is governed by Apache
+// License 2.0 that can be found in the LICENSE file.
+
+package consensusfsm
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWAL(t *testing.T) {
+	require := require.New(t)
+	ctx := context.Background()
+	path := filepath.Join(t.TempDir(), "consensus.wal")
+
+	wal := NewWAL(path)
+	require.Error(wal.Append(&Decision{Height: 1}))
+	require.NoError(wal.Start(ctx))
+	decisions := []*Decision{
+		{Height: 1, Round: 0, Kind: 0, BlockHash: []byte("a"), Message: []byte("msg1")},
+		{Height: 2, Round: 0, Kind: 0, BlockHash: []byte("b"), Message: []byte("msg2")},
+		{Height: 2, Round: 0, Kind: 1, BlockHash: []byte("b"), Message: []byte("msg3")},
+		{Height: 2, Round: 1, Kind: 0, BlockHash: nil, Message: []byte("msg4")},
+	}
+	for _, d := range decisions {
+		require.NoError(wal.Append(d))
+	}
+	// the same decision could be logged again
+	require.NoError(wal.Append(decisions[1]))
+	require.Len(wal.Decisions(2), 3)
+
+	// contradicting decisions
+	for _, d := range []*Decision{
+		{Height: 2, Round: 0, Kind: 0, BlockHash: []byte("c")},
+		{Height: 2, Round: 1, Kind: 0, BlockHash: []byte("b")},
+	} {
+		require.Equal(ErrConflictDecision, errors.Cause(wal.Check(d)))
+		require.Equal(ErrConflictDecision, errors.Cause(wal.Append(d)))
+	}
+	require.NoError(wal.Check(&Decision{Height: 2, Round: 2, Kind: 0, BlockHash: []byte("c")}))
+	require.NoError(wal.Stop(ctx))
+
+	// a torn record is dropped on restart
+	file, err := os.OpenFile(path, os.O_APPEND|os.O_WRONLY, 0600)
+	require.NoError(err)
+	_, err = file.Write([]byte{0, 0, 0, 10, 1, 2})
+	require.NoError(err)
+	require.NoError(file.Close())
+
+	wal = NewWAL(path)
+	require.NoError(wal.Start(ctx))
+	require.Equal(decisions[1:], wal.Decisions(2))
+	require.Equal(decisions[3], wal.Decision(2, 1, 0))
+	require.Nil(wal.Decision(2, 1, 1))
+	require.NoError(wal.Append(&Decision{Height: 3, Round: 0, Kind: 0, BlockHash: []byte("d")}))
+
+	// prune the decisions of the committed heights
+	require.NoError(wal.Prune(2))
+	require.Empty(wal.Decisions(1))
+	require.NoError(wal.Append(&Decision{Height: 3, Round: 0, Kind: 1, BlockHash: []byte("d")}))
+	require.NoError(wal.Stop(ctx))
+
+	wal = NewWAL(path)
+	require.NoError(wal.Start(ctx))
+	require.Empty(wal.Decisions(1))
+	require.Len(wal.Decisions(2), 3)
+	require.Len(wal.Decisions(3), 2)
+	require.NoError(wal.Stop(ctx))
+}
@@ -0,0 +1,110 @@
+// Copyright (c) 2022 IoTeX Foundation
+// This is an alpha (internal) release and is not suitable for production. This source code is provided 'as is' and no
+// warranties are given as to title or non-infringement, merchantability or fitness for purpose and, to the extent
+// permitted by law, all liability for your use of the code is disclaimed. This source code is governed by Apache
+// License 2.0 that can be found in the LICENSE file.
+
+package rolldpos
+
+import (
+	"encoding/hex"
+
+	"github.com/pkg/errors"
+
+	"github.com/iotexproject/iotex-core/blockchain/genesis"
+	"github.com/iotexproject/iotex-core/endorsement"
+)
+
+// blsAggregator aggregates the commit endorsements of a block into a single BLS signature, since the BLS aggregate
+// height in genesis
+type blsAggregator struct {
+	height  uint64
+	pubKeys map[string]*endorsement.BLSPublicKey
+	priKey  *endorsement.BLSPrivateKey
+}
+
+func newBLSAggregator(g genesis.Blockchain, priKey *endorsement.BLSPrivateKey) (*blsAggregator, error) {
+	pubKeys := make(map[string]*endorsement.BLSPublicKey, len(g.BLSDelegates))
+	for _, d := range g.BLSDelegates {
+		pk, err := endorsement.HexStringToBLSPublicKey(d.PubKeyStr)
+		if err != nil {
+			return nil, errors.Wrapf(err, "invalid BLS public key of delegate %s", d.OperatorAddrStr)
+		}
+		proof, err := hex.DecodeString(d.ProofStr)
+		if err != nil {
+			return nil, errors.Wrapf(err, "invalid BLS proof of possession of delegate %s", d.OperatorAddrStr)
+		}
+		if !pk.VerifyProofOfPossession(proof) {
+			return nil, errors.Errorf("failed to verify BLS proof of possession of delegate %s", d.OperatorAddrStr)
+		}
+		pubKeys[d.OperatorAddrStr] = pk
+	}
+	return &blsAggregator{
+		height:  g.BLSAggregateBlockHeight,
+		pubKeys: pubKeys,
+		priKey:  priKey,
+	}, nil
+}
+
+// IsActive returns whether the commit endorsements are aggregated at the height
+func (a *blsAggregator) IsActive(height uint64) bool {
+	return a != nil && height >= a.height
+}
+
+// CanSign returns whether the node signs aggregatable commit endorsements at the height
+func (a *blsAggregator) CanSign(height uint64) bool {
+	return a.IsActive(height) && a.priKey != nil
+}
+
+// Aggregate aggregates the valid BLS signatures in the commit endorsements of the delegates
+func (a *blsAggregator) Aggregate(
+	delegates []string,
+	vote *ConsensusVote,
+	ens []*endorsement.Endorsement,
+) (*endorsement.AggregateEndorsement, error) {
+	index := make(map[string]int, len(delegates))
+	for i, d := range delegates {
+		index[d] = i
+	}
+	agg, err := endorsement.Aggregate(len(delegates), ens, func(en *endorsement.Endorsement) (int, bool) {
+		addr := en.Endorser().Address()
+		if addr == nil {
+			return 0, false
+		}
+		i, ok := index[addr.String()]
+		if !ok || !endorsement.VerifyBLSEndorsement(vote, en, a.pubKeys[addr.String()]) {
+			return 0, false
+		}
+		return i, true
+	})
+	switch errors.Cause(err) {
+	case nil:
+	case endorsement.ErrInvalidBLSSignature:
+		return nil, ErrInsufficientEndorsements
+	default:
+		return nil, err
+	}
+	if !isMajority(agg.NumSigners(), len(delegates)) {
+		return nil, ErrInsufficientEndorsements
+	}
+	return agg, nil
+}
+
+// Verify verifies the aggregate commit endorsement of the delegates
+func (a *blsAggregator) Verify(
+	delegates []string,
+	vote *ConsensusVote,
+	agg *endorsement.AggregateEndorsement,
+) error {
+	pks := make([]*endorsement.BLSPublicKey, len(delegates))
+	for i, d := range delegates {
+		pks[i] = a.pubKeys[d]
+	}
+	if err := endorsement.VerifyAggregateEndorsement(vote, agg, pks); err != nil {
+		return err
+	}
+	if !isMajority(agg.NumSigners(), len(delegates)) {
+		return ErrInsufficientEndorsements
+	}
+	return nil
+}
@@ -0,0 +1,104 @@
+// Copyright (c) 2022 IoTeX Foundation
+// This is an alpha (internal) release and is not suitable for production. This source code is provided 'as is' and no
+// warranties are given as to title or non-infringement, merchantability or fitness for purpose and, to the extent
+// permitted by law, all liability for your use of the code is disclaimed. This source code is governed by Apache
+// License 2.0 that can be found in the LICENSE file.
+
+package rolldpos
+
+import (
+	"encoding/hex"
+	"testing"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/require"
+
+	"github.com/iotexproject/iotex-core/blockchain/genesis"
+	"github.com/iotexproject/iotex-core/endorsement"
+	"github.com/iotexproject/iotex-core/test/identityset"
+)
+
+func makeBLSDelegates(t *testing.T, n int) ([]*endorsement.BLSPrivateKey, []genesis.BLSDelegate) {
+	var (
+		sks       []*endorsement.BLSPrivateKey
+		delegates []genesis.BLSDelegate
+	)
+	for i := 0; i < n; i++ {
+		sk, err := endorsement.GenerateBLSPrivateKey()
+		require.NoError(t, err)
+		proof, err := sk.ProofOfPossession()
+		require.NoError(t, err)
+		sks = append(sks, sk)
+		delegates = append(delegates, genesis.BLSDelegate{
+			OperatorAddrStr: identityset.Address(i).String(),
+			PubKeyStr:       sk.PublicKey().HexString(),
+			ProofStr:        hex.EncodeToString(proof),
+		})
+	}
+	return sks, delegates
+}
+
+func TestBLSAggregator(t *testing.T) {
+	require := require.New(t)
+	sks, blsDelegates := makeBLSDelegates(t, 4)
+	g := genesis.Default.Blockchain
+	g.BLSAggregateBlockHeight = 10
+	g.BLSDelegates = blsDelegates
+
+	t.Run("invalid proof of possession", func(t *testing.T) {
+		bad := g
+		bad.BLSDelegates = append([]genesis.BLSDelegate{}, blsDelegates...)
+		bad.BLSDelegates[1].ProofStr = bad.BLSDelegates[0].ProofStr
+		_, err := newBLSAggregator(bad, nil)
+		require.Error(err)
+	})
+
+	agg, err := newBLSAggregator(g, sks[0])
+	require.NoError(err)
+	require.False(agg.IsActive(9))
+	require.True(agg.IsActive(10))
+	require.True(agg.CanSign(10))
+	var nilAgg *blsAggregator
+	require.False(nilAgg.IsActive(10))
+
+	delegates := make([]string, 4)
+	for i := range delegates {
+		delegates[i] = identityset.Address(i).String()
+	}
+	vote := NewConsensusVote([]byte("block hash"), COMMIT)
+	var ens []*endorsement.Endorsement
+	for i := 0; i < 2; i++ {
+		en, err := endorsement.EndorseWithBLS(identityset.PrivateKey(i), sks[i], vote, time.Now())
+		require.NoError(err)
+		ens = append(ens, en)
+	}
+	// signed with a key not registered for the endorser
+	en, err := endorsement.EndorseWithBLS(identityset.PrivateKey(2), sks[3], vote, time.Now())
+	require.NoError(err)
+	ens = append(ens, en)
+	_, err = agg.Aggregate(delegates, vote, ens)
+	require.Equal(ErrInsufficientEndorsements, errors.Cause(err))
+
+	en, err = endorsement.EndorseWithBLS(identityset.PrivateKey(3), sks[3], vote, time.Now())
+	require.NoError(err)
+	ens = append(ens, en)
+	aggEn, err := agg.Aggregate(delegates, vote, ens)
+	require.NoError(err)
+	require.Equal(3, aggEn.NumSigners())
+	require.False(aggEn.IsSigned(2))
+	require.NoError(agg.Verify(delegates, vote, aggEn))
+	require.Error(agg.Verify(delegates, NewConsensusVote([]byte("block hash"), LOCK), aggEn))
+
+	// not majority
+	aggEn, err = endorsement.Aggregate(len(delegates), ens[:2], func(en *endorsement.Endorsement) (int, bool) {
+		for i := range delegates {
+			if en.Endorser().Address().String() == delegates[i] {
+				return i, true
+			}
+		}
+		return 0, false
+	})
+	require.NoError(err)
+	require.Equal(ErrInsufficientEndorsements, errors.Cause(agg.Verify(delegates, vote, aggEn)))
+}
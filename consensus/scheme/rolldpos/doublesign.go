@@ -0,0 +1,114 @@
+// Copyright (c) 2022 IoTeX Foundation
+// This is an alpha (internal) release and is not suitable for production. This source code is provided 'as is' and no
+// warranties are given as to title or non-infringement, merchantability or fitness for purpose and, to the extent
+// permitted by law, all liability for your use of the code is disclaimed. This source code is governed by Apache
+// License 2.0 that can be found in the LICENSE file.
+
+package rolldpos
+
+import (
+	"sync"
+
+	"github.com/iotexproject/iotex-proto/golang/iotextypes"
+	"go.uber.org/zap"
+
+	"github.com/iotexproject/iotex-core/action/evidence"
+	"github.com/iotexproject/iotex-core/consensus/scheme"
+	"github.com/iotexproject/iotex-core/endorsement"
+	"github.com/iotexproject/iotex-core/pkg/log"
+)
+
+// doubleSignDetector collects the evidences of delegates endorsing conflicting consensus messages, and gossips them
+// to the network, such that they could be included into blocks by any delegate
+type doubleSignDetector struct {
+	mutex            sync.Mutex
+	pool             *evidence.Pool
+	broadcastHandler scheme.Broadcast
+	// proposals keeps the latest block proposal received from each proposer
+	proposals map[string]*iotextypes.ConsensusMessage
+}
+
+func newDoubleSignDetector(pool *evidence.Pool, broadcastHandler scheme.Broadcast) *doubleSignDetector {
+	return &doubleSignDetector{
+		pool:             pool,
+		broadcastHandler: broadcastHandler,
+		proposals:        map[string]*iotextypes.ConsensusMessage{},
+	}
+}
+
+// CheckProposal checks whether the proposer has proposed another block in the same round
+func (d *doubleSignDetector) CheckProposal(msg *iotextypes.ConsensusMessage, en *endorsement.Endorsement) {
+	if d.pool == nil {
+		return
+	}
+	proposer := en.Endorser().HexString()
+	d.mutex.Lock()
+	prev, exists := d.proposals[proposer]
+	d.proposals[proposer] = msg
+	d.mutex.Unlock()
+	if !exists {
+		return
+	}
+	// proposals of different rounds don't conflict
+	if ds, err := evidence.NewDoubleSign(prev, msg); err == nil {
+		d.report(ds)
+	}
+}
+
+// CheckVotes reports two conflicting votes at the given height found by the endorsement manager
+func (d *doubleSignDetector) CheckVotes(
+	height uint64,
+	vote *ConsensusVote,
+	en *endorsement.Endorsement,
+	conflict *ConsensusVote,
+	conflictEn *endorsement.Endorsement,
+) {
+	if d.pool == nil {
+		return
+	}
+	a, err := NewEndorsedConsensusMessage(height, vote, en).Proto()
+	if err != nil {
+		log.Logger("consensus").Error("failed to convert consensus vote", zap.Error(err))
+		return
+	}
+	b, err := NewEndorsedConsensusMessage(height, conflict, conflictEn).Proto()
+	if err != nil {
+		log.Logger("consensus").Error("failed to convert consensus vote", zap.Error(err))
+		return
+	}
+	ds, err := evidence.NewDoubleSign(a, b)
+	if err != nil {
+		log.Logger("consensus").Error("failed to create double sign evidence", zap.Error(err))
+		return
+	}
+	d.report(ds)
+}
+
+// HandleEvidence adds the evidence gossiped by other nodes into the pool, and relays it if it is new
+func (d *doubleSignDetector) HandleEvidence(ds *evidence.DoubleSign) {
+	if d.pool != nil && d.pool.Add(ds) {
+		d.broadcast(ds)
+	}
+}
+
+func (d *doubleSignDetector) report(ds *evidence.DoubleSign) {
+	if !d.pool.Add(ds) {
+		return
+	}
+	log.Logger("consensus").Warn(
+		"detected double sign",
+		zap.String("offender", ds.Offender().HexString()),
+		zap.Uint64("height", ds.Height()),
+		zap.Time("timestamp", ds.Timestamp()),
+	)
+	d.broadcast(ds)
+}
+
+func (d *doubleSignDetector) broadcast(ds *evidence.DoubleSign) {
+	if d.broadcastHandler == nil {
+		return
+	}
+	if err := d.broadcastHandler(ds.ConsensusMessage()); err != nil {
+		log.Logger("consensus").Error("failed to broadcast double sign evidence", zap.Error(err))
+	}
+}
@@ -0,0 +1,75 @@
+// Copyright (c) 2022 IoTeX Foundation
+// This is an alpha (internal) release and is not suitable for production. This source code is provided 'as is' and no
+// warranties are given as to title or non-infringement, merchantability or fitness for purpose and, to the extent
+// permitted by law, all liability for your use of the code is disclaimed. This source code is governed by Apache
+// License 2.0 that can be found in the LICENSE file.
+
+package rolldpos
+
+import (
+	"testing"
+	"time"
+
+	"github.com/iotexproject/go-pkgs/hash"
+	"github.com/iotexproject/iotex-proto/golang/iotextypes"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/proto"
+
+	"github.com/iotexproject/iotex-core/action/evidence"
+	"github.com/iotexproject/iotex-core/endorsement"
+	"github.com/iotexproject/iotex-core/test/identityset"
+)
+
+func TestDoubleSignDetector(t *testing.T) {
+	require := require.New(t)
+	pool := evidence.NewPool()
+	broadcasted := []proto.Message{}
+	d := newDoubleSignDetector(pool, func(msg proto.Message) error {
+		broadcasted = append(broadcasted, msg)
+		return nil
+	})
+	em, err := newEndorsementManager(nil, nil)
+	require.NoError(err)
+	em.SetDoubleSignFunc(func(vote *ConsensusVote, en *endorsement.Endorsement, conflict *ConsensusVote, conflictEn *endorsement.Endorsement) {
+		d.CheckVotes(10, vote, en, conflict, conflictEn)
+	})
+
+	sk := identityset.PrivateKey(1)
+	ts := time.Unix(1600000000, 0)
+	hash1, hash2 := hash.Hash256b([]byte("block1")), hash.Hash256b([]byte("block2"))
+	addVote := func(blkHash []byte, topic ConsensusVoteTopic, ts time.Time) {
+		vote := NewConsensusVote(blkHash, topic)
+		en, err := endorsement.Endorse(sk, vote, ts)
+		require.NoError(err)
+		require.NoError(em.AddVoteEndorsement(vote, en))
+	}
+
+	// votes on different topics, in different rounds, or for nothing are not double sign
+	addVote(hash1[:], PROPOSAL, ts)
+	addVote(hash2[:], LOCK, ts)
+	addVote(hash1[:], COMMIT, ts)
+	addVote(hash2[:], COMMIT, ts.Add(time.Second))
+	addVote(nil, PROPOSAL, ts)
+	require.Zero(pool.Size())
+
+	addVote(hash2[:], PROPOSAL, ts)
+	require.Equal(1, pool.Size())
+	require.Len(broadcasted, 1)
+	ds := pool.Pending()[0]
+	require.Equal(sk.PublicKey().Bytes(), ds.Offender().Bytes())
+	require.Equal(uint64(10), ds.Height())
+
+	// the same double sign is reported once
+	addVote(hash2[:], PROPOSAL, ts)
+	require.Equal(1, pool.Size())
+	require.Len(broadcasted, 1)
+
+	// the gossiped evidence is added into the pool of another node
+	pool2 := evidence.NewPool()
+	d2 := newDoubleSignDetector(pool2, nil)
+	gossiped, err := evidence.FromConsensusMessage(broadcasted[0].(*iotextypes.ConsensusMessage))
+	require.NoError(err)
+	d2.HandleEvidence(gossiped)
+	require.Equal(1, pool2.Size())
+	require.Equal(ds.Hash(), pool2.Pending()[0].Hash())
+}
@@ -34,6 +34,9 @@ var (
 // EndorsedByMajorityFunc defines a function to give an information of consensus status
 type EndorsedByMajorityFunc func(blockHash []byte, topics []ConsensusVoteTopic) bool
 
+// DoubleSignFunc defines a function to report two conflicting votes endorsed by the same delegate
+type DoubleSignFunc func(vote *ConsensusVote, en *endorsement.Endorsement, conflict *ConsensusVote, conflictEn *endorsement.Endorsement)
+
 type endorserEndorsementCollection struct {
 	endorsements map[ConsensusVoteTopic]*endorsement.Endorsement
 }
@@ -219,6 +222,7 @@ func (bc *blockEndorsementCollection) Endorsements(
 
 type endorsementManager struct {
 	isMajorityFunc  EndorsedByMajorityFunc
+	doubleSignFunc  DoubleSignFunc
 	eManagerDB      db.KVStore
 	collections     map[string]*blockEndorsementCollection
 	cachedMintedBlk *block.Block
@@ -279,6 +283,10 @@ func (m *endorsementManager) SetIsMarjorityFunc(isMajorityFunc EndorsedByMajorit
 	m.isMajorityFunc = isMajorityFunc
 }
 
+func (m *endorsementManager) SetDoubleSignFunc(doubleSignFunc DoubleSignFunc) {
+	m.doubleSignFunc = doubleSignFunc
+}
+
 func (m *endorsementManager) fromProto(managerPro *endorsementpb.EndorsementManager, deserializer *block.Deserializer) error {
 	m.collections = make(map[string]*blockEndorsementCollection)
 	for i, block := range managerPro.BlockEndorsements {
@@ -360,6 +368,9 @@ func (m *endorsementManager) AddVoteEndorsement(
 		beforeVote = m.isMajorityFunc(vote.BlockHash(), []ConsensusVoteTopic{vote.Topic()})
 	}
 	encoded := encodeToString(vote.BlockHash())
+	if m.doubleSignFunc != nil && len(vote.BlockHash()) != 0 {
+		m.detectDoubleSign(encoded, vote, en)
+	}
 	c, exists := m.collections[encoded]
 	if !exists {
 		c = newBlockEndorsementCollection(nil)
@@ -379,6 +390,27 @@ func (m *endorsementManager) AddVoteEndorsement(
 	return nil
 }
 
+// detectDoubleSign looks for an endorsement of the same endorser on the same topic in the same round, but for a
+// different block
+func (m *endorsementManager) detectDoubleSign(encoded string, vote *ConsensusVote, en *endorsement.Endorsement) {
+	endorser := en.Endorser().HexString()
+	for blkHash, c := range m.collections {
+		if blkHash == encoded || blkHash == "" {
+			continue
+		}
+		prev := c.Endorsement(endorser, vote.Topic())
+		if prev == nil || !prev.Timestamp().Equal(en.Timestamp()) {
+			continue
+		}
+		decoded, err := hex.DecodeString(blkHash)
+		if err != nil {
+			log.L().Error("failed to decode block hash", zap.String("hash", blkHash), zap.Error(err))
+			continue
+		}
+		m.doubleSignFunc(NewConsensusVote(decoded, vote.Topic()), prev, vote, en)
+	}
+}
+
 func (m *endorsementManager) SetMintedBlock(blk *block.Block) error {
 	m.cachedMintedBlk = blk
 	if m.eManagerDB != nil {
@@ -8,6 +8,8 @@ package rolldpos
 
 import (
 	"context"
+	"path/filepath"
+	"strings"
 	"time"
 
 	"github.com/facebookgo/clock"
@@ -17,6 +19,7 @@ import (
 	"github.com/pkg/errors"
 	"go.uber.org/zap"
 
+	"github.com/iotexproject/iotex-core/action/evidence"
 	"github.com/iotexproject/iotex-core/action/protocol/rolldpos"
 	"github.com/iotexproject/iotex-core/blockchain"
 	"github.com/iotexproject/iotex-core/blockchain/block"
@@ -25,6 +28,7 @@ import (
 	"github.com/iotexproject/iotex-core/consensus/scheme"
 	"github.com/iotexproject/iotex-core/endorsement"
 	"github.com/iotexproject/iotex-core/pkg/log"
+	"github.com/iotexproject/iotex-core/signer"
 )
 
 var (
@@ -157,6 +161,14 @@ func (r *RollDPoS) HandleConsensusMsg(msg *iotextypes.ConsensusMessage) error {
 		return nil
 	}
 	<-r.ready
+	ds, err := evidence.FromConsensusMessage(msg)
+	if err != nil {
+		return errors.Wrap(err, "failed to decode double sign evidence")
+	}
+	if ds != nil {
+		r.ctx.doubleSign.HandleEvidence(ds)
+		return nil
+	}
 	consensusHeight := r.ctx.Height()
 	switch {
 	case consensusHeight == 0:
@@ -190,6 +202,7 @@ func (r *RollDPoS) HandleConsensusMsg(msg *iotextypes.ConsensusMessage) error {
 		if err := r.ctx.CheckBlockProposer(endorsedMessage.Height(), consensusMessage, en); err != nil {
 			return errors.Wrap(err, "failed to verify block proposal")
 		}
+		r.ctx.doubleSign.CheckProposal(msg, en)
 		r.cfsm.ProduceReceiveBlockEvent(endorsedMessage)
 		return nil
 	case *ConsensusVote:
@@ -233,6 +246,30 @@ func (r *RollDPoS) ValidateBlockFooter(blk *block.Block) error {
 		return err
 	}
 	blkHash := blk.HashBlock()
+	// a block without aggregate endorsement carries the individual endorsements, in case the BLS endorsements of
+	// the active delegates could not reach majority
+	if agg := blk.AggregateEndorsement(); agg != nil {
+		if !r.ctx.bls.IsActive(height) {
+			return errors.New("aggregate endorsement before BLS aggregate height")
+		}
+		if len(blk.Endorsements()) != 0 {
+			return errors.New("block carries both aggregate and individual endorsements")
+		}
+		// unlike the individual endorsements, the aggregate endorsement carries no signed timestamp, so the commit
+		// time has to fall in the round of the block
+		commitTime := blk.CommitTime()
+		if commitTime.Before(blk.Timestamp()) {
+			return errors.Errorf("commit time %s is before block time %s", commitTime, blk.Timestamp())
+		}
+		roundNum, _, err := r.ctx.roundCalc.RoundInfo(height, r.ctx.BlockInterval(height), commitTime)
+		if err != nil {
+			return err
+		}
+		if roundNum != round.Number() {
+			return errors.Errorf("commit time %s is in round %d instead of round %d", commitTime, roundNum, round.Number())
+		}
+		return r.ctx.bls.Verify(round.Delegates(), NewConsensusVote(blkHash[:], COMMIT), agg)
+	}
 	for _, en := range blk.Endorsements() {
 		if err := round.AddVoteEndorsement(
 			NewConsensusVote(blkHash[:], COMMIT),
@@ -296,6 +333,8 @@ type Builder struct {
 	// TODO: we should use keystore in the future
 	encodedAddr       string
 	priKey            crypto.PrivateKey
+	signer            signer.Signer
+	blsPriKey         *endorsement.BLSPrivateKey
 	chain             ChainManager
 	blockDeserializer *block.Deserializer
 	broadcastHandler  scheme.Broadcast
@@ -303,6 +342,7 @@ type Builder struct {
 	// TODO: explorer dependency deleted at #1085, need to add api params
 	rp                   *rolldpos.Protocol
 	delegatesByEpochFunc DelegatesByEpochFunc
+	evidencePool         *evidence.Pool
 }
 
 // NewRollDPoSBuilder instantiates a Builder instance
@@ -328,6 +368,18 @@ func (b *Builder) SetPriKey(priKey crypto.PrivateKey) *Builder {
 	return b
 }
 
+// SetSigner sets the signer of consensus messages, which overrides the private key
+func (b *Builder) SetSigner(s signer.Signer) *Builder {
+	b.signer = s
+	return b
+}
+
+// SetBLSPriKey sets the BLS private key to sign aggregatable commit endorsements
+func (b *Builder) SetBLSPriKey(blsPriKey *endorsement.BLSPrivateKey) *Builder {
+	b.blsPriKey = blsPriKey
+	return b
+}
+
 // SetChainManager sets the blockchain APIs
 func (b *Builder) SetChainManager(chain ChainManager) *Builder {
 	b.chain = chain
@@ -360,6 +412,12 @@ func (b *Builder) SetDelegatesByEpochFunc(
 	return b
 }
 
+// SetEvidencePool sets the pool to collect double sign evidences
+func (b *Builder) SetEvidencePool(pool *evidence.Pool) *Builder {
+	b.evidencePool = pool
+	return b
+}
+
 // RegisterProtocol sets the rolldpos protocol
 func (b *Builder) RegisterProtocol(rp *rolldpos.Protocol) *Builder {
 	b.rp = rp
@@ -377,6 +435,9 @@ func (b *Builder) Build() (*RollDPoS, error) {
 	if b.clock == nil {
 		b.clock = clock.New()
 	}
+	if b.signer == nil && b.priKey != nil {
+		b.signer = signer.NewLocalSigner(b.priKey)
+	}
 	b.cfg.DB.DbPath = b.cfg.Consensus.RollDPoS.ConsensusDBPath
 	ctx, err := newRollDPoSCtx(
 		consensusfsm.NewConsensusConfig(b.cfg),
@@ -390,13 +451,20 @@ func (b *Builder) Build() (*RollDPoS, error) {
 		b.broadcastHandler,
 		b.delegatesByEpochFunc,
 		b.encodedAddr,
-		b.priKey,
+		b.signer,
 		b.clock,
 		b.cfg.Genesis.BeringBlockHeight,
 	)
 	if err != nil {
 		return nil, errors.Wrap(err, "error when constructing consensus context")
 	}
+	if ctx.bls, err = newBLSAggregator(b.cfg.Genesis.Blockchain, b.blsPriKey); err != nil {
+		return nil, errors.Wrap(err, "error when constructing BLS aggregator")
+	}
+	ctx.doubleSign = newDoubleSignDetector(b.evidencePool, b.broadcastHandler)
+	if path := walPath(b.cfg.Consensus.RollDPoS); len(path) > 0 {
+		ctx.wal = consensusfsm.NewWAL(path)
+	}
 	cfsm, err := consensusfsm.NewConsensusFSM(ctx, b.clock)
 	if err != nil {
 		return nil, errors.Wrap(err, "error when constructing the consensus FSM")
@@ -408,3 +476,11 @@ func (b *Builder) Build() (*RollDPoS, error) {
 		ready:      make(chan interface{}),
 	}, nil
 }
+
+// walPath returns the path of the consensus WAL, which defaults to the one next to the consensus db
+func walPath(cfg config.RollDPoS) string {
+	if len(cfg.ConsensusWALPath) > 0 || len(cfg.ConsensusDBPath) == 0 {
+		return cfg.ConsensusWALPath
+	}
+	return strings.TrimSuffix(cfg.ConsensusDBPath, filepath.Ext(cfg.ConsensusDBPath)) + ".wal"
+}
@@ -10,6 +10,7 @@ import (
 	"encoding/hex"
 	"fmt"
 	"net"
+	"path/filepath"
 	"sync"
 	"testing"
 	"time"
@@ -252,6 +253,101 @@ func TestValidateBlockFooter(t *testing.T) {
 	require.Error(t, err)
 }
 
+func TestValidateBlockFooterWithBLSAggregate(t *testing.T) {
+	require := require.New(t)
+	ctrl := gomock.NewController(t)
+	candidates := make([]string, 4)
+	for i := 0; i < len(candidates); i++ {
+		candidates[i] = identityset.Address(i).String()
+	}
+	blockchain := mock_blockchain.NewMockBlockchain(ctrl)
+	blockchain.EXPECT().BlockFooterByHeight(uint64(8)).Return(&block.Footer{}, nil).AnyTimes()
+
+	sks, blsDelegates := makeBLSDelegates(t, 4)
+	cfg := config.Default
+	cfg.Genesis.NumDelegates = 4
+	cfg.Genesis.NumSubEpochs = 1
+	cfg.Genesis.BlockInterval = 10 * time.Second
+	cfg.Genesis.Timestamp = int64(1500000000)
+	cfg.Genesis.BLSAggregateBlockHeight = 9
+	cfg.Genesis.BLSDelegates = blsDelegates
+	blockchain.EXPECT().Genesis().Return(cfg.Genesis).AnyTimes()
+	rp := rolldpos.NewProtocol(
+		cfg.Genesis.NumCandidateDelegates,
+		cfg.Genesis.NumDelegates,
+		cfg.Genesis.NumSubEpochs,
+	)
+	r, err := NewRollDPoSBuilder().
+		SetConfig(cfg).
+		SetAddr(identityset.Address(1).String()).
+		SetPriKey(identityset.PrivateKey(1)).
+		SetBLSPriKey(sks[1]).
+		SetChainManager(NewChainManager(blockchain)).
+		SetBroadcast(func(_ proto.Message) error {
+			return nil
+		}).
+		SetDelegatesByEpochFunc(func(uint64) ([]string, error) {
+			return candidates, nil
+		}).
+		SetClock(clock.NewMock()).
+		RegisterProtocol(rp).
+		Build()
+	require.NoError(err)
+
+	makeAggregateBlockAt := func(numOfEndorsements int, commitTime time.Duration) *block.Block {
+		blk := makeBlock(t, 1, 0, false, 9)
+		hs := blk.HashBlock()
+		vote := NewConsensusVote(hs[:], COMMIT)
+		var ens []*endorsement.Endorsement
+		for i := 0; i < numOfEndorsements; i++ {
+			en, err := endorsement.EndorseWithBLS(identityset.PrivateKey(i), sks[i], vote, time.Unix(1500000000, 0))
+			require.NoError(err)
+			ens = append(ens, en)
+		}
+		agg, err := endorsement.Aggregate(len(candidates), ens, func(en *endorsement.Endorsement) (int, bool) {
+			for i := range candidates {
+				if en.Endorser().Address().String() == candidates[i] {
+					return i, true
+				}
+			}
+			return 0, false
+		})
+		require.NoError(err)
+		blk.Footer = block.Footer{}
+		require.NoError(blk.FinalizeWithAggregate(agg, blk.Timestamp().Add(commitTime)))
+		return blk
+	}
+	makeAggregateBlock := func(numOfEndorsements int) *block.Block {
+		return makeAggregateBlockAt(numOfEndorsements, 0)
+	}
+
+	// all right
+	require.NoError(r.ValidateBlockFooter(makeAggregateBlock(3)))
+
+	// not enough endorsements
+	require.Equal(ErrInsufficientEndorsements, errors.Cause(r.ValidateBlockFooter(makeAggregateBlock(2))))
+
+	// commit time out of the round of the block
+	require.ErrorContains(r.ValidateBlockFooter(makeAggregateBlockAt(3, -time.Second)), "before block time")
+	require.ErrorContains(r.ValidateBlockFooter(makeAggregateBlockAt(3, cfg.Genesis.BlockInterval)), "instead of round")
+
+	// aggregate and individual endorsements together
+	aggSer, err := makeAggregateBlock(3).Footer.Serialize()
+	require.NoError(err)
+	blk := makeBlock(t, 1, 4, false, 9)
+	ser, err := blk.Footer.Serialize()
+	require.NoError(err)
+	require.NoError(blk.Footer.Deserialize(append(ser, aggSer...)))
+	require.NotNil(blk.AggregateEndorsement())
+	require.Len(blk.Endorsements(), 4)
+	require.ErrorContains(r.ValidateBlockFooter(blk), "both aggregate and individual endorsements")
+
+	// individual endorsements are accepted after BLS aggregate height, in case the BLS endorsements could not reach
+	// majority
+	require.NoError(r.ValidateBlockFooter(makeBlock(t, 1, 4, false, 9)))
+	require.Equal(ErrInsufficientEndorsements, errors.Cause(r.ValidateBlockFooter(makeBlock(t, 1, 2, false, 9))))
+}
+
 func TestRollDPoS_Metrics(t *testing.T) {
 	t.Parallel()
 
@@ -271,7 +367,7 @@ func TestRollDPoS_Metrics(t *testing.T) {
 
 	sk1 := identityset.PrivateKey(1)
 	cfg := config.Default
-	cfg.Consensus.RollDPoS.ConsensusDBPath = "consensus.db"
+	cfg.Consensus.RollDPoS.ConsensusDBPath = filepath.Join(t.TempDir(), "consensus.db")
 	cfg.Genesis.NumDelegates = 4
 	cfg.Genesis.NumSubEpochs = 1
 	cfg.Genesis.BlockInterval = 10 * time.Second
@@ -13,10 +13,10 @@ import (
 
 	"github.com/facebookgo/clock"
 	fsm "github.com/iotexproject/go-fsm"
-	"github.com/iotexproject/go-pkgs/crypto"
 	"github.com/pkg/errors"
 	"github.com/prometheus/client_golang/prometheus"
 	"go.uber.org/zap"
+	"google.golang.org/protobuf/proto"
 
 	"github.com/iotexproject/iotex-core/action/protocol/rolldpos"
 	"github.com/iotexproject/iotex-core/blockchain"
@@ -26,6 +26,7 @@ import (
 	"github.com/iotexproject/iotex-core/db"
 	"github.com/iotexproject/iotex-core/endorsement"
 	"github.com/iotexproject/iotex-core/pkg/log"
+	"github.com/iotexproject/iotex-core/signer"
 )
 
 var (
@@ -83,7 +84,10 @@ type rollDPoSCtx struct {
 	toleratedOvertime time.Duration
 
 	encodedAddr string
-	priKey      crypto.PrivateKey
+	signer      signer.Signer
+	bls         *blsAggregator
+	doubleSign  *doubleSignDetector
+	wal         *consensusfsm.WAL
 	round       *roundCtx
 	clock       clock.Clock
 	active      bool
@@ -102,7 +106,7 @@ func newRollDPoSCtx(
 	broadcastHandler scheme.Broadcast,
 	delegatesByEpochFunc DelegatesByEpochFunc,
 	encodedAddr string,
-	priKey crypto.PrivateKey,
+	producerSigner signer.Signer,
 	clock clock.Clock,
 	beringHeight uint64,
 ) (*rollDPoSCtx, error) {
@@ -143,10 +147,11 @@ func newRollDPoSCtx(
 		ConsensusConfig:   cfg,
 		active:            active,
 		encodedAddr:       encodedAddr,
-		priKey:            priKey,
+		signer:            producerSigner,
 		chain:             chain,
 		blockDeserializer: blockDeserializer,
 		broadcastHandler:  broadcastHandler,
+		doubleSign:        newDoubleSignDetector(nil, broadcastHandler),
 		clock:             clock,
 		roundCalc:         roundCalc,
 		eManagerDB:        eManagerDB,
@@ -162,12 +167,27 @@ func (ctx *rollDPoSCtx) Start(c context.Context) (err error) {
 		}
 		eManager, err = newEndorsementManager(ctx.eManagerDB, ctx.blockDeserializer)
 	}
-	ctx.round, err = ctx.roundCalc.NewRoundWithToleration(0, ctx.BlockInterval(0), ctx.clock.Now(), eManager, ctx.toleratedOvertime)
-
-	return err
+	if ctx.wal != nil {
+		if err := ctx.wal.Start(c); err != nil {
+			return errors.Wrap(err, "error when starting the consensus WAL")
+		}
+	}
+	if ctx.round, err = ctx.roundCalc.NewRoundWithToleration(0, ctx.BlockInterval(0), ctx.clock.Now(), eManager, ctx.toleratedOvertime); err != nil {
+		return err
+	}
+	// the endorsement manager is passed over to the following rounds
+	ctx.round.eManager.SetDoubleSignFunc(func(vote *ConsensusVote, en *endorsement.Endorsement, conflict *ConsensusVote, conflictEn *endorsement.Endorsement) {
+		ctx.doubleSign.CheckVotes(ctx.round.Height(), vote, en, conflict, conflictEn)
+	})
+	return nil
 }
 
 func (ctx *rollDPoSCtx) Stop(c context.Context) error {
+	if ctx.wal != nil {
+		if err := ctx.wal.Stop(c); err != nil {
+			return errors.Wrap(err, "error when stopping the consensus WAL")
+		}
+	}
 	if ctx.eManagerDB != nil {
 		return ctx.eManagerDB.Stop(c)
 	}
@@ -308,6 +328,12 @@ func (ctx *rollDPoSCtx) Prepare() error {
 		zap.String("roundStartTime", newRound.roundStartTime.String()),
 	)
 	ctx.round = newRound
+	if ctx.wal != nil {
+		// the decisions below the current height are useless once the blocks are committed
+		if err := ctx.wal.Prune(height); err != nil {
+			return err
+		}
+	}
 	_consensusHeightMtc.WithLabelValues().Set(float64(ctx.round.height))
 	_timeSlotMtc.WithLabelValues().Set(float64(ctx.round.roundNum))
 	return nil
@@ -326,6 +352,14 @@ func (ctx *rollDPoSCtx) Proposal() (interface{}, error) {
 	if ctx.round.Proposer() != ctx.encodedAddr {
 		return nil, nil
 	}
+	// propose what has been proposed in the round before restart
+	logged, err := ctx.loggedProposal()
+	if err != nil {
+		return nil, err
+	}
+	if logged != nil {
+		return logged, nil
+	}
 	if ctx.round.IsLocked() {
 		return ctx.endorseBlockProposal(newBlockProposal(
 			ctx.round.Block(ctx.round.HashOfBlockInLock()),
@@ -411,6 +445,9 @@ func (ctx *rollDPoSCtx) NewLockEndorsement(
 	case nil:
 		if len(blkHash) != 0 {
 			ctx.loggerWithStats().Debug("Locked", log.Hex("block", blkHash))
+			if err := ctx.logLockedBlock(blkHash); err != nil {
+				return nil, err
+			}
 			return ctx.newEndorsement(
 				blkHash,
 				LOCK,
@@ -470,13 +507,31 @@ func (ctx *rollDPoSCtx) Commit(msg interface{}) (bool, error) {
 	if ctx.round.Height()%100 == 0 {
 		ctx.logger().Info("consensus reached", zap.Uint64("blockHeight", ctx.round.Height()))
 	}
-	if err := pendingBlock.Finalize(
-		ctx.round.Endorsements(blkHash, []ConsensusVoteTopic{COMMIT}),
-		ctx.round.StartTime().Add(
-			ctx.AcceptBlockTTL(ctx.round.height)+ctx.AcceptProposalEndorsementTTL(ctx.round.height)+ctx.AcceptLockEndorsementTTL(ctx.round.height),
-		),
-	); err != nil {
-		return false, errors.Wrap(err, "failed to add endorsements to block")
+	endorsements := ctx.round.Endorsements(blkHash, []ConsensusVoteTopic{COMMIT})
+	commitTime := ctx.round.StartTime().Add(
+		ctx.AcceptBlockTTL(ctx.round.height) + ctx.AcceptProposalEndorsementTTL(ctx.round.height) + ctx.AcceptLockEndorsementTTL(ctx.round.height),
+	)
+	aggregated := false
+	if ctx.bls.IsActive(ctx.round.height) {
+		// the BLS public keys in genesis may not cover the majority of the active delegates, e.g. after the
+		// delegates change, in which case the block falls back to carry the individual endorsements
+		agg, err := ctx.bls.Aggregate(ctx.round.Delegates(), NewConsensusVote(blkHash, COMMIT), endorsements)
+		switch errors.Cause(err) {
+		case nil:
+			if err := pendingBlock.FinalizeWithAggregate(agg, commitTime); err != nil {
+				return false, errors.Wrap(err, "failed to add aggregate endorsement to block")
+			}
+			aggregated = true
+		case ErrInsufficientEndorsements:
+			ctx.logger().Warn("BLS endorsements do not reach majority, fall back to individual endorsements")
+		default:
+			return false, errors.Wrap(err, "failed to aggregate endorsements")
+		}
+	}
+	if !aggregated {
+		if err := pendingBlock.Finalize(endorsements, commitTime); err != nil {
+			return false, errors.Wrap(err, "failed to add endorsements to block")
+		}
 	}
 
 	// Commit and broadcast the pending block
@@ -617,11 +672,52 @@ func (ctx *rollDPoSCtx) isDelegate() bool {
 }
 
 func (ctx *rollDPoSCtx) endorseBlockProposal(proposal *blockProposal) (*EndorsedConsensusMessage, error) {
-	en, err := endorsement.Endorse(ctx.priKey, proposal, ctx.round.StartTime())
+	blkHash := proposal.block.HashBlock()
+	if err := ctx.checkDecision(_walProposalKind, blkHash[:]); err != nil {
+		return nil, err
+	}
+	msg, err := proposal.Proto()
+	if err != nil {
+		return nil, err
+	}
+	hashSigner, err := ctx.hashSigner(signer.ProposalKind, proposal.Height(), 0, blkHash[:], msg, ctx.round.StartTime())
 	if err != nil {
 		return nil, err
 	}
-	return NewEndorsedConsensusMessage(proposal.block.Height(), proposal, en), nil
+	en, err := endorsement.Endorse(hashSigner, proposal, ctx.round.StartTime())
+	if err != nil {
+		return nil, err
+	}
+	ecm := NewEndorsedConsensusMessage(proposal.block.Height(), proposal, en)
+	if err := ctx.logDecision(_walProposalKind, blkHash[:], ecm); err != nil {
+		return nil, err
+	}
+	return ecm, nil
+}
+
+// hashSigner returns the signer of a consensus message at the height, which is endorsed at the timestamp in the
+// current round
+func (ctx *rollDPoSCtx) hashSigner(
+	kind signer.Kind,
+	height uint64,
+	topic ConsensusVoteTopic,
+	blkHash []byte,
+	msg proto.Message,
+	timestamp time.Time,
+) (signer.HashSigner, error) {
+	data, err := proto.Marshal(msg)
+	if err != nil {
+		return nil, err
+	}
+	return signer.Bind(context.Background(), ctx.signer, signer.Request{
+		Kind:      kind,
+		Height:    height,
+		Round:     ctx.round.Number(),
+		Topic:     uint32(topic),
+		BlockHash: blkHash,
+		Message:   data,
+		Timestamp: timestamp,
+	}), nil
 }
 
 func (ctx *rollDPoSCtx) logger() *zap.Logger {
@@ -707,10 +803,30 @@ func (ctx *rollDPoSCtx) newEndorsement(
 		blkHash,
 		topic,
 	)
-	en, err := endorsement.Endorse(ctx.priKey, vote, timestamp)
+	if err := ctx.checkDecision(uint32(topic), blkHash); err != nil {
+		return nil, err
+	}
+	msg, err := vote.Proto()
+	if err != nil {
+		return nil, err
+	}
+	hashSigner, err := ctx.hashSigner(signer.VoteKind, ctx.round.Height(), topic, blkHash, msg, timestamp)
+	if err != nil {
+		return nil, err
+	}
+	var en *endorsement.Endorsement
+	if topic == COMMIT && ctx.bls.CanSign(ctx.round.Height()) {
+		en, err = endorsement.EndorseWithBLS(hashSigner, ctx.bls.priKey, vote, timestamp)
+	} else {
+		en, err = endorsement.Endorse(hashSigner, vote, timestamp)
+	}
 	if err != nil {
 		return nil, err
 	}
 
-	return NewEndorsedConsensusMessage(ctx.round.Height(), vote, en), nil
+	ecm := NewEndorsedConsensusMessage(ctx.round.Height(), vote, en)
+	if err := ctx.logDecision(uint32(topic), blkHash, ecm); err != nil {
+		return nil, err
+	}
+	return ecm, nil
 }
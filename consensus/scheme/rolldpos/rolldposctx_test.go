@@ -25,6 +25,7 @@ import (
 	"github.com/iotexproject/iotex-core/consensus/consensusfsm"
 	"github.com/iotexproject/iotex-core/db"
 	"github.com/iotexproject/iotex-core/endorsement"
+	"github.com/iotexproject/iotex-core/signer"
 	"github.com/iotexproject/iotex-core/state"
 	"github.com/iotexproject/iotex-core/test/identityset"
 )
@@ -322,7 +323,7 @@ func TestNotProducingMultipleBlocks(t *testing.T) {
 			return addrs, nil
 		},
 		"",
-		identityset.PrivateKey(10),
+		signer.NewLocalSigner(identityset.PrivateKey(10)),
 		c,
 		genesis.Default.BeringBlockHeight,
 	)
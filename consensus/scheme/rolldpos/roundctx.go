@@ -221,6 +221,13 @@ func (ctx *roundCtx) AddVoteEndorsement(
 	if !ctx.isMajority(endorsements) {
 		return nil
 	}
+	ctx.lock(blockHash, endorsements)
+
+	return nil
+}
+
+// lock locks on the block with the majority of proposal and commit endorsements as the proof
+func (ctx *roundCtx) lock(blockHash []byte, proof []*endorsement.Endorsement) {
 	if len(blockHash) == 0 {
 		// TODO: (zhi) look into details of unlock
 		ctx.status = _unlocked
@@ -228,9 +235,7 @@ func (ctx *roundCtx) AddVoteEndorsement(
 		ctx.status = _locked
 	}
 	ctx.blockInLock = blockHash
-	ctx.proofOfLock = endorsements
-
-	return nil
+	ctx.proofOfLock = proof
 }
 
 func (ctx *roundCtx) SetMintedBlock(blk *block.Block) error {
@@ -256,7 +261,11 @@ func (ctx *roundCtx) endorsedByMajority(blockHash []byte, topics []ConsensusVote
 }
 
 func (ctx *roundCtx) isMajority(endorsements []*endorsement.Endorsement) bool {
-	return 3*len(endorsements) > 2*len(ctx.delegates)
+	return isMajority(len(endorsements), len(ctx.delegates))
+}
+
+func isMajority(numEndorsers, numDelegates int) bool {
+	return 3*numEndorsers > 2*numDelegates
 }
 
 func (ctx *roundCtx) block(blkHash []byte) *block.Block {
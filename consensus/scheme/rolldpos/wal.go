@@ -0,0 +1,197 @@
+// Copyright (c) 2022 IoTeX Foundation
+// This is an alpha (internal) release and is not suitable for production. This source code is provided 'as is' and no
+// warranties are given as to title or non-infringement, merchantability or fitness for purpose and, to the extent
+// permitted by law, all liability for your use of the code is disclaimed. This source code is governed by Apache
+// License 2.0 that can be found in the LICENSE file.
+
+package rolldpos
+
+import (
+	"github.com/iotexproject/iotex-proto/golang/iotextypes"
+	"github.com/pkg/errors"
+	"go.uber.org/zap"
+	"google.golang.org/protobuf/proto"
+
+	"github.com/iotexproject/iotex-core/blockchain/block"
+	"github.com/iotexproject/iotex-core/consensus/consensusfsm"
+)
+
+// the kinds of decisions logged in consensus WAL besides the votes, whose kinds are the topics
+const (
+	// _walProposalKind is the kind of a block proposal signed by the delegate
+	_walProposalKind uint32 = 100
+	// _walLockedBlockKind is the kind of a proposed block locked by the delegate
+	_walLockedBlockKind uint32 = 101
+)
+
+// Replay restores the decisions logged at the current height into the round, such that the delegate won't make
+// different decisions after restart
+func (ctx *rollDPoSCtx) Replay() error {
+	ctx.mutex.Lock()
+	defer ctx.mutex.Unlock()
+	if ctx.wal == nil {
+		return nil
+	}
+	decisions := ctx.wal.Decisions(ctx.round.Height())
+	// blocks have to be restored before the votes on them
+	var locked *blockProposal
+	for _, d := range decisions {
+		var (
+			blk *block.Block
+			err error
+		)
+		switch d.Kind {
+		case _walProposalKind:
+			var ecm *EndorsedConsensusMessage
+			if ecm, err = ctx.loadDecisionMessage(d); err != nil {
+				return err
+			}
+			proposal, ok := ecm.Document().(*blockProposal)
+			if !ok {
+				return errors.New("invalid block proposal in consensus WAL")
+			}
+			blk = proposal.block
+			// the proposer reuses the minted block in the same round
+			if d.Round == ctx.round.Number() {
+				if err = ctx.round.SetMintedBlock(blk); err != nil {
+					return err
+				}
+			}
+		case _walLockedBlockKind:
+			// the lock is kept across the rounds at the same height, so the latest one is in effect
+			if locked, err = ctx.loadLockedBlock(d); err != nil {
+				return err
+			}
+			blk = locked.block
+		default:
+			continue
+		}
+		if err = ctx.round.AddBlock(blk); err != nil {
+			return err
+		}
+	}
+	for _, d := range decisions {
+		if d.Kind == _walProposalKind || d.Kind == _walLockedBlockKind {
+			continue
+		}
+		ecm, err := ctx.loadDecisionMessage(d)
+		if err != nil {
+			return err
+		}
+		vote, ok := ecm.Document().(*ConsensusVote)
+		if !ok {
+			return errors.New("invalid consensus vote in consensus WAL")
+		}
+		if err := ctx.round.AddVoteEndorsement(vote, ecm.Endorsement()); err != nil {
+			// the vote of a past round may have been replaced, or its block may be unknown
+			ctx.logger().Debug("failed to replay consensus vote", zap.Uint32("round", d.Round), zap.Error(err))
+		}
+	}
+	if locked != nil {
+		blkHash := locked.block.HashBlock()
+		ctx.round.lock(blkHash[:], locked.proofOfLock)
+	}
+	ctx.logger().Info("replayed consensus WAL", zap.Int("decisions", len(decisions)))
+	return nil
+}
+
+// loggedProposal returns the block proposal signed by the delegate in the current round before restart
+func (ctx *rollDPoSCtx) loggedProposal() (*EndorsedConsensusMessage, error) {
+	if ctx.wal == nil {
+		return nil, nil
+	}
+	d := ctx.wal.Decision(ctx.round.Height(), ctx.round.Number(), _walProposalKind)
+	if d == nil {
+		return nil, nil
+	}
+	return ctx.loadDecisionMessage(d)
+}
+
+// checkDecision returns error if the delegate has decided a different block in the current round
+func (ctx *rollDPoSCtx) checkDecision(kind uint32, blkHash []byte) error {
+	if ctx.wal == nil {
+		return nil
+	}
+	return ctx.wal.Check(&consensusfsm.Decision{
+		Height:    ctx.round.Height(),
+		Round:     ctx.round.Number(),
+		Kind:      kind,
+		BlockHash: blkHash,
+	})
+}
+
+// logDecision persists a signed message into WAL before it is sent out
+func (ctx *rollDPoSCtx) logDecision(kind uint32, blkHash []byte, ecm *EndorsedConsensusMessage) error {
+	if ctx.wal == nil {
+		return nil
+	}
+	msg, err := ecm.Proto()
+	if err != nil {
+		return err
+	}
+	data, err := proto.Marshal(msg)
+	if err != nil {
+		return err
+	}
+	return ctx.wal.Append(&consensusfsm.Decision{
+		Height:    ctx.round.Height(),
+		Round:     ctx.round.Number(),
+		Kind:      kind,
+		BlockHash: blkHash,
+		Message:   data,
+	})
+}
+
+// logLockedBlock persists the block to lock on along with the proof of lock, so that the lock could be restored
+// after restart
+func (ctx *rollDPoSCtx) logLockedBlock(blkHash []byte) error {
+	if ctx.wal == nil {
+		return nil
+	}
+	blk := ctx.round.Block(blkHash)
+	if blk == nil {
+		return errors.Errorf("block %x to lock on is not found", blkHash)
+	}
+	msg, err := newBlockProposal(
+		blk,
+		ctx.round.Endorsements(blkHash, []ConsensusVoteTopic{PROPOSAL, COMMIT}),
+	).Proto()
+	if err != nil {
+		return err
+	}
+	data, err := proto.Marshal(msg)
+	if err != nil {
+		return err
+	}
+	return ctx.wal.Append(&consensusfsm.Decision{
+		Height:    ctx.round.Height(),
+		Round:     ctx.round.Number(),
+		Kind:      _walLockedBlockKind,
+		BlockHash: blkHash,
+		Message:   data,
+	})
+}
+
+func (ctx *rollDPoSCtx) loadDecisionMessage(d *consensusfsm.Decision) (*EndorsedConsensusMessage, error) {
+	msg := &iotextypes.ConsensusMessage{}
+	if err := proto.Unmarshal(d.Message, msg); err != nil {
+		return nil, errors.Wrap(err, "failed to unmarshal consensus message in consensus WAL")
+	}
+	ecm := &EndorsedConsensusMessage{}
+	if err := ecm.LoadProto(msg, ctx.blockDeserializer); err != nil {
+		return nil, errors.Wrap(err, "failed to load consensus message in consensus WAL")
+	}
+	return ecm, nil
+}
+
+func (ctx *rollDPoSCtx) loadLockedBlock(d *consensusfsm.Decision) (*blockProposal, error) {
+	msg := &iotextypes.BlockProposal{}
+	if err := proto.Unmarshal(d.Message, msg); err != nil {
+		return nil, errors.Wrap(err, "failed to unmarshal locked block in consensus WAL")
+	}
+	bp := &blockProposal{}
+	if err := bp.LoadProto(msg, ctx.blockDeserializer); err != nil {
+		return nil, errors.Wrap(err, "failed to load locked block in consensus WAL")
+	}
+	return bp, nil
+}
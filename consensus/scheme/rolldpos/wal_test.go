@@ -0,0 +1,210 @@
+// Copyright (c) 2022 IoTeX Foundation
+// This is an alpha (internal) release and is not suitable for production. This source code is provided 'as is' and no
+// warranties are given as to title or non-infringement, merchantability or fitness for purpose and, to the extent
+// permitted by law, all liability for your use of the code is disclaimed. This source code is governed by Apache
+// License 2.0 that can be found in the LICENSE file.
+
+package rolldpos
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/facebookgo/clock"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/require"
+
+	"github.com/iotexproject/iotex-core/action/protocol"
+	"github.com/iotexproject/iotex-core/blockchain/block"
+	"github.com/iotexproject/iotex-core/blockchain/genesis"
+	"github.com/iotexproject/iotex-core/config"
+	"github.com/iotexproject/iotex-core/consensus/consensusfsm"
+	"github.com/iotexproject/iotex-core/db"
+	"github.com/iotexproject/iotex-core/endorsement"
+	"github.com/iotexproject/iotex-core/signer"
+	"github.com/iotexproject/iotex-core/state"
+	"github.com/iotexproject/iotex-core/test/identityset"
+)
+
+// newWALTestCtxCreator returns a function which creates a new consensus context on the same chain and WAL, acting
+// as the proposer of the current round, to simulate the restart of the delegate
+func newWALTestCtxCreator(t *testing.T) func() *rollDPoSCtx {
+	require := require.New(t)
+	cfg := config.Default
+	b, sf, _, rp, pp := makeChain(t)
+	c := clock.New()
+	cfg.Genesis.BlockInterval = time.Second * 20
+	walPath := filepath.Join(t.TempDir(), "consensus.wal")
+	newCtx := func() *rollDPoSCtx {
+		rctx, err := newRollDPoSCtx(
+			consensusfsm.NewConsensusConfig(cfg),
+			db.DefaultConfig,
+			true,
+			time.Second,
+			true,
+			NewChainManager(b),
+			block.NewDeserializer(0),
+			rp,
+			nil,
+			func(epochnum uint64) ([]string, error) {
+				re := protocol.NewRegistry()
+				if err := rp.Register(re); err != nil {
+					return nil, err
+				}
+				tipHeight := b.TipHeight()
+				ctx := genesis.WithGenesisContext(
+					protocol.WithBlockchainCtx(
+						protocol.WithRegistry(context.Background(), re),
+						protocol.BlockchainCtx{
+							Tip: protocol.TipInfo{
+								Height: tipHeight,
+							},
+						},
+					),
+					cfg.Genesis,
+				)
+				tipEpochNum := rp.GetEpochNum(tipHeight)
+				var candidatesList state.CandidateList
+				var addrs []string
+				var err error
+				switch epochnum {
+				case tipEpochNum:
+					candidatesList, err = pp.Delegates(ctx, sf)
+				case tipEpochNum + 1:
+					candidatesList, err = pp.NextDelegates(ctx, sf)
+				default:
+					err = errors.Errorf("invalid epoch number %d compared to tip epoch number %d", epochnum, tipEpochNum)
+				}
+				if err != nil {
+					return nil, err
+				}
+				for _, cand := range candidatesList {
+					addrs = append(addrs, cand.Address)
+				}
+				return addrs, nil
+			},
+			"",
+			nil,
+			c,
+			genesis.Default.BeringBlockHeight,
+		)
+		require.NoError(err)
+		rctx.wal = consensusfsm.NewWAL(walPath)
+		require.NoError(rctx.Start(context.Background()))
+		require.NoError(rctx.Prepare())
+		// act as the proposer of the round
+		for i := 0; i < identityset.Size(); i++ {
+			if identityset.Address(i).String() == rctx.round.Proposer() {
+				rctx.encodedAddr, rctx.signer = rctx.round.Proposer(), signer.NewLocalSigner(identityset.PrivateKey(i))
+			}
+		}
+		require.NotNil(rctx.signer)
+		require.NoError(rctx.Replay())
+		return rctx
+	}
+	return newCtx
+}
+
+func TestWALPath(t *testing.T) {
+	require := require.New(t)
+	cfg := config.Default.Consensus.RollDPoS
+	require.Equal("/var/data/consensus.wal", walPath(cfg))
+	cfg.ConsensusDBPath = "./consensus1.db"
+	require.Equal("./consensus1.wal", walPath(cfg))
+	cfg.ConsensusWALPath = "/tmp/wal"
+	require.Equal("/tmp/wal", walPath(cfg))
+	cfg.ConsensusWALPath, cfg.ConsensusDBPath = "", ""
+	require.Empty(walPath(cfg))
+}
+
+func TestConsensusWAL(t *testing.T) {
+	require := require.New(t)
+	newCtx := newWALTestCtxCreator(t)
+
+	rctx := newCtx()
+	res, err := rctx.Proposal()
+	require.NoError(err)
+	proposal, ok := res.(*EndorsedConsensusMessage)
+	require.True(ok)
+	blkHash := proposal.Document().(*blockProposal).block.HashBlock()
+	ts := rctx.round.StartTime()
+	_, err = rctx.newEndorsement(blkHash[:], PROPOSAL, ts)
+	require.NoError(err)
+	// a different block cannot be endorsed in the same round
+	_, err = rctx.newEndorsement([]byte("another block"), PROPOSAL, ts)
+	require.Equal(consensusfsm.ErrConflictDecision, errors.Cause(err))
+	_, err = rctx.newEndorsement(blkHash[:], COMMIT, ts)
+	require.NoError(err)
+	require.NoError(rctx.Stop(context.Background()))
+
+	// the decisions are restored after restart
+	rctx = newCtx()
+	defer rctx.Stop(context.Background())
+	require.NotNil(rctx.round.Block(blkHash[:]))
+	res, err = rctx.Proposal()
+	require.NoError(err)
+	logged, ok := res.(*EndorsedConsensusMessage)
+	require.True(ok)
+	require.Equal(blkHash, logged.Document().(*blockProposal).block.HashBlock())
+	require.Equal(proposal.Endorsement().Signature(), logged.Endorsement().Signature())
+	_, err = rctx.newEndorsement([]byte("another block"), PROPOSAL, ts)
+	require.Equal(consensusfsm.ErrConflictDecision, errors.Cause(err))
+	_, err = rctx.newEndorsement(blkHash[:], PROPOSAL, ts)
+	require.NoError(err)
+}
+
+func TestConsensusWALRestoreLock(t *testing.T) {
+	require := require.New(t)
+	newCtx := newWALTestCtxCreator(t)
+
+	rctx := newCtx()
+	res, err := rctx.Proposal()
+	require.NoError(err)
+	blk := res.(*EndorsedConsensusMessage).Document().(*blockProposal).block
+	blkHash := blk.HashBlock()
+	require.NoError(rctx.round.AddBlock(blk))
+	ts := rctx.round.StartTime()
+	// the delegate list is deduplicated, so that a majority of the test identities could endorse the block
+	delegates, added := []string{}, map[string]bool{}
+	for _, d := range rctx.round.Delegates() {
+		if !added[d] {
+			added[d] = true
+			delegates = append(delegates, d)
+		}
+	}
+	rctx.round.delegates = delegates
+	// collect the proposal endorsements of the delegates until the block is locked
+	var lockMsg interface{}
+	for i := 0; i < identityset.Size() && lockMsg == nil; i++ {
+		if !rctx.round.IsDelegate(identityset.Address(i).String()) {
+			continue
+		}
+		vote := NewConsensusVote(blkHash[:], PROPOSAL)
+		en, err := endorsement.Endorse(identityset.PrivateKey(i), vote, ts)
+		require.NoError(err)
+		lockMsg, err = rctx.NewLockEndorsement(NewEndorsedConsensusMessage(blk.Height(), vote, en))
+		require.NoError(err)
+	}
+	require.NotNil(lockMsg)
+	require.True(rctx.round.IsLocked())
+	proofOfLock := rctx.round.ProofOfLock()
+	// crash after locking on the block
+	require.NoError(rctx.Stop(context.Background()))
+
+	rctx = newCtx()
+	defer rctx.Stop(context.Background())
+	require.True(rctx.round.IsLocked())
+	require.Equal(blkHash[:], rctx.round.HashOfBlockInLock())
+	require.Equal(len(proofOfLock), len(rctx.round.ProofOfLock()))
+	require.NotNil(rctx.round.Block(blkHash[:]))
+	// the lock is kept in the next round, in which the locked block is proposed again with the proof of lock
+	rctx.round.roundNum++
+	rctx.round.proposer = rctx.encodedAddr
+	res, err = rctx.Proposal()
+	require.NoError(err)
+	proposal := res.(*EndorsedConsensusMessage).Document().(*blockProposal)
+	require.Equal(blkHash, proposal.block.HashBlock())
+	require.Equal(len(proofOfLock), len(proposal.proofOfLock))
+}
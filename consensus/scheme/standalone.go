@@ -49,8 +49,12 @@ func (s *standaloneHandler) Run() {
 	}
 }
 
-// NewStandalone creates a Standalone struct.
+// NewStandalone creates a Standalone struct. A zero interval means that the blocks are created on demand by others,
+// e.g., a devnet, instead of periodically
 func NewStandalone(create CreateBlockCB, commit ConsensusDoneCB, pub BroadcastCB, bc blockchain.Blockchain, interval time.Duration) Scheme {
+	if interval == 0 {
+		return &Standalone{}
+	}
 	h := &standaloneHandler{
 		bc:       bc,
 		createCb: create,
@@ -64,11 +68,17 @@ func NewStandalone(create CreateBlockCB, commit ConsensusDoneCB, pub BroadcastCB
 
 // Start starts the service for a standalone
 func (s *Standalone) Start(ctx context.Context) error {
+	if s.task == nil {
+		return nil
+	}
 	return s.task.Start(ctx)
 }
 
 // Stop stops the service for a standalone
 func (s *Standalone) Stop(ctx context.Context) error {
+	if s.task == nil {
+		return nil
+	}
 	return s.task.Stop(ctx)
 }
 
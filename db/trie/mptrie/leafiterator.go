@@ -7,55 +7,78 @@
 package mptrie
 
 import (
+	"bytes"
+
 	"github.com/pkg/errors"
 
 	"github.com/iotexproject/iotex-core/db/trie"
 )
 
-// LeafIterator defines an iterator to go through all the leaves under given node
-type LeafIterator struct {
-	mpt   *merklePatriciaTrie
-	stack []node
-}
+type (
+	// LeafIterator defines an iterator to go through all the leaves under given node, in the ascending order of keys
+	LeafIterator struct {
+		mpt   *merklePatriciaTrie
+		start []byte
+		stack []pathNode
+	}
+
+	// pathNode is a node with the path from the root to it
+	pathNode struct {
+		node node
+		path []byte
+	}
+)
 
 // NewLeafIterator returns a new leaf iterator
 func NewLeafIterator(tr trie.Trie) (trie.Iterator, error) {
+	return NewLeafIteratorFrom(tr, nil)
+}
+
+// NewLeafIteratorFrom returns a new leaf iterator starting from the first leaf whose key is not less than start, the
+// sub-tries of smaller keys are skipped without being loaded
+func NewLeafIteratorFrom(tr trie.Trie, start []byte) (trie.Iterator, error) {
 	mpt, ok := tr.(*merklePatriciaTrie)
 	if !ok {
 		return nil, errors.New("trie is not supported type")
 	}
-	stack := []node{mpt.root}
+	stack := []pathNode{{node: mpt.root}}
 
-	return &LeafIterator{mpt: mpt, stack: stack}, nil
+	return &LeafIterator{mpt: mpt, start: start, stack: stack}, nil
 }
 
 // Next moves iterator to next node
 func (li *LeafIterator) Next() ([]byte, []byte, error) {
 	for len(li.stack) > 0 {
 		size := len(li.stack)
-		node := li.stack[size-1]
+		pn := li.stack[size-1]
 		li.stack = li.stack[:size-1]
-		if hn, ok := node.(*hashNode); ok {
+		if hn, ok := pn.node.(*hashNode); ok {
 			node, err := hn.LoadNode()
 			if err != nil {
 				return nil, nil, err
 			}
-			li.stack = append(li.stack, node)
+			li.stack = append(li.stack, pathNode{node: node, path: pn.path})
 			continue
 		}
-		if ln, ok := node.(leaf); ok {
+		if ln, ok := pn.node.(leaf); ok {
 			key := ln.Key()
+			if bytes.Compare(key, li.start) < 0 {
+				continue
+			}
 			value := ln.Value()
 
 			return append(key[:0:0], key...), append(value[:0:0], value...), nil
 		}
-		if bn, ok := node.(branch); ok {
-			children := bn.Children()
-			li.stack = append(li.stack, children...)
+		if bn, ok := pn.node.(*branchNode); ok {
+			indices := bn.indices.List()
+			// push the children in the descending order, such that the smallest is visited first
+			for i := len(indices) - 1; i >= 0; i-- {
+				li.push(bn.children[indices[i]], pn.path, indices[i])
+			}
 			continue
 		}
-		if en, ok := node.(extension); ok {
-			li.stack = append(li.stack, en.Child())
+		if en, ok := pn.node.(*extensionNode); ok {
+			li.push(en.child, pn.path, en.path...)
 			continue
 		}
 		return nil, nil, errors.New("unexpected node type")
@@ -63,3 +86,17 @@ func (li *LeafIterator) Next() ([]byte, []byte, error) {
 
 	return nil, nil, trie.ErrEndOfIterator
 }
+
+// push pushes the child at path + suffix, unless all the keys under it are less than start
+func (li *LeafIterator) push(child node, path []byte, suffix ...byte) {
+	childPath := make([]byte, 0, len(path)+len(suffix))
+	childPath = append(append(childPath, path...), suffix...)
+	n := len(childPath)
+	if n > len(li.start) {
+		n = len(li.start)
+	}
+	if bytes.Compare(childPath[:n], li.start[:n]) < 0 {
+		return
+	}
+	li.stack = append(li.stack, pathNode{node: child, path: childPath})
+}
@@ -55,3 +55,57 @@ func TestIterator(t *testing.T) {
 		require.Equal(item.v, found[item.k], "key: %s", item.k)
 	}
 }
+
+func TestIteratorFrom(t *testing.T) {
+	var (
+		require = require.New(t)
+		keys    = []string{"iotex", "block", "chain", "puppy", "night", "chair", "blobs"}
+	)
+
+	for _, async := range []bool{false, true} {
+		memStore := trie.NewMemKVStore()
+		opts := []Option{KVStoreOption(memStore), KeyLengthOption(5)}
+		if async {
+			opts = append(opts, AsyncOption())
+		}
+		mpt, err := New(opts...)
+		require.NoError(err)
+		require.NoError(mpt.Start(context.Background()))
+		for _, k := range keys {
+			require.NoError(mpt.Upsert([]byte(k), []byte(k)))
+		}
+		if !async {
+			// reload the trie, such that the nodes are loaded from the store
+			rootHash, err := mpt.RootHash()
+			require.NoError(err)
+			mpt, err = New(append(opts, RootHashOption(rootHash))...)
+			require.NoError(err)
+			require.NoError(mpt.Start(context.Background()))
+		}
+
+		for _, test := range []struct {
+			start    string
+			expected []string
+		}{
+			{"", []string{"blobs", "block", "chain", "chair", "iotex", "night", "puppy"}},
+			{"chain", []string{"chain", "chair", "iotex", "night", "puppy"}},
+			{"chaio", []string{"chair", "iotex", "night", "puppy"}},
+			{"d", []string{"iotex", "night", "puppy"}},
+			{"puppz", nil},
+		} {
+			iter, err := NewLeafIteratorFrom(mpt, []byte(test.start))
+			require.NoError(err)
+			var found []string
+			for {
+				k, v, err := iter.Next()
+				if err != nil {
+					require.Equal(trie.ErrEndOfIterator, err)
+					break
+				}
+				require.Equal(k, v)
+				found = append(found, string(k))
+			}
+			require.Equal(test.expected, found, "start: %s", test.start)
+		}
+	}
+}
@@ -171,6 +171,8 @@ func TestBlockEpochReward(t *testing.T) {
 		dbFilePaths = append(dbFilePaths, indexDBPath)
 		consensusDBPath := fmt.Sprintf("./consensus%d.db", i+1)
 		dbFilePaths = append(dbFilePaths, consensusDBPath)
+		consensusWALPath := fmt.Sprintf("./consensus%d.wal", i+1)
+		dbFilePaths = append(dbFilePaths, consensusWALPath)
 		networkPort := 4689 + i
 		apiPort := 14014 + i
 		HTTPStatsPort := 8080 + i
@@ -178,6 +180,7 @@ func TestBlockEpochReward(t *testing.T) {
 		cfg := newConfig(chainDBPath, trieDBPath, indexDBPath, identityset.PrivateKey(i),
 			networkPort, apiPort, uint64(numNodes))
 		cfg.Consensus.RollDPoS.ConsensusDBPath = consensusDBPath
+		cfg.Consensus.RollDPoS.ConsensusWALPath = consensusWALPath
 		if i == 0 {
 			cfg.Network.BootstrapNodes = []string{}
 			cfg.Network.MasterKey = "bootnode"
@@ -0,0 +1,171 @@
+// Copyright (c) 2022 IoTeX Foundation
+// This is an alpha (internal) release and is not suitable for production. This source code is provided 'as is' and no
+// warranties are given as to title or non-infringement, merchantability or fitness for purpose and, to the extent
+// permitted by law, all liability for your use of the code is disclaimed. This source code is governed by Apache
+// License 2.0 that can be found in the LICENSE file.
+
+package endorsement
+
+import (
+	"math/bits"
+
+	"github.com/pkg/errors"
+	"google.golang.org/protobuf/proto"
+
+	"github.com/iotexproject/iotex-core/endorsement/endorsementpb"
+)
+
+// ErrInvalidAggregateEndorsement indicates the aggregate endorsement doesn't match the endorsers
+var ErrInvalidAggregateEndorsement = errors.New("invalid aggregate endorsement")
+
+// AggregateEndorsement is a single BLS signature aggregated from the endorsements of a list of endorsers on the same
+// document, with a bitmap marking which of the endorsers have signed
+type AggregateEndorsement struct {
+	bitmap    []byte
+	signature []byte
+}
+
+// NewAggregateEndorsement creates a new AggregateEndorsement
+func NewAggregateEndorsement(bitmap []byte, sig []byte) *AggregateEndorsement {
+	cb := make([]byte, len(bitmap))
+	copy(cb, bitmap)
+	cs := make([]byte, len(sig))
+	copy(cs, sig)
+	return &AggregateEndorsement{
+		bitmap:    cb,
+		signature: cs,
+	}
+}
+
+// Aggregate aggregates the BLS signatures of the endorsements. numEndorsers is the size of the ordered list of all
+// the possible endorsers, and indexOf returns the position of the endorser of an endorsement in the list. The
+// endorsements without BLS signature or from an unknown endorser are skipped.
+func Aggregate(
+	numEndorsers int,
+	ens []*Endorsement,
+	indexOf func(*Endorsement) (int, bool),
+) (*AggregateEndorsement, error) {
+	var (
+		bitmap = make([]byte, (numEndorsers+7)/8)
+		sigs   = make([][]byte, 0, len(ens))
+	)
+	for _, en := range ens {
+		if len(en.blsSignature) == 0 {
+			continue
+		}
+		i, ok := indexOf(en)
+		if !ok || i < 0 || i >= numEndorsers {
+			continue
+		}
+		if bitmap[i/8]&(1<<(i%8)) != 0 {
+			continue
+		}
+		bitmap[i/8] |= 1 << (i % 8)
+		sigs = append(sigs, en.blsSignature)
+	}
+	sig, err := AggregateBLSSignatures(sigs)
+	if err != nil {
+		return nil, err
+	}
+	return &AggregateEndorsement{
+		bitmap:    bitmap,
+		signature: sig,
+	}, nil
+}
+
+// VerifyAggregateEndorsement checks the aggregate signature against a document. pks is the ordered list of the
+// public keys of all the possible endorsers, which the bitmap refers to.
+func VerifyAggregateEndorsement(doc Document, agg *AggregateEndorsement, pks []*BLSPublicKey) error {
+	if len(agg.bitmap) != (len(pks)+7)/8 {
+		return errors.Wrapf(ErrInvalidAggregateEndorsement, "bitmap length %d for %d endorsers", len(agg.bitmap), len(pks))
+	}
+	signers := make([]*BLSPublicKey, 0, len(pks))
+	for i := 0; i < len(agg.bitmap)*8; i++ {
+		if !agg.IsSigned(i) {
+			continue
+		}
+		if i >= len(pks) {
+			return errors.Wrapf(ErrInvalidAggregateEndorsement, "endorser %d out of range", i)
+		}
+		if pks[i] == nil {
+			return errors.Wrapf(ErrInvalidAggregateEndorsement, "endorser %d has no BLS public key", i)
+		}
+		signers = append(signers, pks[i])
+	}
+	hash, err := doc.Hash()
+	if err != nil {
+		return err
+	}
+	if !VerifyAggregateBLSSignature(signers, hash, agg.signature) {
+		return errors.Wrap(ErrInvalidAggregateEndorsement, "failed to verify aggregate signature")
+	}
+	return nil
+}
+
+// Bitmap returns the bitmap of the endorsers who signed
+func (agg *AggregateEndorsement) Bitmap() []byte {
+	bitmap := make([]byte, len(agg.bitmap))
+	copy(bitmap, agg.bitmap)
+
+	return bitmap
+}
+
+// Signature returns the aggregate signature
+func (agg *AggregateEndorsement) Signature() []byte {
+	signature := make([]byte, len(agg.signature))
+	copy(signature, agg.signature)
+
+	return signature
+}
+
+// IsSigned returns whether the i-th endorser signed
+func (agg *AggregateEndorsement) IsSigned(i int) bool {
+	if i < 0 || i/8 >= len(agg.bitmap) {
+		return false
+	}
+	return agg.bitmap[i/8]&(1<<(i%8)) != 0
+}
+
+// NumSigners returns the number of endorsers who signed
+func (agg *AggregateEndorsement) NumSigners() int {
+	n := 0
+	for _, b := range agg.bitmap {
+		n += bits.OnesCount8(b)
+	}
+	return n
+}
+
+// Proto converts an aggregate endorsement to protobuf message
+func (agg *AggregateEndorsement) Proto() *endorsementpb.AggregateEndorsement {
+	return &endorsementpb.AggregateEndorsement{
+		Bitmap:    agg.Bitmap(),
+		Signature: agg.Signature(),
+	}
+}
+
+// LoadProto converts a protobuf message to aggregate endorsement
+func (agg *AggregateEndorsement) LoadProto(pb *endorsementpb.AggregateEndorsement) error {
+	if len(pb.Signature) != BLSSignatureLength {
+		return errors.Wrapf(ErrInvalidBLSSignature, "signature length %d", len(pb.Signature))
+	}
+	agg.bitmap = make([]byte, len(pb.Bitmap))
+	copy(agg.bitmap, pb.Bitmap)
+	agg.signature = make([]byte, len(pb.Signature))
+	copy(agg.signature, pb.Signature)
+
+	return nil
+}
+
+// Serialize returns the serialized byte stream of the aggregate endorsement
+func (agg *AggregateEndorsement) Serialize() ([]byte, error) {
+	return proto.Marshal(agg.Proto())
+}
+
+// Deserialize loads from the serialized byte stream
+func (agg *AggregateEndorsement) Deserialize(buf []byte) error {
+	pb := &endorsementpb.AggregateEndorsement{}
+	if err := proto.Unmarshal(buf, pb); err != nil {
+		return err
+	}
+	return agg.LoadProto(pb)
+}
@@ -0,0 +1,85 @@
+// Copyright (c) 2022 IoTeX Foundation
+// This is an alpha (internal) release and is not suitable for production. This source code is provided 'as is' and no
+// warranties are given as to title or non-infringement, merchantability or fitness for purpose and, to the extent
+// permitted by law, all liability for your use of the code is disclaimed. This source code is governed by Apache
+// License 2.0 that can be found in the LICENSE file.
+
+package endorsement
+
+import (
+	"testing"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/require"
+
+	"github.com/iotexproject/iotex-core/test/identityset"
+)
+
+type testDoc []byte
+
+func (d testDoc) Hash() ([]byte, error) {
+	return d, nil
+}
+
+func TestAggregateEndorsement(t *testing.T) {
+	require := require.New(t)
+	var (
+		doc  = testDoc("block hash")
+		pks  []*BLSPublicKey
+		ens  []*Endorsement
+		now  = time.Now()
+		pos  = map[string]int{}
+		size = 10
+	)
+	for i := 0; i < size; i++ {
+		sk, err := GenerateBLSPrivateKey()
+		require.NoError(err)
+		pks = append(pks, sk.PublicKey())
+		pos[identityset.PrivateKey(i).PublicKey().HexString()] = i
+		if i%3 == 0 {
+			continue
+		}
+		en, err := EndorseWithBLS(identityset.PrivateKey(i), sk, doc, now.Add(time.Duration(i)*time.Second))
+		require.NoError(err)
+		require.True(VerifyEndorsement(doc, en))
+		require.True(VerifyBLSEndorsement(doc, en, sk.PublicKey()))
+		ens = append(ens, en)
+	}
+	// endorsement without BLS signature is skipped
+	en, err := Endorse(identityset.PrivateKey(0), doc, now)
+	require.NoError(err)
+	require.Nil(en.BLSSignature())
+	ens = append(ens, en)
+
+	agg, err := Aggregate(size, ens, func(en *Endorsement) (int, bool) {
+		i, ok := pos[en.Endorser().HexString()]
+		return i, ok
+	})
+	require.NoError(err)
+	require.Equal(6, agg.NumSigners())
+	for i := 0; i < size; i++ {
+		require.Equal(i%3 != 0, agg.IsSigned(i))
+	}
+	require.NoError(VerifyAggregateEndorsement(doc, agg, pks))
+	require.Equal(ErrInvalidAggregateEndorsement, errors.Cause(VerifyAggregateEndorsement(testDoc("another hash"), agg, pks)))
+	require.Equal(ErrInvalidAggregateEndorsement, errors.Cause(VerifyAggregateEndorsement(doc, agg, pks[:8])))
+	pks[1] = pks[0]
+	require.Equal(ErrInvalidAggregateEndorsement, errors.Cause(VerifyAggregateEndorsement(doc, agg, pks)))
+
+	// serialization
+	ser, err := agg.Serialize()
+	require.NoError(err)
+	agg2 := &AggregateEndorsement{}
+	require.NoError(agg2.Deserialize(ser))
+	require.Equal(agg.Bitmap(), agg2.Bitmap())
+	require.Equal(agg.Signature(), agg2.Signature())
+
+	// BLS signature survives the protobuf round trip of endorsement
+	ePb, err := ens[0].Proto()
+	require.NoError(err)
+	en = &Endorsement{}
+	require.NoError(en.LoadProto(ePb))
+	require.Equal(ens[0].BLSSignature(), en.BLSSignature())
+	require.Equal(ens[0].Signature(), en.Signature())
+}
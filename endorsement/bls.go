@@ -0,0 +1,249 @@
+// Copyright (c) 2022 IoTeX Foundation
+// This is an alpha (internal) release and is not suitable for production. This source code is provided 'as is' and no
+// warranties are given as to title or non-infringement, merchantability or fitness for purpose and, to the extent
+// permitted by law, all liability for your use of the code is disclaimed. This source code is governed by Apache
+// License 2.0 that can be found in the LICENSE file.
+
+package endorsement
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"math/big"
+
+	bls12381 "github.com/consensys/gnark-crypto/ecc/bls12-381"
+	"github.com/consensys/gnark-crypto/ecc/bls12-381/fr"
+	"github.com/pkg/errors"
+)
+
+// BLS12-381 signatures use the "minimal public key size" variant of the proof of possession scheme: public keys live
+// in G1 and signatures in G2, so that aggregating the public keys of all endorsers of a block is cheap. Points are
+// serialized in the compressed form.
+const (
+	// BLSPrivateKeyLength is the byte length of a serialized BLS private key
+	BLSPrivateKeyLength = 32
+	// BLSPublicKeyLength is the byte length of a serialized BLS public key
+	BLSPublicKeyLength = bls12381.SizeOfG1AffineCompressed
+	// BLSSignatureLength is the byte length of a serialized BLS signature
+	BLSSignatureLength = bls12381.SizeOfG2AffineCompressed
+)
+
+var (
+	// ErrInvalidBLSKey indicates the BLS key is malformed
+	ErrInvalidBLSKey = errors.New("invalid BLS key")
+	// ErrInvalidBLSSignature indicates the BLS signature is malformed
+	ErrInvalidBLSSignature = errors.New("invalid BLS signature")
+
+	// the domain separation tags of the BLS_SIG_BLS12381G2_XMD:SHA-256_SSWU_RO_POP_ ciphersuite
+	_blsSigDST = []byte("BLS_SIG_BLS12381G2_XMD:SHA-256_SSWU_RO_POP_")
+	_blsPopDST = []byte("BLS_POP_BLS12381G2_XMD:SHA-256_SSWU_RO_POP_")
+)
+
+type (
+	// BLSPrivateKey is a BLS12-381 private key used to sign aggregatable endorsements
+	BLSPrivateKey struct {
+		scalar *big.Int
+	}
+
+	// BLSPublicKey is a BLS12-381 public key
+	BLSPublicKey struct {
+		point bls12381.G1Affine
+	}
+)
+
+// GenerateBLSPrivateKey generates a random BLS private key
+func GenerateBLSPrivateKey() (*BLSPrivateKey, error) {
+	order := fr.Modulus()
+	for {
+		s, err := rand.Int(rand.Reader, order)
+		if err != nil {
+			return nil, err
+		}
+		if s.Sign() > 0 {
+			return &BLSPrivateKey{scalar: s}, nil
+		}
+	}
+}
+
+// BytesToBLSPrivateKey converts a byte slice to a BLS private key
+func BytesToBLSPrivateKey(b []byte) (*BLSPrivateKey, error) {
+	if len(b) != BLSPrivateKeyLength {
+		return nil, errors.Wrapf(ErrInvalidBLSKey, "private key length %d", len(b))
+	}
+	s := new(big.Int).SetBytes(b)
+	if s.Sign() == 0 || s.Cmp(fr.Modulus()) >= 0 {
+		return nil, errors.Wrap(ErrInvalidBLSKey, "private key out of range")
+	}
+	return &BLSPrivateKey{scalar: s}, nil
+}
+
+// HexStringToBLSPrivateKey converts a hex string to a BLS private key
+func HexStringToBLSPrivateKey(s string) (*BLSPrivateKey, error) {
+	b, err := hex.DecodeString(s)
+	if err != nil {
+		return nil, errors.Wrap(ErrInvalidBLSKey, err.Error())
+	}
+	return BytesToBLSPrivateKey(b)
+}
+
+// Bytes returns the serialized private key
+func (sk *BLSPrivateKey) Bytes() []byte {
+	b := make([]byte, BLSPrivateKeyLength)
+	return sk.scalar.FillBytes(b)
+}
+
+// HexString returns the private key in hex string
+func (sk *BLSPrivateKey) HexString() string {
+	return hex.EncodeToString(sk.Bytes())
+}
+
+// PublicKey returns the public key of the private key
+func (sk *BLSPrivateKey) PublicKey() *BLSPublicKey {
+	_, _, g1, _ := bls12381.Generators()
+	pk := &BLSPublicKey{}
+	pk.point.ScalarMultiplication(&g1, sk.scalar)
+	return pk
+}
+
+// Sign signs the message
+func (sk *BLSPrivateKey) Sign(msg []byte) ([]byte, error) {
+	return sk.sign(msg, _blsSigDST)
+}
+
+// ProofOfPossession signs the public key itself, which proves the ownership of the private key and protects an
+// aggregate signature against rogue public key attacks
+func (sk *BLSPrivateKey) ProofOfPossession() ([]byte, error) {
+	return sk.sign(sk.PublicKey().Bytes(), _blsPopDST)
+}
+
+func (sk *BLSPrivateKey) sign(msg, dst []byte) ([]byte, error) {
+	h, err := bls12381.HashToCurveG2SSWU(msg, dst)
+	if err != nil {
+		return nil, err
+	}
+	var sig bls12381.G2Affine
+	sig.ScalarMultiplication(&h, sk.scalar)
+	b := sig.Bytes()
+	return b[:], nil
+}
+
+// BytesToBLSPublicKey converts a byte slice to a BLS public key
+func BytesToBLSPublicKey(b []byte) (*BLSPublicKey, error) {
+	if len(b) != BLSPublicKeyLength {
+		return nil, errors.Wrapf(ErrInvalidBLSKey, "public key length %d", len(b))
+	}
+	pk := &BLSPublicKey{}
+	// SetBytes checks the point is in the G1 subgroup
+	if _, err := pk.point.SetBytes(b); err != nil {
+		return nil, errors.Wrap(ErrInvalidBLSKey, err.Error())
+	}
+	if pk.point.IsInfinity() {
+		return nil, errors.Wrap(ErrInvalidBLSKey, "public key is the point at infinity")
+	}
+	return pk, nil
+}
+
+// HexStringToBLSPublicKey converts a hex string to a BLS public key
+func HexStringToBLSPublicKey(s string) (*BLSPublicKey, error) {
+	b, err := hex.DecodeString(s)
+	if err != nil {
+		return nil, errors.Wrap(ErrInvalidBLSKey, err.Error())
+	}
+	return BytesToBLSPublicKey(b)
+}
+
+// Bytes returns the serialized public key
+func (pk *BLSPublicKey) Bytes() []byte {
+	b := pk.point.Bytes()
+	return b[:]
+}
+
+// HexString returns the public key in hex string
+func (pk *BLSPublicKey) HexString() string {
+	return hex.EncodeToString(pk.Bytes())
+}
+
+// Verify verifies the signature of the message
+func (pk *BLSPublicKey) Verify(msg, sig []byte) bool {
+	return verifyBLS(&pk.point, msg, sig, _blsSigDST)
+}
+
+// VerifyProofOfPossession verifies the proof of possession of the public key
+func (pk *BLSPublicKey) VerifyProofOfPossession(proof []byte) bool {
+	return verifyBLS(&pk.point, pk.Bytes(), proof, _blsPopDST)
+}
+
+// AggregateBLSPublicKeys adds up the public keys
+func AggregateBLSPublicKeys(pks []*BLSPublicKey) (*BLSPublicKey, error) {
+	if len(pks) == 0 {
+		return nil, errors.Wrap(ErrInvalidBLSKey, "no public key to aggregate")
+	}
+	var agg, p bls12381.G1Jac
+	agg.FromAffine(&pks[0].point)
+	for _, pk := range pks[1:] {
+		agg.AddAssign(p.FromAffine(&pk.point))
+	}
+	ret := &BLSPublicKey{}
+	ret.point.FromJacobian(&agg)
+	return ret, nil
+}
+
+// AggregateBLSSignatures adds up the signatures
+func AggregateBLSSignatures(sigs [][]byte) ([]byte, error) {
+	if len(sigs) == 0 {
+		return nil, errors.Wrap(ErrInvalidBLSSignature, "no signature to aggregate")
+	}
+	var agg, p bls12381.G2Jac
+	for i, sig := range sigs {
+		s, err := bytesToG2(sig)
+		if err != nil {
+			return nil, err
+		}
+		if i == 0 {
+			agg.FromAffine(s)
+			continue
+		}
+		agg.AddAssign(p.FromAffine(s))
+	}
+	var ret bls12381.G2Affine
+	b := ret.FromJacobian(&agg).Bytes()
+	return b[:], nil
+}
+
+// VerifyAggregateBLSSignature verifies an aggregate signature of the same message signed by all public keys
+func VerifyAggregateBLSSignature(pks []*BLSPublicKey, msg, sig []byte) bool {
+	agg, err := AggregateBLSPublicKeys(pks)
+	if err != nil {
+		return false
+	}
+	return agg.Verify(msg, sig)
+}
+
+func verifyBLS(pk *bls12381.G1Affine, msg, sig, dst []byte) bool {
+	s, err := bytesToG2(sig)
+	if err != nil {
+		return false
+	}
+	h, err := bls12381.HashToCurveG2SSWU(msg, dst)
+	if err != nil {
+		return false
+	}
+	// e(pk, H(m)) == e(g1, sig)
+	_, _, g1, _ := bls12381.Generators()
+	var negG1 bls12381.G1Affine
+	negG1.Neg(&g1)
+	ok, err := bls12381.PairingCheck([]bls12381.G1Affine{*pk, negG1}, []bls12381.G2Affine{h, *s})
+	return err == nil && ok
+}
+
+func bytesToG2(sig []byte) (*bls12381.G2Affine, error) {
+	if len(sig) != BLSSignatureLength {
+		return nil, errors.Wrapf(ErrInvalidBLSSignature, "signature length %d", len(sig))
+	}
+	p := &bls12381.G2Affine{}
+	// SetBytes checks the point is in the G2 subgroup
+	if _, err := p.SetBytes(sig); err != nil {
+		return nil, errors.Wrap(ErrInvalidBLSSignature, err.Error())
+	}
+	return p, nil
+}
@@ -0,0 +1,70 @@
+// Copyright (c) 2022 IoTeX Foundation
+// This is an alpha (internal) release and is not suitable for production. This source code is provided 'as is' and no
+// warranties are given as to title or non-infringement, merchantability or fitness for purpose and, to the extent
+// permitted by law, all liability for your use of the code is disclaimed. This source code is governed by Apache
+// License 2.0 that can be found in the LICENSE file.
+
+package endorsement
+
+import (
+	"testing"
+
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBLSSignVerify(t *testing.T) {
+	require := require.New(t)
+	sk, err := GenerateBLSPrivateKey()
+	require.NoError(err)
+	sk2, err := BytesToBLSPrivateKey(sk.Bytes())
+	require.NoError(err)
+	require.Equal(sk.HexString(), sk2.HexString())
+	pk, err := HexStringToBLSPublicKey(sk.PublicKey().HexString())
+	require.NoError(err)
+
+	msg := []byte("hello iotex")
+	sig, err := sk.Sign(msg)
+	require.NoError(err)
+	require.Len(sig, BLSSignatureLength)
+	require.True(pk.Verify(msg, sig))
+	require.False(pk.Verify([]byte("hello world"), sig))
+
+	proof, err := sk.ProofOfPossession()
+	require.NoError(err)
+	require.True(pk.VerifyProofOfPossession(proof))
+	// the proof of possession is not a valid signature of the public key
+	require.False(pk.Verify(pk.Bytes(), proof))
+
+	_, err = BytesToBLSPrivateKey(make([]byte, BLSPrivateKeyLength))
+	require.Error(err)
+	_, err = BytesToBLSPublicKey(make([]byte, BLSPublicKeyLength))
+	require.Error(err)
+}
+
+func TestBLSAggregate(t *testing.T) {
+	require := require.New(t)
+	msg := []byte("block hash")
+	var (
+		pks  []*BLSPublicKey
+		sigs [][]byte
+	)
+	for i := 0; i < 4; i++ {
+		sk, err := GenerateBLSPrivateKey()
+		require.NoError(err)
+		sig, err := sk.Sign(msg)
+		require.NoError(err)
+		pks = append(pks, sk.PublicKey())
+		sigs = append(sigs, sig)
+	}
+	agg, err := AggregateBLSSignatures(sigs)
+	require.NoError(err)
+	require.True(VerifyAggregateBLSSignature(pks, msg, agg))
+	require.False(VerifyAggregateBLSSignature(pks[1:], msg, agg))
+	require.False(VerifyAggregateBLSSignature(pks, []byte("another hash"), agg))
+
+	_, err = AggregateBLSSignatures(nil)
+	require.Equal(ErrInvalidBLSSignature, errors.Cause(err))
+	_, err = AggregateBLSSignatures([][]byte{[]byte("invalid")})
+	require.Equal(ErrInvalidBLSSignature, errors.Cause(err))
+}
@@ -12,9 +12,12 @@ import (
 	"github.com/iotexproject/go-pkgs/crypto"
 	"github.com/iotexproject/go-pkgs/hash"
 	"github.com/iotexproject/iotex-proto/golang/iotextypes"
+	"google.golang.org/protobuf/proto"
 	"google.golang.org/protobuf/types/known/timestamppb"
 
+	"github.com/iotexproject/iotex-core/endorsement/endorsementpb"
 	"github.com/iotexproject/iotex-core/pkg/util/byteutil"
+	"github.com/iotexproject/iotex-core/signer"
 )
 
 type (
@@ -25,9 +28,10 @@ type (
 
 	// Endorsement defines an endorsement with timestamp
 	Endorsement struct {
-		ts        time.Time
-		endorser  crypto.PublicKey
-		signature []byte
+		ts           time.Time
+		endorser     crypto.PublicKey
+		signature    []byte
+		blsSignature []byte
 	}
 
 	// EndorsedDocument is an signed document
@@ -65,7 +69,7 @@ func NewEndorsement(
 
 // Endorse endorses a document
 func Endorse(
-	signer crypto.PrivateKey,
+	signer signer.HashSigner,
 	doc Document,
 	ts time.Time,
 ) (*Endorsement, error) {
@@ -80,6 +84,28 @@ func Endorse(
 	return NewEndorsement(ts, signer.PublicKey(), sig), nil
 }
 
+// EndorseWithBLS endorses a document, and additionally signs the document hash with a BLS private key so that the
+// endorsement could be aggregated with the ones of the other endorsers of the same document
+func EndorseWithBLS(
+	signer signer.HashSigner,
+	blsSigner *BLSPrivateKey,
+	doc Document,
+	ts time.Time,
+) (*Endorsement, error) {
+	en, err := Endorse(signer, doc, ts)
+	if err != nil {
+		return nil, err
+	}
+	hash, err := doc.Hash()
+	if err != nil {
+		return nil, err
+	}
+	if en.blsSignature, err = blsSigner.Sign(hash); err != nil {
+		return nil, err
+	}
+	return en, nil
+}
+
 // VerifyEndorsedDocument checks an endorsed document
 func VerifyEndorsedDocument(endorsedDoc EndorsedDocument) bool {
 	return VerifyEndorsement(endorsedDoc.Document(), endorsedDoc.Endorsement())
@@ -95,6 +121,18 @@ func VerifyEndorsement(doc Document, en *Endorsement) bool {
 	return en.Endorser().Verify(hash, en.Signature())
 }
 
+// VerifyBLSEndorsement checks the BLS signature in an endorsement against a document
+func VerifyBLSEndorsement(doc Document, en *Endorsement, pk *BLSPublicKey) bool {
+	if len(en.blsSignature) == 0 || pk == nil {
+		return false
+	}
+	hash, err := doc.Hash()
+	if err != nil {
+		return false
+	}
+	return pk.Verify(hash, en.blsSignature)
+}
+
 // Timestamp returns the signature time
 func (en *Endorsement) Timestamp() time.Time {
 	return en.ts
@@ -113,14 +151,35 @@ func (en *Endorsement) Signature() []byte {
 	return signature
 }
 
+// BLSSignature returns the BLS signature of this endorsement, which is nil if the endorsement is not aggregatable
+func (en *Endorsement) BLSSignature() []byte {
+	if len(en.blsSignature) == 0 {
+		return nil
+	}
+	signature := make([]byte, len(en.blsSignature))
+	copy(signature, en.blsSignature)
+
+	return signature
+}
+
 // Proto converts an endorsement to protobuf message
 func (en *Endorsement) Proto() (*iotextypes.Endorsement, error) {
-	ts := timestamppb.New(en.ts)
-	return &iotextypes.Endorsement{
-		Timestamp: ts,
-		Endorser:  en.endorser.Bytes(),
-		Signature: en.Signature(),
-	}, nil
+	// the endorsement is encoded as endorsementpb.Endorsement, the BLS signature is kept in the unknown fields of
+	// iotextypes.Endorsement, so that it is transparent to the nodes which don't recognize it
+	ser, err := proto.Marshal(&endorsementpb.Endorsement{
+		Timestamp:    timestamppb.New(en.ts),
+		Endorser:     en.endorser.Bytes(),
+		Signature:    en.Signature(),
+		BlsSignature: en.BLSSignature(),
+	})
+	if err != nil {
+		return nil, err
+	}
+	ePb := &iotextypes.Endorsement{}
+	if err := proto.Unmarshal(ser, ePb); err != nil {
+		return nil, err
+	}
+	return ePb, nil
 }
 
 // LoadProto converts a protobuf message to endorsement
@@ -128,6 +187,10 @@ func (en *Endorsement) LoadProto(ePb *iotextypes.Endorsement) (err error) {
 	if err = ePb.Timestamp.CheckValid(); err != nil {
 		return err
 	}
+	ext := &endorsementpb.Endorsement{}
+	if err = proto.Unmarshal(ePb.ProtoReflect().GetUnknown(), ext); err != nil {
+		return err
+	}
 	en.ts = ePb.Timestamp.AsTime()
 	eb := make([]byte, len(ePb.Endorser))
 	copy(eb, ePb.Endorser)
@@ -136,6 +199,11 @@ func (en *Endorsement) LoadProto(ePb *iotextypes.Endorsement) (err error) {
 	}
 	en.signature = make([]byte, len(ePb.Signature))
 	copy(en.signature, ePb.Signature)
+	en.blsSignature = nil
+	if len(ext.BlsSignature) != 0 {
+		en.blsSignature = make([]byte, len(ext.BlsSignature))
+		copy(en.blsSignature, ext.BlsSignature)
+	}
 
 	return nil
 }
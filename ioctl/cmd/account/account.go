@@ -82,6 +82,7 @@ func init() {
 	AccountCmd.AddCommand(_accountUpdateCmd)
 	AccountCmd.AddCommand(_accountVerifyCmd)
 	AccountCmd.AddCommand(_accountActionsCmd)
+	AccountCmd.AddCommand(_accountHistoryCmd)
 	AccountCmd.PersistentFlags().StringVar(&config.ReadConfig.Endpoint, "endpoint",
 		config.ReadConfig.Endpoint, config.TranslateInLang(_flagEndpoint, config.UILanguage))
 	AccountCmd.PersistentFlags().BoolVar(&config.Insecure, "insecure", config.Insecure, config.TranslateInLang(_flagInsecure, config.UILanguage))
@@ -0,0 +1,415 @@
+// Copyright (c) 2022 IoTeX Foundation
+// This is an alpha (internal) release and is not suitable for production. This source code is provided 'as is' and no
+// warranties are given as to title or non-infringement, merchantability or fitness for purpose and, to the extent
+// permitted by law, all liability for your use of the code is disclaimed. This source code is governed by Apache
+// License 2.0 that can be found in the LICENSE file.
+
+package account
+
+import (
+	"context"
+	"encoding/csv"
+	"fmt"
+	"math/big"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/grpc-ecosystem/go-grpc-middleware/util/metautils"
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/iotexproject/iotex-proto/golang/iotexapi"
+	"github.com/iotexproject/iotex-proto/golang/iotextypes"
+
+	"github.com/iotexproject/iotex-core/ioctl/config"
+	"github.com/iotexproject/iotex-core/ioctl/output"
+	"github.com/iotexproject/iotex-core/ioctl/util"
+)
+
+// Multi-language support
+var (
+	_historyCmdShorts = map[config.Language]string{
+		config.English: "Export the history of an account in a date range into csv, with the IOTX deltas and gas paid",
+		config.Chinese: "将账户在日期范围内的历史记录导出为csv，包括IOTX变化量和支付的gas",
+	}
+	_historyCmdUses = map[config.Language]string{
+		config.English: "history (ALIAS|ADDRESS) [--from-date YYYY-MM-DD] [--to-date YYYY-MM-DD] [--file FILE]",
+		config.Chinese: "history (别名|地址) [--from-date 年-月-日] [--to-date 年-月-日] [--file 文件]",
+	}
+	_flagFromDateUsages = map[config.Language]string{
+		config.English: "the first date (UTC) of the history, all history by default",
+		config.Chinese: "历史记录的起始日期(UTC)，默认为全部历史",
+	}
+	_flagToDateUsages = map[config.Language]string{
+		config.English: "the last date (UTC) of the history, today by default",
+		config.Chinese: "历史记录的结束日期(UTC)，默认为今天",
+	}
+	_flagHistoryFileUsages = map[config.Language]string{
+		config.English: "the csv file exported to, history_ADDRESS.csv by default",
+		config.Chinese: "导出的csv文件，默认为history_地址.csv",
+	}
+)
+
+const (
+	_dateLayout = "2006-01-02"
+	// _historyPageSize is the number of actions queried per request, not greater than the range query limit of api
+	_historyPageSize = 500
+)
+
+// the categories of actions in history
+const (
+	_categoryTransfer     = "transfer"
+	_categoryContractCall = "contract call"
+	_categoryStaking      = "staking"
+	_categoryReward       = "reward"
+	_categoryOther        = "other"
+)
+
+var (
+	_historyFromDate string
+	_historyToDate   string
+	_historyFile     string
+)
+
+// _accountHistoryCmd represents the account history command
+var _accountHistoryCmd = &cobra.Command{
+	Use:   config.TranslateInLang(_historyCmdUses, config.UILanguage),
+	Short: config.TranslateInLang(_historyCmdShorts, config.UILanguage),
+	Args:  cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		cmd.SilenceUsage = true
+		err := accountHistory(args[0])
+		return output.PrintError(err)
+	},
+}
+
+func init() {
+	_accountHistoryCmd.Flags().StringVar(&_historyFromDate, "from-date", "",
+		config.TranslateInLang(_flagFromDateUsages, config.UILanguage))
+	_accountHistoryCmd.Flags().StringVar(&_historyToDate, "to-date", "",
+		config.TranslateInLang(_flagToDateUsages, config.UILanguage))
+	_accountHistoryCmd.Flags().StringVar(&_historyFile, "file", "",
+		config.TranslateInLang(_flagHistoryFileUsages, config.UILanguage))
+}
+
+// historyRecord is a row of the exported history
+type historyRecord struct {
+	time      time.Time
+	height    uint64
+	hash      string
+	category  string
+	actType   string
+	sender    string
+	recipient string
+	amount    *big.Int
+	// delta is the change of the account's IOTX balance made by the action, including the gas paid
+	delta  *big.Int
+	gasFee *big.Int
+	status string
+}
+
+func accountHistory(arg string) error {
+	addr, err := util.Address(arg)
+	if err != nil {
+		return output.NewError(output.AddressError, "failed to get address", err)
+	}
+	from, to, err := historyDateRange(_historyFromDate, _historyToDate)
+	if err != nil {
+		return output.NewError(output.FlagError, "invalid date range", err)
+	}
+	file := _historyFile
+	if file == "" {
+		file = "history_" + addr + ".csv"
+	}
+
+	conn, err := util.ConnectToEndpoint(config.ReadConfig.SecureConnect && !config.Insecure)
+	if err != nil {
+		return output.NewError(output.NetworkError, "failed to connect to endpoint", err)
+	}
+	defer conn.Close()
+	cli := iotexapi.NewAPIServiceClient(conn)
+	ctx := context.Background()
+	jwtMD, err := util.JwtAuth()
+	if err == nil {
+		ctx = metautils.NiceMD(jwtMD).ToOutgoing(ctx)
+	}
+
+	var (
+		records  []*historyRecord
+		noTxLogs bool
+	)
+	// the actions of an address are indexed in the order of execution
+	for start, done := uint64(0), false; !done; start += _historyPageSize {
+		response, err := cli.GetActions(ctx, &iotexapi.GetActionsRequest{
+			Lookup: &iotexapi.GetActionsRequest_ByAddr{
+				ByAddr: &iotexapi.GetActionsByAddressRequest{
+					Address: addr,
+					Start:   start,
+					Count:   _historyPageSize,
+				},
+			},
+		})
+		if err != nil {
+			return historyAPIError("GetActions", err)
+		}
+		infos := response.GetActionInfo()
+		done = uint64(len(infos)) < _historyPageSize
+		for _, info := range infos {
+			ts := info.GetTimestamp().AsTime()
+			if ts.Before(from) {
+				continue
+			}
+			if !ts.Before(to) {
+				done = true
+				break
+			}
+			receipt, err := cli.GetReceiptByAction(ctx, &iotexapi.GetReceiptByActionRequest{ActionHash: info.GetActHash()})
+			if err != nil {
+				return historyAPIError("GetReceiptByAction", err)
+			}
+			var txLog *iotextypes.TransactionLog
+			if !noTxLogs {
+				res, err := cli.GetTransactionLogByActionHash(ctx,
+					&iotexapi.GetTransactionLogByActionHashRequest{ActionHash: info.GetActHash()})
+				switch {
+				case err == nil:
+					txLog = res.GetTransactionLog()
+				case status.Code(err) == codes.Unimplemented:
+					noTxLogs = true
+					fmt.Println(output.StringMessage("transaction logs are not supported by the endpoint, " +
+						"the in-contract transfers and the bucket withdrawals are not counted in the deltas").Warn())
+				case status.Code(err) != codes.NotFound:
+					return historyAPIError("GetTransactionLogByActionHash", err)
+				}
+			}
+			record, err := newHistoryRecord(addr, info, receipt.GetReceiptInfo().GetReceipt(), txLog)
+			if err != nil {
+				return output.NewError(output.SerializationError, "failed to read action "+info.GetActHash(), err)
+			}
+			records = append(records, record)
+		}
+	}
+
+	if err := writeHistory(file, records); err != nil {
+		return output.NewError(output.WriteFileError, "failed to write history", err)
+	}
+	fmt.Printf("%d actions of %s have been exported into %s.\n", len(records), addr, file)
+	fmt.Println(historySummary(records).String())
+	return nil
+}
+
+// historyDateRange returns the time range [from, to) of the dates
+func historyDateRange(fromDate, toDate string) (time.Time, time.Time, error) {
+	var (
+		from time.Time
+		to   = time.Now().UTC().Truncate(24 * time.Hour)
+		err  error
+	)
+	if fromDate != "" {
+		if from, err = time.Parse(_dateLayout, fromDate); err != nil {
+			return from, to, err
+		}
+	}
+	if toDate != "" {
+		if to, err = time.Parse(_dateLayout, toDate); err != nil {
+			return from, to, err
+		}
+	}
+	to = to.AddDate(0, 0, 1)
+	if !from.Before(to) {
+		return from, to, errors.Errorf("from date %s is after to date %s", fromDate, toDate)
+	}
+	return from, to, nil
+}
+
+func historyAPIError(api string, err error) error {
+	if sta, ok := status.FromError(err); ok {
+		return output.NewError(output.APIError, sta.Message(), nil)
+	}
+	return output.NewError(output.NetworkError, "failed to invoke "+api+" api", err)
+}
+
+// newHistoryRecord classifies the action and computes the delta of addr's balance. The delta is summed from the
+// transaction logs if exist, otherwise it is estimated by the amount of action
+func newHistoryRecord(addr string, info *iotexapi.ActionInfo, receipt *iotextypes.Receipt,
+	txLog *iotextypes.TransactionLog) (*historyRecord, error) {
+	core := info.GetAction().GetCore()
+	record := &historyRecord{
+		time:   info.GetTimestamp().AsTime().UTC(),
+		height: info.GetBlkHeight(),
+		hash:   info.GetActHash(),
+		sender: info.GetSender(),
+		amount: new(big.Int),
+		delta:  new(big.Int),
+		gasFee: new(big.Int),
+		status: "failure",
+	}
+	if receipt.GetStatus() == uint64(iotextypes.ReceiptStatus_Success) {
+		record.status = "success"
+	}
+	record.actType = strings.TrimPrefix(fmt.Sprintf("%T", core.GetAction()), "*iotextypes.ActionCore_")
+
+	var amount string
+	switch {
+	case core.GetTransfer() != nil:
+		record.category, record.recipient, amount = _categoryTransfer, core.GetTransfer().GetRecipient(),
+			core.GetTransfer().GetAmount()
+	case core.GetExecution() != nil:
+		record.category, record.recipient, amount = _categoryContractCall, core.GetExecution().GetContract(),
+			core.GetExecution().GetAmount()
+		if record.recipient == "" {
+			record.recipient = receipt.GetContractAddress()
+		}
+	case core.GetStakeCreate() != nil:
+		record.category, record.recipient, amount = _categoryStaking, core.GetStakeCreate().GetCandidateName(),
+			core.GetStakeCreate().GetStakedAmount()
+	case core.GetStakeAddDeposit() != nil:
+		record.category, amount = _categoryStaking, core.GetStakeAddDeposit().GetAmount()
+	case core.GetCandidateRegister() != nil:
+		record.category, amount = _categoryStaking, core.GetCandidateRegister().GetStakedAmount()
+	case core.GetStakeUnstake() != nil, core.GetStakeWithdraw() != nil, core.GetStakeRestake() != nil,
+		core.GetStakeChangeCandidate() != nil, core.GetStakeTransferOwnership() != nil, core.GetCandidateUpdate() != nil:
+		record.category = _categoryStaking
+	case core.GetClaimFromRewardingFund() != nil:
+		record.category, amount = _categoryReward, core.GetClaimFromRewardingFund().GetAmount()
+	case core.GetDepositToRewardingFund() != nil:
+		record.category, amount = _categoryReward, core.GetDepositToRewardingFund().GetAmount()
+	case core.GetGrantReward() != nil:
+		record.category = _categoryReward
+	default:
+		record.category = _categoryOther
+	}
+	if amount != "" {
+		if _, ok := record.amount.SetString(amount, 10); !ok {
+			return nil, errors.Errorf("invalid amount %s", amount)
+		}
+	}
+	if record.sender == addr {
+		if _, ok := record.gasFee.SetString(info.GetGasFee(), 10); !ok {
+			return nil, errors.Errorf("invalid gas fee %s", info.GetGasFee())
+		}
+	}
+
+	if txLog != nil {
+		for _, tx := range txLog.GetTransactions() {
+			value, ok := new(big.Int).SetString(tx.GetAmount(), 10)
+			if !ok {
+				return nil, errors.Errorf("invalid amount %s in transaction log", tx.GetAmount())
+			}
+			if tx.GetRecipient() == addr {
+				record.delta.Add(record.delta, value)
+			}
+			if tx.GetSender() == addr {
+				record.delta.Sub(record.delta, value)
+			}
+		}
+		return record, nil
+	}
+	record.delta.Neg(record.gasFee)
+	if record.status != "success" {
+		return record, nil
+	}
+	switch record.category {
+	case _categoryTransfer, _categoryContractCall:
+		if record.recipient == addr {
+			record.delta.Add(record.delta, record.amount)
+		}
+		if record.sender == addr {
+			record.delta.Sub(record.delta, record.amount)
+		}
+	case _categoryStaking:
+		record.delta.Sub(record.delta, record.amount)
+	case _categoryReward:
+		if core.GetClaimFromRewardingFund() != nil {
+			record.delta.Add(record.delta, record.amount)
+		} else {
+			record.delta.Sub(record.delta, record.amount)
+		}
+	}
+	return record, nil
+}
+
+func writeHistory(file string, records []*historyRecord) error {
+	f, err := os.OpenFile(filepath.Clean(file), os.O_CREATE|os.O_WRONLY|os.O_TRUNC, 0600)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+	w := csv.NewWriter(f)
+	if err := w.Write([]string{"time", "height", "hash", "category", "type", "sender", "recipient",
+		"amount", "delta", "gasFee", "status"}); err != nil {
+		return err
+	}
+	for _, r := range records {
+		if err := w.Write([]string{
+			r.time.Format(time.RFC3339),
+			strconv.FormatUint(r.height, 10),
+			r.hash,
+			r.category,
+			r.actType,
+			r.sender,
+			r.recipient,
+			iotxString(r.amount),
+			iotxString(r.delta),
+			iotxString(r.gasFee),
+			r.status,
+		}); err != nil {
+			return err
+		}
+	}
+	w.Flush()
+	return w.Error()
+}
+
+// historySummary sums up the deltas and gas paid by category
+func historySummary(records []*historyRecord) *output.TableMessage {
+	type sum struct {
+		count  int
+		delta  *big.Int
+		gasFee *big.Int
+	}
+	var (
+		sums  = make(map[string]*sum)
+		total = &sum{delta: new(big.Int), gasFee: new(big.Int)}
+	)
+	for _, r := range records {
+		s, ok := sums[r.category]
+		if !ok {
+			s = &sum{delta: new(big.Int), gasFee: new(big.Int)}
+			sums[r.category] = s
+		}
+		for _, s := range []*sum{s, total} {
+			s.count++
+			s.delta.Add(s.delta, r.delta)
+			s.gasFee.Add(s.gasFee, r.gasFee)
+		}
+	}
+	categories := make([]string, 0, len(sums))
+	for category := range sums {
+		categories = append(categories, category)
+	}
+	sort.Strings(categories)
+	sums["total"] = total
+
+	message := &output.TableMessage{Header: []string{"category", "actions", "delta (IOTX)", "gasFee (IOTX)"}}
+	for _, category := range append(categories, "total") {
+		s := sums[category]
+		message.Rows = append(message.Rows,
+			[]string{category, strconv.Itoa(s.count), iotxString(s.delta), iotxString(s.gasFee)})
+	}
+	return message
+}
+
+// iotxString converts the signed amount in Rau to IOTX
+func iotxString(amount *big.Int) string {
+	if amount.Sign() < 0 {
+		return "-" + util.RauToString(new(big.Int).Neg(amount), util.IotxDecimalNum)
+	}
+	return util.RauToString(amount, util.IotxDecimalNum)
+}
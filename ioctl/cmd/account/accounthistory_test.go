@@ -0,0 +1,117 @@
+// Copyright (c) 2022 IoTeX Foundation
+// This is an alpha (internal) release and is not suitable for production. This source code is provided 'as is' and no
+// warranties are given as to title or non-infringement, merchantability or fitness for purpose and, to the extent
+// permitted by law, all liability for your use of the code is disclaimed. This source code is governed by Apache
+// License 2.0 that can be found in the LICENSE file.
+
+package account
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	"github.com/iotexproject/iotex-proto/golang/iotexapi"
+	"github.com/iotexproject/iotex-proto/golang/iotextypes"
+
+	"github.com/iotexproject/iotex-core/test/identityset"
+)
+
+func TestAccountHistory(t *testing.T) {
+	r := require.New(t)
+	addr, other := identityset.Address(0).String(), identityset.Address(1).String()
+	ts := time.Date(2022, 6, 1, 12, 0, 0, 0, time.UTC)
+	transfer := &iotexapi.ActionInfo{
+		Action: &iotextypes.Action{Core: &iotextypes.ActionCore{
+			Action: &iotextypes.ActionCore_Transfer{Transfer: &iotextypes.Transfer{Amount: "2000000000000000000", Recipient: other}},
+		}},
+		ActHash:   "01",
+		BlkHeight: 10,
+		Sender:    addr,
+		GasFee:    "10000000000000000",
+		Timestamp: timestamppb.New(ts),
+	}
+	success := &iotextypes.Receipt{Status: uint64(iotextypes.ReceiptStatus_Success)}
+
+	// the delta is estimated by the action without transaction logs
+	record, err := newHistoryRecord(addr, transfer, success, nil)
+	r.NoError(err)
+	r.Equal(_categoryTransfer, record.category)
+	r.Equal("Transfer", record.actType)
+	r.Equal(other, record.recipient)
+	r.Equal("-2.01", iotxString(record.delta))
+	r.Equal("0.01", iotxString(record.gasFee))
+
+	// the recipient doesn't pay the gas
+	record, err = newHistoryRecord(other, transfer, success, nil)
+	r.NoError(err)
+	r.Equal("2", iotxString(record.delta))
+	r.Zero(record.gasFee.Sign())
+
+	// only the gas is paid for a failed action
+	record, err = newHistoryRecord(addr, transfer, &iotextypes.Receipt{}, nil)
+	r.NoError(err)
+	r.Equal("failure", record.status)
+	r.Equal("-0.01", iotxString(record.delta))
+
+	// the delta is summed from the transaction logs, including the transfers inside contract
+	execution := &iotexapi.ActionInfo{
+		Action: &iotextypes.Action{Core: &iotextypes.ActionCore{
+			Action: &iotextypes.ActionCore_Execution{Execution: &iotextypes.Execution{Amount: "0", Contract: other}},
+		}},
+		Sender:    addr,
+		GasFee:    "10000000000000000",
+		Timestamp: timestamppb.New(ts),
+	}
+	txLog := &iotextypes.TransactionLog{Transactions: []*iotextypes.TransactionLog_Transaction{
+		{Amount: "10000000000000000", Sender: addr, Recipient: "rewarding", Type: iotextypes.TransactionLogType_GAS_FEE},
+		{Amount: "5000000000000000000", Sender: other, Recipient: addr, Type: iotextypes.TransactionLogType_IN_CONTRACT_TRANSFER},
+	}}
+	record, err = newHistoryRecord(addr, execution, success, txLog)
+	r.NoError(err)
+	r.Equal(_categoryContractCall, record.category)
+	r.Equal("4.99", iotxString(record.delta))
+
+	stake := &iotexapi.ActionInfo{
+		Action: &iotextypes.Action{Core: &iotextypes.ActionCore{
+			Action: &iotextypes.ActionCore_StakeCreate{StakeCreate: &iotextypes.StakeCreate{
+				CandidateName: "robotbp00000", StakedAmount: "100000000000000000000"}},
+		}},
+		Sender:    addr,
+		GasFee:    "0",
+		Timestamp: timestamppb.New(ts.Add(48 * time.Hour)),
+	}
+	stakeRecord, err := newHistoryRecord(addr, stake, success, nil)
+	r.NoError(err)
+	r.Equal(_categoryStaking, stakeRecord.category)
+	r.Equal("-100", iotxString(stakeRecord.delta))
+
+	file := filepath.Join(t.TempDir(), "history.csv")
+	r.NoError(writeHistory(file, []*historyRecord{record, stakeRecord}))
+	content, err := os.ReadFile(file)
+	r.NoError(err)
+	lines := strings.Split(strings.TrimSpace(string(content)), "\n")
+	r.Len(lines, 3)
+	r.Equal("2022-06-03T12:00:00Z,0,,staking,StakeCreate,"+addr+",robotbp00000,100,-100,0,success", lines[2])
+
+	summary := historySummary([]*historyRecord{record, stakeRecord})
+	r.Equal([][]string{
+		{_categoryContractCall, "1", "4.99", "0.01"},
+		{_categoryStaking, "1", "-100", "0"},
+		{"total", "2", "-95.01", "0.01"},
+	}, summary.Rows)
+
+	from, to, err := historyDateRange("2022-06-01", "2022-06-02")
+	r.NoError(err)
+	r.Equal(ts.Truncate(24*time.Hour), from)
+	r.Equal(ts.Truncate(24*time.Hour).AddDate(0, 0, 2), to)
+	_, _, err = historyDateRange("2022-06-02", "2022-06-01")
+	r.Error(err)
+	_, _, err = historyDateRange("06/01/2022", "")
+	r.Error(err)
+}
@@ -92,6 +92,10 @@ func init() {
 	ActionCmd.AddCommand(_actionClaimCmd)
 	ActionCmd.AddCommand(_actionDepositCmd)
 	ActionCmd.AddCommand(_actionSendRawCmd)
+	ActionCmd.AddCommand(_actionBuildCmd)
+	ActionCmd.AddCommand(_actionSignCmd)
+	ActionCmd.AddCommand(_actionBatchCmd)
+	ActionCmd.AddCommand(_actionWatchCmd)
 	ActionCmd.PersistentFlags().StringVar(&config.ReadConfig.Endpoint, "endpoint",
 		config.ReadConfig.Endpoint, config.TranslateInLang(_flagActionEndPointUsages,
 			config.UILanguage))
@@ -253,6 +257,9 @@ func SendRaw(selp *iotextypes.Action) error {
 
 // SendAction sends signed action to blockchain
 func SendAction(elp action.Envelope, signer string) error {
+	if _building {
+		return buildAction(elp, signer)
+	}
 	prvKey, err := account.PrivateKeyFromSigner(signer, _passwordFlag.Value().(string))
 	if err != nil {
 		return err
@@ -0,0 +1,541 @@
+// Copyright (c) 2022 IoTeX Foundation
+// This is an alpha (internal) release and is not suitable for production. This source code is provided 'as is' and no
+// warranties are given as to title or non-infringement, merchantability or fitness for purpose and, to the extent
+// permitted by law, all liability for your use of the code is disclaimed. This source code is governed by Apache
+// License 2.0 that can be found in the LICENSE file.
+
+package action
+
+import (
+	"context"
+	"encoding/csv"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"math/big"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/grpc-ecosystem/go-grpc-middleware/util/metautils"
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+	"google.golang.org/grpc/status"
+	"gopkg.in/yaml.v2"
+
+	"github.com/iotexproject/go-pkgs/hash"
+	"github.com/iotexproject/iotex-proto/golang/iotexapi"
+	"github.com/iotexproject/iotex-proto/golang/iotextypes"
+
+	"github.com/iotexproject/iotex-core/action"
+	"github.com/iotexproject/iotex-core/ioctl/cmd/account"
+	"github.com/iotexproject/iotex-core/ioctl/cmd/alias"
+	"github.com/iotexproject/iotex-core/ioctl/cmd/bc"
+	"github.com/iotexproject/iotex-core/ioctl/config"
+	"github.com/iotexproject/iotex-core/ioctl/output"
+	"github.com/iotexproject/iotex-core/ioctl/util"
+	"github.com/iotexproject/iotex-core/ioctl/validator"
+)
+
+// Multi-language support
+var (
+	_batchCmdShorts = map[config.Language]string{
+		config.English: "Send a batch of transfers, xrc20 transfers, contract invokes and stakes from a csv or yaml file",
+		config.Chinese: "从csv或yaml文件批量发送转账、xrc20转账、合约调用和质押",
+	}
+	_batchCmdUses = map[config.Language]string{
+		config.English: "batch FILE [-s SIGNER] [-p GAS_PRICE] [-P PASSWORD] [-y] [--concurrency NUM] [--result RESULT_FILE]",
+		config.Chinese: "batch 文件 [-s 签署人] [-p GAS价格] [-P 密码] [-y] [--concurrency 并发数] [--result 结果文件]",
+	}
+	_flagBatchConcurrencyUsages = map[config.Language]string{
+		config.English: "the number of actions sent concurrently",
+		config.Chinese: "并发发送的行为数量",
+	}
+	_flagBatchResultUsages = map[config.Language]string{
+		config.English: "the csv file to write results into, FILE.result.csv by default",
+		config.Chinese: "写入结果的csv文件，默认为 文件.result.csv",
+	}
+	_flagBatchTimeoutUsages = map[config.Language]string{
+		config.English: "the time to wait for the receipts of the actions sent",
+		config.Chinese: "等待行为回执的时间",
+	}
+)
+
+const (
+	_batchTransfer  = "transfer"
+	_batchXrc20     = "xrc20"
+	_batchInvoke    = "invoke"
+	_batchStake     = "stake"
+	_batchStakeAdd  = "stake-add"
+	_receiptPolling = 2 * time.Second
+)
+
+var (
+	_batchConcurrency    int
+	_batchResultFile     string
+	_batchReceiptTimeout time.Duration
+)
+
+type (
+	// batchEntry is an action of a batch file. The columns of a csv file are named after the yaml keys, and the
+	// fields used by each type are:
+	//   transfer: to, amount (IOTX), data (hex)
+	//   xrc20: contract, to, amount (in token decimals)
+	//   invoke: contract, amount (IOTX), data (hex)
+	//   stake: candidate, amount (IOTX), duration (days), autoStake, data (hex)
+	//   stake-add: bucket, amount (IOTX), data (hex)
+	batchEntry struct {
+		Type      string `yaml:"type"`
+		To        string `yaml:"to"`
+		Amount    string `yaml:"amount"`
+		Data      string `yaml:"data"`
+		Contract  string `yaml:"contract"`
+		Candidate string `yaml:"candidate"`
+		Duration  string `yaml:"duration"`
+		AutoStake string `yaml:"autoStake"`
+		Bucket    string `yaml:"bucket"`
+	}
+
+	// batchPayload is the action built from a batch entry
+	batchPayload interface {
+		Cost() (*big.Int, error)
+		IntrinsicGas() (uint64, error)
+		SetEnvelopeContext(action.Envelope)
+		SanityCheck() error
+		GasLimit() uint64
+	}
+
+	batchResult struct {
+		hash    hash.Hash256
+		err     error
+		receipt *iotextypes.Receipt
+	}
+)
+
+// _actionBatchCmd represents the action batch command
+var _actionBatchCmd = &cobra.Command{
+	Use:   config.TranslateInLang(_batchCmdUses, config.UILanguage),
+	Short: config.TranslateInLang(_batchCmdShorts, config.UILanguage),
+	Args:  cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		cmd.SilenceUsage = true
+		err := batch(args[0])
+		return output.PrintError(err)
+	},
+}
+
+func init() {
+	_gasPriceFlag.RegisterCommand(_actionBatchCmd)
+	_signerFlag.RegisterCommand(_actionBatchCmd)
+	_yesFlag.RegisterCommand(_actionBatchCmd)
+	_passwordFlag.RegisterCommand(_actionBatchCmd)
+	_actionBatchCmd.Flags().IntVar(&_batchConcurrency, "concurrency", 4,
+		config.TranslateInLang(_flagBatchConcurrencyUsages, config.UILanguage))
+	_actionBatchCmd.Flags().StringVar(&_batchResultFile, "result", "",
+		config.TranslateInLang(_flagBatchResultUsages, config.UILanguage))
+	_actionBatchCmd.Flags().DurationVar(&_batchReceiptTimeout, "receipt-timeout", time.Minute,
+		config.TranslateInLang(_flagBatchTimeoutUsages, config.UILanguage))
+}
+
+func (e *batchEntry) fields() map[string]*string {
+	return map[string]*string{
+		"type":      &e.Type,
+		"to":        &e.To,
+		"amount":    &e.Amount,
+		"data":      &e.Data,
+		"contract":  &e.Contract,
+		"candidate": &e.Candidate,
+		"duration":  &e.Duration,
+		"autostake": &e.AutoStake,
+		"bucket":    &e.Bucket,
+	}
+}
+
+func batch(file string) error {
+	entries, err := loadBatch(file)
+	if err != nil {
+		return output.NewError(output.ReadFileError, "failed to load batch file", err)
+	}
+	if len(entries) == 0 {
+		return output.NewError(output.InputError, "no action in batch file", nil)
+	}
+	signer, err := Signer()
+	if err != nil {
+		return output.NewError(output.AddressError, "failed to get signer address", err)
+	}
+	prvKey, err := account.PrivateKeyFromSigner(signer, _passwordFlag.Value().(string))
+	if err != nil {
+		return err
+	}
+	defer prvKey.Zero()
+	signer = prvKey.PublicKey().Address().String()
+
+	accountMeta, err := account.GetAccountMeta(signer)
+	if err != nil {
+		return output.NewError(0, "failed to get account meta", err)
+	}
+	chainMeta, err := bc.GetChainMeta()
+	if err != nil {
+		return output.NewError(0, "failed to get chain meta", err)
+	}
+	gasPrice, err := gasPriceInRau()
+	if err != nil {
+		return output.NewError(0, "failed to get gas price", err)
+	}
+
+	// the actions take the sequential nonces following the pending nonce
+	var (
+		selps = make([]action.SealedEnvelope, len(entries))
+		cost  = big.NewInt(0)
+	)
+	for i, e := range entries {
+		elp, err := buildBatchAction(signer, e, accountMeta.PendingNonce+uint64(i), gasPrice)
+		if err != nil {
+			return output.NewError(0, fmt.Sprintf("failed to build action #%d", i+1), err)
+		}
+		elp.SetChainID(chainMeta.GetChainID())
+		if selps[i], err = action.Sign(elp, prvKey); err != nil {
+			return output.NewError(output.CryptoError, "failed to sign action", err)
+		}
+		c, err := elp.Cost()
+		if err != nil {
+			return output.NewError(output.RuntimeError, "failed to check cost of an action", err)
+		}
+		cost.Add(cost, c)
+	}
+	balance, ok := new(big.Int).SetString(accountMeta.Balance, 10)
+	if !ok {
+		return output.NewError(output.ConvertError, "failed to convert balance into big int", nil)
+	}
+	if balance.Cmp(cost) < 0 {
+		return output.NewError(output.ValidationError, fmt.Sprintf("balance %s IOTX is not enough for %s IOTX",
+			util.RauToString(balance, util.IotxDecimalNum), util.RauToString(cost, util.IotxDecimalNum)), nil)
+	}
+
+	if _yesFlag.Value() == false {
+		var confirm string
+		info := fmt.Sprintln(batchSummary(signer, entries, selps, cost) + "\nPlease confirm your actions.\n")
+		message := output.ConfirmationMessage{Info: info, Options: []string{"yes"}}
+		fmt.Println(message.String())
+		if _, err := fmt.Scanf("%s", &confirm); err != nil {
+			return output.NewError(output.InputError, "failed to input yes", err)
+		}
+		if !strings.EqualFold(confirm, "yes") {
+			output.PrintResult("quit")
+			return nil
+		}
+	}
+
+	conn, err := util.ConnectToEndpoint(config.ReadConfig.SecureConnect && !config.Insecure)
+	if err != nil {
+		return output.NewError(output.NetworkError, "failed to connect to endpoint", err)
+	}
+	defer conn.Close()
+	cli := iotexapi.NewAPIServiceClient(conn)
+	ctx := context.Background()
+	if jwtMD, err := util.JwtAuth(); err == nil {
+		ctx = metautils.NiceMD(jwtMD).ToOutgoing(ctx)
+	}
+	results := sendBatch(ctx, cli, selps, _batchConcurrency)
+	waitBatchReceipts(ctx, cli, results, _batchReceiptTimeout)
+
+	resultFile := _batchResultFile
+	if resultFile == "" {
+		resultFile = file + ".result.csv"
+	}
+	if err := writeBatchResults(resultFile, entries, selps, results); err != nil {
+		return output.NewError(output.WriteFileError, "failed to write results", err)
+	}
+	var sent, succeeded, pending int
+	for _, r := range results {
+		if r.err == nil {
+			sent++
+		}
+		if r.receipt == nil {
+			if r.err == nil {
+				pending++
+			}
+			continue
+		}
+		if r.receipt.Status == uint64(iotextypes.ReceiptStatus_Success) {
+			succeeded++
+		}
+	}
+	output.PrintResult(fmt.Sprintf("%d of %d actions have been sent, %d succeeded, %d failed, %d pending. "+
+		"Results have been written into %s.", sent, len(selps), succeeded, sent-succeeded-pending, pending, resultFile))
+	return nil
+}
+
+// loadBatch loads the actions from a csv file with a header row, or a yaml file with a list of actions
+func loadBatch(file string) ([]*batchEntry, error) {
+	f, err := os.Open(filepath.Clean(file))
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	var entries []*batchEntry
+	switch strings.ToLower(filepath.Ext(file)) {
+	case ".yaml", ".yml":
+		data, err := io.ReadAll(f)
+		if err != nil {
+			return nil, err
+		}
+		if err := yaml.Unmarshal(data, &entries); err != nil {
+			return nil, err
+		}
+	case ".csv":
+		rows, err := csv.NewReader(f).ReadAll()
+		if err != nil {
+			return nil, err
+		}
+		if len(rows) == 0 {
+			return nil, nil
+		}
+		header := rows[0]
+		for _, row := range rows[1:] {
+			e := &batchEntry{}
+			fields := e.fields()
+			for i, column := range header {
+				field, ok := fields[strings.ToLower(strings.TrimSpace(column))]
+				if !ok {
+					return nil, errors.Errorf("unknown column %s", column)
+				}
+				*field = strings.TrimSpace(row[i])
+			}
+			entries = append(entries, e)
+		}
+	default:
+		return nil, errors.Errorf("unsupported file type %s, csv or yaml expected", filepath.Ext(file))
+	}
+	return entries, nil
+}
+
+// buildBatchAction builds an action of the batch, whose gas limit is estimated
+func buildBatchAction(signer string, e *batchEntry, nonce uint64, gasPrice *big.Int) (action.Envelope, error) {
+	var (
+		amount  = big.NewInt(0)
+		payload []byte
+		err     error
+	)
+	if e.Amount != "" && e.Type != _batchXrc20 {
+		if amount, err = util.StringToRau(e.Amount, util.IotxDecimalNum); err != nil {
+			return nil, output.NewError(output.ConvertError, "invalid amount", err)
+		}
+	}
+	if e.Data != "" {
+		if payload, err = hex.DecodeString(util.TrimHexPrefix(e.Data)); err != nil {
+			return nil, output.NewError(output.ConvertError, "failed to decode data", err)
+		}
+	}
+
+	var act batchPayload
+	switch e.Type {
+	case _batchTransfer:
+		recipient, err := util.Address(e.To)
+		if err != nil {
+			return nil, output.NewError(output.AddressError, "failed to get recipient address", err)
+		}
+		act, err = action.NewTransfer(nonce, amount, recipient, payload, 0, gasPrice)
+		if err != nil {
+			return nil, output.NewError(output.InstantiationError, "failed to make a Transfer instance", err)
+		}
+	case _batchXrc20:
+		contract, err := alias.IOAddress(e.Contract)
+		if err != nil {
+			return nil, output.NewError(output.AddressError, "failed to get contract address", err)
+		}
+		recipient, err := alias.EtherAddress(e.To)
+		if err != nil {
+			return nil, output.NewError(output.AddressError, "failed to get recipient address", err)
+		}
+		tokens, err := parseAmount(contract, e.Amount)
+		if err != nil {
+			return nil, output.NewError(0, "failed to parse amount", err)
+		}
+		if payload, err = _xrc20ABI.Pack("transfer", recipient, tokens); err != nil {
+			return nil, output.NewError(output.ConvertError, "cannot generate bytecode from given command", err)
+		}
+		if act, err = estimateBatchExecution(signer, contract.String(), nonce, amount, gasPrice, payload); err != nil {
+			return nil, err
+		}
+	case _batchInvoke:
+		contract, err := alias.IOAddress(e.Contract)
+		if err != nil {
+			return nil, output.NewError(output.AddressError, "failed to get contract address", err)
+		}
+		if act, err = estimateBatchExecution(signer, contract.String(), nonce, amount, gasPrice, payload); err != nil {
+			return nil, err
+		}
+	case _batchStake:
+		if err := validator.ValidateCandidateNameForStake2(e.Candidate); err != nil {
+			return nil, output.NewError(output.ValidationError, "invalid candidate name", err)
+		}
+		duration, err := parseStakeDuration(e.Duration)
+		if err != nil {
+			return nil, err
+		}
+		var autoStake bool
+		if e.AutoStake != "" {
+			if autoStake, err = strconv.ParseBool(e.AutoStake); err != nil {
+				return nil, output.NewError(output.ConvertError, "invalid auto stake", err)
+			}
+		}
+		act, err = action.NewCreateStake(nonce, e.Candidate, amount.String(), uint32(duration.Uint64()), autoStake,
+			payload, 0, gasPrice)
+		if err != nil {
+			return nil, output.NewError(output.InstantiationError, "failed to make a createStake instance", err)
+		}
+	case _batchStakeAdd:
+		bucket, err := strconv.ParseUint(e.Bucket, 10, 64)
+		if err != nil {
+			return nil, output.NewError(output.ConvertError, "invalid bucket index", err)
+		}
+		act, err = action.NewDepositToStake(nonce, bucket, amount.String(), payload, 0, gasPrice)
+		if err != nil {
+			return nil, output.NewError(output.InstantiationError, "failed to make a depositToStake instance", err)
+		}
+	default:
+		return nil, output.NewError(output.InputError, fmt.Sprintf("unknown action type %s", e.Type), nil)
+	}
+
+	gasLimit := act.GasLimit()
+	if gasLimit == 0 {
+		// the gas of a transfer or a stake is the intrinsic gas
+		if gasLimit, err = act.IntrinsicGas(); err != nil {
+			return nil, output.NewError(output.RuntimeError, "failed to get intrinsic gas", err)
+		}
+	}
+	return (&action.EnvelopeBuilder{}).
+		SetNonce(nonce).
+		SetGasPrice(gasPrice).
+		SetGasLimit(gasLimit).
+		SetAction(act).Build(), nil
+}
+
+func estimateBatchExecution(signer, contract string, nonce uint64, amount *big.Int, gasPrice *big.Int, data []byte) (*action.Execution, error) {
+	tx, err := action.NewExecution(contract, nonce, amount, 0, gasPrice, data)
+	if err != nil {
+		return nil, output.NewError(output.InstantiationError, "failed to make a Execution instance", err)
+	}
+	if tx, err = fixGasLimit(signer, tx); err != nil {
+		return nil, output.NewError(0, "failed to estimate gas of Execution", err)
+	}
+	return tx, nil
+}
+
+func batchSummary(signer string, entries []*batchEntry, selps []action.SealedEnvelope, cost *big.Int) string {
+	var (
+		result string
+		counts = map[string]int{}
+		types  []string
+	)
+	for i, e := range entries {
+		if counts[e.Type] == 0 {
+			types = append(types, e.Type)
+		}
+		counts[e.Type]++
+		dest, _ := selps[i].Destination()
+		result += fmt.Sprintf("#%d %s nonce: %d to: %s amount: %s gasLimit: %d\n",
+			i+1, e.Type, selps[i].Nonce(), dest, e.Amount, selps[i].GasLimit())
+	}
+	result += fmt.Sprintf("\nsigner: %s\nactions:", signer)
+	for _, t := range types {
+		result += fmt.Sprintf(" %d %s", counts[t], t)
+	}
+	result += fmt.Sprintf("\nmax total cost: %s IOTX", util.RauToString(cost, util.IotxDecimalNum))
+	return result
+}
+
+// sendBatch sends the actions with at most concurrency actions being sent at the same time
+func sendBatch(ctx context.Context, cli iotexapi.APIServiceClient, selps []action.SealedEnvelope, concurrency int) []*batchResult {
+	if concurrency <= 0 {
+		concurrency = 1
+	}
+	var (
+		results = make([]*batchResult, len(selps))
+		tasks   = make(chan int, len(selps))
+		wg      sync.WaitGroup
+	)
+	for i := range selps {
+		tasks <- i
+	}
+	close(tasks)
+	for i := 0; i < concurrency; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range tasks {
+				r := &batchResult{}
+				results[i] = r
+				if r.hash, r.err = selps[i].Hash(); r.err != nil {
+					continue
+				}
+				if _, err := cli.SendAction(ctx, &iotexapi.SendActionRequest{Action: selps[i].Proto()}); err != nil {
+					if sta, ok := status.FromError(err); ok {
+						err = errors.New(sta.Message())
+					}
+					r.err = err
+				}
+			}
+		}()
+	}
+	wg.Wait()
+	return results
+}
+
+// waitBatchReceipts polls the receipts of the actions sent until all of them are found or timeout
+func waitBatchReceipts(ctx context.Context, cli iotexapi.APIServiceClient, results []*batchResult, timeout time.Duration) {
+	deadline := time.Now().Add(timeout)
+	for {
+		pending := 0
+		for _, r := range results {
+			if r.err != nil || r.receipt != nil {
+				continue
+			}
+			res, err := cli.GetReceiptByAction(ctx, &iotexapi.GetReceiptByActionRequest{ActionHash: hex.EncodeToString(r.hash[:])})
+			if err != nil {
+				// the action is not yet in a block, or the endpoint is temporarily unavailable
+				pending++
+				continue
+			}
+			r.receipt = res.ReceiptInfo.Receipt
+		}
+		if pending == 0 || time.Now().After(deadline) {
+			return
+		}
+		time.Sleep(_receiptPolling)
+	}
+}
+
+func writeBatchResults(file string, entries []*batchEntry, selps []action.SealedEnvelope, results []*batchResult) error {
+	f, err := os.Create(filepath.Clean(file))
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+	w := csv.NewWriter(f)
+	if err := w.Write([]string{"index", "type", "nonce", "hash", "status", "gasConsumed", "error"}); err != nil {
+		return err
+	}
+	for i, r := range results {
+		row := []string{strconv.Itoa(i + 1), entries[i].Type, strconv.FormatUint(selps[i].Nonce(), 10),
+			hex.EncodeToString(r.hash[:])}
+		switch {
+		case r.err != nil:
+			row = append(row, "unsent", "", r.err.Error())
+		case r.receipt == nil:
+			row = append(row, "pending", "", "")
+		default:
+			st := strconv.FormatUint(r.receipt.Status, 10)
+			row = append(row, st+" "+Match(st, "status"), strconv.FormatUint(r.receipt.GasConsumed, 10), "")
+		}
+		if err := w.Write(row); err != nil {
+			return err
+		}
+	}
+	w.Flush()
+	return w.Error()
+}
@@ -0,0 +1,81 @@
+// Copyright (c) 2022 IoTeX Foundation
+// This is an alpha (internal) release and is not suitable for production. This source code is provided 'as is' and no
+// warranties are given as to title or non-infringement, merchantability or fitness for purpose and, to the extent
+// permitted by law, all liability for your use of the code is disclaimed. This source code is governed by Apache
+// License 2.0 that can be found in the LICENSE file.
+
+package action
+
+import (
+	"math/big"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/iotexproject/iotex-core/action"
+	"github.com/iotexproject/iotex-core/test/identityset"
+)
+
+func TestLoadBatch(t *testing.T) {
+	require := require.New(t)
+	dir := t.TempDir()
+	recipient := identityset.Address(28).String()
+	expected := []*batchEntry{
+		{Type: "transfer", To: recipient, Amount: "1.5", Data: "0x01"},
+		{Type: "stake", Candidate: "robotbp00000", Amount: "100", Duration: "91", AutoStake: "true"},
+		{Type: "stake-add", Bucket: "7", Amount: "10"},
+	}
+
+	csvFile := filepath.Join(dir, "batch.csv")
+	require.NoError(os.WriteFile(csvFile, []byte("type,to,amount,data,candidate,duration,autoStake,bucket\n"+
+		"transfer,"+recipient+",1.5,0x01,,,,\n"+
+		"stake,,100,,robotbp00000,91,true,\n"+
+		"stake-add,,10,,,,,7\n"), 0600))
+	entries, err := loadBatch(csvFile)
+	require.NoError(err)
+	require.Equal(expected, entries)
+
+	yamlFile := filepath.Join(dir, "batch.yaml")
+	require.NoError(os.WriteFile(yamlFile, []byte("- type: transfer\n  to: "+recipient+"\n  amount: 1.5\n  data: 0x01\n"+
+		"- type: stake\n  candidate: robotbp00000\n  amount: 100\n  duration: 91\n  autoStake: true\n"+
+		"- type: stake-add\n  bucket: 7\n  amount: 10\n"), 0600))
+	entries, err = loadBatch(yamlFile)
+	require.NoError(err)
+	require.Equal(expected, entries)
+
+	require.NoError(os.WriteFile(csvFile, []byte("type,recipient\ntransfer,"+recipient+"\n"), 0600))
+	_, err = loadBatch(csvFile)
+	require.Error(err)
+	_, err = loadBatch(filepath.Join(dir, "batch.txt"))
+	require.Error(err)
+
+	// the actions not calling contracts are built without connecting to blockchain
+	gasPrice := big.NewInt(1000)
+	for i, e := range expected {
+		elp, err := buildBatchAction(identityset.Address(0).String(), e, uint64(10+i), gasPrice)
+		require.NoError(err)
+		require.EqualValues(10+i, elp.Nonce())
+		require.Equal(gasPrice, elp.GasPrice())
+		intrinsicGas, err := elp.IntrinsicGas()
+		require.NoError(err)
+		require.Equal(intrinsicGas, elp.GasLimit())
+		switch act := elp.Action().(type) {
+		case *action.Transfer:
+			require.Equal(recipient, act.Recipient())
+			require.Equal("1500000000000000000", act.Amount().String())
+			require.Equal([]byte{1}, act.Payload())
+		case *action.CreateStake:
+			require.Equal("robotbp00000", act.Candidate())
+			require.EqualValues(91, act.Duration())
+			require.True(act.AutoStake())
+		case *action.DepositToStake:
+			require.EqualValues(7, act.BucketIndex())
+		default:
+			require.FailNow("unexpected action")
+		}
+	}
+	_, err = buildBatchAction(identityset.Address(0).String(), &batchEntry{Type: "vote"}, 1, gasPrice)
+	require.Error(err)
+}
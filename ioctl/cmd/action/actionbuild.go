@@ -0,0 +1,118 @@
+// Copyright (c) 2022 IoTeX Foundation
+// This is an alpha (internal) release and is not suitable for production. This source code is provided 'as is' and no
+// warranties are given as to title or non-infringement, merchantability or fitness for purpose and, to the extent
+// permitted by law, all liability for your use of the code is disclaimed. This source code is governed by Apache
+// License 2.0 that can be found in the LICENSE file.
+
+package action
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+
+	"github.com/iotexproject/iotex-core/action"
+	"github.com/iotexproject/iotex-core/ioctl/cmd/bc"
+	"github.com/iotexproject/iotex-core/ioctl/config"
+	"github.com/iotexproject/iotex-core/ioctl/output"
+	"github.com/iotexproject/iotex-core/ioctl/util"
+)
+
+// Multi-language support
+var (
+	_buildCmdShorts = map[config.Language]string{
+		config.English: "Build an unsigned action into a bundle, to be signed offline by \"action sign\"",
+		config.Chinese: "构建未签名的行为并存入文件，以便通过\"action sign\"离线签名",
+	}
+	_buildCmdUses = map[config.Language]string{
+		config.English: "build [-f BUNDLE] COMMAND [ARGS] -s SIGNER_ADDRESS [-n NONCE] [-l GAS_LIMIT] [-p GAS_PRICE]",
+		config.Chinese: "build [-f 文件] 命令 [参数] -s 签署人地址 [-n NONCE] [-l GAS限制] [-p GAS价格]",
+	}
+	_flagBundleUsages = map[config.Language]string{
+		config.English: "the bundle file which the action is added into",
+		config.Chinese: "存入行为的文件",
+	}
+)
+
+var (
+	_bundleFile string
+	// _building is set by the build command, such that the action is added into the bundle instead of being signed
+	// and sent
+	_building bool
+)
+
+// _actionBuildCmd represents the action build command, which builds the action of another command, e.g.,
+// "ioctl action build transfer", "ioctl action build stake2 create" or "ioctl action build xrc20 transfer"
+var _actionBuildCmd = &cobra.Command{
+	Use:   config.TranslateInLang(_buildCmdUses, config.UILanguage),
+	Short: config.TranslateInLang(_buildCmdShorts, config.UILanguage),
+	Args:  cobra.MinimumNArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		cmd.SilenceUsage = true
+		err := build(args)
+		return output.PrintError(err)
+	},
+}
+
+func init() {
+	_actionBuildCmd.Flags().StringVarP(&_bundleFile, "bundle", "f", "bundle.json",
+		config.TranslateInLang(_flagBundleUsages, config.UILanguage))
+	// the flags following the command are the flags of the command
+	_actionBuildCmd.Flags().SetInterspersed(false)
+}
+
+func build(args []string) error {
+	parent, args := ActionCmd, args
+	for _, cmd := range []*cobra.Command{Stake2Cmd, Xrc20Cmd} {
+		if args[0] == cmd.Name() {
+			parent, args = cmd, args[1:]
+		}
+	}
+	cmd, args, err := parent.Find(args)
+	if err != nil || cmd == parent || !cmd.Runnable() {
+		return output.NewError(output.InputError, "unknown command to build", err)
+	}
+	if err := cmd.ParseFlags(args); err != nil {
+		return output.NewError(output.FlagError, "failed to parse flags", err)
+	}
+	args = cmd.Flags().Args()
+	if err := cmd.ValidateArgs(args); err != nil {
+		return output.NewError(output.InputError, "invalid arguments", err)
+	}
+	_building = true
+	return cmd.RunE(cmd, args)
+}
+
+// buildAction adds the action into the bundle instead of signing and sending it
+func buildAction(elp action.Envelope, signer string) error {
+	if util.AliasIsHdwalletKey(signer) {
+		return output.NewError(output.InputError, "use the address of HDWallet key as signer to build action", nil)
+	}
+	chainMeta, err := bc.GetChainMeta()
+	if err != nil {
+		return output.NewError(0, "failed to get chain meta", err)
+	}
+	elp.SetChainID(chainMeta.GetChainID())
+
+	bundle, err := loadBundle(_bundleFile)
+	if err != nil {
+		return output.NewError(output.ReadFileError, "failed to load bundle", err)
+	}
+	if _nonceFlag.Value().(uint64) == 0 {
+		// the pending nonce doesn't count the actions built but not sent yet
+		nonce, err := bundle.nextNonce(signer, elp.Nonce())
+		if err != nil {
+			return output.NewError(output.SerializationError, "invalid bundle", err)
+		}
+		elp.SetNonce(nonce)
+	}
+	if err := bundle.add(signer, elp); err != nil {
+		return output.NewError(output.SerializationError, "failed to add action into bundle", err)
+	}
+	if err := bundle.save(_bundleFile); err != nil {
+		return output.NewError(output.WriteFileError, "failed to save bundle", err)
+	}
+	output.PrintResult(fmt.Sprintf("Action with nonce %d has been added into %s, to be signed by %s.",
+		elp.Nonce(), _bundleFile, signer))
+	return nil
+}
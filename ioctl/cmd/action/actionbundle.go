@@ -0,0 +1,172 @@
+// Copyright (c) 2022 IoTeX Foundation
+// This is an alpha (internal) release and is not suitable for production. This source code is provided 'as is' and no
+// warranties are given as to title or non-infringement, merchantability or fitness for purpose and, to the extent
+// permitted by law, all liability for your use of the code is disclaimed. This source code is governed by Apache
+// License 2.0 that can be found in the LICENSE file.
+
+package action
+
+import (
+	"encoding/hex"
+	"encoding/json"
+	"os"
+	"path/filepath"
+
+	"github.com/pkg/errors"
+	"google.golang.org/protobuf/proto"
+
+	"github.com/iotexproject/go-pkgs/crypto"
+	"github.com/iotexproject/iotex-proto/golang/iotextypes"
+
+	"github.com/iotexproject/iotex-core/action"
+	"github.com/iotexproject/iotex-core/pkg/util/fileutil"
+)
+
+type (
+	// bundleAction is an unsigned action of a bundle, with the signature of its signer once approved
+	bundleAction struct {
+		Signer    string `json:"signer"`
+		Envelope  string `json:"envelope"`
+		PublicKey string `json:"publicKey,omitempty"`
+		Signature string `json:"signature,omitempty"`
+	}
+
+	// actionBundle is a set of actions built online by "action build", which are signed offline by "action sign"
+	// independently by the approvers, and sent by "action sendraw" once all of them are signed
+	actionBundle struct {
+		Actions []*bundleAction `json:"actions"`
+	}
+)
+
+// loadBundle loads a bundle from file, the bundle is empty if the file doesn't exist
+func loadBundle(path string) (*actionBundle, error) {
+	bundle := &actionBundle{}
+	if !fileutil.FileExists(path) {
+		return bundle, nil
+	}
+	data, err := os.ReadFile(filepath.Clean(path))
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to read bundle %s", path)
+	}
+	if err := json.Unmarshal(data, bundle); err != nil {
+		return nil, errors.Wrapf(err, "failed to unmarshal bundle %s", path)
+	}
+	return bundle, nil
+}
+
+func (b *actionBundle) save(path string) error {
+	data, err := json.MarshalIndent(b, "", "  ")
+	if err != nil {
+		return errors.Wrap(err, "failed to marshal bundle")
+	}
+	return os.WriteFile(path, data, 0600)
+}
+
+// nextNonce returns the nonce following the actions of the signer in the bundle, if the given nonce is used
+func (b *actionBundle) nextNonce(signer string, nonce uint64) (uint64, error) {
+	for _, act := range b.Actions {
+		if act.Signer != signer {
+			continue
+		}
+		elp, err := act.envelope()
+		if err != nil {
+			return 0, err
+		}
+		if elp.Nonce() >= nonce {
+			nonce = elp.Nonce() + 1
+		}
+	}
+	return nonce, nil
+}
+
+func (b *actionBundle) add(signer string, elp action.Envelope) error {
+	data, err := proto.Marshal(elp.Proto())
+	if err != nil {
+		return errors.Wrap(err, "failed to serialize action")
+	}
+	b.Actions = append(b.Actions, &bundleAction{
+		Signer:   signer,
+		Envelope: hex.EncodeToString(data),
+	})
+	return nil
+}
+
+// sign signs the actions of the signer not signed yet, and returns them
+func (b *actionBundle) sign(signer string, sk crypto.PrivateKey) ([]*iotextypes.Action, error) {
+	var signed []*iotextypes.Action
+	for _, act := range b.Actions {
+		if act.Signer != signer || act.Signature != "" {
+			continue
+		}
+		elp, err := act.envelope()
+		if err != nil {
+			return nil, err
+		}
+		sealed, err := action.Sign(elp, sk)
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to sign action")
+		}
+		act.PublicKey = sealed.SrcPubkey().HexString()
+		act.Signature = hex.EncodeToString(sealed.Signature())
+		signed = append(signed, sealed.Proto())
+	}
+	return signed, nil
+}
+
+// pending returns the signers whose approvals are pending
+func (b *actionBundle) pending() []string {
+	var (
+		signers []string
+		seen    = map[string]bool{}
+	)
+	for _, act := range b.Actions {
+		if act.Signature == "" && !seen[act.Signer] {
+			seen[act.Signer] = true
+			signers = append(signers, act.Signer)
+		}
+	}
+	return signers
+}
+
+// sealed returns the actions signed, after verifying each one is signed by its signer
+func (b *actionBundle) sealed() ([]*iotextypes.Action, error) {
+	if signers := b.pending(); len(signers) > 0 {
+		return nil, errors.Errorf("the bundle is pending on the approvals of %v", signers)
+	}
+	selps := make([]*iotextypes.Action, 0, len(b.Actions))
+	for i, act := range b.Actions {
+		elp, err := act.envelope()
+		if err != nil {
+			return nil, err
+		}
+		pk, err := crypto.HexStringToPublicKey(act.PublicKey)
+		if err != nil {
+			return nil, errors.Wrapf(err, "invalid public key of action %d", i)
+		}
+		sig, err := hex.DecodeString(act.Signature)
+		if err != nil {
+			return nil, errors.Wrapf(err, "invalid signature of action %d", i)
+		}
+		sealed := action.AssembleSealedEnvelope(elp, pk, sig)
+		if err := sealed.VerifySignature(); err != nil {
+			return nil, errors.Wrapf(err, "failed to verify signature of action %d", i)
+		}
+		if sender := sealed.SenderAddress().String(); sender != act.Signer {
+			return nil, errors.Errorf("action %d is signed by %s instead of %s", i, sender, act.Signer)
+		}
+		selps = append(selps, sealed.Proto())
+	}
+	return selps, nil
+}
+
+func (act *bundleAction) envelope() (action.Envelope, error) {
+	data, err := hex.DecodeString(act.Envelope)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to decode action")
+	}
+	core := &iotextypes.ActionCore{}
+	if err := proto.Unmarshal(data, core); err != nil {
+		return nil, errors.Wrap(err, "failed to deserialize action")
+	}
+	return action.EnvelopeFromProto(core)
+}
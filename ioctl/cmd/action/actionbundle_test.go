@@ -0,0 +1,74 @@
+// Copyright (c) 2022 IoTeX Foundation
+// This is an alpha (internal) release and is not suitable for production. This source code is provided 'as is' and no
+// warranties are given as to title or non-infringement, merchantability or fitness for purpose and, to the extent
+// permitted by law, all liability for your use of the code is disclaimed. This source code is governed by Apache
+// License 2.0 that can be found in the LICENSE file.
+
+package action
+
+import (
+	"math/big"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/iotexproject/iotex-core/action"
+	"github.com/iotexproject/iotex-core/test/identityset"
+)
+
+func TestActionBundle(t *testing.T) {
+	require := require.New(t)
+	file := filepath.Join(t.TempDir(), "bundle.json")
+	bundle, err := loadBundle(file)
+	require.NoError(err)
+	require.Empty(bundle.Actions)
+
+	// the treasury approvers 0 and 1 have 2 and 1 transfers to approve respectively
+	signers := []string{identityset.Address(0).String(), identityset.Address(0).String(), identityset.Address(1).String()}
+	for _, signer := range signers {
+		tsf, err := action.NewTransfer(0, big.NewInt(10), identityset.Address(28).String(), nil, 10000, big.NewInt(1))
+		require.NoError(err)
+		elp := (&action.EnvelopeBuilder{}).SetNonce(5).SetGasLimit(10000).SetGasPrice(big.NewInt(1)).
+			SetChainID(2).SetAction(tsf).Build()
+		nonce, err := bundle.nextNonce(signer, elp.Nonce())
+		require.NoError(err)
+		elp.SetNonce(nonce)
+		require.NoError(bundle.add(signer, elp))
+	}
+	require.NoError(bundle.save(file))
+	require.Equal(signers[1:], bundle.pending())
+
+	// each approver signs the bundle independently
+	bundle, err = loadBundle(file)
+	require.NoError(err)
+	signed, err := bundle.sign(signers[0], identityset.PrivateKey(0))
+	require.NoError(err)
+	require.Len(signed, 2)
+	require.EqualValues(5, signed[0].Core.Nonce)
+	require.EqualValues(6, signed[1].Core.Nonce)
+	require.EqualValues(2, signed[1].Core.ChainID)
+	signed, err = bundle.sign(signers[0], identityset.PrivateKey(0))
+	require.NoError(err)
+	require.Empty(signed)
+	require.Equal(signers[2:], bundle.pending())
+	_, err = bundle.sealed()
+	require.Error(err)
+
+	signed, err = bundle.sign(signers[2], identityset.PrivateKey(1))
+	require.NoError(err)
+	require.Len(signed, 1)
+	require.EqualValues(5, signed[0].Core.Nonce)
+	require.NoError(bundle.save(file))
+	bundle, err = loadBundle(file)
+	require.NoError(err)
+	require.Empty(bundle.pending())
+	selps, err := bundle.sealed()
+	require.NoError(err)
+	require.Len(selps, 3)
+
+	// a signature of another key is rejected
+	bundle.Actions[2].PublicKey = identityset.PrivateKey(2).PublicKey().HexString()
+	_, err = bundle.sealed()
+	require.Error(err)
+}
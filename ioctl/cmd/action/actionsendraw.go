@@ -16,6 +16,7 @@ import (
 
 	"github.com/iotexproject/iotex-core/ioctl/config"
 	"github.com/iotexproject/iotex-core/ioctl/output"
+	"github.com/iotexproject/iotex-core/pkg/util/fileutil"
 )
 
 // Multi-language support
@@ -25,8 +26,8 @@ var (
 		config.Chinese: "在IoTeX区块链上发送原始行为",
 	}
 	_sendRawCmdUses = map[config.Language]string{
-		config.English: "sendraw DATA [-s SIGNER] [-n NONCE] [-l GAS_LIMIT] [-p GAS_PRICE] [-P PASSWORD] [-y]",
-		config.Chinese: "sendraw 数据 [-s 签署人] [-n NONCE] [-l GAS限制] [-p GAS价格] [-P 密码] [-y]",
+		config.English: "sendraw (DATA|BUNDLE) [-s SIGNER] [-n NONCE] [-l GAS_LIMIT] [-p GAS_PRICE] [-P PASSWORD] [-y]",
+		config.Chinese: "sendraw (数据|文件) [-s 签署人] [-n NONCE] [-l GAS限制] [-p GAS价格] [-P 密码] [-y]",
 	}
 )
 
@@ -47,6 +48,9 @@ func init() {
 }
 
 func sendRaw(arg string) error {
+	if fileutil.FileExists(arg) {
+		return sendBundle(arg)
+	}
 	actBytes, err := hex.DecodeString(arg)
 	if err != nil {
 		return output.NewError(output.ConvertError, "failed to decode data", err)
@@ -57,3 +61,21 @@ func sendRaw(arg string) error {
 	}
 	return SendRaw(act)
 }
+
+// sendBundle sends the actions of a bundle once all of them are signed
+func sendBundle(file string) error {
+	bundle, err := loadBundle(file)
+	if err != nil {
+		return output.NewError(output.ReadFileError, "failed to load bundle", err)
+	}
+	selps, err := bundle.sealed()
+	if err != nil {
+		return output.NewError(output.ValidationError, "failed to validate bundle", err)
+	}
+	for _, selp := range selps {
+		if err := SendRaw(selp); err != nil {
+			return err
+		}
+	}
+	return nil
+}
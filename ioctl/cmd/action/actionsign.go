@@ -0,0 +1,117 @@
+// Copyright (c) 2022 IoTeX Foundation
+// This is an alpha (internal) release and is not suitable for production. This source code is provided 'as is' and no
+// warranties are given as to title or non-infringement, merchantability or fitness for purpose and, to the extent
+// permitted by law, all liability for your use of the code is disclaimed. This source code is governed by Apache
+// License 2.0 that can be found in the LICENSE file.
+
+package action
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/iotexproject/iotex-core/ioctl/cmd/account"
+	"github.com/iotexproject/iotex-core/ioctl/config"
+	"github.com/iotexproject/iotex-core/ioctl/output"
+	"github.com/iotexproject/iotex-core/ioctl/util"
+	"github.com/iotexproject/iotex-core/pkg/util/fileutil"
+)
+
+// Multi-language support
+var (
+	_signCmdShorts = map[config.Language]string{
+		config.English: "Sign the actions of signer in a bundle built by \"action build\", without connecting to blockchain",
+		config.Chinese: "离线签署\"action build\"构建的文件中属于签署人的行为",
+	}
+	_signCmdUses = map[config.Language]string{
+		config.English: "sign BUNDLE [-s SIGNER] [-P PASSWORD] [-y]",
+		config.Chinese: "sign 文件 [-s 签署人] [-P 密码] [-y]",
+	}
+)
+
+// _actionSignCmd represents the action sign command
+var _actionSignCmd = &cobra.Command{
+	Use:   config.TranslateInLang(_signCmdUses, config.UILanguage),
+	Short: config.TranslateInLang(_signCmdShorts, config.UILanguage),
+	Args:  cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		cmd.SilenceUsage = true
+		err := sign(args[0])
+		return output.PrintError(err)
+	},
+}
+
+func init() {
+	_signerFlag.RegisterCommand(_actionSignCmd)
+	_passwordFlag.RegisterCommand(_actionSignCmd)
+	_yesFlag.RegisterCommand(_actionSignCmd)
+}
+
+func sign(file string) error {
+	if !fileutil.FileExists(file) {
+		return output.NewError(output.ReadFileError, fmt.Sprintf("bundle %s doesn't exist", file), nil)
+	}
+	bundle, err := loadBundle(file)
+	if err != nil {
+		return output.NewError(output.ReadFileError, "failed to load bundle", err)
+	}
+	signer, err := Signer()
+	if err != nil {
+		return output.NewError(output.AddressError, "failed to get signer address", err)
+	}
+	prvKey, err := account.PrivateKeyFromSigner(signer, _passwordFlag.Value().(string))
+	if err != nil {
+		return err
+	}
+	if util.AliasIsHdwalletKey(signer) {
+		signer = prvKey.PublicKey().Address().String()
+	}
+	signed, err := bundle.sign(signer, prvKey)
+	prvKey.Zero()
+	if err != nil {
+		return output.NewError(output.CryptoError, "failed to sign bundle", err)
+	}
+	if len(signed) == 0 {
+		return output.NewError(output.InputError, fmt.Sprintf("no action in the bundle to be signed by %s", signer), nil)
+	}
+
+	if _yesFlag.Value() == false {
+		var (
+			confirm string
+			info    string
+		)
+		for _, selp := range signed {
+			actionInfo, err := printActionProto(selp)
+			if err != nil {
+				return output.NewError(0, "failed to print action proto message", err)
+			}
+			info += actionInfo
+		}
+		message := output.ConfirmationMessage{
+			Info:    fmt.Sprintln(info + "\nPlease confirm signing the actions above.\n"),
+			Options: []string{"yes"},
+		}
+		fmt.Println(message.String())
+		if _, err := fmt.Scanf("%s", &confirm); err != nil {
+			return output.NewError(output.InputError, "failed to input yes", err)
+		}
+		if !strings.EqualFold(confirm, "yes") {
+			output.PrintResult("quit")
+			return nil
+		}
+	}
+
+	if err := bundle.save(file); err != nil {
+		return output.NewError(output.WriteFileError, "failed to save bundle", err)
+	}
+	result := fmt.Sprintf("%d actions have been signed by %s.", len(signed), signer)
+	if pending := bundle.pending(); len(pending) > 0 {
+		result += fmt.Sprintf(" Pending on the approvals of %s.", strings.Join(pending, ", "))
+	} else {
+		result += fmt.Sprintf(" Send the bundle by \"ioctl action sendraw %s\".", file)
+	}
+	output.PrintResult(result)
+	return nil
+}
@@ -0,0 +1,291 @@
+// Copyright (c) 2022 IoTeX Foundation
+// This is an alpha (internal) release and is not suitable for production. This source code is provided 'as is' and no
+// warranties are given as to title or non-infringement, merchantability or fitness for purpose and, to the extent
+// permitted by law, all liability for your use of the code is disclaimed. This source code is governed by Apache
+// License 2.0 that can be found in the LICENSE file.
+
+package action
+
+import (
+	"context"
+	"encoding/hex"
+	"fmt"
+	"math/big"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/iotexproject/go-pkgs/hash"
+	"github.com/iotexproject/iotex-address/address"
+	"github.com/iotexproject/iotex-proto/golang/iotexapi"
+	"github.com/iotexproject/iotex-proto/golang/iotextypes"
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+
+	"github.com/iotexproject/iotex-core/action/protocol/staking"
+	"github.com/iotexproject/iotex-core/ioctl/cmd/bc"
+	"github.com/iotexproject/iotex-core/ioctl/config"
+	"github.com/iotexproject/iotex-core/ioctl/output"
+	"github.com/iotexproject/iotex-core/ioctl/util"
+	"github.com/iotexproject/iotex-core/pkg/util/byteutil"
+)
+
+// Multi-language support
+var (
+	_watchCmdUses = map[config.Language]string{
+		config.English: "watch [--address CONTRACT_ADDRESS] [--event EVENT]",
+		config.Chinese: "watch [--address 合约地址] [--event 事件]",
+	}
+	_watchCmdShorts = map[config.Language]string{
+		config.English: "Watch the events emitted by actions, in which the staking and XRC20/XRC721 events are " +
+			"decoded, printed as text or json lines (-o json)",
+		config.Chinese: "监听交易产生的事件，其中质押和XRC20/XRC721事件会被解析，以文本或json行(-o json)格式输出",
+	}
+	_flagWatchAddressUsages = map[config.Language]string{
+		config.English: "print only the events emitted by the contracts",
+		config.Chinese: "仅输出这些合约产生的事件",
+	}
+	_flagWatchEventUsages = map[config.Language]string{
+		config.English: "print only the events, given as a known event name (e.g. Transfer, createStake), " +
+			"a signature (e.g. 'Transfer(address,address,uint256)') or a topic hash",
+		config.Chinese: "仅输出这些事件，可以是已知事件名(例如Transfer、createStake)、" +
+			"事件签名(例如'Transfer(address,address,uint256)')或主题哈希",
+	}
+)
+
+var (
+	_watchAddress []string
+	_watchEvents  []string
+
+	_transferTopic = crypto.Keccak256Hash([]byte("Transfer(address,address,uint256)"))
+	_approvalTopic = crypto.Keccak256Hash([]byte("Approval(address,address,uint256)"))
+
+	// _stakingEventArgs are the names of the topics following the event name in staking logs
+	_stakingEventArgs = map[string][]string{
+		staking.HandleCreateStake:       {"bucketIndex", "candidate"},
+		staking.HandleUnstake:           {"bucketIndex", "candidate"},
+		staking.HandleWithdrawStake:     {"bucketIndex", "candidate"},
+		staking.HandleChangeCandidate:   {"bucketIndex", "fromCandidate", "toCandidate"},
+		staking.HandleTransferStake:     {"bucketIndex", "newOwner", "candidate"},
+		staking.HandleDepositToStake:    {"bucketIndex", "owner", "candidate"},
+		staking.HandleRestake:           {"bucketIndex", "candidate"},
+		staking.HandleCandidateRegister: {"bucketIndex", "owner"},
+		staking.HandleCandidateUpdate:   {"owner"},
+		staking.HandleDoubleSign:        {"bucketIndex", "candidate"},
+	}
+)
+
+// _actionWatchCmd represents the action watch command
+var _actionWatchCmd = &cobra.Command{
+	Use:   config.TranslateInLang(_watchCmdUses, config.UILanguage),
+	Short: config.TranslateInLang(_watchCmdShorts, config.UILanguage),
+	Args:  cobra.ExactArgs(0),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		cmd.SilenceUsage = true
+		err := watchEvents()
+		return output.PrintError(err)
+	},
+}
+
+func init() {
+	_actionWatchCmd.Flags().StringSliceVar(&_watchAddress, "address", nil,
+		config.TranslateInLang(_flagWatchAddressUsages, config.UILanguage))
+	_actionWatchCmd.Flags().StringSliceVar(&_watchEvents, "event", nil,
+		config.TranslateInLang(_flagWatchEventUsages, config.UILanguage))
+}
+
+type (
+	eventMessage struct {
+		Height   uint64      `json:"height"`
+		ActHash  string      `json:"actHash"`
+		Index    uint32      `json:"index"`
+		Contract string      `json:"contract"`
+		Event    string      `json:"event,omitempty"`
+		Args     []*eventArg `json:"args,omitempty"`
+		Topics   []string    `json:"topics,omitempty"`
+		Data     string      `json:"data,omitempty"`
+	}
+
+	eventArg struct {
+		Name  string `json:"name"`
+		Value string `json:"value"`
+	}
+)
+
+func (m *eventMessage) String() string {
+	message := fmt.Sprintf("%d %s #%d %s", m.Height, m.ActHash, m.Index, m.Contract)
+	if m.Event == "" {
+		return fmt.Sprintf("%s topics %s data %s", message, strings.Join(m.Topics, ","), m.Data)
+	}
+	args := make([]string, 0, len(m.Args))
+	for _, arg := range m.Args {
+		args = append(args, arg.Name+"="+arg.Value)
+	}
+	return fmt.Sprintf("%s %s(%s)", message, m.Event, strings.Join(args, ", "))
+}
+
+// watchEvents prints the logs streamed from the api server until interrupted
+func watchEvents() error {
+	filter, err := eventsFilter(_watchAddress, _watchEvents)
+	if err != nil {
+		return err
+	}
+	return bc.Watch(func(ctx context.Context, cli iotexapi.APIServiceClient) error {
+		stream, err := cli.StreamLogs(ctx, &iotexapi.StreamLogsRequest{Filter: filter})
+		if err != nil {
+			return err
+		}
+		for {
+			response, err := stream.Recv()
+			if err != nil {
+				return err
+			}
+			bc.PrintWatched(decodeEvent(response.GetLog()))
+		}
+	})
+}
+
+func eventsFilter(addresses, events []string) (*iotexapi.LogsFilter, error) {
+	filter := &iotexapi.LogsFilter{}
+	for _, addr := range addresses {
+		ioAddr, err := util.Address(addr)
+		if err != nil {
+			return nil, output.NewError(output.AddressError, "failed to get address "+addr, err)
+		}
+		filter.Address = append(filter.Address, ioAddr)
+	}
+	if len(events) == 0 {
+		return filter, nil
+	}
+	topics := &iotexapi.Topics{}
+	for _, event := range events {
+		topic, err := eventTopic(event)
+		if err != nil {
+			return nil, output.NewError(output.InputError, "invalid event "+event, err)
+		}
+		topics.Topic = append(topics.Topic, topic)
+	}
+	filter.Topics = []*iotexapi.Topics{topics}
+	return filter, nil
+}
+
+// eventTopic returns the first topic of the logs of event, which is a known event name, a signature or a topic hash
+func eventTopic(event string) ([]byte, error) {
+	switch {
+	case event == "Transfer":
+		return _transferTopic.Bytes(), nil
+	case event == "Approval":
+		return _approvalTopic.Bytes(), nil
+	case _stakingEventArgs[event] != nil:
+		topic := hash.BytesToHash256([]byte(event))
+		return topic[:], nil
+	case strings.Contains(event, "("):
+		return crypto.Keccak256([]byte(strings.ReplaceAll(event, " ", ""))), nil
+	}
+	topic, err := hex.DecodeString(util.TrimHexPrefix(event))
+	if err != nil {
+		return nil, err
+	}
+	if len(topic) != len(hash.ZeroHash256) {
+		return nil, errors.Errorf("topic should be %d bytes", len(hash.ZeroHash256))
+	}
+	return topic, nil
+}
+
+// decodeEvent decodes the staking and XRC20/XRC721 events, the topics and data of other events are printed in hex
+func decodeEvent(log *iotextypes.Log) *eventMessage {
+	message := &eventMessage{
+		Height:   log.GetBlkHeight(),
+		ActHash:  hex.EncodeToString(log.GetActHash()),
+		Index:    log.GetIndex(),
+		Contract: log.GetContractAddress(),
+	}
+	if decodeTokenEvent(message, log) || decodeStakingEvent(message, log) {
+		return message
+	}
+	for _, topic := range log.GetTopics() {
+		message.Topics = append(message.Topics, hex.EncodeToString(topic))
+	}
+	message.Data = hex.EncodeToString(log.GetData())
+	return message
+}
+
+// decodeTokenEvent decodes Transfer and Approval, in which the amount of XRC20 is in data, and the token id of
+// XRC721 is indexed
+func decodeTokenEvent(m *eventMessage, log *iotextypes.Log) bool {
+	topics := log.GetTopics()
+	if len(topics) < 3 || len(topics) > 4 {
+		return false
+	}
+	var names []string
+	switch string(topics[0]) {
+	case string(_transferTopic.Bytes()):
+		m.Event, names = "Transfer", []string{"from", "to"}
+	case string(_approvalTopic.Bytes()):
+		m.Event, names = "Approval", []string{"owner", "spender"}
+	default:
+		return false
+	}
+	for i, name := range names {
+		addr, err := topicAddress(topics[i+1])
+		if err != nil {
+			m.Event = ""
+			return false
+		}
+		m.Args = append(m.Args, &eventArg{Name: name, Value: addr})
+	}
+	if len(topics) == 4 {
+		m.Args = append(m.Args, &eventArg{Name: "tokenId", Value: new(big.Int).SetBytes(topics[3]).String()})
+		return true
+	}
+	if len(log.GetData()) != len(hash.ZeroHash256) {
+		m.Event, m.Args = "", nil
+		return false
+	}
+	m.Args = append(m.Args, &eventArg{Name: "value", Value: new(big.Int).SetBytes(log.GetData()).String()})
+	return true
+}
+
+// decodeStakingEvent decodes the logs of staking protocol, in which the topics are the event name, the bucket index
+// and the addresses, see staking.BucketIndexFromReceiptLog
+func decodeStakingEvent(m *eventMessage, log *iotextypes.Log) bool {
+	topics := log.GetTopics()
+	if log.GetContractAddress() != address.StakingProtocolAddr || len(topics) == 0 {
+		return false
+	}
+	for name, args := range _stakingEventArgs {
+		if hash.BytesToHash256(topics[0]) != hash.BytesToHash256([]byte(name)) {
+			continue
+		}
+		if len(topics) != len(args)+1 {
+			return false
+		}
+		m.Event = name
+		for i, arg := range args {
+			topic := topics[i+1]
+			if arg == "bucketIndex" {
+				m.Args = append(m.Args, &eventArg{Name: arg, Value: fmt.Sprint(byteutil.BytesToUint64BigEndian(topic[len(topic)-8:]))})
+				continue
+			}
+			addr, err := topicAddress(topic)
+			if err != nil {
+				m.Event, m.Args = "", nil
+				return false
+			}
+			m.Args = append(m.Args, &eventArg{Name: arg, Value: addr})
+		}
+		return true
+	}
+	return false
+}
+
+// topicAddress returns the address in the last 20 bytes of topic
+func topicAddress(topic []byte) (string, error) {
+	if len(topic) < 20 {
+		return "", errors.New("topic is too short for an address")
+	}
+	addr, err := address.FromBytes(topic[len(topic)-20:])
+	if err != nil {
+		return "", err
+	}
+	return addr.String(), nil
+}
@@ -0,0 +1,83 @@
+// Copyright (c) 2022 IoTeX Foundation
+// This is an alpha (internal) release and is not suitable for production. This source code is provided 'as is' and no
+// warranties are given as to title or non-infringement, merchantability or fitness for purpose and, to the extent
+// permitted by law, all liability for your use of the code is disclaimed. This source code is governed by Apache
+// License 2.0 that can be found in the LICENSE file.
+
+package action
+
+import (
+	"encoding/hex"
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/iotexproject/go-pkgs/hash"
+	"github.com/iotexproject/iotex-address/address"
+	"github.com/iotexproject/iotex-proto/golang/iotextypes"
+	"github.com/stretchr/testify/require"
+
+	"github.com/iotexproject/iotex-core/action/protocol/staking"
+	"github.com/iotexproject/iotex-core/pkg/util/byteutil"
+	"github.com/iotexproject/iotex-core/test/identityset"
+)
+
+func TestEventTopic(t *testing.T) {
+	require := require.New(t)
+	for _, event := range []string{"Transfer", "Transfer(address, address, uint256)",
+		"0xddf252ad1be2c89b69c2b068fc378daa952ba7f163c4a11628f55a4df523b3ef"} {
+		topic, err := eventTopic(event)
+		require.NoError(err)
+		require.Equal(_transferTopic.Bytes(), topic, event)
+	}
+	topic, err := eventTopic(staking.HandleCreateStake)
+	require.NoError(err)
+	require.Equal(hash.BytesToHash256([]byte(staking.HandleCreateStake)), hash.BytesToHash256(topic))
+	_, err = eventTopic("0x1234")
+	require.Error(err)
+}
+
+func TestDecodeEvent(t *testing.T) {
+	require := require.New(t)
+	from, to := identityset.Address(1), identityset.Address(2)
+	addrTopic := func(addr address.Address) []byte {
+		return common.BytesToHash(addr.Bytes()).Bytes()
+	}
+
+	// XRC20 transfer
+	m := decodeEvent(&iotextypes.Log{
+		ContractAddress: identityset.Address(3).String(),
+		Topics:          [][]byte{_transferTopic.Bytes(), addrTopic(from), addrTopic(to)},
+		Data:            common.BigToHash(big.NewInt(100)).Bytes(),
+		BlkHeight:       5,
+	})
+	require.Equal("Transfer", m.Event)
+	require.Equal([]*eventArg{{"from", from.String()}, {"to", to.String()}, {"value", "100"}}, m.Args)
+
+	// XRC721 approval
+	m = decodeEvent(&iotextypes.Log{
+		Topics: [][]byte{_approvalTopic.Bytes(), addrTopic(from), addrTopic(to), common.BigToHash(big.NewInt(7)).Bytes()},
+	})
+	require.Equal("Approval", m.Event)
+	require.Equal([]*eventArg{{"owner", from.String()}, {"spender", to.String()}, {"tokenId", "7"}}, m.Args)
+
+	// staking
+	name := hash.BytesToHash256([]byte(staking.HandleChangeCandidate))
+	index := hash.BytesToHash256(byteutil.Uint64ToBytesBigEndian(12))
+	m = decodeEvent(&iotextypes.Log{
+		ContractAddress: address.StakingProtocolAddr,
+		Topics:          [][]byte{name[:], index[:], addrTopic(from), addrTopic(to)},
+	})
+	require.Equal(staking.HandleChangeCandidate, m.Event)
+	require.Equal([]*eventArg{{"bucketIndex", "12"}, {"fromCandidate", from.String()}, {"toCandidate", to.String()}}, m.Args)
+
+	// unknown event
+	m = decodeEvent(&iotextypes.Log{
+		ContractAddress: identityset.Address(3).String(),
+		Topics:          [][]byte{index[:]},
+		Data:            []byte{1, 2},
+	})
+	require.Empty(m.Event)
+	require.Equal([]string{hex.EncodeToString(index[:])}, m.Topics)
+	require.Equal("0102", m.Data)
+}
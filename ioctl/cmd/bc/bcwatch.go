@@ -0,0 +1,329 @@
+// Copyright (c) 2022 IoTeX Foundation
+// This is an alpha (internal) release and is not suitable for production. This source code is provided 'as is' and no
+// warranties are given as to title or non-infringement, merchantability or fitness for purpose and, to the extent
+// permitted by law, all liability for your use of the code is disclaimed. This source code is governed by Apache
+// License 2.0 that can be found in the LICENSE file.
+
+package bc
+
+import (
+	"context"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"os"
+	"os/signal"
+	"strings"
+	"syscall"
+	"time"
+
+	"github.com/grpc-ecosystem/go-grpc-middleware/util/metautils"
+	"github.com/iotexproject/go-pkgs/crypto"
+	"github.com/spf13/cobra"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/iotexproject/iotex-proto/golang/iotexapi"
+	"github.com/iotexproject/iotex-proto/golang/iotextypes"
+
+	"github.com/iotexproject/iotex-core/blockchain/block"
+	"github.com/iotexproject/iotex-core/ioctl/config"
+	"github.com/iotexproject/iotex-core/ioctl/output"
+	"github.com/iotexproject/iotex-core/ioctl/util"
+)
+
+// Multi-language support
+var (
+	_bcWatchCmdUses = map[config.Language]string{
+		config.English: "watch [--actions] [--address ADDRESS] [--type ACTION_TYPE]",
+		config.Chinese: "watch [--actions] [--address 地址] [--type 交易类型]",
+	}
+	_bcWatchCmdShorts = map[config.Language]string{
+		config.English: "Watch new blocks and their actions, printed as text or json lines (-o json)",
+		config.Chinese: "监听新区块及其中的交易，以文本或json行(-o json)格式输出",
+	}
+	_flagWatchActionsUsages = map[config.Language]string{
+		config.English: "print the actions of blocks",
+		config.Chinese: "输出区块中的交易",
+	}
+	_flagWatchAddressUsages = map[config.Language]string{
+		config.English: "print only the actions sent from or to the addresses",
+		config.Chinese: "仅输出由这些地址发送或发送给这些地址的交易",
+	}
+	_flagWatchTypeUsages = map[config.Language]string{
+		config.English: "print only the actions of the types, e.g. transfer, execution, stakeCreate",
+		config.Chinese: "仅输出这些类型的交易，例如transfer、execution、stakeCreate",
+	}
+)
+
+// _watchRetryInterval is the interval to subscribe again after the stream is broken
+const _watchRetryInterval = 3 * time.Second
+
+var (
+	_watchActions bool
+	_watchAddress []string
+	_watchTypes   []string
+)
+
+// _bcWatchCmd represents the bc watch command
+var _bcWatchCmd = &cobra.Command{
+	Use:   config.TranslateInLang(_bcWatchCmdUses, config.UILanguage),
+	Short: config.TranslateInLang(_bcWatchCmdShorts, config.UILanguage),
+	Args:  cobra.ExactArgs(0),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		cmd.SilenceUsage = true
+		err := watchBlocks()
+		return output.PrintError(err)
+	},
+}
+
+func init() {
+	_bcWatchCmd.Flags().BoolVar(&_watchActions, "actions", false,
+		config.TranslateInLang(_flagWatchActionsUsages, config.UILanguage))
+	_bcWatchCmd.Flags().StringSliceVar(&_watchAddress, "address", nil,
+		config.TranslateInLang(_flagWatchAddressUsages, config.UILanguage))
+	_bcWatchCmd.Flags().StringSliceVar(&_watchTypes, "type", nil,
+		config.TranslateInLang(_flagWatchTypeUsages, config.UILanguage))
+}
+
+type (
+	watchBlockMessage struct {
+		Height    uint64                `json:"height"`
+		Hash      string                `json:"hash"`
+		Timestamp string                `json:"timestamp"`
+		Producer  string                `json:"producer"`
+		NumAction int                   `json:"numAction"`
+		Actions   []*watchActionMessage `json:"actions,omitempty"`
+	}
+
+	watchActionMessage struct {
+		Height    uint64 `json:"height"`
+		ActHash   string `json:"actHash"`
+		Type      string `json:"type"`
+		Sender    string `json:"sender"`
+		Recipient string `json:"recipient,omitempty"`
+		Amount    string `json:"amount,omitempty"`
+		Status    uint64 `json:"status"`
+		GasUsed   uint64 `json:"gasUsed"`
+	}
+)
+
+func (m *watchBlockMessage) String() string {
+	lines := []string{fmt.Sprintf("block %d %s at %s by %s, %d actions",
+		m.Height, m.Hash, m.Timestamp, m.Producer, m.NumAction)}
+	for _, act := range m.Actions {
+		lines = append(lines, "  "+act.String())
+	}
+	return strings.Join(lines, "\n")
+}
+
+func (m *watchActionMessage) String() string {
+	message := fmt.Sprintf("%s %s from %s", m.ActHash, m.Type, m.Sender)
+	if m.Recipient != "" {
+		message += " to " + m.Recipient
+	}
+	if amount, ok := new(big.Int).SetString(m.Amount, 10); ok && amount.Sign() > 0 {
+		message += " amount " + util.RauToString(amount, util.IotxDecimalNum) + " IOTX"
+	}
+	return fmt.Sprintf("%s, status %d, gas used %d", message, m.Status, m.GasUsed)
+}
+
+// watchBlocks prints the blocks streamed from the api server until interrupted
+func watchBlocks() error {
+	filter, err := newActionFilter(_watchAddress, _watchTypes)
+	if err != nil {
+		return err
+	}
+	showActions := _watchActions || !filter.empty()
+	var lastHeight uint64
+	return Watch(func(ctx context.Context, cli iotexapi.APIServiceClient) error {
+		stream, err := cli.StreamBlocks(ctx, &iotexapi.StreamBlocksRequest{})
+		if err != nil {
+			return err
+		}
+		for {
+			response, err := stream.Recv()
+			if err != nil {
+				return err
+			}
+			message, err := newWatchBlockMessage(response.GetBlock(), showActions, filter)
+			if err != nil {
+				return output.NewError(output.SerializationError, "failed to parse block", err)
+			}
+			if lastHeight != 0 && message.Height > lastHeight+1 {
+				fmt.Fprintf(os.Stderr, "blocks %d to %d are missed while reconnecting\n", lastHeight+1, message.Height-1)
+			}
+			lastHeight = message.Height
+			if !filter.empty() && len(message.Actions) == 0 {
+				continue
+			}
+			PrintWatched(message)
+		}
+	})
+}
+
+func newWatchBlockMessage(info *iotexapi.BlockInfo, showActions bool, filter *actionFilter) (*watchBlockMessage, error) {
+	var header block.Header
+	if err := header.LoadFromBlockHeaderProto(info.GetBlock().GetHeader()); err != nil {
+		return nil, err
+	}
+	hash := header.HashBlock()
+	actions := info.GetBlock().GetBody().GetActions()
+	message := &watchBlockMessage{
+		Height:    header.Height(),
+		Hash:      hex.EncodeToString(hash[:]),
+		Timestamp: header.Timestamp().UTC().Format(time.RFC3339),
+		Producer:  header.ProducerAddress(),
+		NumAction: len(actions),
+	}
+	if !showActions {
+		return message, nil
+	}
+	receipts := info.GetReceipts()
+	for i, act := range actions {
+		var receipt *iotextypes.Receipt
+		if i < len(receipts) {
+			receipt = receipts[i]
+		}
+		actMessage, err := newWatchActionMessage(header.Height(), act, receipt)
+		if err != nil {
+			return nil, err
+		}
+		if filter.match(actMessage) {
+			message.Actions = append(message.Actions, actMessage)
+		}
+	}
+	return message, nil
+}
+
+func newWatchActionMessage(height uint64, act *iotextypes.Action, receipt *iotextypes.Receipt) (*watchActionMessage, error) {
+	pubKey, err := crypto.BytesToPublicKey(act.GetSenderPubKey())
+	if err != nil {
+		return nil, err
+	}
+	core := act.GetCore()
+	message := &watchActionMessage{
+		Height:  height,
+		ActHash: hex.EncodeToString(receipt.GetActHash()),
+		Type:    ActionType(core),
+		Sender:  pubKey.Address().String(),
+		Status:  receipt.GetStatus(),
+		GasUsed: receipt.GetGasConsumed(),
+	}
+	switch {
+	case core.GetTransfer() != nil:
+		message.Recipient, message.Amount = core.GetTransfer().GetRecipient(), core.GetTransfer().GetAmount()
+	case core.GetExecution() != nil:
+		message.Recipient, message.Amount = core.GetExecution().GetContract(), core.GetExecution().GetAmount()
+		if message.Recipient == "" {
+			message.Recipient = receipt.GetContractAddress()
+		}
+	case core.GetStakeCreate() != nil:
+		message.Recipient, message.Amount = core.GetStakeCreate().GetCandidateName(),
+			core.GetStakeCreate().GetStakedAmount()
+	case core.GetStakeAddDeposit() != nil:
+		message.Amount = core.GetStakeAddDeposit().GetAmount()
+	case core.GetStakeChangeCandidate() != nil:
+		message.Recipient = core.GetStakeChangeCandidate().GetCandidateName()
+	case core.GetStakeTransferOwnership() != nil:
+		message.Recipient = core.GetStakeTransferOwnership().GetVoterAddress()
+	case core.GetCandidateRegister() != nil:
+		message.Amount = core.GetCandidateRegister().GetStakedAmount()
+	}
+	return message, nil
+}
+
+// ActionType returns the type of action in lower camel case, e.g. transfer, stakeCreate
+func ActionType(core *iotextypes.ActionCore) string {
+	t := strings.TrimPrefix(fmt.Sprintf("%T", core.GetAction()), "*iotextypes.ActionCore_")
+	if t == "" || t == "<nil>" {
+		return "unknown"
+	}
+	return strings.ToLower(t[:1]) + t[1:]
+}
+
+type actionFilter struct {
+	addresses map[string]bool
+	types     map[string]bool
+}
+
+func newActionFilter(addresses, types []string) (*actionFilter, error) {
+	filter := &actionFilter{
+		addresses: make(map[string]bool, len(addresses)),
+		types:     make(map[string]bool, len(types)),
+	}
+	for _, addr := range addresses {
+		ioAddr, err := util.Address(addr)
+		if err != nil {
+			return nil, output.NewError(output.AddressError, "failed to get address "+addr, err)
+		}
+		filter.addresses[ioAddr] = true
+	}
+	for _, t := range types {
+		filter.types[strings.ToLower(t)] = true
+	}
+	return filter, nil
+}
+
+func (f *actionFilter) empty() bool {
+	return len(f.addresses) == 0 && len(f.types) == 0
+}
+
+func (f *actionFilter) match(m *watchActionMessage) bool {
+	if len(f.types) > 0 && !f.types[strings.ToLower(m.Type)] {
+		return false
+	}
+	return len(f.addresses) == 0 || f.addresses[m.Sender] || f.addresses[m.Recipient]
+}
+
+// Watch subscribes to a stream of the api server until interrupted. The stream is subscribed again after
+// _watchRetryInterval if it's broken, unless the request is rejected by the api server
+func Watch(subscribe func(context.Context, iotexapi.APIServiceClient) error) error {
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+	jwtMD, err := util.JwtAuth()
+	if err == nil {
+		ctx = metautils.NiceMD(jwtMD).ToOutgoing(ctx)
+	}
+	for {
+		conn, err := util.ConnectToEndpoint(config.ReadConfig.SecureConnect && !config.Insecure)
+		if err != nil {
+			return output.NewError(output.NetworkError, "failed to connect to endpoint", err)
+		}
+		err = subscribe(ctx, iotexapi.NewAPIServiceClient(conn))
+		conn.Close()
+		if ctx.Err() != nil {
+			return nil
+		}
+		if _, ok := err.(output.ErrorMessage); ok {
+			return err
+		}
+		if sta, ok := status.FromError(err); ok {
+			switch sta.Code() {
+			case codes.InvalidArgument, codes.Unimplemented, codes.Unauthenticated, codes.PermissionDenied:
+				return output.NewError(output.APIError, sta.Message(), nil)
+			}
+		}
+		fmt.Fprintf(os.Stderr, "stream is broken: %v, reconnecting in %s\n", err, _watchRetryInterval)
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-time.After(_watchRetryInterval):
+		}
+	}
+}
+
+// PrintWatched prints a message received from a stream, as a line of json in json format
+func PrintWatched(m fmt.Stringer) {
+	if output.Format == "" {
+		fmt.Println(m.String())
+		return
+	}
+	data, err := json.Marshal(m)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "failed to marshal message:", err)
+		return
+	}
+	fmt.Println(string(data))
+}
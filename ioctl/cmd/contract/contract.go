@@ -63,6 +63,7 @@ func init() {
 	ContractCmd.AddCommand(_contractInvokeCmd)
 	ContractCmd.AddCommand(_contractTestCmd)
 	ContractCmd.AddCommand(_contractShareCmd)
+	ContractCmd.AddCommand(_contractLogsCmd)
 	ContractCmd.PersistentFlags().StringVar(&config.ReadConfig.Endpoint, "endpoint",
 		config.ReadConfig.Endpoint, config.TranslateInLang(_flagEndpointUsages, config.UILanguage))
 	ContractCmd.PersistentFlags().BoolVar(&config.Insecure, "insecure", config.Insecure,
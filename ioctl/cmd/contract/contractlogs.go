@@ -0,0 +1,272 @@
+// Copyright (c) 2022 IoTeX Foundation
+// This is an alpha (internal) release and is not suitable for production. This source code is provided 'as is' and no
+// warranties are given as to title or non-infringement, merchantability or fitness for purpose and, to the extent
+// permitted by law, all liability for your use of the code is disclaimed. This source code is governed by Apache
+// License 2.0 that can be found in the LICENSE file.
+
+package contract
+
+import (
+	"context"
+	"encoding/hex"
+	"fmt"
+	"strconv"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/grpc-ecosystem/go-grpc-middleware/util/metautils"
+	"github.com/iotexproject/iotex-proto/golang/iotexapi"
+	"github.com/iotexproject/iotex-proto/golang/iotextypes"
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+	"google.golang.org/grpc/status"
+
+	"github.com/iotexproject/iotex-core/ioctl/cmd/bc"
+	"github.com/iotexproject/iotex-core/ioctl/config"
+	"github.com/iotexproject/iotex-core/ioctl/output"
+	"github.com/iotexproject/iotex-core/ioctl/util"
+)
+
+// Multi-language support
+var (
+	_logsCmdUses = map[config.Language]string{
+		config.English: "logs (CONTRACT_ADDRESS|ALIAS) ABI_PATH EVENT_NAME [--filter INDEXED_ARGUMENTS] " +
+			"[--from-height START] [--to-height END] [--page-size SIZE]",
+		config.Chinese: "logs (合约地址|别名) ABI文件路径 事件名 [--filter 索引参数] " +
+			"[--from-height 起始高度] [--to-height 结束高度] [--page-size 分页大小]",
+	}
+	_logsCmdShorts = map[config.Language]string{
+		config.English: "Query and decode the events of smart contract on IoTeX blockchain, " +
+			"printed as table, json (-o json) or csv (-o csv)",
+		config.Chinese: "查询并解析IoTeX区块链上智能合约的事件，以表格、json(-o json)或csv(-o csv)格式输出",
+	}
+	_flagLogsFilterUsages = map[config.Language]string{
+		config.English: "filter the events by the indexed arguments in json, e.g. '{\"from\":\"io1...\"}'",
+		config.Chinese: "以json格式按索引参数过滤事件，例如'{\"from\":\"io1...\"}'",
+	}
+	_flagFromHeightUsages = map[config.Language]string{
+		config.English: "the start height of the query",
+		config.Chinese: "查询的起始高度",
+	}
+	_flagToHeightUsages = map[config.Language]string{
+		config.English: "the end height of the query, 0 means the tip height",
+		config.Chinese: "查询的结束高度，0表示最新高度",
+	}
+	_flagPageSizeUsages = map[config.Language]string{
+		config.English: "the number of blocks queried per request, at most 5000",
+		config.Chinese: "每次请求查询的区块数量，最多5000",
+	}
+)
+
+// _maxLogsPageSize is the max number of blocks the api server scans for logs in a request
+const _maxLogsPageSize = 5000
+
+var (
+	_logsFilter     string
+	_logsFromHeight uint64
+	_logsToHeight   uint64
+	_logsPageSize   uint64
+)
+
+// _contractLogsCmd represents the contract logs command
+var _contractLogsCmd = &cobra.Command{
+	Use:   config.TranslateInLang(_logsCmdUses, config.UILanguage),
+	Short: config.TranslateInLang(_logsCmdShorts, config.UILanguage),
+	Args:  cobra.ExactArgs(3),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		cmd.SilenceUsage = true
+		err := contractLogs(args)
+		return output.PrintError(err)
+	},
+}
+
+func init() {
+	_contractLogsCmd.Flags().StringVar(&_logsFilter, "filter", "",
+		config.TranslateInLang(_flagLogsFilterUsages, config.UILanguage))
+	_contractLogsCmd.Flags().Uint64Var(&_logsFromHeight, "from-height", 1,
+		config.TranslateInLang(_flagFromHeightUsages, config.UILanguage))
+	_contractLogsCmd.Flags().Uint64Var(&_logsToHeight, "to-height", 0,
+		config.TranslateInLang(_flagToHeightUsages, config.UILanguage))
+	_contractLogsCmd.Flags().Uint64Var(&_logsPageSize, "page-size", 1000,
+		config.TranslateInLang(_flagPageSizeUsages, config.UILanguage))
+}
+
+func contractLogs(args []string) error {
+	contract, err := util.Address(args[0])
+	if err != nil {
+		return output.NewError(output.AddressError, "failed to get contract address", err)
+	}
+	abi, err := readAbiFile(args[1])
+	if err != nil {
+		return output.NewError(output.ReadFileError, "failed to read abi file "+args[1], err)
+	}
+	event, ok := abi.Events[args[2]]
+	if !ok {
+		return output.NewError(output.InputError, "invalid event name "+args[2], nil)
+	}
+	topics, err := eventTopics(&event, _logsFilter)
+	if err != nil {
+		return output.NewError(output.InputError, "failed to parse filter", err)
+	}
+	if _logsPageSize == 0 || _logsPageSize > _maxLogsPageSize {
+		return output.NewError(output.FlagError, fmt.Sprintf("page size should be in [1, %d]", _maxLogsPageSize), nil)
+	}
+	if _logsFromHeight == 0 {
+		return output.NewError(output.FlagError, "start height should be greater than 0", nil)
+	}
+	end := _logsToHeight
+	if end == 0 {
+		chainMeta, err := bc.GetChainMeta()
+		if err != nil {
+			return output.NewError(0, "failed to get chain meta", err)
+		}
+		end = chainMeta.GetHeight()
+	}
+	if _logsFromHeight > end {
+		return output.NewError(output.FlagError, "start height should not be greater than end height", nil)
+	}
+
+	logs, err := getLogs(&iotexapi.LogsFilter{Address: []string{contract}, Topics: topics}, _logsFromHeight, end)
+	if err != nil {
+		return err
+	}
+	message := output.TableMessage{Header: []string{"height", "actHash", "index"}}
+	for _, input := range event.Inputs {
+		message.Header = append(message.Header, input.Name)
+	}
+	for _, log := range logs {
+		values, err := decodeLog(&event, log)
+		if err != nil {
+			return output.NewError(output.SerializationError,
+				fmt.Sprintf("failed to decode log %d of action %x", log.GetIndex(), log.GetActHash()), err)
+		}
+		row := []string{strconv.FormatUint(log.GetBlkHeight(), 10), hex.EncodeToString(log.GetActHash()),
+			strconv.FormatUint(uint64(log.GetIndex()), 10)}
+		message.Rows = append(message.Rows, append(row, values...))
+	}
+	fmt.Println(message.String())
+	return nil
+}
+
+// getLogs queries the logs in [start, end] page by page, each page covers at most _logsPageSize blocks,
+// such that the api server never stops scanning before the end of the page
+func getLogs(filter *iotexapi.LogsFilter, start, end uint64) ([]*iotextypes.Log, error) {
+	conn, err := util.ConnectToEndpoint(config.ReadConfig.SecureConnect && !config.Insecure)
+	if err != nil {
+		return nil, output.NewError(output.NetworkError, "failed to connect to endpoint", err)
+	}
+	defer conn.Close()
+	cli := iotexapi.NewAPIServiceClient(conn)
+	ctx := context.Background()
+
+	jwtMD, err := util.JwtAuth()
+	if err == nil {
+		ctx = metautils.NiceMD(jwtMD).ToOutgoing(ctx)
+	}
+
+	var logs []*iotextypes.Log
+	for from := start; from <= end; from += _logsPageSize {
+		to := from + _logsPageSize - 1
+		if to > end {
+			to = end
+		}
+		response, err := cli.GetLogs(ctx, &iotexapi.GetLogsRequest{
+			Filter: filter,
+			Lookup: &iotexapi.GetLogsRequest_ByRange{
+				ByRange: &iotexapi.GetLogsByRange{
+					FromBlock:      from,
+					ToBlock:        to,
+					PaginationSize: _logsPageSize,
+				},
+			},
+		})
+		if err != nil {
+			sta, ok := status.FromError(err)
+			if ok {
+				return nil, output.NewError(output.APIError, sta.Message(), nil)
+			}
+			return nil, output.NewError(output.NetworkError, "failed to invoke GetLogs api", err)
+		}
+		logs = append(logs, response.GetLogs()...)
+	}
+	return logs, nil
+}
+
+// eventTopics returns the topics filtering the event, in which the indexed arguments not in the filter match any value
+func eventTopics(event *abi.Event, rowFilter string) ([]*iotexapi.Topics, error) {
+	topics := []*iotexapi.Topics{{Topic: [][]byte{event.ID.Bytes()}}}
+	if rowFilter == "" {
+		return topics, nil
+	}
+	filter, err := parseInput(rowFilter)
+	if err != nil {
+		return nil, err
+	}
+
+	var (
+		indexed = indexedArguments(event)
+		query   = make([][]interface{}, len(indexed))
+	)
+	for i, arg := range indexed {
+		value, ok := filter[arg.Name]
+		if !ok {
+			continue
+		}
+		delete(filter, arg.Name)
+		rule, err := parseInputArgument(&arg.Type, value)
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to parse argument %s", arg.Name)
+		}
+		query[i] = []interface{}{rule}
+	}
+	for name := range filter {
+		return nil, errors.Errorf("%s is not an indexed argument of event %s", name, event.Name)
+	}
+	hashes, err := abi.MakeTopics(query...)
+	if err != nil {
+		return nil, err
+	}
+	for _, rules := range hashes {
+		t := &iotexapi.Topics{}
+		for _, rule := range rules {
+			t.Topic = append(t.Topic, rule.Bytes())
+		}
+		topics = append(topics, t)
+	}
+	return topics, nil
+}
+
+// decodeLog decodes the arguments of event from the log, in the order of the event inputs
+func decodeLog(event *abi.Event, log *iotextypes.Log) ([]string, error) {
+	if len(log.GetTopics()) == 0 || common.BytesToHash(log.GetTopics()[0]) != event.ID {
+		return nil, errors.New("log doesn't match the event")
+	}
+	values := make(map[string]interface{}, len(event.Inputs))
+	if err := event.Inputs.NonIndexed().UnpackIntoMap(values, log.GetData()); err != nil {
+		return nil, err
+	}
+	topics := make([]common.Hash, 0, len(log.GetTopics())-1)
+	for _, topic := range log.GetTopics()[1:] {
+		topics = append(topics, common.BytesToHash(topic))
+	}
+	if err := abi.ParseTopicsIntoMap(values, indexedArguments(event), topics); err != nil {
+		return nil, err
+	}
+
+	result := make([]string, 0, len(event.Inputs))
+	for _, input := range event.Inputs {
+		str, _ := parseOutputArgument(values[input.Name], &input.Type)
+		result = append(result, str)
+	}
+	return result, nil
+}
+
+func indexedArguments(event *abi.Event) abi.Arguments {
+	var indexed abi.Arguments
+	for _, input := range event.Inputs {
+		if input.Indexed {
+			indexed = append(indexed, input)
+		}
+	}
+	return indexed
+}
@@ -0,0 +1,62 @@
+// Copyright (c) 2022 IoTeX Foundation
+// This is an alpha (internal) release and is not suitable for production. This source code is provided 'as is' and no
+// warranties are given as to title or non-infringement, merchantability or fitness for purpose and, to the extent
+// permitted by law, all liability for your use of the code is disclaimed. This source code is governed by Apache
+// License 2.0 that can be found in the LICENSE file.
+
+package contract
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/iotexproject/iotex-proto/golang/iotextypes"
+	"github.com/stretchr/testify/require"
+
+	"github.com/iotexproject/iotex-core/test/identityset"
+)
+
+const _transferEventAbi = `[{"anonymous":false,"inputs":[{"indexed":true,"name":"from","type":"address"},` +
+	`{"indexed":true,"name":"to","type":"address"},{"indexed":false,"name":"value","type":"uint256"}],` +
+	`"name":"Transfer","type":"event"}]`
+
+func TestContractLogs(t *testing.T) {
+	r := require.New(t)
+	testAbi, err := parseAbi([]byte(_transferEventAbi))
+	r.NoError(err)
+	event := testAbi.Events["Transfer"]
+	from, to := identityset.Address(0), identityset.Address(1)
+
+	topics, err := eventTopics(&event, "")
+	r.NoError(err)
+	r.Len(topics, 1)
+	r.Equal([][]byte{event.ID.Bytes()}, topics[0].Topic)
+
+	// the argument not in the filter matches any value
+	topics, err = eventTopics(&event, `{"to":"`+to.String()+`"}`)
+	r.NoError(err)
+	r.Len(topics, 3)
+	r.Empty(topics[1].Topic)
+	r.Equal([][]byte{common.BytesToHash(to.Bytes()).Bytes()}, topics[2].Topic)
+
+	_, err = eventTopics(&event, `{"value":"1"}`)
+	r.Error(err)
+	_, err = eventTopics(&event, `{"from":1}`)
+	r.Error(err)
+
+	value, err := event.Inputs.NonIndexed().Pack(big.NewInt(1000))
+	r.NoError(err)
+	log := &iotextypes.Log{
+		Topics: [][]byte{event.ID.Bytes(), common.BytesToHash(from.Bytes()).Bytes(),
+			common.BytesToHash(to.Bytes()).Bytes()},
+		Data: value,
+	}
+	values, err := decodeLog(&event, log)
+	r.NoError(err)
+	r.Equal([]string{from.String(), to.String(), "1000"}, values)
+
+	log.Topics[0] = log.Topics[1]
+	_, err = decodeLog(&event, log)
+	r.Error(err)
+}
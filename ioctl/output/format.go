@@ -7,14 +7,21 @@
 package output
 
 import (
+	"bytes"
+	"encoding/csv"
 	"encoding/json"
 	"fmt"
 	"log"
+	"strings"
+	"text/tabwriter"
 )
 
 // Format is the target of output-format flag
 var Format string
 
+// CSVFormat is the output format printing tabular results as csv, other results are printed as json
+const CSVFormat = "csv"
+
 // ErrorCode is the code of error
 type ErrorCode int
 
@@ -152,6 +159,50 @@ func (m StringMessage) Warn() string {
 	return FormatString(Warn, m)
 }
 
+// TableMessage is the Message of tabular results, printed as an aligned table by default,
+// as csv in csv format, and as a list of objects keyed by the header in json format
+type TableMessage struct {
+	Header []string
+	Rows   [][]string
+}
+
+func (m *TableMessage) String() string {
+	switch Format {
+	case "":
+		var buf bytes.Buffer
+		w := tabwriter.NewWriter(&buf, 0, 0, 2, ' ', 0)
+		fmt.Fprintln(w, strings.Join(m.Header, "\t"))
+		for _, row := range m.Rows {
+			fmt.Fprintln(w, strings.Join(row, "\t"))
+		}
+		w.Flush()
+		return strings.TrimSuffix(buf.String(), "\n")
+	case CSVFormat:
+		var buf bytes.Buffer
+		w := csv.NewWriter(&buf)
+		w.Write(m.Header)
+		w.WriteAll(m.Rows)
+		return strings.TrimSuffix(buf.String(), "\n")
+	default:
+		return FormatString(Result, m)
+	}
+}
+
+// MarshalJSON marshals the rows as objects keyed by the header
+func (m *TableMessage) MarshalJSON() ([]byte, error) {
+	objects := make([]map[string]string, 0, len(m.Rows))
+	for _, row := range m.Rows {
+		object := make(map[string]string, len(m.Header))
+		for i, key := range m.Header {
+			if i < len(row) {
+				object[key] = row[i]
+			}
+		}
+		objects = append(objects, object)
+	}
+	return json.Marshal(objects)
+}
+
 // FormatString returns Output as string in certain format
 func FormatString(t MessageType, m Message) string {
 	out := Output{
@@ -211,7 +262,7 @@ func NewError(code ErrorCode, info string, pre error) error {
 
 // PrintError prints Error Message in format, only used at top layer of a command
 func PrintError(err error) error {
-	if err == nil || Format == "" {
+	if err == nil || Format == "" || Format == CSVFormat {
 		return err
 	}
 	newErr := NewError(0, "", err)
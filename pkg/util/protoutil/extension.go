@@ -0,0 +1,73 @@
+// Copyright (c) 2022 IoTeX Foundation
+// This is an alpha (internal) release and is not suitable for production. This source code is provided 'as is' and no
+// warranties are given as to title or non-infringement, merchantability or fitness for purpose and, to the extent
+// permitted by law, all liability for your use of the code is disclaimed. This source code is governed by Apache
+// License 2.0 that can be found in the LICENSE file.
+
+package protoutil
+
+import (
+	"github.com/pkg/errors"
+	"google.golang.org/protobuf/encoding/protowire"
+	"google.golang.org/protobuf/proto"
+)
+
+// Extension fields are bytes fields which are not defined in the protobuf messages of iotex-proto. They are stored
+// in the unknown fields of a message, so that they survive marshaling and unmarshaling, and are ignored by the
+// nodes which don't recognize them.
+
+// SetExtensionField sets a bytes field into the unknown fields of the message, replacing the existing one if any
+func SetExtensionField(msg proto.Message, num protowire.Number, value []byte) {
+	m := msg.ProtoReflect()
+	unknown := removeField(m.GetUnknown(), num)
+	unknown = protowire.AppendTag(unknown, num, protowire.BytesType)
+	unknown = protowire.AppendBytes(unknown, value)
+	m.SetUnknown(unknown)
+}
+
+// ExtensionField returns a bytes field from the unknown fields of the message, or nil if it does not exist
+func ExtensionField(msg proto.Message, num protowire.Number) ([]byte, error) {
+	var (
+		unknown = msg.ProtoReflect().GetUnknown()
+		value   []byte
+	)
+	for len(unknown) > 0 {
+		n, typ, tagLen := protowire.ConsumeTag(unknown)
+		if tagLen < 0 {
+			return nil, errors.Wrap(protowire.ParseError(tagLen), "failed to parse extension field tag")
+		}
+		fieldLen := protowire.ConsumeFieldValue(n, typ, unknown[tagLen:])
+		if fieldLen < 0 {
+			return nil, errors.Wrap(protowire.ParseError(fieldLen), "failed to parse extension field value")
+		}
+		if n == num {
+			if typ != protowire.BytesType {
+				return nil, errors.Errorf("unexpected wire type %d of extension field %d", typ, num)
+			}
+			v, _ := protowire.ConsumeBytes(unknown[tagLen:])
+			value = make([]byte, len(v))
+			copy(value, v)
+		}
+		unknown = unknown[tagLen+fieldLen:]
+	}
+	return value, nil
+}
+
+func removeField(unknown []byte, num protowire.Number) []byte {
+	var ret []byte
+	for len(unknown) > 0 {
+		n, typ, tagLen := protowire.ConsumeTag(unknown)
+		if tagLen < 0 {
+			return ret
+		}
+		fieldLen := protowire.ConsumeFieldValue(n, typ, unknown[tagLen:])
+		if fieldLen < 0 {
+			return ret
+		}
+		if n != num {
+			ret = append(ret, unknown[:tagLen+fieldLen]...)
+		}
+		unknown = unknown[tagLen+fieldLen:]
+	}
+	return ret
+}
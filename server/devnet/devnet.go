@@ -0,0 +1,420 @@
+// Copyright (c) 2022 IoTeX Foundation
+// This is an alpha (internal) release and is not suitable for production. This source code is provided 'as is' and no
+// warranties are given as to title or non-infringement, merchantability or fitness for purpose and, to the extent
+// permitted by law, all liability for your use of the code is disclaimed. This source code is governed by Apache
+// License 2.0 that can be found in the LICENSE file.
+
+package devnet
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+	"net"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	ecrypto "github.com/ethereum/go-ethereum/crypto"
+	hdwallet "github.com/miguelmota/go-ethereum-hdwallet"
+	"github.com/pkg/errors"
+	"go.uber.org/zap"
+
+	"github.com/iotexproject/go-pkgs/crypto"
+
+	"github.com/iotexproject/iotex-core/api"
+	"github.com/iotexproject/iotex-core/blockchain/block"
+	"github.com/iotexproject/iotex-core/blockchain/genesis"
+	"github.com/iotexproject/iotex-core/chainservice"
+	"github.com/iotexproject/iotex-core/config"
+	"github.com/iotexproject/iotex-core/pkg/log"
+	"github.com/iotexproject/iotex-core/pkg/routine"
+	"github.com/iotexproject/iotex-core/pkg/unit"
+)
+
+const (
+	// DefaultMnemonic is the mnemonic which the prefunded accounts are derived from by default
+	DefaultMnemonic = "test test test test test test test test test test test junk"
+	// _derivationPath is the path of the i-th account, which is the same as "ioctl hdwallet derive 0/0/i"
+	_derivationPath = "m/44'/304'/0'/0/%d"
+	// _automineInterval is the interval to check the actpool for new actions when mining on demand
+	_automineInterval = 100 * time.Millisecond
+)
+
+var (
+	// ErrSnapshotNotExist indicates that the snapshot to revert doesn't exist
+	ErrSnapshotNotExist = errors.New("snapshot doesn't exist")
+)
+
+type (
+	// Config is the config of devnet
+	Config struct {
+		// Accounts is the number of prefunded accounts
+		Accounts int
+		// Balance is the initial balance of each prefunded account in IOTX
+		Balance int64
+		// Mnemonic is the mnemonic which the prefunded accounts are derived from
+		Mnemonic string
+		// BlockTime is the interval of blocks, 0 means a block is mined as soon as actions arrive
+		BlockTime time.Duration
+	}
+
+	// Devnet is a local blockchain with prefunded accounts, which mines blocks periodically or
+	// on demand, and supports to snapshot, revert and shift the time of chain for testing contracts
+	Devnet struct {
+		cfg       config.Config
+		keys      []crypto.PrivateKey
+		web3      *api.HTTPServer
+		miner     *routine.RecurringTask
+		blockTime time.Duration
+
+		// mutex is held to read during forwarding web3 requests, such that they are not forwarded to the node
+		// being restarted
+		mutex     sync.RWMutex
+		cs        *chainservice.ChainService
+		apiServer *api.ServerV2
+		dataDir   string
+		// offset is the time shifted by evm_increaseTime and evm_mine
+		offset    time.Duration
+		snapshots []snapshot
+	}
+
+	snapshot struct {
+		height uint64
+		offset time.Duration
+	}
+)
+
+// DefaultConfig is the default config of devnet
+var DefaultConfig = Config{
+	Accounts: 10,
+	Balance:  10000,
+	Mnemonic: DefaultMnemonic,
+}
+
+// New creates a devnet. The node runs with the api ports in cfg, except that the web3 port is served by devnet to
+// handle the evm_* methods, and the other web3 requests are forwarded to the node
+func New(cfg config.Config, dcfg Config) (*Devnet, error) {
+	keys, err := deriveKeys(dcfg.Mnemonic, dcfg.Accounts)
+	if err != nil {
+		return nil, err
+	}
+	cfg.Genesis = devnetGenesis(cfg.Genesis, keys, unit.ConvertIotxToRau(dcfg.Balance))
+	genesis.SetGenesisTimestamp(cfg.Genesis.Timestamp)
+	block.LoadGenesisHash(&cfg.Genesis)
+
+	// the blocks are mined by devnet instead of the standalone scheme
+	cfg.Consensus.Scheme = config.StandaloneScheme
+	cfg.Genesis.BlockInterval = 0
+	plugins := make(map[int]interface{}, len(cfg.Plugins)+1)
+	for k, v := range cfg.Plugins {
+		plugins[k] = v
+	}
+	plugins[config.GatewayPlugin] = nil
+	cfg.Plugins = plugins
+	cfg.Chain.EnableAsyncIndexWrite = false
+	cfg.Chain.PipelineDepth = 0
+	cfg.ActPool.MinGasPriceStr = "0"
+	web3Port := cfg.API.HTTPPort
+	if cfg.API.HTTPPort, err = freePort(); err != nil {
+		return nil, err
+	}
+
+	d := &Devnet{
+		cfg:       cfg,
+		keys:      keys,
+		blockTime: dcfg.BlockTime,
+	}
+	d.web3 = api.NewHTTPServer("", web3Port, newWeb3Proxy(d, fmt.Sprintf("http://127.0.0.1:%d", cfg.API.HTTPPort)))
+	interval := dcfg.BlockTime
+	if interval == 0 {
+		interval = _automineInterval
+	}
+	d.miner = routine.NewRecurringTask(d.tick, interval)
+	return d, nil
+}
+
+// freePort returns a port free on the loopback interface, which the web3 server of the node listens on behind the
+// devnet proxy
+func freePort() (int, error) {
+	l, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		return 0, errors.Wrap(err, "failed to pick a free port")
+	}
+	defer l.Close()
+	return l.Addr().(*net.TCPAddr).Port, nil
+}
+
+// devnetGenesis returns the genesis in which all the features are enabled since the first block, and the accounts
+// are prefunded
+func devnetGenesis(g genesis.Genesis, keys []crypto.PrivateKey, balance *big.Int) genesis.Genesis {
+	g.Timestamp = time.Now().Unix()
+	g.EnableGravityChainVoting = false
+	g.PacificBlockHeight = 1
+	g.AleutianBlockHeight = 1
+	g.BeringBlockHeight = 1
+	g.CookBlockHeight = 1
+	g.DardanellesBlockHeight = 1
+	g.DaytonaBlockHeight = 1
+	g.EasterBlockHeight = 1
+	g.FbkMigrationBlockHeight = 1
+	g.FairbankBlockHeight = 1
+	g.GreenlandBlockHeight = 1
+	g.HawaiiBlockHeight = 1
+	g.IcelandBlockHeight = 1
+	g.JutlandBlockHeight = 1
+	g.KamchatkaBlockHeight = 1
+	g.LordHoweBlockHeight = 1
+	g.MidwayBlockHeight = 1
+	g.NewfoundlandBlockHeight = 1
+	g.OkhotskBlockHeight = 1
+	g.InitBalanceMap = make(map[string]string, len(keys))
+	for _, key := range keys {
+		g.InitBalanceMap[key.PublicKey().Address().String()] = balance.String()
+	}
+	return g
+}
+
+func deriveKeys(mnemonic string, n int) ([]crypto.PrivateKey, error) {
+	wallet, err := hdwallet.NewFromMnemonic(mnemonic)
+	if err != nil {
+		return nil, errors.Wrap(err, "invalid mnemonic")
+	}
+	keys := make([]crypto.PrivateKey, 0, n)
+	for i := 0; i < n; i++ {
+		account, err := wallet.Derive(hdwallet.MustParseDerivationPath(fmt.Sprintf(_derivationPath, i)), false)
+		if err != nil {
+			return nil, err
+		}
+		sk, err := wallet.PrivateKey(account)
+		if err != nil {
+			return nil, err
+		}
+		key, err := crypto.BytesToPrivateKey(ecrypto.FromECDSA(sk))
+		if err != nil {
+			return nil, err
+		}
+		keys = append(keys, key)
+	}
+	return keys, nil
+}
+
+// Keys returns the private keys of the prefunded accounts
+func (d *Devnet) Keys() []crypto.PrivateKey {
+	return d.keys
+}
+
+// Start starts the devnet
+func (d *Devnet) Start(ctx context.Context) error {
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+	if err := d.startNode(ctx, nil); err != nil {
+		return err
+	}
+	if err := d.web3.Start(ctx); err != nil {
+		return err
+	}
+	return d.miner.Start(ctx)
+}
+
+// Stop stops the devnet
+func (d *Devnet) Stop(ctx context.Context) error {
+	if err := d.miner.Stop(ctx); err != nil {
+		return err
+	}
+	if err := d.web3.Stop(ctx); err != nil {
+		return err
+	}
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+	return d.stopNode(ctx)
+}
+
+// ChainService returns the chain service of the running node, which is replaced after reverting to a snapshot
+func (d *Devnet) ChainService() *chainservice.ChainService {
+	d.mutex.RLock()
+	defer d.mutex.RUnlock()
+	return d.cs
+}
+
+// startNode starts a node with the dbs in a temporary directory, and commits the blocks of an earlier node if any.
+// The in-memory dbs are not used because they don't support to filter the states, which is required by staking
+func (d *Devnet) startNode(ctx context.Context, blks []*block.Block) error {
+	dataDir, err := os.MkdirTemp("", "iotex-devnet")
+	if err != nil {
+		return errors.Wrap(err, "failed to create data directory")
+	}
+	cfg := d.cfg
+	cfg.Chain.ChainDBPath = filepath.Join(dataDir, "chain.db")
+	cfg.Chain.TrieDBPath = filepath.Join(dataDir, "trie.db")
+	cfg.Chain.TrieDBPatchFile = ""
+	cfg.Chain.IndexDBPath = filepath.Join(dataDir, "index.db")
+	cfg.Chain.BloomfilterIndexDBPath = filepath.Join(dataDir, "bloomfilter.index.db")
+	cfg.Chain.CandidateIndexDBPath = filepath.Join(dataDir, "candidate.index.db")
+	cfg.Chain.StakingIndexDBPath = filepath.Join(dataDir, "staking.index.db")
+	cfg.Chain.AnalyticsIndexDBPath = filepath.Join(dataDir, "analytics.index.db")
+	cfg.Chain.BalanceIndexDBPath = filepath.Join(dataDir, "balance.index.db")
+	cfg.Chain.GravityChainDB.DbPath = filepath.Join(dataDir, "poll.db")
+	cfg.Consensus.RollDPoS.ConsensusDBPath = filepath.Join(dataDir, "consensus.db")
+	cfg.Consensus.RollDPoS.ConsensusWALPath = filepath.Join(dataDir, "consensus.wal")
+	d.dataDir = dataDir
+
+	cs, err := chainservice.NewBuilder(cfg).Build()
+	if err != nil {
+		return errors.Wrap(err, "failed to create chain service")
+	}
+	apiServer, err := cs.NewAPIServer(cfg.API, cfg.Plugins)
+	if err != nil {
+		return errors.Wrap(err, "failed to create api server")
+	}
+	if err := cs.Blockchain().AddSubscriber(apiServer); err != nil {
+		return errors.Wrap(err, "failed to add api server as subscriber")
+	}
+	if err := cs.Start(ctx); err != nil {
+		return errors.Wrap(err, "failed to start chain service")
+	}
+	bc := cs.Blockchain()
+	for _, blk := range blks {
+		if err := bc.ValidateBlock(blk); err != nil {
+			return errors.Wrapf(err, "failed to validate block %d", blk.Height())
+		}
+		if err := bc.CommitBlock(blk); err != nil {
+			return errors.Wrapf(err, "failed to commit block %d", blk.Height())
+		}
+	}
+	if err := apiServer.Start(ctx); err != nil {
+		return errors.Wrap(err, "failed to start api server")
+	}
+	d.cs, d.apiServer = cs, apiServer
+	return waitForPort(d.cfg.API.HTTPPort)
+}
+
+// waitForPort waits for the http server of node to listen, which is started asynchronously
+func waitForPort(port int) error {
+	addr := fmt.Sprintf("127.0.0.1:%d", port)
+	for i := 0; i < 50; i++ {
+		conn, err := net.DialTimeout("tcp", addr, time.Second)
+		if err == nil {
+			return conn.Close()
+		}
+		time.Sleep(20 * time.Millisecond)
+	}
+	return errors.Errorf("node doesn't listen on %s", addr)
+}
+
+func (d *Devnet) stopNode(ctx context.Context) error {
+	if err := d.apiServer.Stop(ctx); err != nil {
+		return errors.Wrap(err, "failed to stop api server")
+	}
+	if err := d.cs.Stop(ctx); err != nil {
+		return err
+	}
+	return os.RemoveAll(d.dataDir)
+}
+
+// tick mines a block every block time, or as soon as actions arrive if the block time is 0
+func (d *Devnet) tick() {
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+	if d.blockTime == 0 && len(d.cs.ActionPool().PendingActionMap()) == 0 {
+		return
+	}
+	if _, err := d.mine(time.Time{}); err != nil {
+		log.L().Error("Failed to mine a block.", zap.Error(err))
+	}
+}
+
+// Mine mines a block at the timestamp, a zero timestamp means the current time shifted by evm_increaseTime. The time
+// of the following blocks is shifted to continue from the timestamp
+func (d *Devnet) Mine(timestamp time.Time) (*block.Block, error) {
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+	if !timestamp.IsZero() {
+		d.offset = time.Until(timestamp)
+	}
+	return d.mine(timestamp)
+}
+
+func (d *Devnet) mine(timestamp time.Time) (*block.Block, error) {
+	bc := d.cs.Blockchain()
+	if timestamp.IsZero() {
+		timestamp = time.Now().Add(d.offset)
+	}
+	timestamp = timestamp.Truncate(time.Second)
+	// the timestamps of blocks are strictly increasing
+	tipTime := time.Unix(d.cfg.Genesis.Timestamp, 0)
+	if tipHeight := bc.TipHeight(); tipHeight > 0 {
+		header, err := bc.BlockHeaderByHeight(tipHeight)
+		if err != nil {
+			return nil, err
+		}
+		tipTime = header.Timestamp()
+	}
+	if !timestamp.After(tipTime) {
+		timestamp = tipTime.Add(time.Second)
+	}
+	blk, err := bc.MintNewBlock(timestamp)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to mint block")
+	}
+	if err := bc.CommitBlock(blk); err != nil {
+		return nil, errors.Wrap(err, "failed to commit block")
+	}
+	log.L().Info("Mined a block.", zap.Uint64("height", blk.Height()), zap.Int("actions", len(blk.Actions)))
+	return blk, nil
+}
+
+// Snapshot takes a snapshot of the chain, and returns the id of snapshot
+func (d *Devnet) Snapshot() uint64 {
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+	d.snapshots = append(d.snapshots, snapshot{
+		height: d.cs.Blockchain().TipHeight(),
+		offset: d.offset,
+	})
+	return uint64(len(d.snapshots))
+}
+
+// Revert reverts the chain to the snapshot, the snapshot and the ones taken after it are deleted. The node is
+// restarted by committing the blocks before the snapshot again
+func (d *Devnet) Revert(ctx context.Context, id uint64) error {
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+	if id == 0 || id > uint64(len(d.snapshots)) {
+		return errors.Wrapf(ErrSnapshotNotExist, "id %d", id)
+	}
+	s := d.snapshots[id-1]
+	dao := d.cs.BlockDAO()
+	deser := block.NewDeserializer(d.cfg.Chain.EVMNetworkID)
+	blks := make([]*block.Block, 0, s.height)
+	for h := uint64(1); h <= s.height; h++ {
+		blk, err := dao.GetBlockByHeight(h)
+		if err != nil {
+			return err
+		}
+		// the block is copied, such that it can be committed again
+		if blk, err = deser.FromBlockProto(blk.ConvertToBlockPb()); err != nil {
+			return err
+		}
+		blks = append(blks, blk)
+	}
+	if err := d.stopNode(ctx); err != nil {
+		return err
+	}
+	if err := d.startNode(ctx, blks); err != nil {
+		return err
+	}
+	d.offset = s.offset
+	d.snapshots = d.snapshots[:id-1]
+	log.L().Info("Reverted to snapshot.", zap.Uint64("id", id), zap.Uint64("height", s.height))
+	return nil
+}
+
+// IncreaseTime shifts the time of the following blocks, and returns the total time shifted
+func (d *Devnet) IncreaseTime(delta time.Duration) time.Duration {
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+	d.offset += delta
+	return d.offset
+}
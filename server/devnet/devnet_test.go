@@ -0,0 +1,127 @@
+// Copyright (c) 2022 IoTeX Foundation
+// This is an alpha (internal) release and is not suitable for production. This source code is provided 'as is' and no
+// warranties are given as to title or non-infringement, merchantability or fitness for purpose and, to the extent
+// permitted by law, all liability for your use of the code is disclaimed. This source code is governed by Apache
+// License 2.0 that can be found in the LICENSE file.
+
+package devnet
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"github.com/tidwall/gjson"
+
+	"github.com/iotexproject/iotex-core/config"
+	"github.com/iotexproject/iotex-core/testutil"
+)
+
+func TestDeriveKeys(t *testing.T) {
+	require := require.New(t)
+	keys, err := deriveKeys(DefaultMnemonic, 3)
+	require.NoError(err)
+	require.Len(keys, 3)
+	again, err := deriveKeys(DefaultMnemonic, 3)
+	require.NoError(err)
+	for i := range keys {
+		require.Equal(keys[i].Bytes(), again[i].Bytes())
+	}
+	require.NotEqual(keys[0].Bytes(), keys[1].Bytes())
+
+	_, err = deriveKeys("not a mnemonic", 1)
+	require.Error(err)
+}
+
+func TestParseQuantity(t *testing.T) {
+	require := require.New(t)
+	for _, c := range []struct {
+		in  string
+		out uint64
+		err bool
+	}{
+		{`10`, 10, false},
+		{`"0x10"`, 16, false},
+		{`"10"`, 10, false},
+		{`-1`, 0, true},
+		{`true`, 0, true},
+		{`"0xzz"`, 0, true},
+	} {
+		v, err := parseQuantity(gjson.Parse(c.in))
+		if c.err {
+			require.Error(err, c.in)
+			continue
+		}
+		require.NoError(err, c.in)
+		require.Equal(c.out, v, c.in)
+	}
+}
+
+func TestDevnet(t *testing.T) {
+	require := require.New(t)
+	cfg := config.Default
+	cfg.API.GRPCPort = testutil.RandomPort()
+	cfg.API.HTTPPort = testutil.RandomPort()
+	cfg.API.WebSocketPort = 0
+	d, err := New(cfg, Config{
+		Accounts: 2,
+		Balance:  100,
+		Mnemonic: DefaultMnemonic,
+	})
+	require.NoError(err)
+	ctx := context.Background()
+	require.NoError(d.Start(ctx))
+	defer func() {
+		require.NoError(d.Stop(ctx))
+	}()
+	require.NoError(waitForPort(cfg.API.HTTPPort))
+
+	url := fmt.Sprintf("http://127.0.0.1:%d", cfg.API.HTTPPort)
+	call := func(method, params string) gjson.Result {
+		body := fmt.Sprintf(`{"jsonrpc":"2.0","id":1,"method":"%s","params":[%s]}`, method, params)
+		resp, err := http.Post(url, "application/json", strings.NewReader(body))
+		require.NoError(err)
+		defer resp.Body.Close()
+		data, err := io.ReadAll(resp.Body)
+		require.NoError(err)
+		res := gjson.ParseBytes(data)
+		require.False(res.Get("error").Exists(), string(data))
+		return res.Get("result")
+	}
+
+	// the accounts are prefunded
+	addr := d.Keys()[0].PublicKey().Address().Hex()
+	require.Equal("0x56bc75e2d63100000", call("eth_getBalance", `"`+addr+`", "latest"`).String())
+
+	// no block is mined without actions
+	require.Equal("0x0", call("eth_blockNumber", "").String())
+	id := call("evm_snapshot", "").String()
+	require.Equal("0x1", id)
+	call("evm_mine", "")
+	require.Equal(int64(3600), call("evm_increaseTime", "3600").Int())
+	call("evm_mine", "")
+	require.Equal("0x2", call("eth_blockNumber", "").String())
+	blk := call("eth_getBlockByNumber", `"0x2", false`)
+	ts, err := parseQuantity(blk.Get("timestamp"))
+	require.NoError(err)
+	require.True(time.Unix(int64(ts), 0).After(time.Now().Add(59 * time.Minute)))
+
+	// revert to the snapshot
+	require.True(call("evm_revert", `"`+id+`"`).Bool())
+	require.Equal("0x0", call("eth_blockNumber", "").String())
+	require.False(call("evm_revert", `"`+id+`"`).Bool())
+	require.Equal(int64(0), call("evm_increaseTime", "0").Int())
+
+	// mine at a timestamp
+	future := time.Now().Add(24 * time.Hour).Unix()
+	call("evm_mine", fmt.Sprintf("%d", future))
+	blk = call("eth_getBlockByNumber", `"0x1", false`)
+	ts, err = parseQuantity(blk.Get("timestamp"))
+	require.NoError(err)
+	require.Equal(uint64(future), ts)
+}
@@ -0,0 +1,246 @@
+// Copyright (c) 2022 IoTeX Foundation
+// This is an alpha (internal) release and is not suitable for production. This source code is provided 'as is' and no
+// warranties are given as to title or non-infringement, merchantability or fitness for purpose and, to the extent
+// permitted by law, all liability for your use of the code is disclaimed. This source code is governed by Apache
+// License 2.0 that can be found in the LICENSE file.
+
+package devnet
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/tidwall/gjson"
+	"go.uber.org/zap"
+
+	"github.com/iotexproject/iotex-core/pkg/log"
+)
+
+const (
+	_evmMethodPrefix = "evm_"
+	// _errCodeInvalidParams is the json-rpc error code of invalid params
+	_errCodeInvalidParams = -32602
+	// _errCodeInternal is the json-rpc error code of internal error
+	_errCodeInternal = -32603
+	// _errCodeMethodNotFound is the json-rpc error code of unknown method
+	_errCodeMethodNotFound = -32601
+)
+
+type (
+	// web3Proxy handles the evm_* methods of devnet, and forwards the other web3 requests to the node
+	web3Proxy struct {
+		d      *Devnet
+		target string
+		client *http.Client
+	}
+
+	rpcResponse struct {
+		JSONRPC string          `json:"jsonrpc"`
+		ID      json.RawMessage `json:"id"`
+		Result  interface{}     `json:"result,omitempty"`
+		Error   *rpcError       `json:"error,omitempty"`
+	}
+
+	rpcError struct {
+		Code    int    `json:"code"`
+		Message string `json:"message"`
+	}
+)
+
+func newWeb3Proxy(d *Devnet, target string) *web3Proxy {
+	return &web3Proxy{
+		d:      d,
+		target: target,
+		client: &http.Client{Timeout: time.Minute},
+	}
+}
+
+func (p *web3Proxy) ServeHTTP(w http.ResponseWriter, req *http.Request) {
+	if req.Method != http.MethodPost {
+		w.WriteHeader(http.StatusMethodNotAllowed)
+		return
+	}
+	data, err := io.ReadAll(req.Body)
+	if err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+	if !gjson.ValidBytes(data) {
+		// let the node reply the error in its format
+		p.forward(w, data)
+		return
+	}
+	reqs := gjson.ParseBytes(data)
+	if !reqs.IsArray() {
+		if !isEVMMethod(&reqs) {
+			p.forward(w, data)
+			return
+		}
+		p.write(w, p.handleEVM(req.Context(), &reqs))
+		return
+	}
+	hasEVM := false
+	for _, r := range reqs.Array() {
+		r := r
+		hasEVM = hasEVM || isEVMMethod(&r)
+	}
+	if !hasEVM {
+		p.forward(w, data)
+		return
+	}
+	// the batch is split, such that the evm_* methods are handled in order with the others
+	resps := make([]json.RawMessage, 0, len(reqs.Array()))
+	for _, r := range reqs.Array() {
+		r := r
+		var resp []byte
+		if isEVMMethod(&r) {
+			resp, err = json.Marshal(p.handleEVM(req.Context(), &r))
+		} else {
+			resp, err = p.roundTrip([]byte(r.Raw))
+		}
+		if err != nil {
+			resp, _ = json.Marshal(errorResponse(&r, _errCodeInternal, err))
+		}
+		resps = append(resps, bytes.TrimSpace(resp))
+	}
+	p.write(w, resps)
+}
+
+func isEVMMethod(req *gjson.Result) bool {
+	return strings.HasPrefix(req.Get("method").String(), _evmMethodPrefix)
+}
+
+func (p *web3Proxy) write(w http.ResponseWriter, resp interface{}) {
+	w.Header().Set("Content-Type", "application/json; charset=UTF-8")
+	if err := json.NewEncoder(w).Encode(resp); err != nil {
+		log.L().Warn("Failed to respond devnet request.", zap.Error(err))
+	}
+}
+
+// forward forwards the request to the node, the node is not restarted until the response is received
+func (p *web3Proxy) forward(w http.ResponseWriter, data []byte) {
+	resp, err := p.roundTrip(data)
+	if err != nil {
+		log.L().Warn("Failed to forward devnet request.", zap.Error(err))
+		w.WriteHeader(http.StatusBadGateway)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json; charset=UTF-8")
+	if _, err := w.Write(resp); err != nil {
+		log.L().Warn("Failed to respond devnet request.", zap.Error(err))
+	}
+}
+
+func (p *web3Proxy) roundTrip(data []byte) ([]byte, error) {
+	p.d.mutex.RLock()
+	defer p.d.mutex.RUnlock()
+	resp, err := p.client.Post(p.target, "application/json", bytes.NewReader(data))
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	return io.ReadAll(resp.Body)
+}
+
+func (p *web3Proxy) handleEVM(ctx context.Context, req *gjson.Result) *rpcResponse {
+	var (
+		res    interface{}
+		err    error
+		code   = _errCodeInvalidParams
+		method = req.Get("method").String()
+		params = req.Get("params")
+	)
+	switch method {
+	case "evm_snapshot":
+		res = hexUint64(p.d.Snapshot())
+	case "evm_revert":
+		var id uint64
+		if id, err = parseQuantity(params.Get("0")); err == nil {
+			err = p.d.Revert(ctx, id)
+			// a snapshot which doesn't exist is reported by the result as hardhat does
+			res = err == nil
+			if errors.Cause(err) == ErrSnapshotNotExist {
+				err = nil
+			}
+			code = _errCodeInternal
+		}
+	case "evm_increaseTime":
+		var delta uint64
+		if delta, err = parseQuantity(params.Get("0")); err == nil {
+			res = int64(p.d.IncreaseTime(time.Duration(delta)*time.Second) / time.Second)
+		}
+	case "evm_mine":
+		var timestamp time.Time
+		if ts := params.Get("0"); ts.Exists() {
+			var sec uint64
+			if sec, err = parseQuantity(ts); err != nil {
+				break
+			}
+			timestamp = time.Unix(int64(sec), 0)
+		}
+		if _, err = p.d.Mine(timestamp); err == nil {
+			res = "0x0"
+		}
+		code = _errCodeInternal
+	default:
+		err = errors.Errorf("devnet method %s not found", method)
+		code = _errCodeMethodNotFound
+	}
+	if err != nil {
+		log.L().Error("Failed to handle devnet request.", zap.String("method", method), zap.Error(err))
+		return errorResponse(req, code, err)
+	}
+	return &rpcResponse{
+		JSONRPC: "2.0",
+		ID:      requestID(req),
+		Result:  res,
+	}
+}
+
+func errorResponse(req *gjson.Result, code int, err error) *rpcResponse {
+	return &rpcResponse{
+		JSONRPC: "2.0",
+		ID:      requestID(req),
+		Error: &rpcError{
+			Code:    code,
+			Message: err.Error(),
+		},
+	}
+}
+
+func requestID(req *gjson.Result) json.RawMessage {
+	if id := req.Get("id"); id.Exists() {
+		return json.RawMessage(id.Raw)
+	}
+	return json.RawMessage("null")
+}
+
+// parseQuantity parses a number or a hex string prefixed with 0x
+func parseQuantity(v gjson.Result) (uint64, error) {
+	switch v.Type {
+	case gjson.Number:
+		if v.Num < 0 {
+			return 0, errors.Errorf("invalid quantity %s", v.Raw)
+		}
+		return v.Uint(), nil
+	case gjson.String:
+		s := v.String()
+		if strings.HasPrefix(s, "0x") {
+			return strconv.ParseUint(s[2:], 16, 64)
+		}
+		return strconv.ParseUint(s, 10, 64)
+	default:
+		return 0, errors.Errorf("invalid quantity %s", v.Raw)
+	}
+}
+
+func hexUint64(v uint64) string {
+	return "0x" + strconv.FormatUint(v, 16)
+}
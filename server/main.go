@@ -20,6 +20,7 @@ import (
 	"os/signal"
 	"strings"
 	"syscall"
+	"time"
 
 	"github.com/iotexproject/go-pkgs/hash"
 	_ "go.uber.org/automaxprocs"
@@ -31,6 +32,7 @@ import (
 	"github.com/iotexproject/iotex-core/pkg/log"
 	"github.com/iotexproject/iotex-core/pkg/probe"
 	"github.com/iotexproject/iotex-core/pkg/recovery"
+	"github.com/iotexproject/iotex-core/server/devnet"
 	"github.com/iotexproject/iotex-core/server/itx"
 )
 
@@ -44,6 +46,12 @@ var (
 	_secretPath    string
 	_subChainPath  string
 	_plugins       strs
+
+	_devnet          bool
+	_devnetAccounts  int
+	_devnetBalance   int64
+	_devnetMnemonic  string
+	_devnetBlockTime time.Duration
 )
 
 type strs []string
@@ -63,6 +71,11 @@ func init() {
 	flag.StringVar(&_secretPath, "secret-path", "", "Secret path")
 	flag.StringVar(&_subChainPath, "sub-config-path", "", "Sub chain Config path")
 	flag.Var(&_plugins, "plugin", "Plugin of the node")
+	flag.BoolVar(&_devnet, "devnet", false, "Run a local devnet with prefunded accounts")
+	flag.IntVar(&_devnetAccounts, "devnet-accounts", devnet.DefaultConfig.Accounts, "Number of prefunded accounts of devnet")
+	flag.Int64Var(&_devnetBalance, "devnet-balance", devnet.DefaultConfig.Balance, "Balance of each prefunded account of devnet in IOTX")
+	flag.StringVar(&_devnetMnemonic, "devnet-mnemonic", devnet.DefaultConfig.Mnemonic, "Mnemonic which the prefunded accounts of devnet are derived from")
+	flag.DurationVar(&_devnetBlockTime, "devnet-block-time", devnet.DefaultConfig.BlockTime, "Block time of devnet, 0 means mining a block as soon as actions arrive")
 	flag.Usage = func() {
 		_, _ = fmt.Fprintf(os.Stderr,
 			"usage: server -config-path=[string]\n")
@@ -80,6 +93,15 @@ func main() {
 	stopped := make(chan struct{})
 	livenessCtx, livenessCancel := context.WithCancel(context.Background())
 
+	if _devnet {
+		go func() {
+			<-stop
+			cancel()
+		}()
+		runDevnet(ctx)
+		return
+	}
+
 	genesisCfg, err := genesis.New(_genesisPath)
 	if err != nil {
 		glog.Fatalln("Failed to new genesis config.", zap.Error(err))
@@ -167,6 +189,40 @@ func main() {
 	<-livenessCtx.Done()
 }
 
+// runDevnet runs a devnet until the context is done, the genesis of devnet is generated instead of loaded
+func runDevnet(ctx context.Context) {
+	cfg, err := config.New([]string{_overwritePath, _secretPath}, _plugins)
+	if err != nil {
+		glog.Fatalln("Failed to new config.", zap.Error(err))
+	}
+	if err = initLogger(cfg); err != nil {
+		glog.Fatalln("Cannot config global logger, use default one: ", zap.Error(err))
+	}
+	d, err := devnet.New(cfg, devnet.Config{
+		Accounts:  _devnetAccounts,
+		Balance:   _devnetBalance,
+		Mnemonic:  _devnetMnemonic,
+		BlockTime: _devnetBlockTime,
+	})
+	if err != nil {
+		log.L().Fatal("Failed to create devnet.", zap.Error(err))
+	}
+	if err := d.Start(ctx); err != nil {
+		log.L().Fatal("Failed to start devnet.", zap.Error(err))
+	}
+	fmt.Printf("Devnet is running, web3 endpoint: http://127.0.0.1:%d, grpc endpoint: 127.0.0.1:%d\n",
+		cfg.API.HTTPPort, cfg.API.GRPCPort)
+	fmt.Printf("Accounts (%d IOTX each):\n", _devnetBalance)
+	for i, key := range d.Keys() {
+		addr := key.PublicKey().Address()
+		fmt.Printf("(%d) %s %s\n    private key: %x\n", i, addr.String(), addr.Hex(), key.Bytes())
+	}
+	<-ctx.Done()
+	if err := d.Stop(context.Background()); err != nil {
+		log.L().Error("Failed to stop devnet.", zap.Error(err))
+	}
+}
+
 func initLogger(cfg config.Config) error {
 	addr := cfg.Chain.ProducerAddress()
 	return log.InitLoggers(cfg.Log, cfg.SubLogs, zap.AddCaller(), zap.Fields(
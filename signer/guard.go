@@ -0,0 +1,157 @@
+// Copyright (c) 2022 IoTeX Foundation
+// This is an alpha (internal) release and is not suitable for production. This source code is provided 'as is' and no
+// warranties are given as to title or non-infringement, merchantability or fitness for purpose and, to the extent
+// permitted by law, all liability for your use of the code is disclaimed. This source code is governed by Apache
+// License 2.0 that can be found in the LICENSE file.
+
+package signer
+
+import (
+	"bytes"
+	"os"
+	"sync"
+
+	"github.com/pkg/errors"
+	"google.golang.org/protobuf/proto"
+
+	"github.com/iotexproject/iotex-core/signer/signerpb"
+)
+
+type (
+	signedKey struct {
+		kind  Kind
+		topic uint32
+	}
+
+	// guard keeps the consensus messages signed in the latest round, and refuses the requests which would make the
+	// producer double sign, i.e., the ones of an earlier round, or of another block in the same round. If the path
+	// is not empty, the latest round and the signed messages are persisted before a signature is returned, so that
+	// they are kept after restart.
+	guard struct {
+		mutex  sync.Mutex
+		path   string
+		height uint64
+		round  uint32
+		signed map[signedKey][]byte
+	}
+)
+
+func newGuard() *guard {
+	return &guard{signed: map[signedKey][]byte{}}
+}
+
+// newPersistentGuard creates a guard persisted in the file of the path, and loads the state if the file exists
+func newPersistentGuard(path string) (*guard, error) {
+	g := newGuard()
+	g.path = path
+	data, err := os.ReadFile(path)
+	switch {
+	case os.IsNotExist(err):
+		return g, nil
+	case err != nil:
+		return nil, errors.Wrapf(err, "failed to read guard state %s", path)
+	}
+	state := &signerpb.GuardState{}
+	if err := proto.Unmarshal(data, state); err != nil {
+		return nil, errors.Wrapf(err, "failed to unmarshal guard state %s", path)
+	}
+	g.height, g.round = state.GetHeight(), state.GetRound()
+	for _, msg := range state.GetSigned() {
+		g.signed[signedKey{kind: Kind(msg.GetKind()), topic: msg.GetTopic()}] = msg.GetBlockHash()
+	}
+	return g, nil
+}
+
+// approve checks the request and records it as signed
+func (g *guard) approve(req *Request) error {
+	g.mutex.Lock()
+	defer g.mutex.Unlock()
+	switch req.Kind {
+	case BlockKind, ActionKind:
+		// a block and the system actions in it are signed at the height to propose
+		if req.Height < g.height {
+			return errors.Wrapf(ErrDoubleSign, "height %d is behind the signed height %d", req.Height, g.height)
+		}
+		return nil
+	case ProposalKind, VoteKind:
+	default:
+		return errors.Wrapf(ErrInvalidRequest, "unknown kind %d", req.Kind)
+	}
+	height, round, signed := g.height, g.round, g.signed
+	switch {
+	case req.Height < height || (req.Height == height && req.Round < round):
+		return errors.Wrapf(
+			ErrDoubleSign,
+			"height %d round %d is behind the signed height %d round %d",
+			req.Height,
+			req.Round,
+			height,
+			round,
+		)
+	case req.Height > height || req.Round > round:
+		height, round = req.Height, req.Round
+		signed = map[signedKey][]byte{}
+	}
+	key := signedKey{kind: req.Kind, topic: req.Topic}
+	blkHash, ok := signed[key]
+	switch {
+	case ok && !bytes.Equal(blkHash, req.BlockHash):
+		return errors.Wrapf(
+			ErrDoubleSign,
+			"block %x has been signed at height %d round %d",
+			blkHash,
+			req.Height,
+			req.Round,
+		)
+	case ok:
+		// the same message has been signed and persisted
+		return nil
+	}
+	signed[key] = req.BlockHash
+	if err := g.persist(height, round, signed); err != nil {
+		delete(signed, key)
+		return err
+	}
+	g.height, g.round, g.signed = height, round, signed
+	return nil
+}
+
+// persist writes the state into a temporary file and renames it to the path, such that the file is never partially
+// written
+func (g *guard) persist(height uint64, round uint32, signed map[signedKey][]byte) error {
+	if g.path == "" {
+		return nil
+	}
+	state := &signerpb.GuardState{
+		Height: height,
+		Round:  round,
+	}
+	for key, blkHash := range signed {
+		state.Signed = append(state.Signed, &signerpb.SignedMessage{
+			Kind:      signerpb.Kind(key.kind),
+			Topic:     key.topic,
+			BlockHash: blkHash,
+		})
+	}
+	data, err := proto.Marshal(state)
+	if err != nil {
+		return err
+	}
+	tmp := g.path + ".tmp"
+	file, err := os.OpenFile(tmp, os.O_WRONLY|os.O_CREATE|os.O_TRUNC, 0600)
+	if err != nil {
+		return errors.Wrapf(err, "failed to open guard state %s", tmp)
+	}
+	if _, err := file.Write(data); err != nil {
+		file.Close()
+		return errors.Wrapf(err, "failed to write guard state %s", tmp)
+	}
+	if err := file.Sync(); err != nil {
+		file.Close()
+		return errors.Wrapf(err, "failed to sync guard state %s", tmp)
+	}
+	if err := file.Close(); err != nil {
+		return errors.Wrapf(err, "failed to close guard state %s", tmp)
+	}
+	return errors.Wrapf(os.Rename(tmp, g.path), "failed to rename guard state %s", tmp)
+}
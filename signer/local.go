@@ -0,0 +1,53 @@
+// Copyright (c) 2022 IoTeX Foundation
+// This is an alpha (internal) release and is not suitable for production. This source code is provided 'as is' and no
+// warranties are given as to title or non-infringement, merchantability or fitness for purpose and, to the extent
+// permitted by law, all liability for your use of the code is disclaimed. This source code is governed by Apache
+// License 2.0 that can be found in the LICENSE file.
+
+package signer
+
+import (
+	"context"
+
+	"github.com/iotexproject/go-pkgs/crypto"
+)
+
+type localSigner struct {
+	sk    crypto.PrivateKey
+	guard *guard
+}
+
+// NewLocalSigner creates a signer with the private key held in memory
+func NewLocalSigner(sk crypto.PrivateKey) Signer {
+	return &localSigner{
+		sk:    sk,
+		guard: newGuard(),
+	}
+}
+
+// NewPersistentLocalSigner creates a signer with the private key held in memory, which persists the signed consensus
+// messages of the latest round in the file of the path, so that it refuses to double sign after restart
+func NewPersistentLocalSigner(sk crypto.PrivateKey, path string) (Signer, error) {
+	g, err := newPersistentGuard(path)
+	if err != nil {
+		return nil, err
+	}
+	return &localSigner{
+		sk:    sk,
+		guard: g,
+	}, nil
+}
+
+func (s *localSigner) PublicKey() crypto.PublicKey {
+	return s.sk.PublicKey()
+}
+
+func (s *localSigner) Sign(_ context.Context, req *Request) ([]byte, error) {
+	if err := req.verify(); err != nil {
+		return nil, err
+	}
+	if err := s.guard.approve(req); err != nil {
+		return nil, err
+	}
+	return s.sk.Sign(req.Hash)
+}
@@ -0,0 +1,135 @@
+// Copyright (c) 2022 IoTeX Foundation
+// This is an alpha (internal) release and is not suitable for production. This source code is provided 'as is' and no
+// warranties are given as to title or non-infringement, merchantability or fitness for purpose and, to the extent
+// permitted by law, all liability for your use of the code is disclaimed. This source code is governed by Apache
+// License 2.0 that can be found in the LICENSE file.
+
+package signer
+
+import (
+	"bytes"
+	"context"
+	"crypto/tls"
+	"time"
+
+	"github.com/iotexproject/go-pkgs/crypto"
+	"github.com/pkg/errors"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/status"
+
+	"github.com/iotexproject/iotex-core/signer/signerpb"
+)
+
+type (
+	// Config is the config of the producer signer
+	Config struct {
+		// Endpoint is the address of the signer server, the remote signer is disabled if it is empty
+		Endpoint string `yaml:"endpoint"`
+		// PublicKey is the hex string of the public key held by the signer server
+		PublicKey string `yaml:"publicKey"`
+		// Insecure disables TLS to the signer server
+		Insecure bool `yaml:"insecure"`
+		// Timeout is the timeout of a signing request
+		Timeout time.Duration `yaml:"timeout"`
+		// GuardPath is the file to persist the consensus messages signed by the local signer in the latest round,
+		// which is used if the remote signer is disabled. They are kept in memory only if it is empty.
+		GuardPath string `yaml:"guardPath"`
+	}
+
+	// RemoteSigner signs messages via a signer server, so that the private key could be kept on a separate host
+	RemoteSigner struct {
+		cfg    Config
+		pk     crypto.PublicKey
+		conn   *grpc.ClientConn
+		client signerpb.SignerClient
+	}
+)
+
+// DefaultConfig is the default config of remote signer
+var DefaultConfig = Config{
+	Timeout: 5 * time.Second,
+}
+
+// NewRemoteSigner creates a remote signer
+func NewRemoteSigner(cfg Config) (*RemoteSigner, error) {
+	if cfg.Endpoint == "" {
+		return nil, errors.New("signer endpoint is empty")
+	}
+	pk, err := crypto.HexStringToPublicKey(cfg.PublicKey)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to decode the public key of remote signer")
+	}
+	return &RemoteSigner{
+		cfg: cfg,
+		pk:  pk,
+	}, nil
+}
+
+// Start connects to the signer server, and checks the public key held by the server
+func (s *RemoteSigner) Start(ctx context.Context) error {
+	opt := grpc.WithTransportCredentials(credentials.NewTLS(&tls.Config{MinVersion: tls.VersionTLS12}))
+	if s.cfg.Insecure {
+		opt = grpc.WithInsecure()
+	}
+	conn, err := grpc.Dial(s.cfg.Endpoint, opt)
+	if err != nil {
+		return errors.Wrapf(err, "failed to connect to signer %s", s.cfg.Endpoint)
+	}
+	client := signerpb.NewSignerClient(conn)
+	ctx, cancel := s.withTimeout(ctx)
+	defer cancel()
+	res, err := client.GetPublicKey(ctx, &signerpb.GetPublicKeyRequest{})
+	if err != nil {
+		conn.Close()
+		return errors.Wrapf(err, "failed to get public key from signer %s", s.cfg.Endpoint)
+	}
+	if !bytes.Equal(res.GetPublicKey(), s.pk.Bytes()) {
+		conn.Close()
+		return errors.Errorf("signer %s holds a different key %x", s.cfg.Endpoint, res.GetPublicKey())
+	}
+	s.conn = conn
+	s.client = client
+	return nil
+}
+
+// Stop disconnects from the signer server
+func (s *RemoteSigner) Stop(_ context.Context) error {
+	if s.conn == nil {
+		return nil
+	}
+	return s.conn.Close()
+}
+
+// PublicKey returns the public key of the signing key
+func (s *RemoteSigner) PublicKey() crypto.PublicKey {
+	return s.pk
+}
+
+// Sign sends the request to the signer server
+func (s *RemoteSigner) Sign(ctx context.Context, req *Request) ([]byte, error) {
+	if s.client == nil {
+		return nil, errors.New("remote signer is not started")
+	}
+	ctx, cancel := s.withTimeout(ctx)
+	defer cancel()
+	res, err := s.client.Sign(ctx, req.Proto())
+	if err != nil {
+		switch status.Code(err) {
+		case codes.FailedPrecondition:
+			return nil, errors.Wrap(ErrDoubleSign, status.Convert(err).Message())
+		case codes.InvalidArgument:
+			return nil, errors.Wrap(ErrInvalidRequest, status.Convert(err).Message())
+		}
+		return nil, errors.Wrapf(err, "failed to sign with signer %s", s.cfg.Endpoint)
+	}
+	return res.GetSignature(), nil
+}
+
+func (s *RemoteSigner) withTimeout(ctx context.Context) (context.Context, context.CancelFunc) {
+	if s.cfg.Timeout == 0 {
+		return context.WithCancel(ctx)
+	}
+	return context.WithTimeout(ctx, s.cfg.Timeout)
+}
@@ -0,0 +1,90 @@
+// Copyright (c) 2022 IoTeX Foundation
+// This is an alpha (internal) release and is not suitable for production. This source code is provided 'as is' and no
+// warranties are given as to title or non-infringement, merchantability or fitness for purpose and, to the extent
+// permitted by law, all liability for your use of the code is disclaimed. This source code is governed by Apache
+// License 2.0 that can be found in the LICENSE file.
+
+package signer
+
+import (
+	"context"
+	"net"
+
+	"github.com/iotexproject/go-pkgs/crypto"
+	"github.com/pkg/errors"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/iotexproject/iotex-core/signer/signerpb"
+)
+
+type (
+	// Server serves the signing requests of remote signers with a signer
+	Server struct {
+		signerpb.UnimplementedSignerServer
+		signer Signer
+	}
+
+	// MockServer is a signer server with a local key listening on the loopback interface, which is used in tests
+	MockServer struct {
+		listener net.Listener
+		server   *grpc.Server
+	}
+)
+
+// NewServer creates a signer server
+func NewServer(s Signer) *Server {
+	return &Server{signer: s}
+}
+
+// GetPublicKey returns the public key of the signing key
+func (svr *Server) GetPublicKey(context.Context, *signerpb.GetPublicKeyRequest) (*signerpb.GetPublicKeyResponse, error) {
+	return &signerpb.GetPublicKeyResponse{
+		PublicKey: svr.signer.PublicKey().Bytes(),
+	}, nil
+}
+
+// Sign signs the hash in the request
+func (svr *Server) Sign(ctx context.Context, in *signerpb.SignRequest) (*signerpb.SignResponse, error) {
+	req := &Request{}
+	req.LoadProto(in)
+	sig, err := svr.signer.Sign(ctx, req)
+	switch errors.Cause(err) {
+	case nil:
+		return &signerpb.SignResponse{Signature: sig}, nil
+	case ErrDoubleSign:
+		return nil, status.Error(codes.FailedPrecondition, err.Error())
+	case ErrInvalidRequest:
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	default:
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+}
+
+// NewMockServer starts a mock signer server with the private key
+func NewMockServer(sk crypto.PrivateKey) (*MockServer, error) {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to listen")
+	}
+	server := grpc.NewServer()
+	signerpb.RegisterSignerServer(server, NewServer(NewLocalSigner(sk)))
+	go func() {
+		_ = server.Serve(listener)
+	}()
+	return &MockServer{
+		listener: listener,
+		server:   server,
+	}, nil
+}
+
+// Endpoint returns the address the server listens on
+func (svr *MockServer) Endpoint() string {
+	return svr.listener.Addr().String()
+}
+
+// Stop stops the server
+func (svr *MockServer) Stop() {
+	svr.server.Stop()
+}
@@ -0,0 +1,228 @@
+// Copyright (c) 2022 IoTeX Foundation
+// This is an alpha (internal) release and is not suitable for production. This source code is provided 'as is' and no
+// warranties are given as to title or non-infringement, merchantability or fitness for purpose and, to the extent
+// permitted by law, all liability for your use of the code is disclaimed. This source code is governed by Apache
+// License 2.0 that can be found in the LICENSE file.
+
+package signer
+
+import (
+	"bytes"
+	"context"
+	"time"
+
+	"github.com/iotexproject/go-pkgs/crypto"
+	"github.com/iotexproject/go-pkgs/hash"
+	"github.com/iotexproject/iotex-proto/golang/iotextypes"
+	blake2b "github.com/minio/blake2b-simd"
+	"github.com/pkg/errors"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	"github.com/iotexproject/iotex-core/pkg/util/byteutil"
+	"github.com/iotexproject/iotex-core/signer/signerpb"
+)
+
+// the kinds of the messages to sign
+const (
+	// BlockKind is the kind of a block header
+	BlockKind Kind = iota
+	// ActionKind is the kind of an action, e.g., a system action created by the block producer
+	ActionKind
+	// ProposalKind is the kind of a consensus block proposal
+	ProposalKind
+	// VoteKind is the kind of a consensus vote
+	VoteKind
+)
+
+var (
+	// ErrDoubleSign indicates that the message to sign conflicts with a signed one
+	ErrDoubleSign = errors.New("refuse to double sign")
+	// ErrInvalidRequest indicates that the message to sign does not match the request
+	ErrInvalidRequest = errors.New("invalid sign request")
+)
+
+type (
+	// Kind is the kind of a message to sign
+	Kind uint32
+
+	// Request is a request to sign the hash of a message
+	Request struct {
+		Kind   Kind
+		Height uint64
+		Round  uint32
+		// Topic is the topic of a consensus vote
+		Topic uint32
+		// BlockHash is the hash of the block proposed or voted
+		BlockHash []byte
+		// Hash is the hash to sign
+		Hash []byte
+		// Message is the serialized message to sign, i.e., the block header core, the action core, the block
+		// proposal or the consensus vote, from which the hash is recomputed
+		Message []byte
+		// Timestamp is the time of the endorsement of a consensus message
+		Timestamp time.Time
+	}
+
+	// Signer signs messages with the key of the block producer
+	Signer interface {
+		// PublicKey returns the public key of the signing key
+		PublicKey() crypto.PublicKey
+		// Sign signs the hash in the request. It returns ErrDoubleSign if the request is a consensus message
+		// conflicting with the signed ones.
+		Sign(context.Context, *Request) ([]byte, error)
+	}
+
+	// HashSigner signs a hash directly, which is implemented by crypto.PrivateKey
+	HashSigner interface {
+		PublicKey() crypto.PublicKey
+		Sign([]byte) ([]byte, error)
+	}
+
+	boundSigner struct {
+		ctx    context.Context
+		signer Signer
+		req    Request
+	}
+)
+
+// Bind binds a signer with the request, so that it could be used as a HashSigner to sign the hash of the message
+// described by the request
+func Bind(ctx context.Context, s Signer, req Request) HashSigner {
+	return &boundSigner{
+		ctx:    ctx,
+		signer: s,
+		req:    req,
+	}
+}
+
+func (s *boundSigner) PublicKey() crypto.PublicKey {
+	return s.signer.PublicKey()
+}
+
+func (s *boundSigner) Sign(hash []byte) ([]byte, error) {
+	req := s.req
+	req.Hash = hash
+	return s.signer.Sign(s.ctx, &req)
+}
+
+// Proto converts the request to protobuf message
+func (req *Request) Proto() *signerpb.SignRequest {
+	pb := &signerpb.SignRequest{
+		Kind:      signerpb.Kind(req.Kind),
+		Height:    req.Height,
+		Round:     req.Round,
+		Topic:     req.Topic,
+		BlockHash: req.BlockHash,
+		Hash:      req.Hash,
+		Message:   req.Message,
+	}
+	if !req.Timestamp.IsZero() {
+		pb.Timestamp = timestamppb.New(req.Timestamp)
+	}
+	return pb
+}
+
+// LoadProto loads the request from protobuf message
+func (req *Request) LoadProto(pb *signerpb.SignRequest) {
+	req.Kind = Kind(pb.GetKind())
+	req.Height = pb.GetHeight()
+	req.Round = pb.GetRound()
+	req.Topic = pb.GetTopic()
+	req.BlockHash = pb.GetBlockHash()
+	req.Hash = pb.GetHash()
+	req.Message = pb.GetMessage()
+	req.Timestamp = time.Time{}
+	if pb.GetTimestamp() != nil {
+		req.Timestamp = pb.GetTimestamp().AsTime()
+	}
+}
+
+// verify recomputes the hash from the message, and checks that the message matches the request
+func (req *Request) verify() error {
+	var (
+		h   []byte
+		err error
+	)
+	switch req.Kind {
+	case BlockKind:
+		h, err = req.blockHash()
+	case ActionKind:
+		h, err = req.actionHash()
+	case ProposalKind:
+		h, err = req.proposalHash()
+	case VoteKind:
+		h, err = req.voteHash()
+	default:
+		return errors.Wrapf(ErrInvalidRequest, "unknown kind %d", req.Kind)
+	}
+	if err != nil {
+		return err
+	}
+	if !bytes.Equal(h, req.Hash) {
+		return errors.Wrapf(ErrInvalidRequest, "hash %x is not the hash of the message", req.Hash)
+	}
+	return nil
+}
+
+func (req *Request) blockHash() ([]byte, error) {
+	core := &iotextypes.BlockHeaderCore{}
+	if err := proto.Unmarshal(req.Message, core); err != nil {
+		return nil, errors.Wrap(ErrInvalidRequest, err.Error())
+	}
+	if core.GetHeight() != req.Height {
+		return nil, errors.Wrapf(ErrInvalidRequest, "block height %d doesn't match %d", core.GetHeight(), req.Height)
+	}
+	h := hash.Hash256b(req.Message)
+	return h[:], nil
+}
+
+func (req *Request) actionHash() ([]byte, error) {
+	if err := proto.Unmarshal(req.Message, &iotextypes.ActionCore{}); err != nil {
+		return nil, errors.Wrap(ErrInvalidRequest, err.Error())
+	}
+	h := hash.Hash256b(req.Message)
+	return h[:], nil
+}
+
+func (req *Request) proposalHash() ([]byte, error) {
+	bp := &iotextypes.BlockProposal{}
+	if err := proto.Unmarshal(req.Message, bp); err != nil {
+		return nil, errors.Wrap(ErrInvalidRequest, err.Error())
+	}
+	header := bp.GetBlock().GetHeader()
+	if height := header.GetCore().GetHeight(); height != req.Height {
+		return nil, errors.Wrapf(ErrInvalidRequest, "block height %d doesn't match %d", height, req.Height)
+	}
+	ser, err := proto.Marshal(header)
+	if err != nil {
+		return nil, errors.Wrap(ErrInvalidRequest, err.Error())
+	}
+	if blkHash := hash.Hash256b(ser); !bytes.Equal(blkHash[:], req.BlockHash) {
+		return nil, errors.Wrapf(ErrInvalidRequest, "block hash %x doesn't match %x", blkHash, req.BlockHash)
+	}
+	docHash := hash.Hash256b(req.Message)
+	return req.hashWithTime(docHash[:]), nil
+}
+
+func (req *Request) voteHash() ([]byte, error) {
+	vote := &iotextypes.ConsensusVote{}
+	if err := proto.Unmarshal(req.Message, vote); err != nil {
+		return nil, errors.Wrap(ErrInvalidRequest, err.Error())
+	}
+	if !bytes.Equal(vote.GetBlockHash(), req.BlockHash) {
+		return nil, errors.Wrapf(ErrInvalidRequest, "block hash %x doesn't match %x", vote.GetBlockHash(), req.BlockHash)
+	}
+	if uint32(vote.GetTopic()) != req.Topic {
+		return nil, errors.Wrapf(ErrInvalidRequest, "topic %d doesn't match %d", vote.GetTopic(), req.Topic)
+	}
+	docHash := blake2b.Sum256(req.Message)
+	return req.hashWithTime(docHash[:]), nil
+}
+
+// hashWithTime hashes the document hash with the timestamp in the same way as an endorsement
+func (req *Request) hashWithTime(docHash []byte) []byte {
+	h := append(docHash, byteutil.Uint64ToBytes(uint64(req.Timestamp.Unix()))...)
+	h256 := hash.Hash256b(append(h, byteutil.Uint32ToBytes(uint32(req.Timestamp.Nanosecond()))...))
+	return h256[:]
+}
@@ -0,0 +1,196 @@
+// Copyright (c) 2022 IoTeX Foundation
+// This is an alpha (internal) release and is not suitable for production. This source code is provided 'as is' and no
+// warranties are given as to title or non-infringement, merchantability or fitness for purpose and, to the extent
+// permitted by law, all liability for your use of the code is disclaimed. This source code is governed by Apache
+// License 2.0 that can be found in the LICENSE file.
+
+package signer
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/iotexproject/go-pkgs/hash"
+	"github.com/iotexproject/iotex-proto/golang/iotextypes"
+	blake2b "github.com/minio/blake2b-simd"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/proto"
+
+	"github.com/iotexproject/iotex-core/test/identityset"
+)
+
+// signRequest creates the request to sign the message of the kind, in which the block is identified by its name
+func signRequest(t *testing.T, kind Kind, height uint64, round uint32, topic uint32, blk string) Request {
+	require := require.New(t)
+	ts := time.Unix(1662000000, 0).Add(time.Duration(round) * time.Second)
+	header := &iotextypes.BlockHeader{Core: &iotextypes.BlockHeaderCore{Height: height, TxRoot: []byte(blk)}}
+	ser, err := proto.Marshal(header)
+	require.NoError(err)
+	blkHash := hash.Hash256b(ser)
+	req := Request{Kind: kind, Height: height, Round: round, Topic: topic, BlockHash: blkHash[:], Timestamp: ts}
+	var msg proto.Message
+	switch kind {
+	case BlockKind:
+		msg = header.Core
+	case ActionKind:
+		msg = &iotextypes.ActionCore{Nonce: height}
+	case ProposalKind:
+		msg = &iotextypes.BlockProposal{Block: &iotextypes.Block{Header: header}}
+	case VoteKind:
+		msg = &iotextypes.ConsensusVote{BlockHash: blkHash[:], Topic: iotextypes.ConsensusVote_Topic(topic)}
+	default:
+		return req
+	}
+	req.Message, err = proto.Marshal(msg)
+	require.NoError(err)
+	return req
+}
+
+// hashOf computes the hash to sign in the same way as the callers
+func hashOf(req Request) []byte {
+	switch req.Kind {
+	case ProposalKind:
+		h := hash.Hash256b(req.Message)
+		return req.hashWithTime(h[:])
+	case VoteKind:
+		h := blake2b.Sum256(req.Message)
+		return req.hashWithTime(h[:])
+	default:
+		h := hash.Hash256b(req.Message)
+		return h[:]
+	}
+}
+
+func sign(t *testing.T, s Signer, req Request) error {
+	h := hashOf(req)
+	sig, err := Bind(context.Background(), s, req).Sign(h)
+	if err != nil {
+		return err
+	}
+	require.True(t, s.PublicKey().Verify(h, sig))
+	return nil
+}
+
+func testSigner(t *testing.T, s Signer) {
+	require := require.New(t)
+	for _, test := range []struct {
+		req Request
+		err error
+	}{
+		{signRequest(t, ProposalKind, 2, 1, 0, "a"), nil},
+		{signRequest(t, ProposalKind, 2, 1, 0, "a"), nil},
+		{signRequest(t, VoteKind, 2, 1, 0, "a"), nil},
+		{signRequest(t, VoteKind, 2, 1, 1, "a"), nil},
+		// another block in the same round
+		{signRequest(t, ProposalKind, 2, 1, 0, "b"), ErrDoubleSign},
+		{signRequest(t, VoteKind, 2, 1, 1, "b"), ErrDoubleSign},
+		// blocks and actions are signed at the height to propose
+		{signRequest(t, BlockKind, 2, 0, 0, "b"), nil},
+		{signRequest(t, ActionKind, 2, 0, 0, ""), nil},
+		{signRequest(t, BlockKind, 1, 0, 0, "b"), ErrDoubleSign},
+		// a past round
+		{signRequest(t, VoteKind, 2, 0, 0, "a"), ErrDoubleSign},
+		{signRequest(t, VoteKind, 1, 5, 0, "a"), ErrDoubleSign},
+		// a new round
+		{signRequest(t, VoteKind, 2, 2, 1, "b"), nil},
+		{signRequest(t, VoteKind, 2, 1, 1, "a"), ErrDoubleSign},
+		{signRequest(t, ProposalKind, 3, 0, 0, "c"), nil},
+	} {
+		require.Equal(test.err, errors.Cause(sign(t, s, test.req)))
+	}
+
+	// the message doesn't match the request
+	req := signRequest(t, ProposalKind, 3, 0, 0, "c")
+	req.Height = 4
+	require.Equal(ErrInvalidRequest, errors.Cause(sign(t, s, req)))
+	req = signRequest(t, VoteKind, 3, 0, 0, "c")
+	req.BlockHash = []byte("d")
+	require.Equal(ErrInvalidRequest, errors.Cause(sign(t, s, req)))
+	req = signRequest(t, VoteKind, 3, 0, 0, "c")
+	req.Topic = 1
+	require.Equal(ErrInvalidRequest, errors.Cause(sign(t, s, req)))
+	req = signRequest(t, BlockKind, 3, 0, 0, "c")
+	req.Height = 5
+	require.Equal(ErrInvalidRequest, errors.Cause(sign(t, s, req)))
+	// the hash is not the hash of the message
+	req = signRequest(t, VoteKind, 3, 0, 0, "c")
+	h := hash.Hash256b([]byte("another message"))
+	_, err := Bind(context.Background(), s, req).Sign(h[:])
+	require.Equal(ErrInvalidRequest, errors.Cause(err))
+	// unknown kind
+	require.Equal(ErrInvalidRequest, errors.Cause(sign(t, s, signRequest(t, VoteKind+1, 3, 0, 0, "c"))))
+}
+
+func TestLocalSigner(t *testing.T) {
+	sk := identityset.PrivateKey(1)
+	s := NewLocalSigner(sk)
+	require.Equal(t, sk.PublicKey(), s.PublicKey())
+	testSigner(t, s)
+}
+
+func TestRemoteSigner(t *testing.T) {
+	require := require.New(t)
+	ctx := context.Background()
+	sk := identityset.PrivateKey(1)
+	svr, err := NewMockServer(sk)
+	require.NoError(err)
+	defer svr.Stop()
+
+	_, err = NewRemoteSigner(Config{Endpoint: svr.Endpoint(), PublicKey: "invalid"})
+	require.Error(err)
+	cfg := Config{
+		Endpoint:  svr.Endpoint(),
+		PublicKey: identityset.PrivateKey(2).PublicKey().HexString(),
+		Insecure:  true,
+		Timeout:   DefaultConfig.Timeout,
+	}
+	s, err := NewRemoteSigner(cfg)
+	require.NoError(err)
+	req := signRequest(t, ActionKind, 1, 0, 0, "")
+	req.Hash = hashOf(req)
+	_, err = s.Sign(ctx, &req)
+	require.Error(err)
+	// the server holds a different key
+	require.Error(s.Start(ctx))
+
+	cfg.PublicKey = sk.PublicKey().HexString()
+	s, err = NewRemoteSigner(cfg)
+	require.NoError(err)
+	require.NoError(s.Start(ctx))
+	defer s.Stop(ctx)
+	require.Equal(sk.PublicKey().HexString(), s.PublicKey().HexString())
+	testSigner(t, s)
+}
+
+func TestPersistentLocalSigner(t *testing.T) {
+	require := require.New(t)
+	sk := identityset.PrivateKey(1)
+	path := filepath.Join(t.TempDir(), "guard.db")
+	s, err := NewPersistentLocalSigner(sk, path)
+	require.NoError(err)
+	require.NoError(sign(t, s, signRequest(t, VoteKind, 2, 1, 0, "a")))
+	require.NoError(sign(t, s, signRequest(t, ProposalKind, 2, 1, 0, "a")))
+
+	// the signed messages are kept after restart
+	s, err = NewPersistentLocalSigner(sk, path)
+	require.NoError(err)
+	require.NoError(sign(t, s, signRequest(t, VoteKind, 2, 1, 0, "a")))
+	require.Equal(ErrDoubleSign, errors.Cause(sign(t, s, signRequest(t, VoteKind, 2, 1, 0, "b"))))
+	require.Equal(ErrDoubleSign, errors.Cause(sign(t, s, signRequest(t, ProposalKind, 2, 1, 0, "b"))))
+	require.Equal(ErrDoubleSign, errors.Cause(sign(t, s, signRequest(t, VoteKind, 2, 0, 0, "b"))))
+	require.NoError(sign(t, s, signRequest(t, VoteKind, 2, 2, 0, "b")))
+
+	s, err = NewPersistentLocalSigner(sk, path)
+	require.NoError(err)
+	require.Equal(ErrDoubleSign, errors.Cause(sign(t, s, signRequest(t, VoteKind, 2, 1, 0, "a"))))
+	require.Equal(ErrDoubleSign, errors.Cause(sign(t, s, signRequest(t, VoteKind, 2, 2, 0, "c"))))
+
+	// corrupted state is refused
+	require.NoError(os.WriteFile(path, []byte("invalid"), 0600))
+	_, err = NewPersistentLocalSigner(sk, path)
+	require.Error(err)
+}
@@ -0,0 +1,90 @@
+// Copyright (c) 2022 IoTeX Foundation
+// This is an alpha (internal) release and is not suitable for production. This source code is provided 'as is' and no
+// warranties are given as to title or non-infringement, merchantability or fitness for purpose and, to the extent
+// permitted by law, all liability for your use of the code is disclaimed. This source code is governed by Apache
+// License 2.0 that can be found in the LICENSE file.
+
+package factory
+
+import (
+	"math/big"
+
+	"github.com/iotexproject/go-pkgs/hash"
+	"github.com/pkg/errors"
+	"go.uber.org/zap"
+
+	"github.com/iotexproject/iotex-core/action/protocol"
+	"github.com/iotexproject/iotex-core/pkg/log"
+	"github.com/iotexproject/iotex-core/state"
+)
+
+type (
+	// BalanceSubscriber receives the balances of the accounts touched by a block, once the states of the block are
+	// committed. The balances of the genesis states are received with height 0. The subscriber handles its own
+	// failures, which never fail the states committed
+	BalanceSubscriber interface {
+		ReceiveBalances(uint64, map[hash.Hash160]*big.Int)
+	}
+
+	// accountTracker wraps the store of a working set and tracks the accounts written, no matter by the actions, by
+	// the protocols or by the patches
+	accountTracker struct {
+		workingSetStore
+		touched map[hash.Hash160]struct{}
+	}
+)
+
+func newAccountTracker(store workingSetStore) *accountTracker {
+	return &accountTracker{
+		workingSetStore: store,
+		touched:         make(map[hash.Hash160]struct{}),
+	}
+}
+
+func (t *accountTracker) Put(ns string, key []byte, value []byte) error {
+	t.touch(ns, key)
+	return t.workingSetStore.Put(ns, key, value)
+}
+
+func (t *accountTracker) Delete(ns string, key []byte) error {
+	t.touch(ns, key)
+	return t.workingSetStore.Delete(ns, key)
+}
+
+func (t *accountTracker) touch(ns string, key []byte) {
+	// the other states in account namespace, such as the current height, have keys of other lengths
+	if ns == AccountKVNamespace && len(key) == len(hash.ZeroHash160) {
+		t.touched[hash.BytesToHash160(key)] = struct{}{}
+	}
+}
+
+// balances returns the balances of the accounts touched, an account deleted has zero balance
+func (t *accountTracker) balances(sr protocol.StateReader) (map[hash.Hash160]*big.Int, error) {
+	balances := make(map[hash.Hash160]*big.Int, len(t.touched))
+	for addr := range t.touched {
+		acct := &state.Account{}
+		switch _, err := sr.State(acct, protocol.LegacyKeyOption(addr)); errors.Cause(err) {
+		case nil:
+			balances[addr] = acct.Balance
+		case state.ErrStateNotExist:
+			balances[addr] = big.NewInt(0)
+		default:
+			return nil, errors.Wrapf(err, "failed to read the balance of %x", addr)
+		}
+	}
+	return balances, nil
+}
+
+// notifyBalances sends the balances of the accounts touched by the working set to the subscriber, nothing is sent if
+// the balances fail to be read
+func notifyBalances(subscriber BalanceSubscriber, ws *workingSet) {
+	if subscriber == nil || ws.tracker == nil {
+		return
+	}
+	balances, err := ws.tracker.balances(ws)
+	if err != nil {
+		log.L().Error("Failed to read the balances of the accounts touched.", zap.Uint64("height", ws.height), zap.Error(err))
+		return
+	}
+	subscriber.ReceiveBalances(ws.height, balances)
+}
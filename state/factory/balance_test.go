@@ -0,0 +1,71 @@
+// Copyright (c) 2022 IoTeX Foundation
+// This is an alpha (internal) release and is not suitable for production. This source code is provided 'as is' and no
+// warranties are given as to title or non-infringement, merchantability or fitness for purpose and, to the extent
+// permitted by law, all liability for your use of the code is disclaimed. This source code is governed by Apache
+// License 2.0 that can be found in the LICENSE file.
+
+package factory
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/iotexproject/go-pkgs/hash"
+	"github.com/stretchr/testify/require"
+
+	"github.com/iotexproject/iotex-core/action/protocol"
+	"github.com/iotexproject/iotex-core/db"
+	"github.com/iotexproject/iotex-core/db/batch"
+	"github.com/iotexproject/iotex-core/state"
+	"github.com/iotexproject/iotex-core/test/identityset"
+)
+
+type testBalanceSubscriber struct {
+	height   uint64
+	balances map[hash.Hash160]*big.Int
+}
+
+func (s *testBalanceSubscriber) ReceiveBalances(height uint64, balances map[hash.Hash160]*big.Int) {
+	s.height, s.balances = height, balances
+}
+
+func TestNotifyBalances(t *testing.T) {
+	require := require.New(t)
+	flusher, err := db.NewKVStoreFlusher(db.NewMemKVStore(), batch.NewCachedBatch())
+	require.NoError(err)
+	a, b, c := hash.BytesToHash160(identityset.Address(1).Bytes()),
+		hash.BytesToHash160(identityset.Address(2).Bytes()),
+		hash.BytesToHash160(identityset.Address(3).Bytes())
+
+	store := newStateDBWorkingSetStore(protocol.View{}, flusher, true)
+	ws := newWorkingSet(5, store, 0, nil)
+	// the account of a is written by a patch before the working set is created
+	acct, err := state.NewAccount()
+	require.NoError(err)
+	require.NoError(acct.AddBalance(big.NewInt(10)))
+	_, err = ws.PutState(acct, protocol.LegacyKeyOption(a))
+	require.NoError(err)
+	ws.trackAccounts([]*patch{{Type: _Put, Namespace: AccountKVNamespace, Key: a[:]}})
+
+	require.NoError(acct.AddBalance(big.NewInt(20)))
+	_, err = ws.PutState(acct, protocol.LegacyKeyOption(b))
+	require.NoError(err)
+	_, err = ws.PutState(acct, protocol.LegacyKeyOption(c))
+	require.NoError(err)
+	_, err = ws.DelState(protocol.LegacyKeyOption(c))
+	require.NoError(err)
+	// the other states in account namespace are left out
+	_, err = ws.PutState(acct, protocol.NamespaceOption(AccountKVNamespace), protocol.KeyOption([]byte(CurrentHeightKey)))
+	require.NoError(err)
+
+	// nothing is sent without subscriber
+	notifyBalances(nil, ws)
+	s := &testBalanceSubscriber{}
+	notifyBalances(s, ws)
+	require.EqualValues(5, s.height)
+	require.Equal(map[hash.Hash160]*big.Int{
+		a: big.NewInt(10),
+		b: big.NewInt(30),
+		c: big.NewInt(0),
+	}, s.balances)
+}
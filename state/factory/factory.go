@@ -86,12 +86,21 @@ type (
 		Validate(context.Context, *block.Block) error
 		// NewBlockBuilder creates block builder
 		NewBlockBuilder(context.Context, actpool.ActPool, func(action.Envelope) (action.SealedEnvelope, error)) (*block.Builder, error)
+		// NewPendingBlockBuilder creates block builder and returns the state after running its actions, the
+		// working set is not cached so the block can never be committed
+		NewPendingBlockBuilder(context.Context, actpool.ActPool, func(action.Envelope) (action.SealedEnvelope, error)) (*block.Builder, protocol.StateManager, error)
 		SimulateExecution(context.Context, address.Address, *action.Execution, evm.GetBlockHash) ([]byte, *action.Receipt, error)
 		ReadContractStorage(context.Context, address.Address, []byte) ([]byte, error)
+		// ContractStorageRange reads at most limit slots of contract's storage at height, from the first key not less
+		// than start, and returns the key of the next slot if there are more
+		ContractStorageRange(context.Context, uint64, address.Address, []byte, uint32) ([]*evm.ContractStorageSlot, []byte, error)
 		PutBlock(context.Context, *block.Block) error
 		DeleteTipBlock(context.Context, *block.Block) error
 		StateAtHeight(uint64, interface{}, ...protocol.StateOption) error
 		StatesAtHeight(uint64, ...protocol.StateOption) (state.Iterator, error)
+		// StateDiff returns the states changed by each action of a committed block, which are read from the state
+		// diff index, or replayed on the archive data
+		StateDiff(context.Context, *block.Block) ([]*ActionStateDiff, error)
 	}
 
 	// factory implements StateFactory interface, tracks changes to account/contract and batch-commits to DB
@@ -109,6 +118,8 @@ type (
 		protocolView             protocol.View
 		skipBlockValidationOnPut bool
 		ps                       *patchStore
+		sv                       *action.SignatureVerifier
+		balanceSubscriber        BalanceSubscriber
 	}
 
 	// Config contains the config for factory
@@ -145,6 +156,22 @@ func SkipBlockValidationOption() Option {
 	}
 }
 
+// SignatureVerifierOption verifies the signatures of a block's actions in parallel before validating the block
+func SignatureVerifierOption(sv *action.SignatureVerifier) Option {
+	return func(sf *factory, cfg *Config) error {
+		sf.sv = sv
+		return nil
+	}
+}
+
+// BalanceSubscriberOption sends the balances of the accounts touched by each committed block to the subscriber
+func BalanceSubscriberOption(subscriber BalanceSubscriber) Option {
+	return func(sf *factory, cfg *Config) error {
+		sf.balanceSubscriber = subscriber
+		return nil
+	}
+}
+
 // DefaultTriePatchOption loads patchs
 func DefaultTriePatchOption() Option {
 	return func(sf *factory, cfg *Config) (err error) {
@@ -287,7 +314,64 @@ func (sf *factory) newWorkingSet(ctx context.Context, height uint64) (*workingSe
 		}
 	}
 
-	return newWorkingSet(height, store), nil
+	ws := newWorkingSet(height, store, sf.cfg.Chain.ParallelExecutionWorkers, sf.sv)
+	if sf.balanceSubscriber != nil {
+		ws.trackAccounts(sf.ps.Get(height))
+	}
+	if sf.cfg.Chain.EnableStateDiffIndex {
+		ws.recordStateDiff()
+	}
+	return ws, nil
+}
+
+// newArchiveWorkingSet returns the working set of the block at height atop the archived states of its previous
+// block, with the protocol views rebuilt from those states
+func (sf *factory) newArchiveWorkingSet(ctx context.Context, height uint64) (*workingSet, error) {
+	view := protocol.View{}
+	store, err := sf.newArchiveStore(ctx, view, height-1)
+	if err != nil {
+		return nil, err
+	}
+	prevView, err := sf.registry.StartAll(ctx, newWorkingSet(height-1, store, 0, nil))
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to start protocols at height %d", height-1)
+	}
+	for name, v := range prevView {
+		view[name] = v
+	}
+	for _, p := range sf.ps.Get(height) {
+		if p.Type == _Delete {
+			if err := store.Delete(p.Namespace, p.Key); err != nil {
+				return nil, err
+			}
+		} else {
+			if err := store.Put(p.Namespace, p.Key, p.Value); err != nil {
+				return nil, err
+			}
+		}
+	}
+	return newWorkingSet(height, store, 0, nil), nil
+}
+
+// newArchiveStore returns the store atop the archived states at height, on which the block of height+1 is built
+func (sf *factory) newArchiveStore(ctx context.Context, view protocol.View, height uint64) (workingSetStore, error) {
+	g := genesis.MustExtractGenesisContext(ctx)
+	flusher, err := db.NewKVStoreFlusher(
+		sf.dao,
+		batch.NewCachedBatch(),
+		sf.flusherOptions(!g.IsEaster(height+1))...,
+	)
+	if err != nil {
+		return nil, err
+	}
+	store, err := newFactoryWorkingSetStoreAtHeight(view, flusher, height)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to get archive data of height %d", height)
+	}
+	if err := store.Start(ctx); err != nil {
+		return nil, err
+	}
+	return store, nil
 }
 
 func (sf *factory) flusherOptions(preEaster bool) []db.KVStoreFlusherOption {
@@ -351,38 +435,50 @@ func (sf *factory) NewBlockBuilder(
 	ap actpool.ActPool,
 	sign func(action.Envelope) (action.SealedEnvelope, error),
 ) (*block.Builder, error) {
+	blkBuilder, ws, err := sf.newBlockBuilder(ctx, ap, sign)
+	if err != nil {
+		return nil, err
+	}
+
+	blkCtx := protocol.MustGetBlockCtx(ctx)
+	key := generateWorkingSetCacheKey(blkBuilder.GetCurrentBlockHeader(), blkCtx.Producer.String())
+	sf.putIntoWorkingSets(key, ws)
+	return blkBuilder, nil
+}
+
+func (sf *factory) NewPendingBlockBuilder(
+	ctx context.Context,
+	ap actpool.ActPool,
+	sign func(action.Envelope) (action.SealedEnvelope, error),
+) (*block.Builder, protocol.StateManager, error) {
+	blkBuilder, ws, err := sf.newBlockBuilder(ctx, ap, sign)
+	if err != nil {
+		return nil, nil, err
+	}
+	return blkBuilder, ws, nil
+}
+
+func (sf *factory) newBlockBuilder(
+	ctx context.Context,
+	ap actpool.ActPool,
+	sign func(action.Envelope) (action.SealedEnvelope, error),
+) (*block.Builder, *workingSet, error) {
 	sf.mutex.Lock()
 	ctx = protocol.WithRegistry(ctx, sf.registry)
 	ws, err := sf.newWorkingSet(ctx, sf.currentChainHeight+1)
 	sf.mutex.Unlock()
 	if err != nil {
-		return nil, errors.Wrap(err, "Failed to obtain working set from state factory")
+		return nil, nil, errors.Wrap(err, "Failed to obtain working set from state factory")
 	}
-	postSystemActions := make([]action.SealedEnvelope, 0)
-	for _, p := range sf.registry.All() {
-		if psac, ok := p.(protocol.PostSystemActionsCreator); ok {
-			elps, err := psac.CreatePostSystemActions(ctx, ws)
-			if err != nil {
-				return nil, err
-			}
-			for _, elp := range elps {
-				se, err := sign(elp)
-				if err != nil {
-					return nil, err
-				}
-				postSystemActions = append(postSystemActions, se)
-			}
-		}
+	postSystemActions, err := createPostSystemActions(ctx, sf.registry, ws, sign)
+	if err != nil {
+		return nil, nil, err
 	}
 	blkBuilder, err := ws.CreateBuilder(ctx, ap, postSystemActions, sf.cfg.Chain.AllowedBlockGasResidue)
 	if err != nil {
-		return nil, err
+		return nil, nil, err
 	}
-
-	blkCtx := protocol.MustGetBlockCtx(ctx)
-	key := generateWorkingSetCacheKey(blkBuilder.GetCurrentBlockHeader(), blkCtx.Producer.String())
-	sf.putIntoWorkingSets(key, ws)
-	return blkBuilder, nil
+	return blkBuilder, ws, nil
 }
 
 // SimulateExecution simulates a running of smart contract operation, this is done off the network since it does not
@@ -417,6 +513,38 @@ func (sf *factory) ReadContractStorage(ctx context.Context, contract address.Add
 	return evm.ReadContractStorage(ctx, ws, contract, key)
 }
 
+// ContractStorageRange reads the slots of contract's storage at height, from the first key not less than start
+func (sf *factory) ContractStorageRange(
+	ctx context.Context,
+	height uint64,
+	contract address.Address,
+	start []byte,
+	limit uint32,
+) ([]*evm.ContractStorageSlot, []byte, error) {
+	sf.mutex.Lock()
+	tip := sf.currentChainHeight
+	if height == tip {
+		ws, err := sf.newWorkingSet(ctx, tip+1)
+		sf.mutex.Unlock()
+		if err != nil {
+			return nil, nil, errors.Wrap(err, "failed to generate working set from state factory")
+		}
+		return evm.ReadContractStorageRange(ws, contract, start, limit)
+	}
+	sf.mutex.Unlock()
+	if height > tip {
+		return nil, nil, errors.Errorf("query height %d is higher than tip height %d", height, tip)
+	}
+	if !sf.saveHistory {
+		return nil, nil, ErrNoArchiveData
+	}
+	store, err := sf.newArchiveStore(ctx, protocol.View{}, height)
+	if err != nil {
+		return nil, nil, err
+	}
+	return evm.ReadContractStorageRange(newWorkingSet(height+1, store, 0, nil), contract, start, limit)
+}
+
 // PutBlock persists all changes in RunActions() into the DB
 func (sf *factory) PutBlock(ctx context.Context, blk *block.Block) error {
 	sf.mutex.Lock()
@@ -482,6 +610,7 @@ func (sf *factory) PutBlock(ctx context.Context, blk *block.Block) error {
 		return err
 	}
 	sf.currentChainHeight = h
+	notifyBalances(sf.balanceSubscriber, ws)
 
 	return nil
 }
@@ -490,6 +619,29 @@ func (sf *factory) DeleteTipBlock(_ context.Context, _ *block.Block) error {
 	return errors.Wrap(ErrNotSupported, "cannot delete tip block from factory")
 }
 
+// StateDiff returns the states changed by each action of a committed block
+func (sf *factory) StateDiff(ctx context.Context, blk *block.Block) ([]*ActionStateDiff, error) {
+	diffs, err := getStateDiff(sf.dao, blk.Height())
+	if errors.Cause(err) != db.ErrNotExist {
+		return diffs, err
+	}
+	if !sf.saveHistory {
+		return nil, ErrNoArchiveData
+	}
+	sf.mutex.RLock()
+	tip := sf.currentChainHeight
+	sf.mutex.RUnlock()
+	if blk.Height() == 0 || blk.Height() > tip {
+		return nil, errors.Wrapf(ErrNotSupported, "cannot replay block %d, tip height = %d", blk.Height(), tip)
+	}
+	ctx = protocol.WithRegistry(ctx, sf.registry)
+	ws, err := sf.newArchiveWorkingSet(ctx, blk.Height())
+	if err != nil {
+		return nil, err
+	}
+	return replayStateDiff(ctx, ws, blk)
+}
+
 // StateAtHeight returns a confirmed state at height -- archive mode
 func (sf *factory) StateAtHeight(height uint64, s interface{}, opts ...protocol.StateOption) error {
 	sf.mutex.RLock()
@@ -627,8 +779,12 @@ func (sf *factory) createGenesisStates(ctx context.Context) error {
 	if err := ws.CreateGenesisStates(ctx); err != nil {
 		return err
 	}
+	if err := ws.Commit(ctx); err != nil {
+		return err
+	}
+	notifyBalances(sf.balanceSubscriber, ws)
 
-	return ws.Commit(ctx)
+	return nil
 }
 
 // getFromWorkingSets returns (workingset, true) if it exists in a cache, otherwise generates new workingset and return (ws, false)
@@ -980,6 +980,43 @@ func TestSTXRunActions(t *testing.T) {
 	testCommit(sdb, t)
 }
 
+func TestHandleTracer(t *testing.T) {
+	require := require.New(t)
+	cfg := DefaultConfig
+	cfg.Genesis.InitBalanceMap[identityset.Address(28).String()] = "100"
+	registry := protocol.NewRegistry()
+	sf, err := NewFactory(cfg, db.NewMemKVStore(), RegistryOption(registry), SkipBlockValidationOption())
+	require.NoError(err)
+	acc := account.NewProtocol(rewarding.DepositGas)
+	require.NoError(acc.Register(registry))
+	ctx := genesis.WithGenesisContext(context.Background(), cfg.Genesis)
+	require.NoError(sf.Start(protocol.WithBlockCtx(ctx, protocol.BlockCtx{})))
+	defer func() {
+		require.NoError(sf.Stop(ctx))
+	}()
+
+	selp, err := action.SignedTransfer(identityset.Address(29).String(), identityset.PrivateKey(28), 1, big.NewInt(10), nil, 100000, big.NewInt(0))
+	require.NoError(err)
+	blk, err := block.NewTestingBuilder().
+		SetHeight(1).
+		SetTimeStamp(testutil.TimestampNow()).
+		AddActions(selp).
+		SignAndBuild(identityset.PrivateKey(27))
+	require.NoError(err)
+	var traced []string
+	ctx = protocol.WithHandleTracerCtx(
+		protocol.WithBlockchainCtx(ctx, protocol.BlockchainCtx{}),
+		func(name string, act action.Action, receipt *action.Receipt, _ time.Duration) {
+			_, ok := act.(*action.Transfer)
+			require.True(ok)
+			require.NotNil(receipt)
+			traced = append(traced, name)
+		},
+	)
+	require.NoError(sf.PutBlock(ctx, &blk))
+	require.Equal([]string{"account"}, traced)
+}
+
 func testCommit(factory Factory, t *testing.T) {
 	require := require.New(t)
 	a := identityset.Address(28).String()
@@ -1121,7 +1158,7 @@ func testNewBlockBuilder(factory Factory, t *testing.T) {
 	accMap[identityset.Address(29).String()] = []action.SealedEnvelope{selp2}
 	ctrl := gomock.NewController(t)
 	ap := mock_actpool.NewMockActPool(ctrl)
-	ap.EXPECT().PendingActionMap().Return(accMap).Times(1)
+	ap.EXPECT().PendingActionMap().Return(accMap).Times(2)
 	gasLimit := uint64(1000000)
 	ctx := protocol.WithBlockCtx(context.Background(),
 		protocol.BlockCtx{
@@ -1134,6 +1171,15 @@ func testNewBlockBuilder(factory Factory, t *testing.T) {
 		protocol.BlockchainCtx{},
 	)
 	ctx = protocol.WithFeatureCtx(protocol.WithFeatureWithHeightCtx(ctx))
+	pendingBuilder, sm, err := factory.NewPendingBlockBuilder(ctx, ap, nil)
+	require.NoError(err)
+	require.NotNil(pendingBuilder)
+	acct, err := accountutil.AccountState(ctx, sm, identityset.Address(29))
+	require.NoError(err)
+	require.EqualValues(2, acct.PendingNonce())
+	height, err := factory.Height()
+	require.NoError(err)
+	require.Zero(height)
 	blkBuilder, err := factory.NewBlockBuilder(ctx, ap, nil)
 	require.NoError(err)
 	require.NotNil(blkBuilder)
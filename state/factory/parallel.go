@@ -0,0 +1,416 @@
+// Copyright (c) 2022 IoTeX Foundation
+// This is an alpha (internal) release and is not suitable for production. This source code is provided 'as is' and no
+// warranties are given as to title or non-infringement, merchantability or fitness for purpose and, to the extent
+// permitted by law, all liability for your use of the code is disclaimed. This source code is governed by Apache
+// License 2.0 that can be found in the LICENSE file.
+
+package factory
+
+import (
+	"context"
+	"sync"
+
+	"github.com/iotexproject/go-pkgs/hash"
+	"github.com/pkg/errors"
+	"github.com/prometheus/client_golang/prometheus"
+
+	"github.com/iotexproject/iotex-core/action"
+	"github.com/iotexproject/iotex-core/action/protocol"
+	"github.com/iotexproject/iotex-core/state"
+)
+
+var (
+	_parallelExecutionMtc = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "iotex_parallel_execution",
+			Help: "IoTeX parallel execution of actions",
+		},
+		[]string{"result"},
+	)
+
+	errSpeculationAborted = errors.New("speculative execution aborted")
+)
+
+func init() {
+	prometheus.MustRegister(_parallelExecutionMtc)
+}
+
+type (
+	stateKey struct {
+		ns  string
+		key string
+	}
+
+	// specEntry is a write buffered by the speculative execution, a deferred entry is a read-modify-write of the
+	// keys, which is replayed on the working set
+	specEntry struct {
+		keys     []stateKey
+		value    []byte
+		deferred func(protocol.StateManager) error
+	}
+
+	// speculativeStateManager runs an action atop the working set without changing it. It records the states read
+	// from the working set and buffers the writes. Protocol views, dock, iterating and deleting states are not
+	// supported, the speculation is aborted once the action accesses them.
+	speculativeStateManager struct {
+		ws        *workingSet
+		mutex     *sync.Mutex
+		reads     map[stateKey]struct{}
+		entries   []specEntry
+		latest    map[stateKey]int
+		shots     []int
+		deferring bool
+		aborted   bool
+	}
+
+	// speculation is the result of running an action speculatively
+	speculation struct {
+		sm          *speculativeStateManager
+		gasLimit    uint64
+		validateErr error
+		receipt     *action.Receipt
+		err         error
+	}
+
+	// writeRecorder runs actions on the working set and records the keys written
+	writeRecorder struct {
+		*workingSet
+		written map[stateKey]struct{}
+	}
+
+	// parallelExecutor executes actions optimistically in parallel. The actions are run speculatively atop the
+	// working set by a pool of workers, and then committed one by one in order. The writes of a speculation are
+	// replayed on the working set if none of the states it read has been written by the preceding actions,
+	// otherwise the action is executed again. The writes are replayed in the same order as they are made, so the
+	// receipts and the state root are identical to serial execution.
+	parallelExecutor struct {
+		*writeRecorder
+		workers int
+		specs   map[hash.Hash256]*speculation
+	}
+)
+
+func newSpeculativeStateManager(ws *workingSet, mutex *sync.Mutex) *speculativeStateManager {
+	return &speculativeStateManager{
+		ws:     ws,
+		mutex:  mutex,
+		reads:  map[stateKey]struct{}{},
+		latest: map[stateKey]int{},
+	}
+}
+
+func (sm *speculativeStateManager) abort() error {
+	sm.aborted = true
+	return errSpeculationAborted
+}
+
+func (sm *speculativeStateManager) Height() (uint64, error) {
+	return sm.ws.height, nil
+}
+
+func (sm *speculativeStateManager) State(s interface{}, opts ...protocol.StateOption) (uint64, error) {
+	cfg, err := processOptions(opts...)
+	if err != nil {
+		return sm.ws.height, err
+	}
+	k := stateKey{cfg.Namespace, string(cfg.Key)}
+	if i, ok := sm.latest[k]; ok {
+		if sm.entries[i].deferred != nil {
+			// the value is unknown until the deferred write is replayed
+			return sm.ws.height, sm.abort()
+		}
+		return sm.ws.height, state.Deserialize(s, sm.entries[i].value)
+	}
+	sm.mutex.Lock()
+	value, err := sm.ws.store.Get(cfg.Namespace, cfg.Key)
+	sm.mutex.Unlock()
+	if !sm.deferring {
+		sm.reads[k] = struct{}{}
+	}
+	if err != nil {
+		return sm.ws.height, err
+	}
+	return sm.ws.height, state.Deserialize(s, value)
+}
+
+func (sm *speculativeStateManager) States(...protocol.StateOption) (uint64, state.Iterator, error) {
+	return sm.ws.height, nil, sm.abort()
+}
+
+func (sm *speculativeStateManager) ReadView(string) (interface{}, error) {
+	return nil, sm.abort()
+}
+
+func (sm *speculativeStateManager) Snapshot() int {
+	sm.shots = append(sm.shots, len(sm.entries))
+	return len(sm.shots) - 1
+}
+
+func (sm *speculativeStateManager) Revert(snapshot int) error {
+	if snapshot < 0 || snapshot >= len(sm.shots) {
+		return errors.Errorf("invalid snapshot number = %d", snapshot)
+	}
+	sm.entries = sm.entries[:sm.shots[snapshot]]
+	sm.shots = sm.shots[:snapshot+1]
+	sm.latest = map[stateKey]int{}
+	for i, e := range sm.entries {
+		for _, k := range e.keys {
+			sm.latest[k] = i
+		}
+	}
+	return nil
+}
+
+func (sm *speculativeStateManager) PutState(s interface{}, opts ...protocol.StateOption) (uint64, error) {
+	cfg, err := processOptions(opts...)
+	if err != nil {
+		return sm.ws.height, err
+	}
+	ss, err := state.Serialize(s)
+	if err != nil {
+		return sm.ws.height, errors.Wrapf(err, "failed to convert account %v to bytes", s)
+	}
+	k := stateKey{cfg.Namespace, string(cfg.Key)}
+	sm.latest[k] = len(sm.entries)
+	sm.entries = append(sm.entries, specEntry{keys: []stateKey{k}, value: ss})
+	return sm.ws.height, nil
+}
+
+func (sm *speculativeStateManager) DelState(...protocol.StateOption) (uint64, error) {
+	// the error of deleting a non-existing state differs among the working set stores
+	return sm.ws.height, sm.abort()
+}
+
+func (sm *speculativeStateManager) WriteView(string, interface{}) error {
+	return sm.abort()
+}
+
+func (sm *speculativeStateManager) ProtocolDirty(string) bool {
+	sm.abort()
+	return false
+}
+
+func (sm *speculativeStateManager) Load(string, string, interface{}) error {
+	return sm.abort()
+}
+
+func (sm *speculativeStateManager) Unload(string, string, interface{}) error {
+	return sm.abort()
+}
+
+func (sm *speculativeStateManager) Reset() {}
+
+// Defer runs the read-modify-write without recording the states read, and replaces the writes with the function,
+// which is replayed on the working set upon commit
+func (sm *speculativeStateManager) Defer(f func(protocol.StateManager) error) error {
+	if sm.deferring {
+		return f(sm)
+	}
+	start := len(sm.entries)
+	sm.deferring = true
+	err := f(sm)
+	sm.deferring = false
+	if err != nil {
+		return sm.abort()
+	}
+	keys := []stateKey{}
+	for _, e := range sm.entries[start:] {
+		keys = append(keys, e.keys...)
+	}
+	sm.entries = append(sm.entries[:start], specEntry{keys: keys, deferred: f})
+	for _, k := range keys {
+		sm.latest[k] = start
+	}
+	return nil
+}
+
+func newWriteRecorder(ws *workingSet) *writeRecorder {
+	return &writeRecorder{
+		workingSet: ws,
+		written:    map[stateKey]struct{}{},
+	}
+}
+
+// PutState puts a state into DB
+func (rec *writeRecorder) PutState(s interface{}, opts ...protocol.StateOption) (uint64, error) {
+	if err := rec.record(opts...); err != nil {
+		return rec.height, err
+	}
+	return rec.workingSet.PutState(s, opts...)
+}
+
+// DelState deletes a state from DB
+func (rec *writeRecorder) DelState(opts ...protocol.StateOption) (uint64, error) {
+	if err := rec.record(opts...); err != nil {
+		return rec.height, err
+	}
+	return rec.workingSet.DelState(opts...)
+}
+
+func (rec *writeRecorder) record(opts ...protocol.StateOption) error {
+	cfg, err := processOptions(opts...)
+	if err != nil {
+		return err
+	}
+	rec.written[stateKey{cfg.Namespace, string(cfg.Key)}] = struct{}{}
+	return nil
+}
+
+// runAction runs the action on the working set
+func (rec *writeRecorder) runAction(ctx context.Context, elp action.SealedEnvelope) (*action.Receipt, error) {
+	receipt, err := rec.handle(ctx, elp, rec)
+	rec.ResetSnapshots()
+	return receipt, err
+}
+
+// replay writes the buffered changes of a speculation to the working set
+func (rec *writeRecorder) replay(sm *speculativeStateManager) error {
+	for _, e := range sm.entries {
+		if e.deferred != nil {
+			if err := e.deferred(rec); err != nil {
+				return err
+			}
+			continue
+		}
+		k := e.keys[0]
+		rec.written[k] = struct{}{}
+		if err := rec.store.Put(k.ns, []byte(k.key), e.value); err != nil {
+			return err
+		}
+	}
+	rec.ResetSnapshots()
+	return nil
+}
+
+func newParallelExecutor(ws *workingSet, workers int) *parallelExecutor {
+	return &parallelExecutor{
+		writeRecorder: newWriteRecorder(ws),
+		workers:       workers,
+		specs:         map[hash.Hash256]*speculation{},
+	}
+}
+
+// speculate runs the first action of each sender in parallel atop the current working set. The later actions of a
+// sender depend on the earlier ones (at least on the nonce), so they are left to be executed serially.
+func (e *parallelExecutor) speculate(ctx context.Context, elps []action.SealedEnvelope, validate bool) {
+	if e.workers <= 1 {
+		return
+	}
+	type task struct {
+		ctx  context.Context
+		elp  action.SealedEnvelope
+		spec *speculation
+	}
+	var (
+		tasks   = make([]*task, 0, len(elps))
+		senders = map[string]bool{}
+		mutex   = &sync.Mutex{}
+		gas     = protocol.MustGetBlockCtx(ctx).GasLimit
+	)
+	for _, elp := range elps {
+		caller := elp.SenderAddress()
+		if caller == nil || senders[caller.String()] {
+			continue
+		}
+		senders[caller.String()] = true
+		h, err := elp.Hash()
+		if err != nil {
+			continue
+		}
+		actionCtx, err := withActionCtx(ctx, elp)
+		if err != nil {
+			continue
+		}
+		spec := &speculation{
+			sm:       newSpeculativeStateManager(e.workingSet, mutex),
+			gasLimit: gas,
+		}
+		e.specs[h] = spec
+		tasks = append(tasks, &task{actionCtx, elp, spec})
+	}
+	queue := make(chan *task, len(tasks))
+	for _, t := range tasks {
+		queue <- t
+	}
+	close(queue)
+	var wg sync.WaitGroup
+	for i := 0; i < e.workers; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for t := range queue {
+				if validate {
+					if t.spec.validateErr = validateAction(t.ctx, t.elp, t.spec.sm); t.spec.validateErr != nil {
+						continue
+					}
+				}
+				t.spec.receipt, t.spec.err = e.handle(t.ctx, t.elp, t.spec.sm)
+			}
+		}()
+	}
+	wg.Wait()
+}
+
+// take returns the speculation of the action and replays its writes if it is still valid, otherwise returns nil
+func (e *parallelExecutor) take(ctx context.Context, elp action.SealedEnvelope) (*speculation, error) {
+	if len(e.specs) == 0 {
+		return nil, nil
+	}
+	h, err := elp.Hash()
+	if err != nil {
+		return nil, err
+	}
+	spec, ok := e.specs[h]
+	if !ok {
+		return nil, nil
+	}
+	delete(e.specs, h)
+	if !e.valid(ctx, elp, spec) {
+		_parallelExecutionMtc.WithLabelValues("conflict").Inc()
+		return nil, nil
+	}
+	if err := e.replay(spec.sm); err != nil {
+		return nil, err
+	}
+	_parallelExecutionMtc.WithLabelValues("hit").Inc()
+	return spec, nil
+}
+
+// runAction runs the action serially on the working set
+func (e *parallelExecutor) runAction(ctx context.Context, elp action.SealedEnvelope) (*action.Receipt, error) {
+	if e.workers <= 1 {
+		return e.workingSet.runAction(ctx, elp)
+	}
+	return e.writeRecorder.runAction(ctx, elp)
+}
+
+func (e *parallelExecutor) valid(ctx context.Context, elp action.SealedEnvelope, spec *speculation) bool {
+	if spec.sm.aborted {
+		return false
+	}
+	// the block gas limit only matters if it is less than the gas limit of the action, see handle and
+	// evm.securityDeposit, so the speculation is still valid if both of them are large enough
+	enough := func(gas uint64) bool {
+		return gas >= elp.GasLimit() && gas >= protocol.MustGetActionCtx(ctx).IntrinsicGas
+	}
+	if gas := protocol.MustGetBlockCtx(ctx).GasLimit; gas != spec.gasLimit && !(enough(gas) && enough(spec.gasLimit)) {
+		return false
+	}
+	for k := range spec.sm.reads {
+		if _, ok := e.written[k]; ok {
+			return false
+		}
+	}
+	return true
+}
+
+func validateAction(ctx context.Context, elp action.SealedEnvelope, sr protocol.StateReader) error {
+	for _, p := range protocol.MustGetRegistry(ctx).All() {
+		if validator, ok := p.(protocol.ActionValidator); ok {
+			if err := validator.Validate(ctx, elp.Action(), sr); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
@@ -0,0 +1,172 @@
+// Copyright (c) 2022 IoTeX Foundation
+// This is an alpha (internal) release and is not suitable for production. This source code is provided 'as is' and no
+// warranties are given as to title or non-infringement, merchantability or fitness for purpose and, to the extent
+// permitted by law, all liability for your use of the code is disclaimed. This source code is governed by Apache
+// License 2.0 that can be found in the LICENSE file.
+
+package factory
+
+import (
+	"context"
+	"encoding/hex"
+	"math/big"
+	"path/filepath"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/iotexproject/go-pkgs/hash"
+	"github.com/stretchr/testify/require"
+
+	"github.com/iotexproject/iotex-core/action"
+	"github.com/iotexproject/iotex-core/action/protocol"
+	"github.com/iotexproject/iotex-core/action/protocol/account"
+	"github.com/iotexproject/iotex-core/action/protocol/execution"
+	"github.com/iotexproject/iotex-core/action/protocol/rewarding"
+	"github.com/iotexproject/iotex-core/action/protocol/staking"
+	"github.com/iotexproject/iotex-core/blockchain/block"
+	"github.com/iotexproject/iotex-core/blockchain/genesis"
+	"github.com/iotexproject/iotex-core/db"
+	"github.com/iotexproject/iotex-core/pkg/unit"
+	"github.com/iotexproject/iotex-core/test/identityset"
+	"github.com/iotexproject/iotex-core/test/mock/mock_actpool"
+)
+
+// _counterContract is the bytecode to deploy a contract, which increments the counter in slot 0 upon each call
+var _counterContract, _ = hex.DecodeString("600a600c600039600a6000f3600054600101600055" + "00")
+
+func TestParallelExecution(t *testing.T) {
+	require := require.New(t)
+	cfg := DefaultConfig
+	cfg.Genesis.InitBalanceMap = map[string]string{}
+	for i := 0; i < 6; i++ {
+		cfg.Genesis.InitBalanceMap[identityset.Address(i).String()] = "100000000"
+	}
+	// the senders of the contract executions and staking actions
+	for i := 6; i < 12; i++ {
+		cfg.Genesis.InitBalanceMap[identityset.Address(i).String()] = unit.ConvertIotxToRau(3000000).String()
+	}
+	ctx := protocol.WithBlockCtx(context.Background(), protocol.BlockCtx{
+		BlockHeight: 1,
+		Producer:    identityset.Address(27),
+		GasLimit:    10000000,
+	})
+	ctx = protocol.WithBlockchainCtx(genesis.WithGenesisContext(ctx, cfg.Genesis), protocol.BlockchainCtx{})
+	ctx = protocol.WithFeatureCtx(protocol.WithFeatureWithHeightCtx(ctx))
+
+	newFactory := func(workers int, stateDB bool) Factory {
+		cfg := cfg
+		cfg.Chain.ParallelExecutionWorkers = workers
+		registry := protocol.NewRegistry()
+		// staking protocol filters the states on start, which is not supported by in-memory KVStore
+		store, err := db.CreateKVStore(db.DefaultConfig, filepath.Join(t.TempDir(), _triePath))
+		require.NoError(err)
+		var sf Factory
+		if stateDB {
+			sf, err = NewStateDB(cfg, store, RegistryStateDBOption(registry))
+		} else {
+			sf, err = NewFactory(cfg, store, RegistryOption(registry))
+		}
+		require.NoError(err)
+		require.NoError(account.NewProtocol(rewarding.DepositGas).Register(registry))
+		require.NoError(rewarding.NewProtocol(cfg.Genesis.Rewarding).Register(registry))
+		require.NoError(execution.NewProtocol(func(uint64) (hash.Hash256, error) {
+			return hash.ZeroHash256, nil
+		}, rewarding.DepositGas).Register(registry))
+		sp, err := staking.NewProtocol(rewarding.DepositGas, cfg.Genesis.Staking, nil, cfg.Genesis.GreenlandBlockHeight)
+		require.NoError(err)
+		require.NoError(sp.Register(registry))
+		require.NoError(sf.Start(protocol.WithRegistry(ctx, registry)))
+		return sf
+	}
+	ctrl := gomock.NewController(t)
+	build := func(sf Factory, accMap map[string][]action.SealedEnvelope) *block.Block {
+		ap := mock_actpool.NewMockActPool(ctrl)
+		ap.EXPECT().PendingActionMap().DoAndReturn(func() map[string][]action.SealedEnvelope {
+			m := map[string][]action.SealedEnvelope{}
+			for k, v := range accMap {
+				m[k] = v
+			}
+			return m
+		}).Times(1)
+		builder, err := sf.NewBlockBuilder(ctx, ap, func(elp action.Envelope) (action.SealedEnvelope, error) {
+			return action.Sign(elp, identityset.PrivateKey(27))
+		})
+		require.NoError(err)
+		blk, err := builder.SignAndBuild(identityset.PrivateKey(27))
+		require.NoError(err)
+		return &blk
+	}
+
+	// sender 6 deploys a contract which increments the counter in its storage upon each call
+	deploy, err := action.SignedExecution(action.EmptyAddress, identityset.PrivateKey(6), 1, big.NewInt(0), 200000,
+		big.NewInt(20), _counterContract)
+	require.NoError(err)
+	blk := build(newFactory(0, false), map[string][]action.SealedEnvelope{
+		identityset.Address(6).String(): {deploy},
+	})
+	require.EqualValues(1, blk.Receipts[0].Status)
+	contract := blk.Receipts[0].ContractAddress
+	// sender 0 sends twice, senders 1 to 4 conflict with the preceding ones as recipients, sender 5 doesn't conflict
+	// with the others, and all of them deposit gas fee into the rewarding fund
+	tests := []struct {
+		sender, recipient int
+		nonce             uint64
+		amount, gasPrice  int64
+	}{
+		{0, 1, 1, 100, 10},
+		{0, 2, 2, 200, 10},
+		{1, 3, 1, 1000, 9},
+		{2, 4, 1, 300, 8},
+		{3, 5, 1, 400, 7},
+		{4, 0, 1, 500, 6},
+		{5, 6, 1, 600, 11},
+	}
+	accMap := map[string][]action.SealedEnvelope{}
+	add := func(sender int, selp action.SealedEnvelope, err error) {
+		require.NoError(err)
+		accMap[identityset.Address(sender).String()] = append(accMap[identityset.Address(sender).String()], selp)
+	}
+	for _, test := range tests {
+		selp, err := action.SignedTransfer(identityset.Address(test.recipient).String(), identityset.PrivateKey(test.sender),
+			test.nonce, big.NewInt(test.amount), nil, 20000, big.NewInt(test.gasPrice))
+		add(test.sender, selp, err)
+	}
+	// the contract is deployed first for the highest gas price, and then senders 7 and 8 call the contract, which
+	// conflict with each other on the storage of the contract. The gas prices are distinct, so that the actions are
+	// packed in the same order.
+	add(6, deploy, nil)
+	for i, sender := range []int{7, 8} {
+		selp, err := action.SignedExecution(contract, identityset.PrivateKey(sender), 1, big.NewInt(0), 100000,
+			big.NewInt(16-int64(i)), nil)
+		add(sender, selp, err)
+	}
+	// sender 9 registers a candidate, and then senders 10 and 11 stake on it, which conflict with each other on the
+	// candidate, and defer the deposit of gas fee into the rewarding fund
+	selp, err := action.SignedCandidateRegister(1, "cand", identityset.Address(9).String(), identityset.Address(9).String(),
+		identityset.Address(9).String(), cfg.Genesis.Staking.RegistrationConsts.MinSelfStake, 0, false, nil, 100000,
+		big.NewInt(14), identityset.PrivateKey(9))
+	add(9, selp, err)
+	for i, sender := range []int{10, 11} {
+		selp, err := action.SignedCreateStake(1, "cand", cfg.Genesis.Staking.MinStakeAmount, 0, false, nil, 100000,
+			big.NewInt(13-int64(i)), identityset.PrivateKey(sender))
+		add(sender, selp, err)
+	}
+	numActions := len(tests) + 6
+	for _, stateDB := range []bool{false, true} {
+		serial, parallel := newFactory(0, stateDB), newFactory(4, stateDB)
+		blk := build(serial, accMap)
+		// the actions and the grant reward
+		require.Len(blk.Actions, numActions+1)
+		blk2 := build(parallel, accMap)
+		require.Equal(blk.TxRoot(), blk2.TxRoot())
+		require.Equal(blk.DeltaStateDigest(), blk2.DeltaStateDigest())
+		require.Equal(blk.ReceiptRoot(), blk2.ReceiptRoot())
+		require.Equal(blk.Receipts, blk2.Receipts)
+		for _, r := range blk2.Receipts {
+			require.EqualValues(1, r.Status)
+		}
+
+		// validate the block in parallel
+		require.NoError(newFactory(4, stateDB).Validate(ctx, blk))
+	}
+}
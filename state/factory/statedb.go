@@ -47,6 +47,8 @@ type stateDB struct {
 	protocolView             protocol.View
 	skipBlockValidationOnPut bool
 	ps                       *patchStore
+	sv                       *action.SignatureVerifier
+	balanceSubscriber        BalanceSubscriber
 }
 
 // StateDBOption sets stateDB construction parameter
@@ -76,6 +78,22 @@ func SkipBlockValidationStateDBOption() StateDBOption {
 	}
 }
 
+// SignatureVerifierStateDBOption verifies the signatures of a block's actions in parallel before validating the block
+func SignatureVerifierStateDBOption(sv *action.SignatureVerifier) StateDBOption {
+	return func(sdb *stateDB, cfg *Config) error {
+		sdb.sv = sv
+		return nil
+	}
+}
+
+// BalanceSubscriberStateDBOption sends the balances of the accounts touched by each committed block to the subscriber
+func BalanceSubscriberStateDBOption(subscriber BalanceSubscriber) StateDBOption {
+	return func(sdb *stateDB, cfg *Config) error {
+		sdb.balanceSubscriber = subscriber
+		return nil
+	}
+}
+
 // DisableWorkingSetCacheOption disable workingset cache
 func DisableWorkingSetCacheOption() StateDBOption {
 	return func(sdb *stateDB, cfg *Config) error {
@@ -196,7 +214,14 @@ func (sdb *stateDB) newWorkingSet(ctx context.Context, height uint64) (*workingS
 		return nil, err
 	}
 
-	return newWorkingSet(height, store), nil
+	ws := newWorkingSet(height, store, sdb.cfg.Chain.ParallelExecutionWorkers, sdb.sv)
+	if sdb.balanceSubscriber != nil {
+		ws.trackAccounts(sdb.ps.Get(height))
+	}
+	if sdb.cfg.Chain.EnableStateDiffIndex {
+		ws.recordStateDiff()
+	}
+	return ws, nil
 }
 
 func (sdb *stateDB) Register(p protocol.Protocol) error {
@@ -230,39 +255,51 @@ func (sdb *stateDB) NewBlockBuilder(
 	ap actpool.ActPool,
 	sign func(action.Envelope) (action.SealedEnvelope, error),
 ) (*block.Builder, error) {
+	blkBuilder, ws, err := sdb.newBlockBuilder(ctx, ap, sign)
+	if err != nil {
+		return nil, err
+	}
+
+	blkCtx := protocol.MustGetBlockCtx(ctx)
+	key := generateWorkingSetCacheKey(blkBuilder.GetCurrentBlockHeader(), blkCtx.Producer.String())
+	sdb.workingsets.Add(key, ws)
+	return blkBuilder, nil
+}
+
+func (sdb *stateDB) NewPendingBlockBuilder(
+	ctx context.Context,
+	ap actpool.ActPool,
+	sign func(action.Envelope) (action.SealedEnvelope, error),
+) (*block.Builder, protocol.StateManager, error) {
+	blkBuilder, ws, err := sdb.newBlockBuilder(ctx, ap, sign)
+	if err != nil {
+		return nil, nil, err
+	}
+	return blkBuilder, ws, nil
+}
+
+func (sdb *stateDB) newBlockBuilder(
+	ctx context.Context,
+	ap actpool.ActPool,
+	sign func(action.Envelope) (action.SealedEnvelope, error),
+) (*block.Builder, *workingSet, error) {
 	ctx = protocol.WithRegistry(ctx, sdb.registry)
 	sdb.mutex.RLock()
 	currHeight := sdb.currentChainHeight
 	sdb.mutex.RUnlock()
 	ws, err := sdb.newWorkingSet(ctx, currHeight+1)
 	if err != nil {
-		return nil, err
+		return nil, nil, err
 	}
-	postSystemActions := make([]action.SealedEnvelope, 0)
-	for _, p := range sdb.registry.All() {
-		if psac, ok := p.(protocol.PostSystemActionsCreator); ok {
-			elps, err := psac.CreatePostSystemActions(ctx, ws)
-			if err != nil {
-				return nil, err
-			}
-			for _, elp := range elps {
-				se, err := sign(elp)
-				if err != nil {
-					return nil, err
-				}
-				postSystemActions = append(postSystemActions, se)
-			}
-		}
+	postSystemActions, err := createPostSystemActions(ctx, sdb.registry, ws, sign)
+	if err != nil {
+		return nil, nil, err
 	}
 	blkBuilder, err := ws.CreateBuilder(ctx, ap, postSystemActions, sdb.cfg.Chain.AllowedBlockGasResidue)
 	if err != nil {
-		return nil, err
+		return nil, nil, err
 	}
-
-	blkCtx := protocol.MustGetBlockCtx(ctx)
-	key := generateWorkingSetCacheKey(blkBuilder.GetCurrentBlockHeader(), blkCtx.Producer.String())
-	sdb.workingsets.Add(key, ws)
-	return blkBuilder, nil
+	return blkBuilder, ws, nil
 }
 
 // SimulateExecution simulates a running of smart contract operation, this is done off the network since it does not
@@ -299,6 +336,27 @@ func (sdb *stateDB) ReadContractStorage(ctx context.Context, contract address.Ad
 	return evm.ReadContractStorage(ctx, ws, contract, key)
 }
 
+// ContractStorageRange reads the slots of contract's storage at the tip, state db does not keep the history
+func (sdb *stateDB) ContractStorageRange(
+	ctx context.Context,
+	height uint64,
+	contract address.Address,
+	start []byte,
+	limit uint32,
+) ([]*evm.ContractStorageSlot, []byte, error) {
+	sdb.mutex.RLock()
+	currHeight := sdb.currentChainHeight
+	sdb.mutex.RUnlock()
+	if height != currHeight {
+		return nil, nil, errors.Wrapf(ErrNotSupported, "state db has no states of height %d, tip height = %d", height, currHeight)
+	}
+	ws, err := sdb.newWorkingSet(ctx, currHeight+1)
+	if err != nil {
+		return nil, nil, errors.Wrap(err, "failed to generate working set from state db")
+	}
+	return evm.ReadContractStorageRange(ws, contract, start, limit)
+}
+
 // PutBlock persists all changes in RunActions() into the DB
 func (sdb *stateDB) PutBlock(ctx context.Context, blk *block.Block) error {
 	sdb.mutex.Lock()
@@ -356,9 +414,20 @@ func (sdb *stateDB) PutBlock(ctx context.Context, blk *block.Block) error {
 		return err
 	}
 	sdb.currentChainHeight = h
+	notifyBalances(sdb.balanceSubscriber, ws)
 	return nil
 }
 
+// StateDiff returns the states changed by each action of a committed block, which is only available in the state
+// diff index as the statedb keeps no history
+func (sdb *stateDB) StateDiff(_ context.Context, blk *block.Block) ([]*ActionStateDiff, error) {
+	diffs, err := getStateDiff(sdb.dao, blk.Height())
+	if errors.Cause(err) == db.ErrNotExist {
+		return nil, errors.Wrapf(ErrNotSupported, "state diff of block %d is not indexed", blk.Height())
+	}
+	return diffs, err
+}
+
 func (sdb *stateDB) DeleteTipBlock(_ context.Context, _ *block.Block) error {
 	return errors.Wrap(ErrNotSupported, "cannot delete tip block from state db")
 }
@@ -457,8 +526,12 @@ func (sdb *stateDB) createGenesisStates(ctx context.Context) error {
 	if err := ws.CreateGenesisStates(ctx); err != nil {
 		return err
 	}
+	if err := ws.Commit(ctx); err != nil {
+		return err
+	}
+	notifyBalances(sdb.balanceSubscriber, ws)
 
-	return ws.Commit(ctx)
+	return nil
 }
 
 // getFromWorkingSets returns (workingset, true) if it exists in a cache, otherwise generates new workingset and return (ws, false)
@@ -0,0 +1,259 @@
+// Copyright (c) 2022 IoTeX Foundation
+// This is an alpha (internal) release and is not suitable for production. This source code is provided 'as is' and no
+// warranties are given as to title or non-infringement, merchantability or fitness for purpose and, to the extent
+// permitted by law, all liability for your use of the code is disclaimed. This source code is governed by Apache
+// License 2.0 that can be found in the LICENSE file.
+
+package factory
+
+import (
+	"bytes"
+	"context"
+
+	"github.com/iotexproject/go-pkgs/hash"
+	"github.com/pkg/errors"
+	"google.golang.org/protobuf/proto"
+
+	"github.com/iotexproject/iotex-core/action/protocol"
+	"github.com/iotexproject/iotex-core/action/protocol/execution/evm"
+	"github.com/iotexproject/iotex-core/blockchain/block"
+	"github.com/iotexproject/iotex-core/db"
+	"github.com/iotexproject/iotex-core/pkg/util/byteutil"
+	"github.com/iotexproject/iotex-core/state"
+	"github.com/iotexproject/iotex-core/state/factory/statediffpb"
+)
+
+// StateDiffNamespace is the bucket storing the state diffs of blocks
+const StateDiffNamespace = "StateDiff"
+
+type (
+	// StateChange is a state changed in a namespace, Before or After is nil if the state did not exist
+	StateChange struct {
+		Namespace string
+		Key       []byte
+		Before    []byte
+		After     []byte
+	}
+
+	// StorageChange is a slot of contract storage changed, Before or After is nil if the slot did not exist
+	StorageChange struct {
+		Contract hash.Hash160
+		Key      hash.Hash256
+		Before   []byte
+		After    []byte
+	}
+
+	// ActionStateDiff is the states changed by an action. The changes made by protocols outside of actions, such as
+	// the pre-states created at the beginning of a block, have zero action hash
+	ActionStateDiff struct {
+		ActionHash hash.Hash256
+		States     []*StateChange
+		Storage    []*StorageChange
+	}
+
+	// stateDiffRecorder wraps the store of a working set and records the values of the states before and after each
+	// action. The nodes of contract storage tries are left out, the slots are reported by the evm instead
+	stateDiffRecorder struct {
+		workingSetStore
+		current *ActionStateDiff
+		origins map[stateKey][]byte
+		keys    []stateKey
+		diffs   []*ActionStateDiff
+		err     error
+	}
+)
+
+func newStateDiffRecorder(store workingSetStore) *stateDiffRecorder {
+	r := &stateDiffRecorder{workingSetStore: store}
+	r.reset(hash.ZeroHash256)
+	return r
+}
+
+func (r *stateDiffRecorder) Put(ns string, key []byte, value []byte) error {
+	r.touch(ns, key)
+	return r.workingSetStore.Put(ns, key, value)
+}
+
+func (r *stateDiffRecorder) Delete(ns string, key []byte) error {
+	r.touch(ns, key)
+	return r.workingSetStore.Delete(ns, key)
+}
+
+func (r *stateDiffRecorder) touch(ns string, key []byte) {
+	if ns == evm.ContractKVNameSpace || r.err != nil {
+		return
+	}
+	k := stateKey{ns, string(key)}
+	if _, ok := r.origins[k]; ok {
+		return
+	}
+	v, err := r.value(ns, key)
+	if err != nil {
+		r.err = err
+		return
+	}
+	r.origins[k] = v
+	r.keys = append(r.keys, k)
+}
+
+func (r *stateDiffRecorder) value(ns string, key []byte) ([]byte, error) {
+	v, err := r.workingSetStore.Get(ns, key)
+	if errors.Cause(err) == state.ErrStateNotExist {
+		return nil, nil
+	}
+	return v, err
+}
+
+// begin starts recording the changes of an action, and returns the context tracing its contract storage
+func (r *stateDiffRecorder) begin(ctx context.Context, actionHash hash.Hash256) context.Context {
+	r.flush()
+	r.reset(actionHash)
+	current := r.current
+	return protocol.WithStorageTracerCtx(ctx, func(contract hash.Hash160, key hash.Hash256, before, after []byte) {
+		current.Storage = append(current.Storage, &StorageChange{
+			Contract: contract,
+			Key:      key,
+			Before:   before,
+			After:    after,
+		})
+	})
+}
+
+// end stops recording the changes of the action, the changes afterwards are made outside of actions
+func (r *stateDiffRecorder) end() {
+	r.flush()
+	r.reset(hash.ZeroHash256)
+}
+
+// result returns the changes recorded so far
+func (r *stateDiffRecorder) result() ([]*ActionStateDiff, error) {
+	r.flush()
+	r.reset(hash.ZeroHash256)
+	if r.err != nil {
+		return nil, r.err
+	}
+	return r.diffs, nil
+}
+
+func (r *stateDiffRecorder) reset(actionHash hash.Hash256) {
+	r.current = &ActionStateDiff{ActionHash: actionHash}
+	r.origins = make(map[stateKey][]byte)
+	r.keys = nil
+}
+
+func (r *stateDiffRecorder) flush() {
+	if r.err != nil {
+		return
+	}
+	for _, k := range r.keys {
+		after, err := r.value(k.ns, []byte(k.key))
+		if err != nil {
+			r.err = err
+			return
+		}
+		// the states reverted are not changed
+		if before := r.origins[k]; !bytes.Equal(before, after) {
+			r.current.States = append(r.current.States, &StateChange{
+				Namespace: k.ns,
+				Key:       []byte(k.key),
+				Before:    before,
+				After:     after,
+			})
+		}
+	}
+	if len(r.current.States) > 0 || len(r.current.Storage) > 0 {
+		r.diffs = append(r.diffs, r.current)
+	}
+}
+
+// replayStateDiff validates the block on the working set, which should be of the state before the block, and
+// returns the changes made by the block
+func replayStateDiff(ctx context.Context, ws *workingSet, blk *block.Block) ([]*ActionStateDiff, error) {
+	ws.recordStateDiff()
+	if err := ws.ValidateBlock(ctx, blk); err != nil {
+		return nil, errors.Wrapf(err, "failed to replay block %d", blk.Height())
+	}
+	return ws.recorder.result()
+}
+
+func putStateDiff(put func(string, []byte, []byte) error, height uint64, diffs []*ActionStateDiff) error {
+	pb := &statediffpb.BlockStateDiff{}
+	for _, d := range diffs {
+		pb.Actions = append(pb.Actions, d.toProto())
+	}
+	data, err := proto.Marshal(pb)
+	if err != nil {
+		return errors.Wrapf(err, "failed to serialize state diff of block %d", height)
+	}
+	return put(StateDiffNamespace, byteutil.Uint64ToBytesBigEndian(height), data)
+}
+
+func getStateDiff(kv db.KVStore, height uint64) ([]*ActionStateDiff, error) {
+	data, err := kv.Get(StateDiffNamespace, byteutil.Uint64ToBytesBigEndian(height))
+	if err != nil {
+		return nil, err
+	}
+	pb := &statediffpb.BlockStateDiff{}
+	if err := proto.Unmarshal(data, pb); err != nil {
+		return nil, errors.Wrapf(err, "failed to deserialize state diff of block %d", height)
+	}
+	diffs := make([]*ActionStateDiff, 0, len(pb.Actions))
+	for _, a := range pb.Actions {
+		diffs = append(diffs, fromActionStateDiffProto(a))
+	}
+	return diffs, nil
+}
+
+func (d *ActionStateDiff) toProto() *statediffpb.ActionStateDiff {
+	pb := &statediffpb.ActionStateDiff{
+		ActionHash: d.ActionHash[:],
+	}
+	for _, s := range d.States {
+		pb.States = append(pb.States, &statediffpb.StateChange{
+			Namespace: s.Namespace,
+			Key:       s.Key,
+			Before:    s.Before,
+			After:     s.After,
+		})
+	}
+	for _, s := range d.Storage {
+		pb.Storage = append(pb.Storage, &statediffpb.StorageChange{
+			Contract: s.Contract[:],
+			Key:      s.Key[:],
+			Before:   s.Before,
+			After:    s.After,
+		})
+	}
+	return pb
+}
+
+func fromActionStateDiffProto(pb *statediffpb.ActionStateDiff) *ActionStateDiff {
+	d := &ActionStateDiff{
+		ActionHash: hash.BytesToHash256(pb.ActionHash),
+	}
+	for _, s := range pb.States {
+		d.States = append(d.States, &StateChange{
+			Namespace: s.Namespace,
+			Key:       s.Key,
+			Before:    nilIfEmpty(s.Before),
+			After:     nilIfEmpty(s.After),
+		})
+	}
+	for _, s := range pb.Storage {
+		d.Storage = append(d.Storage, &StorageChange{
+			Contract: hash.BytesToHash160(s.Contract),
+			Key:      hash.BytesToHash256(s.Key),
+			Before:   nilIfEmpty(s.Before),
+			After:    nilIfEmpty(s.After),
+		})
+	}
+	return d
+}
+
+// nilIfEmpty restores the value of a state which did not exist, the serialized states are never empty
+func nilIfEmpty(b []byte) []byte {
+	if len(b) == 0 {
+		return nil
+	}
+	return b
+}
@@ -0,0 +1,76 @@
+// Copyright (c) 2022 IoTeX Foundation
+// This is an alpha (internal) release and is not suitable for production. This source code is provided 'as is' and no
+// warranties are given as to title or non-infringement, merchantability or fitness for purpose and, to the extent
+// permitted by law, all liability for your use of the code is disclaimed. This source code is governed by Apache
+// License 2.0 that can be found in the LICENSE file.
+
+package factory
+
+import (
+	"context"
+	"testing"
+
+	"github.com/iotexproject/go-pkgs/hash"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/require"
+
+	"github.com/iotexproject/iotex-core/action/protocol"
+	"github.com/iotexproject/iotex-core/action/protocol/execution/evm"
+	"github.com/iotexproject/iotex-core/db"
+	"github.com/iotexproject/iotex-core/db/batch"
+)
+
+func TestStateDiffRecorder(t *testing.T) {
+	require := require.New(t)
+	flusher, err := db.NewKVStoreFlusher(db.NewMemKVStore(), batch.NewCachedBatch())
+	require.NoError(err)
+	store := newStateDBWorkingSetStore(protocol.View{}, flusher, true)
+	require.NoError(store.Put("ns", []byte("k1"), []byte("v1")))
+	r := newStateDiffRecorder(store)
+
+	// changes outside of actions
+	require.NoError(r.Put("ns", []byte("k2"), []byte("v2")))
+
+	h1 := hash.BytesToHash256([]byte("action1"))
+	ctx := r.begin(context.Background(), h1)
+	require.NoError(r.Put("ns", []byte("k1"), []byte("v1-1")))
+	require.NoError(r.Put("ns", []byte("k1"), []byte("v1-2")))
+	require.NoError(r.Delete("ns", []byte("k2")))
+	// the nodes of contract storage tries are left out
+	require.NoError(r.Put(evm.ContractKVNameSpace, []byte("node"), []byte("node")))
+	// a change reverted is not recorded
+	snapshot := r.Snapshot()
+	require.NoError(r.Put("ns", []byte("k3"), []byte("v3")))
+	require.NoError(r.RevertSnapshot(snapshot))
+	tracer, ok := protocol.GetStorageTracerCtx(ctx)
+	require.True(ok)
+	contract := hash.BytesToHash160([]byte("contract"))
+	slot := hash.BytesToHash256([]byte("slot"))
+	tracer(contract, slot, nil, []byte("v"))
+	r.end()
+
+	// an action changing nothing is left out
+	r.begin(context.Background(), hash.BytesToHash256([]byte("action2")))
+	r.end()
+
+	diffs, err := r.result()
+	require.NoError(err)
+	require.Len(diffs, 2)
+	require.Equal(hash.ZeroHash256, diffs[0].ActionHash)
+	require.Equal([]*StateChange{{Namespace: "ns", Key: []byte("k2"), After: []byte("v2")}}, diffs[0].States)
+	require.Equal(h1, diffs[1].ActionHash)
+	require.Equal([]*StateChange{
+		{Namespace: "ns", Key: []byte("k1"), Before: []byte("v1"), After: []byte("v1-2")},
+		{Namespace: "ns", Key: []byte("k2"), Before: []byte("v2")},
+	}, diffs[1].States)
+	require.Equal([]*StorageChange{{Contract: contract, Key: slot, After: []byte("v")}}, diffs[1].Storage)
+
+	// the diffs are persisted
+	kv := db.NewMemKVStore()
+	_, err = getStateDiff(kv, 5)
+	require.Equal(db.ErrNotExist, errors.Cause(err))
+	require.NoError(putStateDiff(kv.Put, 5, diffs))
+	persisted, err := getStateDiff(kv, 5)
+	require.NoError(err)
+	require.Equal(diffs, persisted)
+}
@@ -95,6 +95,31 @@ func protocolCommit(ctx context.Context, sr protocol.StateManager) error {
 	return nil
 }
 
+func createPostSystemActions(
+	ctx context.Context,
+	reg *protocol.Registry,
+	sr protocol.StateReader,
+	sign func(action.Envelope) (action.SealedEnvelope, error),
+) ([]action.SealedEnvelope, error) {
+	postSystemActions := make([]action.SealedEnvelope, 0)
+	for _, p := range reg.All() {
+		if psac, ok := p.(protocol.PostSystemActionsCreator); ok {
+			elps, err := psac.CreatePostSystemActions(ctx, sr)
+			if err != nil {
+				return nil, err
+			}
+			for _, elp := range elps {
+				se, err := sign(elp)
+				if err != nil {
+					return nil, err
+				}
+				postSystemActions = append(postSystemActions, se)
+			}
+		}
+	}
+	return postSystemActions, nil
+}
+
 func readStates(kvStore db.KVStore, namespace string, keys [][]byte) ([][]byte, error) {
 	if keys == nil {
 		_, values, err := kvStore.Filter(namespace, func(k, v []byte) bool { return true }, nil, nil)
@@ -9,6 +9,7 @@ package factory
 import (
 	"context"
 	"sort"
+	"time"
 
 	"github.com/iotexproject/go-pkgs/hash"
 	"github.com/pkg/errors"
@@ -48,17 +49,50 @@ type (
 		finalized bool
 		dock      protocol.Dock
 		receipts  []*action.Receipt
+		workers   int
+		sv        *action.SignatureVerifier
+		recorder  *stateDiffRecorder
+		tracker   *accountTracker
 	}
 )
 
-func newWorkingSet(height uint64, store workingSetStore) *workingSet {
+func newWorkingSet(height uint64, store workingSetStore, workers int, sv *action.SignatureVerifier) *workingSet {
 	return &workingSet{
-		height: height,
-		store:  store,
-		dock:   protocol.NewDock(),
+		height:  height,
+		store:   store,
+		dock:    protocol.NewDock(),
+		workers: workers,
+		sv:      sv,
 	}
 }
 
+// recordStateDiff records the states changed by each action run afterwards. The actions are executed one by one, as
+// the changes could not be told apart in parallel execution
+func (ws *workingSet) recordStateDiff() {
+	ws.recorder = newStateDiffRecorder(ws.store)
+	ws.store = ws.recorder
+	ws.workers = 0
+}
+
+// trackAccounts tracks the accounts written by the patches and everything run afterwards
+func (ws *workingSet) trackAccounts(patches []*patch) {
+	ws.tracker = newAccountTracker(ws.store)
+	ws.store = ws.tracker
+	for _, p := range patches {
+		ws.tracker.touch(p.Namespace, p.Key)
+	}
+}
+
+// persistStateDiff puts the states changed by the block into the batch of the working set, such that the state diff
+// index is committed along with the states
+func (ws *workingSet) persistStateDiff() error {
+	diffs, err := ws.recorder.result()
+	if err != nil {
+		return errors.Wrapf(err, "failed to record state diff of block %d", ws.height)
+	}
+	return putStateDiff(ws.store.PutIndex, ws.height, diffs)
+}
+
 func (ws *workingSet) digest() (hash.Hash256, error) {
 	if !ws.finalized {
 		return hash.ZeroHash256, errors.New("workingset has not been finalized yet")
@@ -99,12 +133,23 @@ func (ws *workingSet) runActions(
 ) ([]*action.Receipt, error) {
 	// Handle actions
 	receipts := make([]*action.Receipt, 0)
+	executor := newParallelExecutor(ws, ws.workers)
+	executor.speculate(ctx, elps, false)
 	for _, elp := range elps {
 		ctxWithActionContext, err := withActionCtx(ctx, elp)
 		if err != nil {
 			return nil, err
 		}
-		receipt, err := ws.runAction(ctxWithActionContext, elp)
+		var receipt *action.Receipt
+		spec, err := executor.take(ctxWithActionContext, elp)
+		if err != nil {
+			return nil, errors.Wrap(err, "error when commit speculative execution")
+		}
+		if spec != nil {
+			receipt, err = spec.receipt, spec.err
+		} else {
+			receipt, err = executor.runAction(ctxWithActionContext, elp)
+		}
 		if err != nil {
 			return nil, errors.Wrap(err, "error when run action")
 		}
@@ -144,6 +189,25 @@ func withActionCtx(ctx context.Context, selp action.SealedEnvelope) (context.Con
 func (ws *workingSet) runAction(
 	ctx context.Context,
 	elp action.SealedEnvelope,
+) (*action.Receipt, error) {
+	if ws.recorder != nil {
+		elpHash, err := elp.Hash()
+		if err != nil {
+			return nil, err
+		}
+		ctx = ws.recorder.begin(ctx, elpHash)
+		defer ws.recorder.end()
+	}
+	receipt, err := ws.handle(ctx, elp, ws)
+	ws.ResetSnapshots()
+	return receipt, err
+}
+
+// handle handles the action with the state manager, which is either the working set or the speculative one
+func (ws *workingSet) handle(
+	ctx context.Context,
+	elp action.SealedEnvelope,
+	sm protocol.StateManager,
 ) (*action.Receipt, error) {
 	if protocol.MustGetBlockCtx(ctx).GasLimit < protocol.MustGetActionCtx(ctx).IntrinsicGas {
 		return nil, action.ErrGasLimit
@@ -162,8 +226,10 @@ func (ws *workingSet) runAction(
 		return nil, errors.Wrapf(err, "Failed to get hash")
 	}
 	var receipt *action.Receipt
+	tracer, traced := protocol.GetHandleTracerCtx(ctx)
 	for _, actionHandler := range reg.All() {
-		receipt, err = actionHandler.Handle(ctx, elp.Action(), ws)
+		start := time.Now()
+		receipt, err = actionHandler.Handle(ctx, elp.Action(), sm)
 		if err != nil {
 			err = errors.Wrapf(
 				err,
@@ -172,10 +238,12 @@ func (ws *workingSet) runAction(
 			)
 		}
 		if receipt != nil || err != nil {
+			if traced {
+				tracer(actionHandler.Name(), elp.Action(), receipt, time.Since(start))
+			}
 			break
 		}
 	}
-	ws.ResetSnapshots()
 
 	// TODO (zhi): return error if both receipt and err are nil
 	return receipt, err
@@ -216,6 +284,11 @@ func (ws *workingSet) ResetSnapshots() {
 
 // Commit persists all changes in RunActions() into the DB
 func (ws *workingSet) Commit(ctx context.Context) error {
+	if ws.recorder != nil {
+		if err := ws.persistStateDiff(); err != nil {
+			return err
+		}
+	}
 	if err := ws.store.Commit(); err != nil {
 		return err
 	}
@@ -425,8 +498,17 @@ func (ws *workingSet) pickAndRunActions(
 	// initial action iterator
 	blkCtx := protocol.MustGetBlockCtx(ctx)
 	ctxWithBlockContext := ctx
+	executor := newParallelExecutor(ws, ws.workers)
 	if ap != nil {
-		actionIterator := actioniterator.NewActionIterator(ap.PendingActionMap())
+		pendingActions := ap.PendingActionMap()
+		heads := make([]action.SealedEnvelope, 0, len(pendingActions))
+		for _, acts := range pendingActions {
+			if len(acts) > 0 {
+				heads = append(heads, acts[0])
+			}
+		}
+		executor.speculate(ctx, heads, true)
+		actionIterator := actioniterator.NewActionIterator(pendingActions)
 		for {
 			nextAction, ok := actionIterator.Next()
 			if !ok {
@@ -436,14 +518,16 @@ func (ws *workingSet) pickAndRunActions(
 				actionIterator.PopAccount()
 				continue
 			}
+			var spec *speculation
 			actionCtx, err := withActionCtx(ctxWithBlockContext, nextAction)
 			if err == nil {
-				for _, p := range reg.All() {
-					if validator, ok := p.(protocol.ActionValidator); ok {
-						if err = validator.Validate(actionCtx, nextAction.Action(), ws); err != nil {
-							break
-						}
-					}
+				if spec, err = executor.take(actionCtx, nextAction); err != nil {
+					return nil, errors.Wrap(err, "error when commit speculative execution")
+				}
+				if spec != nil {
+					err = spec.validateErr
+				} else {
+					err = validateAction(actionCtx, nextAction, ws)
 				}
 			}
 			if err != nil {
@@ -455,7 +539,12 @@ func (ws *workingSet) pickAndRunActions(
 				actionIterator.PopAccount()
 				continue
 			}
-			receipt, err := ws.runAction(actionCtx, nextAction)
+			var receipt *action.Receipt
+			if spec != nil {
+				receipt, err = spec.receipt, spec.err
+			} else {
+				receipt, err = executor.runAction(actionCtx, nextAction)
+			}
 			switch errors.Cause(err) {
 			case nil:
 				// do nothing
@@ -491,7 +580,7 @@ func (ws *workingSet) pickAndRunActions(
 		if err != nil {
 			return nil, err
 		}
-		receipt, err := ws.runAction(actionCtx, selp)
+		receipt, err := executor.runAction(actionCtx, selp)
 		if err != nil {
 			return nil, err
 		}
@@ -517,6 +606,12 @@ func updateReceiptIndex(receipts []*action.Receipt) {
 }
 
 func (ws *workingSet) ValidateBlock(ctx context.Context, blk *block.Block) error {
+	if ws.sv != nil {
+		// the signatures verified here are skipped when the actions are validated by protocols
+		if err := ws.sv.VerifyBatch(blk.Actions); err != nil {
+			return err
+		}
+	}
 	if err := ws.validateNonce(ctx, blk); err != nil {
 		return errors.Wrap(err, "failed to validate nonce")
 	}
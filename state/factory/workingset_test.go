@@ -226,6 +226,24 @@ func TestWorkingSet_ValidateBlock(t *testing.T) {
 			require.Equal(test.err, errors.Cause(f.Validate(zctx, test.block)))
 		}
 	}
+
+	// the signatures are verified ahead with the verifier
+	sv := action.NewSignatureVerifier(10, 2)
+	f3, err := NewFactory(DefaultConfig, db.NewMemKVStore(), SignatureVerifierOption(sv))
+	require.NoError(err)
+	f4, err := NewStateDB(DefaultConfig, db.NewMemKVStore(), SignatureVerifierStateDBOption(sv))
+	require.NoError(err)
+	forged := makeBlock(t, 1, hash.ZeroHash256, digestHash)
+	selp := forged.Actions[0]
+	pk := identityset.PrivateKey(28).PublicKey()
+	if pk.HexString() == selp.SrcPubkey().HexString() {
+		pk = identityset.PrivateKey(29).PublicKey()
+	}
+	forged.Actions[0] = action.AssembleSealedEnvelope(selp.Envelope, pk, selp.Signature())
+	for _, f := range []Factory{f3, f4} {
+		require.Equal(action.ErrInvalidSender, errors.Cause(f.Validate(zctx, forged)))
+		require.NoError(f.Validate(zctx, makeBlock(t, 1, hash.ZeroHash256, digestHash)))
+	}
 }
 
 func makeBlock(t *testing.T, nonce uint64, rootHash hash.Hash256, digest hash.Hash256) *block.Block {
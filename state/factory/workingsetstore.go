@@ -27,6 +27,8 @@ import (
 type (
 	workingSetStore interface {
 		db.KVStoreBasic
+		// PutIndex puts a record of an index into the batch committed along with the states, which is not a state
+		PutIndex(string, []byte, []byte) error
 		Commit() error
 		States(string, [][]byte) ([][]byte, error)
 		Digest() hash.Hash256
@@ -72,6 +74,26 @@ func newFactoryWorkingSetStore(view protocol.View, flusher db.KVStoreFlusher) (w
 	}, nil
 }
 
+// newFactoryWorkingSetStoreAtHeight returns the store atop the archived states at height
+func newFactoryWorkingSetStoreAtHeight(view protocol.View, flusher db.KVStoreFlusher, height uint64) (workingSetStore, error) {
+	tlt, err := newTwoLayerTrie(
+		ArchiveTrieNamespace,
+		flusher.KVStoreWithBuffer(),
+		fmt.Sprintf("%s-%d", ArchiveTrieRootKey, height),
+		false,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return &factoryWorkingSetStore{
+		flusher:   flusher,
+		view:      view,
+		tlt:       tlt,
+		trieRoots: make(map[int][]byte),
+	}, nil
+}
+
 func (store *stateDBWorkingSetStore) Start(context.Context) error {
 	return nil
 }
@@ -104,6 +126,11 @@ func (store *stateDBWorkingSetStore) Put(ns string, key []byte, value []byte) er
 	return nil
 }
 
+func (store *stateDBWorkingSetStore) PutIndex(ns string, key []byte, value []byte) error {
+	store.flusher.KVStoreWithBuffer().MustPut(ns, key, value)
+	return nil
+}
+
 func (store *stateDBWorkingSetStore) Delete(ns string, key []byte) error {
 	store.flusher.KVStoreWithBuffer().MustDelete(ns, key)
 	return nil
@@ -173,6 +200,11 @@ func (store *factoryWorkingSetStore) Put(ns string, key []byte, value []byte) er
 	return store.tlt.Upsert(nsHash[:], toLegacyKey(key), value)
 }
 
+func (store *factoryWorkingSetStore) PutIndex(ns string, key []byte, value []byte) error {
+	store.flusher.KVStoreWithBuffer().MustPut(ns, key, value)
+	return nil
+}
+
 func (store *factoryWorkingSetStore) Delete(ns string, key []byte) error {
 	store.flusher.KVStoreWithBuffer().MustDelete(ns, key)
 	nsHash := hash.Hash160b([]byte(ns))
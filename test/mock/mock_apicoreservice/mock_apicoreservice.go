@@ -6,15 +6,21 @@ package mock_apicoreservice
 
 import (
 	context "context"
+	big "math/big"
 	reflect "reflect"
 
+	types "github.com/ethereum/go-ethereum/core/types"
 	gomock "github.com/golang/mock/gomock"
 	hash "github.com/iotexproject/go-pkgs/hash"
 	address "github.com/iotexproject/iotex-address/address"
 	action "github.com/iotexproject/iotex-core/action"
+	evm "github.com/iotexproject/iotex-core/action/protocol/execution/evm"
 	logfilter "github.com/iotexproject/iotex-core/api/logfilter"
 	apitypes "github.com/iotexproject/iotex-core/api/types"
 	block "github.com/iotexproject/iotex-core/blockchain/block"
+	blockindex "github.com/iotexproject/iotex-core/blockindex"
+	state "github.com/iotexproject/iotex-core/state"
+	factory "github.com/iotexproject/iotex-core/state/factory"
 	iotexapi "github.com/iotexproject/iotex-proto/golang/iotexapi"
 	iotextypes "github.com/iotexproject/iotex-proto/golang/iotextypes"
 )
@@ -107,18 +113,19 @@ func (mr *MockCoreServiceMockRecorder) Actions(start, count interface{}) *gomock
 }
 
 // ActionsByAddress mocks base method.
-func (m *MockCoreService) ActionsByAddress(addr address.Address, start, count uint64) ([]*iotexapi.ActionInfo, error) {
+func (m *MockCoreService) ActionsByAddress(addr address.Address, filter *blockindex.ActionFilter, cursor string, desc bool, count uint64) ([]*iotexapi.ActionInfo, string, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "ActionsByAddress", addr, start, count)
+	ret := m.ctrl.Call(m, "ActionsByAddress", addr, filter, cursor, desc, count)
 	ret0, _ := ret[0].([]*iotexapi.ActionInfo)
-	ret1, _ := ret[1].(error)
-	return ret0, ret1
+	ret1, _ := ret[1].(string)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
 }
 
 // ActionsByAddress indicates an expected call of ActionsByAddress.
-func (mr *MockCoreServiceMockRecorder) ActionsByAddress(addr, start, count interface{}) *gomock.Call {
+func (mr *MockCoreServiceMockRecorder) ActionsByAddress(addr, filter, cursor, desc, count interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ActionsByAddress", reflect.TypeOf((*MockCoreService)(nil).ActionsByAddress), addr, start, count)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ActionsByAddress", reflect.TypeOf((*MockCoreService)(nil).ActionsByAddress), addr, filter, cursor, desc, count)
 }
 
 // ActionsInActPool mocks base method.
@@ -136,6 +143,37 @@ func (mr *MockCoreServiceMockRecorder) ActionsInActPool(actHashes interface{}) *
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ActionsInActPool", reflect.TypeOf((*MockCoreService)(nil).ActionsInActPool), actHashes)
 }
 
+// BalanceAt mocks base method.
+func (m *MockCoreService) BalanceAt(addr address.Address, height uint64) (*big.Int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "BalanceAt", addr, height)
+	ret0, _ := ret[0].(*big.Int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// BalanceAt indicates an expected call of BalanceAt.
+func (mr *MockCoreServiceMockRecorder) BalanceAt(addr, height interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "BalanceAt", reflect.TypeOf((*MockCoreService)(nil).BalanceAt), addr, height)
+}
+
+// BalanceHistory mocks base method.
+func (m *MockCoreService) BalanceHistory(addr address.Address, startHeight uint64, count uint32) ([]*blockindex.BalanceChange, uint64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "BalanceHistory", addr, startHeight, count)
+	ret0, _ := ret[0].([]*blockindex.BalanceChange)
+	ret1, _ := ret[1].(uint64)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// BalanceHistory indicates an expected call of BalanceHistory.
+func (mr *MockCoreServiceMockRecorder) BalanceHistory(addr, startHeight, count interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "BalanceHistory", reflect.TypeOf((*MockCoreService)(nil).BalanceHistory), addr, startHeight, count)
+}
+
 // BlockByHash mocks base method.
 func (m *MockCoreService) BlockByHash(arg0 string) (*apitypes.BlockWithReceipts, error) {
 	m.ctrl.T.Helper()
@@ -240,6 +278,38 @@ func (mr *MockCoreServiceMockRecorder) ChainMeta() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ChainMeta", reflect.TypeOf((*MockCoreService)(nil).ChainMeta))
 }
 
+// ContractStorageRange mocks base method.
+func (m *MockCoreService) ContractStorageRange(ctx context.Context, addr address.Address, height uint64, start []byte, count uint32) ([]*evm.ContractStorageSlot, []byte, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ContractStorageRange", ctx, addr, height, start, count)
+	ret0, _ := ret[0].([]*evm.ContractStorageSlot)
+	ret1, _ := ret[1].([]byte)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// ContractStorageRange indicates an expected call of ContractStorageRange.
+func (mr *MockCoreServiceMockRecorder) ContractStorageRange(ctx, addr, height, start, count interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ContractStorageRange", reflect.TypeOf((*MockCoreService)(nil).ContractStorageRange), ctx, addr, height, start, count)
+}
+
+// CreateAccessList mocks base method.
+func (m *MockCoreService) CreateAccessList(ctx context.Context, callerAddr address.Address, sc *action.Execution) (types.AccessList, *action.Receipt, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateAccessList", ctx, callerAddr, sc)
+	ret0, _ := ret[0].(types.AccessList)
+	ret1, _ := ret[1].(*action.Receipt)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// CreateAccessList indicates an expected call of CreateAccessList.
+func (mr *MockCoreServiceMockRecorder) CreateAccessList(ctx, callerAddr, sc interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateAccessList", reflect.TypeOf((*MockCoreService)(nil).CreateAccessList), ctx, callerAddr, sc)
+}
+
 // EVMNetworkID mocks base method.
 func (m *MockCoreService) EVMNetworkID() uint32 {
 	m.ctrl.T.Helper()
@@ -287,18 +357,23 @@ func (mr *MockCoreServiceMockRecorder) EpochMeta(epochNum interface{}) *gomock.C
 }
 
 // EstimateExecutionGasConsumption mocks base method.
-func (m *MockCoreService) EstimateExecutionGasConsumption(ctx context.Context, sc *action.Execution, callerAddr address.Address) (uint64, error) {
+func (m *MockCoreService) EstimateExecutionGasConsumption(ctx context.Context, sc *action.Execution, callerAddr address.Address, opts ...apitypes.EstimateOption) (uint64, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "EstimateExecutionGasConsumption", ctx, sc, callerAddr)
+	varargs := []interface{}{ctx, sc, callerAddr}
+	for _, a := range opts {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "EstimateExecutionGasConsumption", varargs...)
 	ret0, _ := ret[0].(uint64)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // EstimateExecutionGasConsumption indicates an expected call of EstimateExecutionGasConsumption.
-func (mr *MockCoreServiceMockRecorder) EstimateExecutionGasConsumption(ctx, sc, callerAddr interface{}) *gomock.Call {
+func (mr *MockCoreServiceMockRecorder) EstimateExecutionGasConsumption(ctx, sc, callerAddr interface{}, opts ...interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "EstimateExecutionGasConsumption", reflect.TypeOf((*MockCoreService)(nil).EstimateExecutionGasConsumption), ctx, sc, callerAddr)
+	varargs := append([]interface{}{ctx, sc, callerAddr}, opts...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "EstimateExecutionGasConsumption", reflect.TypeOf((*MockCoreService)(nil).EstimateExecutionGasConsumption), varargs...)
 }
 
 // EstimateGasForAction mocks base method.
@@ -331,6 +406,36 @@ func (mr *MockCoreServiceMockRecorder) EstimateGasForNonExecution(arg0 interface
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "EstimateGasForNonExecution", reflect.TypeOf((*MockCoreService)(nil).EstimateGasForNonExecution), arg0)
 }
 
+// LegacyActionsByAddress mocks base method.
+func (m *MockCoreService) LegacyActionsByAddress(addr address.Address, start, count uint64) ([]*iotexapi.ActionInfo, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "LegacyActionsByAddress", addr, start, count)
+	ret0, _ := ret[0].([]*iotexapi.ActionInfo)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// LegacyActionsByAddress indicates an expected call of LegacyActionsByAddress.
+func (mr *MockCoreServiceMockRecorder) LegacyActionsByAddress(addr, start, count interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "LegacyActionsByAddress", reflect.TypeOf((*MockCoreService)(nil).LegacyActionsByAddress), addr, start, count)
+}
+
+// LegacyUnconfirmedActionsByAddress mocks base method.
+func (m *MockCoreService) LegacyUnconfirmedActionsByAddress(address string, start, count uint64) ([]*iotexapi.ActionInfo, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "LegacyUnconfirmedActionsByAddress", address, start, count)
+	ret0, _ := ret[0].([]*iotexapi.ActionInfo)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// LegacyUnconfirmedActionsByAddress indicates an expected call of LegacyUnconfirmedActionsByAddress.
+func (mr *MockCoreServiceMockRecorder) LegacyUnconfirmedActionsByAddress(address, start, count interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "LegacyUnconfirmedActionsByAddress", reflect.TypeOf((*MockCoreService)(nil).LegacyUnconfirmedActionsByAddress), address, start, count)
+}
+
 // LogsInBlockByHash mocks base method.
 func (m *MockCoreService) LogsInBlockByHash(filter *logfilter.LogFilter, blockHash hash.Hash256) ([]*action.Log, error) {
 	m.ctrl.T.Helper()
@@ -362,6 +467,36 @@ func (mr *MockCoreServiceMockRecorder) LogsInRange(filter, start, end, paginatio
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "LogsInRange", reflect.TypeOf((*MockCoreService)(nil).LogsInRange), filter, start, end, paginationSize)
 }
 
+// PendingAccount mocks base method.
+func (m *MockCoreService) PendingAccount(arg0 address.Address) (*state.Account, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PendingAccount", arg0)
+	ret0, _ := ret[0].(*state.Account)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// PendingAccount indicates an expected call of PendingAccount.
+func (mr *MockCoreServiceMockRecorder) PendingAccount(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PendingAccount", reflect.TypeOf((*MockCoreService)(nil).PendingAccount), arg0)
+}
+
+// PendingBlock mocks base method.
+func (m *MockCoreService) PendingBlock() (*apitypes.BlockWithReceipts, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PendingBlock")
+	ret0, _ := ret[0].(*apitypes.BlockWithReceipts)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// PendingBlock indicates an expected call of PendingBlock.
+func (mr *MockCoreServiceMockRecorder) PendingBlock() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PendingBlock", reflect.TypeOf((*MockCoreService)(nil).PendingBlock))
+}
+
 // PendingNonce mocks base method.
 func (m *MockCoreService) PendingNonce(arg0 address.Address) (uint64, error) {
 	m.ctrl.T.Helper()
@@ -423,6 +558,22 @@ func (mr *MockCoreServiceMockRecorder) ReadContractStorage(ctx, addr, key interf
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReadContractStorage", reflect.TypeOf((*MockCoreService)(nil).ReadContractStorage), ctx, addr, key)
 }
 
+// ReadPendingContract mocks base method.
+func (m *MockCoreService) ReadPendingContract(ctx context.Context, callerAddr address.Address, sc *action.Execution) (string, *iotextypes.Receipt, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ReadPendingContract", ctx, callerAddr, sc)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(*iotextypes.Receipt)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// ReadPendingContract indicates an expected call of ReadPendingContract.
+func (mr *MockCoreServiceMockRecorder) ReadPendingContract(ctx, callerAddr, sc interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReadPendingContract", reflect.TypeOf((*MockCoreService)(nil).ReadPendingContract), ctx, callerAddr, sc)
+}
+
 // ReadState mocks base method.
 func (m *MockCoreService) ReadState(protocolID, height string, methodName []byte, arguments [][]byte) (*iotexapi.ReadStateResponse, error) {
 	m.ctrl.T.Helper()
@@ -530,6 +681,21 @@ func (mr *MockCoreServiceMockRecorder) Start(ctx interface{}) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Start", reflect.TypeOf((*MockCoreService)(nil).Start), ctx)
 }
 
+// StateDiff mocks base method.
+func (m *MockCoreService) StateDiff(ctx context.Context, height uint64) ([]*factory.ActionStateDiff, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "StateDiff", ctx, height)
+	ret0, _ := ret[0].([]*factory.ActionStateDiff)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// StateDiff indicates an expected call of StateDiff.
+func (mr *MockCoreServiceMockRecorder) StateDiff(ctx, height interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "StateDiff", reflect.TypeOf((*MockCoreService)(nil).StateDiff), ctx, height)
+}
+
 // Stop mocks base method.
 func (m *MockCoreService) Stop(ctx context.Context) error {
 	m.ctrl.T.Helper()
@@ -621,18 +787,19 @@ func (mr *MockCoreServiceMockRecorder) TransactionLogByBlockHeight(blockHeight i
 }
 
 // UnconfirmedActionsByAddress mocks base method.
-func (m *MockCoreService) UnconfirmedActionsByAddress(address string, start, count uint64) ([]*iotexapi.ActionInfo, error) {
+func (m *MockCoreService) UnconfirmedActionsByAddress(address string, filter *blockindex.ActionFilter, cursor string, desc bool, count uint64) ([]*iotexapi.ActionInfo, string, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "UnconfirmedActionsByAddress", address, start, count)
+	ret := m.ctrl.Call(m, "UnconfirmedActionsByAddress", address, filter, cursor, desc, count)
 	ret0, _ := ret[0].([]*iotexapi.ActionInfo)
-	ret1, _ := ret[1].(error)
-	return ret0, ret1
+	ret1, _ := ret[1].(string)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
 }
 
 // UnconfirmedActionsByAddress indicates an expected call of UnconfirmedActionsByAddress.
-func (mr *MockCoreServiceMockRecorder) UnconfirmedActionsByAddress(address, start, count interface{}) *gomock.Call {
+func (mr *MockCoreServiceMockRecorder) UnconfirmedActionsByAddress(address, filter, cursor, desc, count interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UnconfirmedActionsByAddress", reflect.TypeOf((*MockCoreService)(nil).UnconfirmedActionsByAddress), address, start, count)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UnconfirmedActionsByAddress", reflect.TypeOf((*MockCoreService)(nil).UnconfirmedActionsByAddress), address, filter, cursor, desc, count)
 }
 
 // MockintrinsicGasCalculator is a mock of intrinsicGasCalculator interface.
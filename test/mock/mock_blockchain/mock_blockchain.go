@@ -184,6 +184,20 @@ func (mr *MockBlockchainMockRecorder) MintNewBlock(timestamp interface{}) *gomoc
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MintNewBlock", reflect.TypeOf((*MockBlockchain)(nil).MintNewBlock), timestamp)
 }
 
+// PrevalidateBlock mocks base method.
+func (m *MockBlockchain) PrevalidateBlock(blk *block.Block) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PrevalidateBlock", blk)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// PrevalidateBlock indicates an expected call of PrevalidateBlock.
+func (mr *MockBlockchainMockRecorder) PrevalidateBlock(blk interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PrevalidateBlock", reflect.TypeOf((*MockBlockchain)(nil).PrevalidateBlock), blk)
+}
+
 // RemoveSubscriber mocks base method.
 func (m *MockBlockchain) RemoveSubscriber(arg0 blockchain.BlockCreationSubscriber) error {
 	m.ctrl.T.Helper()
@@ -16,6 +16,7 @@ import (
 	actpool "github.com/iotexproject/iotex-core/actpool"
 	block "github.com/iotexproject/iotex-core/blockchain/block"
 	state "github.com/iotexproject/iotex-core/state"
+	factory "github.com/iotexproject/iotex-core/state/factory"
 )
 
 // MockFactory is a mock of Factory interface.
@@ -41,6 +42,22 @@ func (m *MockFactory) EXPECT() *MockFactoryMockRecorder {
 	return m.recorder
 }
 
+// ContractStorageRange mocks base method.
+func (m *MockFactory) ContractStorageRange(arg0 context.Context, arg1 uint64, arg2 address.Address, arg3 []byte, arg4 uint32) ([]*evm.ContractStorageSlot, []byte, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ContractStorageRange", arg0, arg1, arg2, arg3, arg4)
+	ret0, _ := ret[0].([]*evm.ContractStorageSlot)
+	ret1, _ := ret[1].([]byte)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// ContractStorageRange indicates an expected call of ContractStorageRange.
+func (mr *MockFactoryMockRecorder) ContractStorageRange(arg0, arg1, arg2, arg3, arg4 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ContractStorageRange", reflect.TypeOf((*MockFactory)(nil).ContractStorageRange), arg0, arg1, arg2, arg3, arg4)
+}
+
 // DeleteTipBlock mocks base method.
 func (m *MockFactory) DeleteTipBlock(arg0 context.Context, arg1 *block.Block) error {
 	m.ctrl.T.Helper()
@@ -85,6 +102,22 @@ func (mr *MockFactoryMockRecorder) NewBlockBuilder(arg0, arg1, arg2 interface{})
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "NewBlockBuilder", reflect.TypeOf((*MockFactory)(nil).NewBlockBuilder), arg0, arg1, arg2)
 }
 
+// NewPendingBlockBuilder mocks base method.
+func (m *MockFactory) NewPendingBlockBuilder(arg0 context.Context, arg1 actpool.ActPool, arg2 func(action.Envelope) (action.SealedEnvelope, error)) (*block.Builder, protocol.StateManager, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "NewPendingBlockBuilder", arg0, arg1, arg2)
+	ret0, _ := ret[0].(*block.Builder)
+	ret1, _ := ret[1].(protocol.StateManager)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// NewPendingBlockBuilder indicates an expected call of NewPendingBlockBuilder.
+func (mr *MockFactoryMockRecorder) NewPendingBlockBuilder(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "NewPendingBlockBuilder", reflect.TypeOf((*MockFactory)(nil).NewPendingBlockBuilder), arg0, arg1, arg2)
+}
+
 // PutBlock mocks base method.
 func (m *MockFactory) PutBlock(arg0 context.Context, arg1 *block.Block) error {
 	m.ctrl.T.Helper()
@@ -212,6 +245,21 @@ func (mr *MockFactoryMockRecorder) StateAtHeight(arg0, arg1 interface{}, arg2 ..
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "StateAtHeight", reflect.TypeOf((*MockFactory)(nil).StateAtHeight), varargs...)
 }
 
+// StateDiff mocks base method.
+func (m *MockFactory) StateDiff(arg0 context.Context, arg1 *block.Block) ([]*factory.ActionStateDiff, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "StateDiff", arg0, arg1)
+	ret0, _ := ret[0].([]*factory.ActionStateDiff)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// StateDiff indicates an expected call of StateDiff.
+func (mr *MockFactoryMockRecorder) StateDiff(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "StateDiff", reflect.TypeOf((*MockFactory)(nil).StateDiff), arg0, arg1)
+}
+
 // States mocks base method.
 func (m *MockFactory) States(arg0 ...protocol.StateOption) (uint64, state.Iterator, error) {
 	m.ctrl.T.Helper()
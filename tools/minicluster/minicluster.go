@@ -96,6 +96,8 @@ func main() {
 		dbFilePaths = append(dbFilePaths, bloomfilterIndexDBPath)
 		consensusDBPath := fmt.Sprintf("./consensus%d.db", i+1)
 		dbFilePaths = append(dbFilePaths, consensusDBPath)
+		consensusWALPath := fmt.Sprintf("./consensus%d.wal", i+1)
+		dbFilePaths = append(dbFilePaths, consensusWALPath)
 		systemLogDBPath := fmt.Sprintf("./systemlog%d.db", i+1)
 		dbFilePaths = append(dbFilePaths, systemLogDBPath)
 		candidateIndexDBPath := fmt.Sprintf("./candidate.index%d.db", i+1)
@@ -113,6 +115,7 @@ func main() {
 		config.Chain.BloomfilterIndexDBPath = bloomfilterIndexDBPath
 		config.Chain.CandidateIndexDBPath = candidateIndexDBPath
 		config.Consensus.RollDPoS.ConsensusDBPath = consensusDBPath
+		config.Consensus.RollDPoS.ConsensusWALPath = consensusWALPath
 		config.System.SystemLogDBPath = systemLogDBPath
 		if i == 0 {
 			config.Network.BootstrapNodes = []string{}
@@ -0,0 +1,427 @@
+// Copyright (c) 2022 IoTeX Foundation
+// This is an alpha (internal) release and is not suitable for production. This source code is provided 'as is' and no
+// warranties are given as to title or non-infringement, merchantability or fitness for purpose and, to the extent
+// permitted by law, all liability for your use of the code is disclaimed. This source code is governed by Apache
+// License 2.0 that can be found in the LICENSE file.
+
+// This is a benchmark tool that replays the blocks of an exported chain database on top of a state snapshot, and
+// reports the time spent per block, per protocol and per action type, as well as the state root divergence.
+// To use, run "make build-replaybench"
+package main
+
+import (
+	"context"
+	"encoding/csv"
+	"flag"
+	"fmt"
+	"io"
+	glog "log"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/pkg/errors"
+	"go.uber.org/zap"
+
+	"github.com/iotexproject/iotex-core/action"
+	"github.com/iotexproject/iotex-core/action/protocol"
+	"github.com/iotexproject/iotex-core/blockchain/block"
+	"github.com/iotexproject/iotex-core/blockchain/blockdao"
+	"github.com/iotexproject/iotex-core/blockchain/genesis"
+	"github.com/iotexproject/iotex-core/chainservice"
+	"github.com/iotexproject/iotex-core/config"
+	"github.com/iotexproject/iotex-core/pkg/log"
+	"github.com/iotexproject/iotex-core/state/factory"
+)
+
+/**
+ * overwritePath is the path to the config file which overwrite default values
+ * secretPath is the path to the  config file store secret values
+ * chainDBPath is the path to the exported chain db, which the blocks are read from
+ * stateDBPath is the path to the state snapshot, which is copied before replaying
+ */
+var (
+	_genesisPath   string
+	_overwritePath string
+	_secretPath    string
+	_plugins       strs
+	_chainDBPath   string
+	_stateDBPath   string
+	_startHeight   uint64
+	_endHeight     uint64
+	_blockReport   string
+)
+
+type (
+	strs []string
+
+	stat struct {
+		count    int
+		gas      uint64
+		duration time.Duration
+	}
+
+	blockStat struct {
+		height  uint64
+		actions int
+		gas     uint64
+		// total is the time of PutBlock, and handle is the time spent in protocol handlers
+		total  time.Duration
+		handle time.Duration
+	}
+
+	report struct {
+		blocks      []blockStat
+		protocols   map[string]*stat
+		actionTypes map[string]*stat
+	}
+)
+
+func (ss *strs) String() string {
+	return strings.Join(*ss, ",")
+}
+
+func (ss *strs) Set(str string) error {
+	*ss = append(*ss, str)
+	return nil
+}
+
+func (s *stat) add(gas uint64, elapsed time.Duration) {
+	s.count++
+	s.gas += gas
+	s.duration += elapsed
+}
+
+func init() {
+	flag.StringVar(&_genesisPath, "genesis-path", "", "Genesis path")
+	flag.StringVar(&_overwritePath, "config-path", "", "Config path")
+	flag.StringVar(&_secretPath, "secret-path", "", "Secret path")
+	flag.Var(&_plugins, "plugin", "Plugin of the node")
+	flag.StringVar(&_chainDBPath, "chain-db", "", "Path of the exported chain db containing the blocks to replay")
+	flag.StringVar(&_stateDBPath, "state-db", "", "Path of the state snapshot to start from, empty to start from genesis")
+	flag.Uint64Var(&_startHeight, "start", 0, "First height to replay, which has to follow the snapshot height")
+	flag.Uint64Var(&_endHeight, "end", 0, "Last height to replay, 0 to replay up to the tip of the exported chain db")
+	flag.StringVar(&_blockReport, "block-report", "", "Path of the csv file to write the per block report")
+	flag.Usage = func() {
+		_, _ = fmt.Fprintf(os.Stderr,
+			"usage: replaybench -config-path=[string]\n -chain-db=[string]\n -state-db=[string]\n -end=[int]\n")
+		flag.PrintDefaults()
+		os.Exit(2)
+	}
+	flag.Parse()
+}
+
+func main() {
+	if _chainDBPath == "" {
+		flag.Usage()
+	}
+	genesisCfg, err := genesis.New(_genesisPath)
+	if err != nil {
+		glog.Fatalln("Failed to new genesis config.", zap.Error(err))
+	}
+	cfg, err := config.New([]string{_overwritePath, _secretPath}, _plugins)
+	if err != nil {
+		glog.Fatalln("Failed to new config.", zap.Error(err))
+	}
+	cfg.Genesis = genesisCfg
+
+	workDir, err := os.MkdirTemp("", "replaybench")
+	if err != nil {
+		log.L().Fatal("Failed to create work dir.", zap.Error(err))
+	}
+	defer os.RemoveAll(workDir)
+	// the replay puts no block into the exported chain db, which is opened read-write though, and builds no index
+	// nor writes into the snapshot, which is copied into the work dir
+	cfg.Chain.ChainDBPath = _chainDBPath
+	cfg.Chain.TrieDBPath = filepath.Join(workDir, "trie.db")
+	cfg.Chain.IndexDBPath = filepath.Join(workDir, "index.db")
+	cfg.Chain.BloomfilterIndexDBPath = filepath.Join(workDir, "bloomfilter.index.db")
+	cfg.Chain.CandidateIndexDBPath = filepath.Join(workDir, "candidate.index.db")
+	cfg.Chain.StakingIndexDBPath = filepath.Join(workDir, "staking.index.db")
+	// the handle tracer isn't safe for the speculative runs in parallel, which also get discarded on conflicts
+	cfg.Chain.ParallelExecutionWorkers = 0
+	if _stateDBPath != "" {
+		if err := copyFile(_stateDBPath, cfg.Chain.TrieDBPath); err != nil {
+			log.L().Fatal("Failed to copy state snapshot.", zap.Error(err))
+		}
+	}
+
+	dbConfig := cfg.DB
+	dbConfig.DbPath = cfg.Chain.ChainDBPath
+	dao := blockdao.NewBlockDAO(nil, dbConfig, block.NewDeserializer(cfg.Chain.EVMNetworkID))
+	cs, err := chainservice.NewBuilder(cfg).SetBlockDAO(dao).Build()
+	if err != nil {
+		log.L().Fatal("Failed to build chain service.", zap.Error(err))
+	}
+	ctx := context.Background()
+	if err := cs.Blockchain().Start(ctx); err != nil {
+		log.L().Fatal("Failed to start blockchain.", zap.Error(err))
+	}
+	defer func() {
+		if err := cs.Blockchain().Stop(ctx); err != nil {
+			log.L().Error("Failed to stop blockchain.", zap.Error(err))
+		}
+	}()
+	ctx = protocol.WithFeatureWithHeightCtx(genesis.WithGenesisContext(
+		protocol.WithBlockchainCtx(ctx, protocol.BlockchainCtx{
+			ChainID:      cfg.Chain.ID,
+			EvmNetworkID: cfg.Chain.EVMNetworkID,
+		}),
+		cfg.Genesis,
+	))
+	sf := cs.StateFactory()
+	if err := sf.Start(protocol.WithRegistry(ctx, cs.Registry())); err != nil {
+		log.L().Fatal("Failed to start state factory.", zap.Error(err))
+	}
+	defer func() {
+		if err := sf.Stop(ctx); err != nil {
+			log.L().Error("Failed to stop state factory.", zap.Error(err))
+		}
+	}()
+
+	r := newReport()
+	replayErr := replay(ctx, dao, sf, r)
+	r.print(os.Stdout)
+	if _blockReport != "" {
+		if err := r.writeBlocks(_blockReport); err != nil {
+			log.L().Error("Failed to write block report.", zap.Error(err))
+		}
+	}
+	if replayErr != nil {
+		log.L().Fatal("Failed to replay blocks.", zap.Error(replayErr))
+	}
+}
+
+// replay puts the blocks following the state height into the state factory one by one, the same way as
+// BlockIndexerChecker does on node start
+func replay(ctx context.Context, dao blockdao.BlockDAO, sf factory.Factory, r *report) error {
+	g := genesis.MustExtractGenesisContext(ctx)
+	bcCtx := protocol.MustGetBlockchainCtx(ctx)
+	height, err := sf.Height()
+	if err != nil {
+		return err
+	}
+	if _startHeight != 0 && _startHeight != height+1 {
+		return errors.Errorf("the state snapshot is at height %d, replaying has to start at %d", height, height+1)
+	}
+	end := _endHeight
+	daoTip, err := dao.Height()
+	if err != nil {
+		return err
+	}
+	if end == 0 || end > daoTip {
+		end = daoTip
+	}
+	if height > 0 {
+		tip, err := dao.HeaderByHeight(height)
+		if err != nil {
+			return errors.Wrapf(err, "failed to get the block at the snapshot height %d", height)
+		}
+		bcCtx.Tip.Hash = tip.HashBlock()
+		bcCtx.Tip.Timestamp = tip.Timestamp()
+	} else {
+		bcCtx.Tip.Hash = g.Hash()
+		bcCtx.Tip.Timestamp = time.Unix(g.Timestamp, 0)
+	}
+	bcCtx.Tip.Height = height
+	log.L().Info("Start replaying.", zap.Uint64("from", height+1), zap.Uint64("to", end))
+	for i := height + 1; i <= end; i++ {
+		blk, err := dao.GetBlockByHeight(i)
+		if err != nil {
+			return err
+		}
+		expected, err := dao.GetReceipts(i)
+		if err != nil {
+			return err
+		}
+		producer := blk.PublicKey().Address()
+		if producer == nil {
+			return errors.New("failed to get address")
+		}
+		bs := blockStat{height: i, actions: len(blk.Actions)}
+		blkCtx := protocol.WithHandleTracerCtx(
+			protocol.WithBlockCtx(
+				protocol.WithBlockchainCtx(ctx, bcCtx),
+				protocol.BlockCtx{
+					BlockHeight:    i,
+					BlockTimeStamp: blk.Timestamp(),
+					Producer:       producer,
+					GasLimit:       g.BlockGasLimit,
+				},
+			),
+			func(name string, act action.Action, receipt *action.Receipt, elapsed time.Duration) {
+				var gas uint64
+				if receipt != nil {
+					gas = receipt.GasConsumed
+				}
+				bs.handle += elapsed
+				r.trace(name, act, gas, elapsed)
+			},
+		)
+		start := time.Now()
+		err = sf.PutBlock(blkCtx, blk)
+		bs.total = time.Since(start)
+		for _, receipt := range blk.Receipts {
+			bs.gas += receipt.GasConsumed
+		}
+		r.blocks = append(r.blocks, bs)
+		switch errors.Cause(err) {
+		case nil:
+		case block.ErrDeltaStateMismatch, block.ErrReceiptRootMismatch:
+			return errors.Wrapf(err, "state diverges at height %d", i)
+		default:
+			return errors.Wrapf(err, "failed to replay block %d", i)
+		}
+		if err := compareReceipts(expected, blk.Receipts); err != nil {
+			return errors.Wrapf(err, "state diverges at height %d", i)
+		}
+		bcCtx.Tip.Height = i
+		bcCtx.Tip.Hash = blk.HashBlock()
+		bcCtx.Tip.Timestamp = blk.Timestamp()
+	}
+	return nil
+}
+
+// compareReceipts compares the receipts of the exported chain with the ones replayed, to tell the action diverging
+// when the receipt root isn't validated
+func compareReceipts(expected, actual []*action.Receipt) error {
+	if len(expected) != len(actual) {
+		return errors.Errorf("%d receipts expected, got %d", len(expected), len(actual))
+	}
+	for i := range expected {
+		e, a := expected[i], actual[i]
+		if e.ActionHash != a.ActionHash {
+			return errors.Errorf("receipt %d is for action %x, expected %x", i, a.ActionHash, e.ActionHash)
+		}
+		if e.Status != a.Status || e.GasConsumed != a.GasConsumed || len(e.Logs()) != len(a.Logs()) {
+			return errors.Errorf(
+				"receipt of action %x mismatches, status %d, gas %d, %d logs, expected status %d, gas %d, %d logs",
+				a.ActionHash, a.Status, a.GasConsumed, len(a.Logs()), e.Status, e.GasConsumed, len(e.Logs()),
+			)
+		}
+	}
+	return nil
+}
+
+func copyFile(src, dst string) error {
+	in, err := os.Open(src)
+	if err != nil {
+		return err
+	}
+	defer in.Close()
+	out, err := os.Create(dst)
+	if err != nil {
+		return err
+	}
+	if _, err := io.Copy(out, in); err != nil {
+		out.Close()
+		return err
+	}
+	return out.Close()
+}
+
+func newReport() *report {
+	return &report{
+		protocols:   map[string]*stat{},
+		actionTypes: map[string]*stat{},
+	}
+}
+
+func (r *report) trace(name string, act action.Action, gas uint64, elapsed time.Duration) {
+	if _, ok := r.protocols[name]; !ok {
+		r.protocols[name] = &stat{}
+	}
+	r.protocols[name].add(gas, elapsed)
+	actType := strings.TrimPrefix(fmt.Sprintf("%T", act), "*action.")
+	if _, ok := r.actionTypes[actType]; !ok {
+		r.actionTypes[actType] = &stat{}
+	}
+	r.actionTypes[actType].add(gas, elapsed)
+}
+
+func (r *report) print(w io.Writer) {
+	var (
+		total   blockStat
+		slowest = make([]blockStat, len(r.blocks))
+	)
+	for _, bs := range r.blocks {
+		total.actions += bs.actions
+		total.gas += bs.gas
+		total.total += bs.total
+		total.handle += bs.handle
+	}
+	tw := tabwriter.NewWriter(w, 0, 4, 2, ' ', 0)
+	fmt.Fprintf(tw, "blocks\tactions\tgas\ttime\thandle time\toverhead\tgas/sec\n")
+	fmt.Fprintf(tw, "%d\t%d\t%d\t%s\t%s\t%s\t%s\n\n", len(r.blocks), total.actions, total.gas,
+		total.total, total.handle, total.total-total.handle, gasPerSec(total.gas, total.total))
+	printStats(tw, "protocol", r.protocols)
+	printStats(tw, "action type", r.actionTypes)
+
+	copy(slowest, r.blocks)
+	sort.Slice(slowest, func(i, j int) bool {
+		return slowest[i].total > slowest[j].total
+	})
+	if len(slowest) > 10 {
+		slowest = slowest[:10]
+	}
+	fmt.Fprintf(tw, "slowest block\tactions\tgas\ttime\thandle time\toverhead\tgas/sec\n")
+	for _, bs := range slowest {
+		fmt.Fprintf(tw, "%d\t%d\t%d\t%s\t%s\t%s\t%s\n", bs.height, bs.actions, bs.gas,
+			bs.total, bs.handle, bs.total-bs.handle, gasPerSec(bs.gas, bs.total))
+	}
+	tw.Flush()
+}
+
+func printStats(w io.Writer, title string, stats map[string]*stat) {
+	names := make([]string, 0, len(stats))
+	for name := range stats {
+		names = append(names, name)
+	}
+	sort.Slice(names, func(i, j int) bool {
+		return stats[names[i]].duration > stats[names[j]].duration
+	})
+	fmt.Fprintf(w, "%s\tactions\tgas\ttime\tavg time\tgas/sec\n", title)
+	for _, name := range names {
+		s := stats[name]
+		fmt.Fprintf(w, "%s\t%d\t%d\t%s\t%s\t%s\n", name, s.count, s.gas,
+			s.duration, s.duration/time.Duration(s.count), gasPerSec(s.gas, s.duration))
+	}
+	fmt.Fprintln(w)
+}
+
+func (r *report) writeBlocks(path string) error {
+	f, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+	w := csv.NewWriter(f)
+	if err := w.Write([]string{"height", "actions", "gas", "time_us", "handle_time_us", "gas_per_sec"}); err != nil {
+		return err
+	}
+	for _, bs := range r.blocks {
+		if err := w.Write([]string{
+			strconv.FormatUint(bs.height, 10),
+			strconv.Itoa(bs.actions),
+			strconv.FormatUint(bs.gas, 10),
+			strconv.FormatInt(bs.total.Microseconds(), 10),
+			strconv.FormatInt(bs.handle.Microseconds(), 10),
+			gasPerSec(bs.gas, bs.total),
+		}); err != nil {
+			return err
+		}
+	}
+	w.Flush()
+	return w.Error()
+}
+
+func gasPerSec(gas uint64, elapsed time.Duration) string {
+	if elapsed <= 0 {
+		return "0"
+	}
+	return strconv.FormatFloat(float64(gas)/elapsed.Seconds(), 'f', 0, 64)
+}